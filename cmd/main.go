@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"incident-triage-assistant/internal/clock"
 	"incident-triage-assistant/internal/config"
+	"incident-triage-assistant/internal/domain"
 	"incident-triage-assistant/internal/handler"
+	"incident-triage-assistant/internal/logging"
 	"incident-triage-assistant/internal/repository"
 	"incident-triage-assistant/internal/service"
 	"incident-triage-assistant/internal/usecase"
@@ -14,44 +22,355 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 func main() {
+	// Log JSON lines; this also routes the standard log package through slog
+	slog.SetDefault(logging.New(os.Stderr))
+
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, using environment variables")
 	}
 
+	// Shutdown is signalled through ctx, which also ends waits for AI capacity
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Initialize database configuration
-	dbConfig := config.NewDatabaseConfig()
-	db, err := dbConfig.Connect()
+	dbConfig, err := config.NewDatabaseConfig()
+	if err != nil {
+		log.Fatalf("Invalid database configuration: %v", err)
+	}
+	db, readDB, err := dbConfig.Connect()
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer db.Close()
+	if readDB != db {
+		defer readDB.Close()
+	}
 
 	// Initialize repositories
-	incidentRepo := repository.NewMySQLIncidentRepository(db)
+	incidentRepo := repository.NewMySQLIncidentRepositoryWithReader(db, readDB)
+	embeddingRepo := repository.NewMySQLEmbeddingRepositoryWithReader(db, readDB)
+	historyRepo := repository.NewMySQLHistoryRepositoryWithReader(db, readDB)
+
+	// Initialize the triage mode
+	triagePolicy, err := config.LoadTriagePolicy()
+	if err != nil {
+		log.Fatalf("Invalid triage configuration: %v", err)
+	}
 
 	// Initialize services
-	aiService := service.NewOpenAIService()
+	aiModel, refineModels, err := config.LoadAIModels()
+	if err != nil {
+		log.Fatalf("Invalid AI model configuration: %v", err)
+	}
+	promptTokenBudget, err := config.LoadPromptTokenBudget()
+	if err != nil {
+		log.Fatalf("Invalid AI prompt budget: %v", err)
+	}
+	aiRetry, err := config.LoadAIRetry()
+	if err != nil {
+		log.Fatalf("Invalid AI retry configuration: %v", err)
+	}
+	aiProvider, err := config.LoadAIProvider()
+	if err != nil {
+		log.Fatalf("Invalid AI provider configuration: %v", err)
+	}
+	// With triage off the AI is never called, so its credentials are not required
+	if triagePolicy.Off() {
+		aiProvider = domain.AIProviderMock
+	}
+	aiService, err := service.NewAIProvider(aiProvider,
+		service.WithModel(aiModel),
+		service.WithRefineModels(refineModels),
+		service.WithPromptTokenBudget(promptTokenBudget),
+		service.WithRetry(aiRetry.MaxAttempts, aiRetry.BaseDelay),
+	)
+	if err != nil {
+		log.Fatalf("Failed to initialize the %s AI provider: %v", aiProvider, err)
+	}
+
+	// Initialize sanitization
+	redactPatterns, err := config.LoadRedactPatterns()
+	if err != nil {
+		log.Fatalf("Failed to load redact patterns: %v", err)
+	}
+	if redactPatterns == nil {
+		redactPatterns = usecase.DefaultRedactPatterns
+	}
+	sanitizer, err := usecase.NewSanitizer(redactPatterns)
+	if err != nil {
+		log.Fatalf("Failed to initialize sanitizer: %v", err)
+	}
+
+	// Initialize feature flags
+	flagOverrides, err := config.LoadFeatureFlags()
+	if err != nil {
+		log.Fatalf("Invalid feature flags: %v", err)
+	}
+	featureFlags := usecase.NewStaticFlags(flagOverrides)
+
+	// Initialize live incident events
+	broker := usecase.NewBroker()
+
+	// Initialize team routing
+	useCaseOptions := []usecase.Option{
+		usecase.WithTriagePolicy(triagePolicy),
+		usecase.WithSanitizer(sanitizer),
+		usecase.WithHistory(historyRepo),
+		usecase.WithFeatureFlags(featureFlags),
+		usecase.WithEventPublisher(broker),
+		usecase.WithStrictUnique(os.Getenv("STRICT_UNIQUE_INCIDENTS") == "true"),
+	}
+	// Embeddings are computed by the AI provider, so they are off along with triage and with
+	// providers that have no embeddings
+	if embedder, ok := aiService.(domain.EmbeddingService); ok && !triagePolicy.Off() {
+		useCaseOptions = append(useCaseOptions, usecase.WithEmbeddings(embedder, embeddingRepo, embeddingRepo))
+	}
+	routingConfig, err := config.LoadRoutingConfig()
+	if err != nil {
+		log.Fatalf("Failed to load category routing: %v", err)
+	}
+	if routingConfig != nil {
+		useCaseOptions = append(useCaseOptions, usecase.WithTeamRouter(usecase.NewCategoryRouter(routingConfig.Routes, routingConfig.Default)))
+	}
+
+	// Initialize duplicate windows
+	dedupWindows, err := config.LoadDedupWindows()
+	if err != nil {
+		log.Fatalf("Invalid dedup window configuration: %v", err)
+	}
+	useCaseOptions = append(useCaseOptions, usecase.WithDedupWindows(dedupWindows))
+
+	// Initialize the update reanalysis policy
+	reanalysisPolicy, err := config.LoadReanalysisPolicy()
+	if err != nil {
+		log.Fatalf("Invalid reanalysis policy: %v", err)
+	}
+	useCaseOptions = append(useCaseOptions, usecase.WithReanalysisPolicy(reanalysisPolicy))
+
+	// Initialize the create AI budget
+	aiBudget, err := config.LoadAIBudget()
+	if err != nil {
+		log.Fatalf("Invalid AI response budget: %v", err)
+	}
+	useCaseOptions = append(useCaseOptions, usecase.WithAIBudget(aiBudget))
+
+	// Initialize alert storm suppression
+	stormLimit, stormWindow, err := config.LoadStormLimit()
+	if err != nil {
+		log.Fatalf("Invalid alert storm configuration: %v", err)
+	}
+	if stormLimit > 0 {
+		useCaseOptions = append(useCaseOptions, usecase.WithStormLimit(stormLimit, stormWindow, nil))
+	}
+
+	// Initialize incident references
+	referenceFormat, err := config.LoadReferenceFormat()
+	if err != nil {
+		log.Fatalf("Invalid incident reference format: %v", err)
+	}
+	useCaseOptions = append(useCaseOptions, usecase.WithReferences(referenceFormat, repository.NewMySQLSequenceRepository(db)))
+
+	// Initialize runbook checklists
+	useCaseOptions = append(useCaseOptions, usecase.WithRunbooks(repository.NewMySQLRunbookRepositoryWithReader(db, readDB)))
+
+	// Initialize incident notifications
+	digestInterval, err := config.LoadNotifyDigestInterval()
+	if err != nil {
+		log.Fatalf("Invalid notification configuration: %v", err)
+	}
+	var notifier domain.IncidentNotifier = usecase.LogNotifier{}
+	if digestInterval > 0 {
+		digest := usecase.NewDigestNotifier(notifier, digestInterval, clock.Real{})
+		digest.Start()
+		defer digest.Stop()
+		notifier = digest
+	}
+	useCaseOptions = append(useCaseOptions, usecase.WithNotifier(notifier))
+	confidenceFloor, err := config.LoadConfidenceFloor()
+	if err != nil {
+		log.Fatalf("Invalid confidence floor configuration: %v", err)
+	}
+	useCaseOptions = append(useCaseOptions, usecase.WithConfidenceFloor(confidenceFloor, usecase.LogNotifier{}))
+	followUpCheckInterval, err := config.LoadFollowUpCheckInterval()
+	if err != nil {
+		log.Fatalf("Invalid follow-up configuration: %v", err)
+	}
+	// Without the scheduler nothing would send the reminders, so follow-ups are unavailable
+	if followUpCheckInterval > 0 {
+		useCaseOptions = append(useCaseOptions, usecase.WithFollowUps(repository.NewMySQLFollowUpRepository(db), usecase.LogNotifier{}))
+	}
+	ackPolicy, ackCheckInterval, err := config.LoadAckReminders()
+	if err != nil {
+		log.Fatalf("Invalid acknowledgment reminder configuration: %v", err)
+	}
+	if ackPolicy.Enabled() && ackCheckInterval > 0 {
+		useCaseOptions = append(useCaseOptions, usecase.WithAckReminders(repository.NewMySQLAckReminderRepository(db), usecase.LogNotifier{}, ackPolicy))
+	}
+	trendPolicy, trendCheckInterval, err := config.LoadSeverityTrend()
+	if err != nil {
+		log.Fatalf("Invalid severity trend configuration: %v", err)
+	}
+	if trendPolicy.Enabled() && trendCheckInterval > 0 {
+		useCaseOptions = append(useCaseOptions, usecase.WithSeverityTrend(repository.NewMySQLTrendRepository(readDB), usecase.LogNotifier{}, trendPolicy))
+	}
+
+	// Initialize the known service catalog
+	serviceCatalog, err := config.LoadServiceCatalog()
+	if err != nil {
+		log.Fatalf("Failed to load service catalog: %v", err)
+	}
+	if serviceCatalog != nil {
+		useCaseOptions = append(useCaseOptions, usecase.WithServiceCatalog(serviceCatalog))
+	}
+
+	// Initialize service metadata enrichment
+	serviceMetadata, serviceMetadataTTL, err := config.LoadServiceMetadata()
+	if err != nil {
+		log.Fatalf("Failed to load service metadata: %v", err)
+	}
+	if serviceMetadata != nil {
+		directory := usecase.NewCachedServiceDirectory(usecase.NewStaticServiceDirectory(serviceMetadata), serviceMetadataTTL, clock.Real{})
+		useCaseOptions = append(useCaseOptions, usecase.WithServiceDirectory(directory))
+	}
+
+	// Initialize round-robin assignment
+	rosters, err := config.LoadTeamRosters()
+	if err != nil {
+		log.Fatalf("Failed to load team rosters: %v", err)
+	}
+	if rosters != nil {
+		rotationRepo := repository.NewMySQLRotationRepository(db)
+		useCaseOptions = append(useCaseOptions, usecase.WithAssigner(usecase.NewRoundRobinAssigner(rosters, rotationRepo)))
+	}
+
+	// Initialize slow query logging
+	slowQueryThreshold, err := config.LoadSlowQueryThreshold()
+	if err != nil {
+		log.Fatalf("Invalid slow query configuration: %v", err)
+	}
+	var incidentStore domain.IncidentRepository = incidentRepo
+	if slowQueryThreshold > 0 {
+		incidentStore = repository.NewSlowQueryIncidentRepository(incidentRepo, slowQueryThreshold, clock.Real{})
+	}
+
+	// Initialize the AI analysis cache
+	aiCache, err := config.LoadAICache()
+	if err != nil {
+		log.Fatalf("Invalid AI cache configuration: %v", err)
+	}
+	aiMaxConcurrency, err := config.LoadAIMaxConcurrency()
+	if err != nil {
+		log.Fatalf("Invalid AI concurrency configuration: %v", err)
+	}
+	var upstream domain.AIService = aiService
+	if aiMaxConcurrency > 0 {
+		upstream = service.NewLimitingAIService(ctx, aiService, aiMaxConcurrency, clock.Real{})
+	}
+	var analyzer domain.AIService = service.NewCoalescingAIService(upstream)
+	if aiCache.TTL > 0 {
+		normalizer, err := service.NewNormalizer(aiCache.Normalize)
+		if err != nil {
+			log.Fatalf("Invalid AI_CACHE_NORMALIZE: %v", err)
+		}
+		analyzer = service.NewCachingAIService(analyzer, aiCache.TTL, aiCache.MaxEntries, normalizer, clock.Real{})
+	}
+
+	fieldLimits, err := config.LoadFieldLimits()
+	if err != nil {
+		log.Fatalf("Invalid field length configuration: %v", err)
+	}
+	columnLimits, err := incidentRepo.ColumnLimits()
+	if err != nil {
+		log.Fatalf("Failed to read incident column lengths: %v", err)
+	}
+	if err := fieldLimits.FitWithin(columnLimits); err != nil {
+		log.Fatalf("Field length limits do not fit the incidents table (run the migrations?): %v", err)
+	}
+	customFieldSchema, err := config.LoadCustomFieldSchema()
+	if err != nil {
+		log.Fatalf("Failed to load custom fields schema: %v", err)
+	}
+	// Sanitizing can lengthen or empty a validated field, so the use case validates again
+	useCaseOptions = append(useCaseOptions, usecase.WithFieldLimits(fieldLimits), usecase.WithCustomFieldSchema(customFieldSchema))
 
 	// Initialize use cases
-	incidentUseCase := usecase.NewIncidentUseCase(incidentRepo, aiService)
+	incidentUseCase := usecase.NewIncidentUseCase(incidentStore, analyzer, useCaseOptions...)
+	if followUpCheckInterval > 0 {
+		followUps := usecase.NewFollowUpScheduler(incidentUseCase, followUpCheckInterval)
+		followUps.Start()
+		defer followUps.Stop()
+	}
+	reprocessInterval, err := config.LoadReprocessInterval()
+	if err != nil {
+		log.Fatalf("Invalid reprocess configuration: %v", err)
+	}
+	// With triage off there is no analysis to retry
+	if reprocessInterval > 0 && !triagePolicy.Off() {
+		reprocessor := usecase.NewReprocessScheduler(incidentUseCase, reprocessInterval, clock.Real{})
+		reprocessor.Start()
+		defer reprocessor.Stop()
+	}
+	if ackPolicy.Enabled() && ackCheckInterval > 0 {
+		ackReminders := usecase.NewScheduler("acknowledgment reminders", ackCheckInterval, clock.Real{}, func(ctx context.Context) error {
+			_, err := incidentUseCase.SendAckReminders(ctx)
+			return err
+		})
+		ackReminders.Start()
+		defer ackReminders.Stop()
+	}
+	if trendPolicy.Enabled() && trendCheckInterval > 0 {
+		trend := usecase.NewScheduler("severity trend", trendCheckInterval, clock.Real{}, func(ctx context.Context) error {
+			_, err := incidentUseCase.CheckSeverityTrend(ctx)
+			return err
+		})
+		trend.Start()
+		defer trend.Stop()
+	}
 
 	// Initialize handlers
-	incidentHandler := handler.NewIncidentHandler(incidentUseCase)
+	paginationConfig, err := config.NewPaginationConfig()
+	if err != nil {
+		log.Fatalf("Invalid pagination configuration: %v", err)
+	}
+	incidentHandler := handler.NewIncidentHandler(incidentUseCase,
+		handler.WithPageSizes(paginationConfig.DefaultPageSize, paginationConfig.MaxPageSize, paginationConfig.RejectOversized()),
+		handler.WithFieldLimits(fieldLimits),
+		handler.WithCustomFieldSchema(customFieldSchema),
+		handler.WithServiceCatalog(serviceCatalog),
+		handler.WithResponseEnvelope(os.Getenv("RESPONSE_ENVELOPE") == "true"),
+		handler.WithDebugTimings(os.Getenv("DEBUG_TIMINGS") == "true"),
+		handler.WithFeatureFlags(featureFlags),
+		handler.WithAdminToken(os.Getenv("ADMIN_TOKEN")),
+		handler.WithIDAsString(os.Getenv("ID_AS_STRING") == "true"),
+		handler.WithEventStream(broker),
+		handler.WithEffectiveConfig(config.EffectiveSettings()),
+		handler.WithReferenceFormat(referenceFormat),
+	)
+
+	// Answer unknown routes and unsupported methods with the JSON error shape
+	echo.NotFoundHandler = handler.RouteNotFound
+	echo.MethodNotAllowedHandler = handler.MethodNotAllowed
 
 	// Initialize Echo server
 	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
 
 	// Add middleware
-	e.Use(middleware.Logger())
+	e.Use(handler.RequestID())
+	e.Use(handler.LogRequests())
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept},
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, handler.AdminTokenHeader, handler.RequestIDHeader},
+		ExposeHeaders: []string{handler.RequestIDHeader},
 	}))
 
 	// Setup routes
@@ -59,13 +378,49 @@ func main() {
 	
 	// Health check
 	api.GET("/health", incidentHandler.HealthCheck)
+	api.GET("/health/ai", handler.NewAIHealthHandler(aiService, 30*time.Second, clock.Real{}).Check)
+
+	// Prometheus metrics
+	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
 	
+	// Admin middleware
+	requireAdmin := handler.RequireAdmin(os.Getenv("ADMIN_TOKEN"))
+
+	// Admin routes
+	admin := api.Group("/admin", requireAdmin)
+	admin.GET("/flags", incidentHandler.GetFeatureFlags)
+	admin.GET("/config", incidentHandler.GetEffectiveConfig)
+	admin.GET("/history", incidentHandler.GetHistory)
+	admin.POST("/incidents/reprocess-failed", incidentHandler.ReprocessFailedAnalyses)
+	admin.POST("/incidents/remap-severity", incidentHandler.RemapSeverity)
+	admin.GET("/incidents/quality", incidentHandler.GetDataQuality)
+
 	// Incident routes
 	incidents := api.Group("/incidents")
 	incidents.POST("", incidentHandler.CreateIncident)
+	incidents.POST("/batch", incidentHandler.CreateIncidentsBatch)
+	incidents.POST("/ingest/:source", incidentHandler.IngestIncident)
+	incidents.POST("/import", incidentHandler.ImportIncidents, requireAdmin)
+	incidents.POST("/reassign", incidentHandler.ReassignIncidents, requireAdmin)
 	incidents.GET("", incidentHandler.GetAllIncidents)
+	incidents.GET("/export.zip", incidentHandler.ExportIncidentsZip, requireAdmin)
+	incidents.GET("/export.csv", incidentHandler.ExportIncidentsCSV, requireAdmin)
+	incidents.GET("/export.xlsx", incidentHandler.ExportIncidentsXLSX, requireAdmin)
+	incidents.GET("/queue", incidentHandler.GetTriageQueue)
+	incidents.GET("/stream", incidentHandler.StreamIncidents)
+	incidents.GET("/compare", incidentHandler.CompareIncidents)
+	incidents.GET("/search", incidentHandler.SearchIncidents)
 	incidents.GET("/:id", incidentHandler.GetIncident)
+	incidents.GET("/:id/similar", incidentHandler.GetSimilarIncidents)
+	incidents.GET("/:id/severity-history", incidentHandler.GetSeverityHistory)
 	incidents.PUT("/:id", incidentHandler.UpdateIncident)
+	incidents.POST("/:id/false-positive", incidentHandler.MarkFalsePositive)
+	incidents.POST("/:id/priority", incidentHandler.SetPriorityOverride)
+	incidents.GET("/:id/runbook", incidentHandler.ListRunbookSteps)
+	incidents.POST("/:id/runbook", incidentHandler.AddRunbookStep)
+	incidents.POST("/:id/runbook/:step/done", incidentHandler.CompleteRunbookStep)
+	incidents.GET("/:id/follow-up", incidentHandler.GetFollowUp)
+	incidents.PUT("/:id/follow-up", incidentHandler.SetFollowUp)
 	incidents.DELETE("/:id", incidentHandler.DeleteIncident)
 
 	// Start server
@@ -73,9 +428,32 @@ func main() {
 	if port == "" {
 		port = "8080"
 	}
+	shutdownTimeout, err := config.LoadShutdownTimeout()
+	if err != nil {
+		log.Fatalf("Invalid shutdown configuration: %v", err)
+	}
+
+	// http.Server.Shutdown does not cancel active requests, so end the live update streams
+	// for it not to wait on them
+	e.Server.RegisterOnShutdown(broker.Close)
 
 	log.Printf("Server starting on port %s", port)
-	if err := e.Start(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	go func() {
+		if err := e.Start(":" + port); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	// Shut down gracefully so in-flight requests finish and pending digests are sent. The
+	// database connections are closed by the deferred calls once the server has stopped.
+	<-ctx.Done()
+
+	log.Printf("Shutting down server, waiting up to %s for in-flight requests", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down server: %v", err)
+		return
 	}
+	log.Println("Server stopped")
 }
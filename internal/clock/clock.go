@@ -0,0 +1,141 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time so time-dependent logic can be tested deterministically
+type Clock interface {
+	Now() time.Time
+	// NewTicker returns a ticker that ticks every d, like time.NewTicker
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks on C until stopped. Like time.Ticker, it drops ticks for slow receivers.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Real is a Clock backed by the system time
+type Real struct{}
+
+// Now returns the current system time
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// NewTicker returns a ticker backed by time.Ticker
+func (Real) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// realTicker adapts time.Ticker to Ticker
+type realTicker struct {
+	*time.Ticker
+}
+
+// C returns the channel the ticks are delivered on
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// Mock is a Clock that returns a controllable time, for use in tests. Its tickers tick when
+// the time is moved past their next tick.
+type Mock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*mockTicker
+}
+
+// NewMock creates a mock clock set to the given time
+func NewMock(now time.Time) *Mock {
+	return &Mock{now: now}
+}
+
+// Now returns the mock's current time
+func (m *Mock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+// NewTicker returns a ticker whose first tick is d from the mock's current time
+func (m *Mock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ticker := &mockTicker{clock: m, every: d, next: m.now.Add(d), c: make(chan time.Time, 1)}
+	m.tickers = append(m.tickers, ticker)
+	return ticker
+}
+
+// Tickers returns the number of tickers that have not been stopped, so a test can wait for
+// the code under test to start its schedule before moving the time
+func (m *Mock) Tickers() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.tickers)
+}
+
+// Set moves the mock clock to the given time
+func (m *Mock) Set(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = now
+	m.tick()
+}
+
+// Advance moves the mock clock forward by d
+func (m *Mock) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = m.now.Add(d)
+	m.tick()
+}
+
+// tick delivers a tick from every ticker whose next tick has been reached. A ticker passed
+// by several intervals ticks once, as a time.Ticker with a slow receiver would.
+func (m *Mock) tick() {
+	for _, ticker := range m.tickers {
+		if ticker.next.After(m.now) {
+			continue
+		}
+		for !ticker.next.After(m.now) {
+			ticker.next = ticker.next.Add(ticker.every)
+		}
+		select {
+		case ticker.c <- m.now:
+		default:
+		}
+	}
+}
+
+// mockTicker is a Ticker driven by a Mock
+type mockTicker struct {
+	clock *Mock
+	every time.Duration
+	next  time.Time
+	c     chan time.Time
+}
+
+// C returns the channel the ticks are delivered on
+func (t *mockTicker) C() <-chan time.Time {
+	return t.c
+}
+
+// Stop removes the ticker from its clock; no more ticks are delivered
+func (t *mockTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, ticker := range t.clock.tickers {
+		if ticker == t {
+			t.clock.tickers = append(t.clock.tickers[:i], t.clock.tickers[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := NewMock(start)
+
+	assert.Equal(t, start, c.Now())
+
+	c.Advance(90 * time.Minute)
+	assert.Equal(t, start.Add(90*time.Minute), c.Now())
+
+	later := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.Set(later)
+	assert.Equal(t, later, c.Now())
+}
+
+func TestReal(t *testing.T) {
+	before := time.Now()
+	now := Real{}.Now()
+	assert.False(t, now.Before(before))
+}
+
+func TestMock_Ticker(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := NewMock(start)
+	ticker := c.NewTicker(time.Minute)
+	assert.Equal(t, 1, c.Tickers())
+
+	c.Advance(59 * time.Second)
+	assert.Empty(t, ticker.C())
+
+	c.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-ticker.C())
+
+	// Passing several intervals at once delivers a single tick
+	c.Advance(3 * time.Minute)
+	assert.Equal(t, start.Add(4*time.Minute), <-ticker.C())
+	assert.Empty(t, ticker.C())
+
+	ticker.Stop()
+	assert.Zero(t, c.Tickers())
+	c.Advance(time.Hour)
+	assert.Empty(t, ticker.C())
+}
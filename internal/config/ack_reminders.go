@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadAckReminders reads the reminders about incidents nobody has been assigned to:
+// ACK_REMINDER_AFTER, how long an incident may stay unassigned before the first reminder
+// (default 0, which turns the reminders off), ACK_REMINDER_REPEAT, how often the reminder is
+// repeated while it stays unassigned (default 1h), and ACK_REMINDER_CHECK_INTERVAL, how often
+// due reminders are looked for (default 1m, 0 turns the reminders off too).
+func LoadAckReminders() (domain.AckReminderPolicy, time.Duration, error) {
+	after, err := getEnvDuration("ACK_REMINDER_AFTER", 0)
+	if err != nil {
+		return domain.AckReminderPolicy{}, 0, err
+	}
+	if after < 0 {
+		return domain.AckReminderPolicy{}, 0, fmt.Errorf("ACK_REMINDER_AFTER must not be negative, got %s", after)
+	}
+
+	repeat, err := getEnvDuration("ACK_REMINDER_REPEAT", time.Hour)
+	if err != nil {
+		return domain.AckReminderPolicy{}, 0, err
+	}
+	if repeat <= 0 {
+		return domain.AckReminderPolicy{}, 0, fmt.Errorf("ACK_REMINDER_REPEAT must be positive, got %s", repeat)
+	}
+
+	every, err := getEnvDuration("ACK_REMINDER_CHECK_INTERVAL", time.Minute)
+	if err != nil {
+		return domain.AckReminderPolicy{}, 0, err
+	}
+	if every < 0 {
+		return domain.AckReminderPolicy{}, 0, fmt.Errorf("ACK_REMINDER_CHECK_INTERVAL must not be negative, got %s", every)
+	}
+
+	return domain.AckReminderPolicy{After: after, Repeat: repeat}, every, nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAckReminders(t *testing.T) {
+	t.Run("off by default", func(t *testing.T) {
+		policy, every, err := LoadAckReminders()
+		assert.NoError(t, err)
+		assert.False(t, policy.Enabled())
+		assert.Equal(t, time.Hour, policy.Repeat)
+		assert.Equal(t, time.Minute, every)
+	})
+
+	t.Run("custom schedule", func(t *testing.T) {
+		t.Setenv("ACK_REMINDER_AFTER", "30m")
+		t.Setenv("ACK_REMINDER_REPEAT", "2h")
+		t.Setenv("ACK_REMINDER_CHECK_INTERVAL", "5m")
+
+		policy, every, err := LoadAckReminders()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AckReminderPolicy{After: 30 * time.Minute, Repeat: 2 * time.Hour}, policy)
+		assert.Equal(t, 5*time.Minute, every)
+	})
+
+	t.Run("zero repeat", func(t *testing.T) {
+		t.Setenv("ACK_REMINDER_REPEAT", "0")
+
+		_, _, err := LoadAckReminders()
+		assert.Error(t, err)
+	})
+
+	t.Run("negative threshold", func(t *testing.T) {
+		t.Setenv("ACK_REMINDER_AFTER", "-1m")
+
+		_, _, err := LoadAckReminders()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoadAIBudget reads AI_RESPONSE_BUDGET, how long create waits for the AI analysis before
+// saving the incident as pending and finishing the analysis in the background. Zero (the
+// default) waits for the analysis.
+func LoadAIBudget() (time.Duration, error) {
+	budget, err := getEnvDuration("AI_RESPONSE_BUDGET", 0)
+	if err != nil {
+		return 0, err
+	}
+	if budget < 0 {
+		return 0, fmt.Errorf("AI_RESPONSE_BUDGET must not be negative, got %s", budget)
+	}
+	return budget, nil
+}
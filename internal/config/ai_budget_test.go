@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAIBudget(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		budget, err := LoadAIBudget()
+		assert.NoError(t, err)
+		assert.Zero(t, budget)
+	})
+
+	t.Run("custom budget", func(t *testing.T) {
+		t.Setenv("AI_RESPONSE_BUDGET", "800ms")
+
+		budget, err := LoadAIBudget()
+		assert.NoError(t, err)
+		assert.Equal(t, 800*time.Millisecond, budget)
+	})
+
+	t.Run("negative budget", func(t *testing.T) {
+		t.Setenv("AI_RESPONSE_BUDGET", "-1s")
+
+		_, err := LoadAIBudget()
+		assert.Error(t, err)
+	})
+	t.Run("not a duration", func(t *testing.T) {
+		t.Setenv("AI_RESPONSE_BUDGET", "800")
+
+		_, err := LoadAIBudget()
+		assert.ErrorContains(t, err, "AI_RESPONSE_BUDGET must be a duration")
+	})
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultAICacheNormalize lists the normalization rules applied to AI cache keys by default
+const DefaultAICacheNormalize = "lowercase,whitespace,punctuation"
+
+// AICacheConfig configures the cache of AI analyses
+type AICacheConfig struct {
+	TTL        time.Duration
+	MaxEntries int
+
+	// Normalize lists the normalization rules applied to cache keys; empty matches exactly
+	Normalize []string
+}
+
+// LoadAICache reads AI_CACHE_TTL, how long an analysis is reused for equivalent incidents
+// (0, the default, disables the cache), AI_CACHE_MAX_ENTRIES (default 1000) and
+// AI_CACHE_NORMALIZE, the comma-separated rules that decide which incidents are equivalent
+// (default lowercase,whitespace,punctuation; "none" requires an exact match)
+func LoadAICache() (*AICacheConfig, error) {
+	ttl, err := getEnvDuration("AI_CACHE_TTL", 0)
+	if err != nil {
+		return nil, err
+	}
+	if ttl < 0 {
+		return nil, fmt.Errorf("AI_CACHE_TTL must not be negative, got %s", ttl)
+	}
+
+	maxEntries, err := getEnvInt("AI_CACHE_MAX_ENTRIES", 1000)
+	if err != nil {
+		return nil, err
+	}
+	if maxEntries <= 0 {
+		return nil, fmt.Errorf("AI_CACHE_MAX_ENTRIES must be positive, got %d", maxEntries)
+	}
+
+	var rules []string
+	if value := strings.TrimSpace(getEnv("AI_CACHE_NORMALIZE", DefaultAICacheNormalize)); value != "none" {
+		for _, rule := range strings.Split(value, ",") {
+			if rule = strings.TrimSpace(rule); rule != "" {
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	return &AICacheConfig{TTL: ttl, MaxEntries: maxEntries, Normalize: rules}, nil
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAICache(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		cache, err := LoadAICache()
+		assert.NoError(t, err)
+		assert.Equal(t, &AICacheConfig{MaxEntries: 1000, Normalize: []string{"lowercase", "whitespace", "punctuation"}}, cache)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		t.Setenv("AI_CACHE_TTL", "10m")
+		t.Setenv("AI_CACHE_MAX_ENTRIES", "50")
+		t.Setenv("AI_CACHE_NORMALIZE", "whitespace, lowercase")
+
+		cache, err := LoadAICache()
+		assert.NoError(t, err)
+		assert.Equal(t, &AICacheConfig{TTL: 10 * time.Minute, MaxEntries: 50, Normalize: []string{"whitespace", "lowercase"}}, cache)
+	})
+
+	t.Run("exact match", func(t *testing.T) {
+		t.Setenv("AI_CACHE_NORMALIZE", "none")
+
+		cache, err := LoadAICache()
+		assert.NoError(t, err)
+		assert.Empty(t, cache.Normalize)
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		t.Setenv("AI_CACHE_MAX_ENTRIES", "0")
+
+		_, err := LoadAICache()
+		assert.Error(t, err)
+	})
+}
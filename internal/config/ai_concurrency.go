@@ -0,0 +1,16 @@
+package config
+
+import "fmt"
+
+// LoadAIMaxConcurrency reads AI_MAX_CONCURRENCY, how many AI analyses may run at once.
+// Further analyses wait for a free slot. Zero (the default) does not limit concurrency.
+func LoadAIMaxConcurrency() (int, error) {
+	limit, err := getEnvInt("AI_MAX_CONCURRENCY", 0)
+	if err != nil {
+		return 0, err
+	}
+	if limit < 0 {
+		return 0, fmt.Errorf("AI_MAX_CONCURRENCY must not be negative, got %d", limit)
+	}
+	return limit, nil
+}
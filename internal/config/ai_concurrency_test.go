@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAIMaxConcurrency(t *testing.T) {
+	t.Run("unlimited by default", func(t *testing.T) {
+		limit, err := LoadAIMaxConcurrency()
+		assert.NoError(t, err)
+		assert.Zero(t, limit)
+	})
+
+	t.Run("custom limit", func(t *testing.T) {
+		t.Setenv("AI_MAX_CONCURRENCY", "4")
+
+		limit, err := LoadAIMaxConcurrency()
+		assert.NoError(t, err)
+		assert.Equal(t, 4, limit)
+	})
+
+	t.Run("negative limit", func(t *testing.T) {
+		t.Setenv("AI_MAX_CONCURRENCY", "-1")
+
+		_, err := LoadAIMaxConcurrency()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// DefaultAIModel is the model of the first analysis pass when OPENAI_MODEL is unset
+const DefaultAIModel = "gpt-3.5-turbo"
+
+// DefaultPromptTokenBudget is the prompt token budget when OPENAI_PROMPT_TOKEN_BUDGET is unset
+const DefaultPromptTokenBudget = 12000
+
+// minPromptTokenBudget leaves room for the prompt instructions around the description
+const minPromptTokenBudget = 500
+
+// LoadAIModels reads OPENAI_MODEL, the model of the first analysis pass, and
+// OPENAI_REFINE_MODELS, a comma-separated list of category=model pairs such as
+// "Security=gpt-4o,Database=gpt-4o". Incidents classified in a listed category get a second
+// pass with its model. An empty list disables the second pass.
+func LoadAIModels() (string, map[string]string, error) {
+	model := strings.TrimSpace(getEnv("OPENAI_MODEL", DefaultAIModel))
+
+	refine := map[string]string{}
+	for _, pair := range strings.Split(os.Getenv("OPENAI_REFINE_MODELS"), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		category, refineModel, ok := strings.Cut(pair, "=")
+		refineModel = strings.TrimSpace(refineModel)
+		if !ok || refineModel == "" {
+			return "", nil, fmt.Errorf("OPENAI_REFINE_MODELS entry %q must be category=model", pair)
+		}
+		canonical, known := domain.CanonicalValue(domain.Categories, strings.TrimSpace(category))
+		if !known {
+			return "", nil, fmt.Errorf("OPENAI_REFINE_MODELS references unknown category %q", category)
+		}
+		refine[canonical] = refineModel
+	}
+
+	return model, refine, nil
+}
+
+// LoadPromptTokenBudget reads OPENAI_PROMPT_TOKEN_BUDGET, the estimated prompt size in tokens
+// above which incident descriptions are shortened before analysis (default 12000)
+func LoadPromptTokenBudget() (int, error) {
+	budget, err := getEnvInt("OPENAI_PROMPT_TOKEN_BUDGET", DefaultPromptTokenBudget)
+	if err != nil {
+		return 0, err
+	}
+	if budget < minPromptTokenBudget {
+		return 0, fmt.Errorf("OPENAI_PROMPT_TOKEN_BUDGET must be at least %d, got %d", minPromptTokenBudget, budget)
+	}
+	return budget, nil
+}
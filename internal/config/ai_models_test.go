@@ -0,0 +1,63 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAIModels(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		model, refine, err := LoadAIModels()
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultAIModel, model)
+		assert.Empty(t, refine)
+	})
+
+	t.Run("custom models", func(t *testing.T) {
+		t.Setenv("OPENAI_MODEL", "gpt-4o-mini")
+		t.Setenv("OPENAI_REFINE_MODELS", "security=gpt-4o, Database = gpt-4o")
+
+		model, refine, err := LoadAIModels()
+		assert.NoError(t, err)
+		assert.Equal(t, "gpt-4o-mini", model)
+		assert.Equal(t, map[string]string{"Security": "gpt-4o", "Database": "gpt-4o"}, refine)
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		t.Setenv("OPENAI_REFINE_MODELS", "Billing=gpt-4o")
+
+		_, _, err := LoadAIModels()
+		assert.Error(t, err)
+	})
+
+	t.Run("missing model", func(t *testing.T) {
+		t.Setenv("OPENAI_REFINE_MODELS", "Security")
+
+		_, _, err := LoadAIModels()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadPromptTokenBudget(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		budget, err := LoadPromptTokenBudget()
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultPromptTokenBudget, budget)
+	})
+
+	t.Run("custom budget", func(t *testing.T) {
+		t.Setenv("OPENAI_PROMPT_TOKEN_BUDGET", "100000")
+
+		budget, err := LoadPromptTokenBudget()
+		assert.NoError(t, err)
+		assert.Equal(t, 100000, budget)
+	})
+
+	t.Run("too small", func(t *testing.T) {
+		t.Setenv("OPENAI_PROMPT_TOKEN_BUDGET", "100")
+
+		_, err := LoadPromptTokenBudget()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadAIProvider reads AI_PROVIDER, the backend that analyzes incidents: "openai" (default),
+// "anthropic" or "mock"
+func LoadAIProvider() (string, error) {
+	provider, ok := domain.CanonicalValue(domain.AIProviders, strings.TrimSpace(getEnv("AI_PROVIDER", domain.AIProviderOpenAI)))
+	if !ok {
+		return "", fmt.Errorf("AI_PROVIDER must be one of %s", strings.Join(domain.AIProviders, ", "))
+	}
+	return provider, nil
+}
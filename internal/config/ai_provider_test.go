@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAIProvider(t *testing.T) {
+	t.Run("openai by default", func(t *testing.T) {
+		provider, err := LoadAIProvider()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AIProviderOpenAI, provider)
+	})
+
+	t.Run("case-insensitive", func(t *testing.T) {
+		t.Setenv("AI_PROVIDER", " Mock ")
+
+		provider, err := LoadAIProvider()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AIProviderMock, provider)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		t.Setenv("AI_PROVIDER", "llama")
+
+		_, err := LoadAIProvider()
+		assert.EqualError(t, err, "AI_PROVIDER must be one of openai, anthropic, mock")
+	})
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Defaults of OPENAI_MAX_ATTEMPTS and OPENAI_RETRY_BASE_DELAY
+const (
+	DefaultAIMaxAttempts    = 3
+	DefaultAIRetryBaseDelay = 500 * time.Millisecond
+)
+
+// maxAIAttempts keeps a misconfiguration from holding a create for minutes
+const maxAIAttempts = 10
+
+// AIRetryConfig bounds the retries of OpenAI analyses that fail with a rate limit or a
+// transient server error
+type AIRetryConfig struct {
+	// MaxAttempts counts every call of one analysis, so 1 disables retries
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry, doubled before each further one
+	BaseDelay time.Duration
+}
+
+// LoadAIRetry reads OPENAI_MAX_ATTEMPTS (default 3) and OPENAI_RETRY_BASE_DELAY (default 500ms)
+func LoadAIRetry() (*AIRetryConfig, error) {
+	attempts, err := getEnvInt("OPENAI_MAX_ATTEMPTS", DefaultAIMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	if attempts < 1 || attempts > maxAIAttempts {
+		return nil, fmt.Errorf("OPENAI_MAX_ATTEMPTS must be between 1 and %d, got %d", maxAIAttempts, attempts)
+	}
+
+	delay, err := getEnvDuration("OPENAI_RETRY_BASE_DELAY", DefaultAIRetryBaseDelay)
+	if err != nil {
+		return nil, err
+	}
+	if delay < 0 {
+		return nil, fmt.Errorf("OPENAI_RETRY_BASE_DELAY must not be negative, got %s", delay)
+	}
+
+	return &AIRetryConfig{MaxAttempts: attempts, BaseDelay: delay}, nil
+}
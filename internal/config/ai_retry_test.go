@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAIRetry(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		retry, err := LoadAIRetry()
+		assert.NoError(t, err)
+		assert.Equal(t, &AIRetryConfig{MaxAttempts: 3, BaseDelay: 500 * time.Millisecond}, retry)
+	})
+
+	t.Run("custom settings", func(t *testing.T) {
+		t.Setenv("OPENAI_MAX_ATTEMPTS", "1")
+		t.Setenv("OPENAI_RETRY_BASE_DELAY", "2s")
+
+		retry, err := LoadAIRetry()
+		assert.NoError(t, err)
+		assert.Equal(t, &AIRetryConfig{MaxAttempts: 1, BaseDelay: 2 * time.Second}, retry)
+	})
+
+	t.Run("no attempts", func(t *testing.T) {
+		t.Setenv("OPENAI_MAX_ATTEMPTS", "0")
+
+		_, err := LoadAIRetry()
+		assert.Error(t, err)
+	})
+
+	t.Run("negative delay", func(t *testing.T) {
+		t.Setenv("OPENAI_RETRY_BASE_DELAY", "-1s")
+
+		_, err := LoadAIRetry()
+		assert.Error(t, err)
+	})
+}
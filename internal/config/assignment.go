@@ -0,0 +1,42 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadTeamRosters reads team rosters for round-robin assignment from the JSON file named by
+// TEAM_ROSTERS_FILE, an object mapping each routed team name to its members.
+// It returns nil, disabling assignment, when the variable is unset.
+func LoadTeamRosters() (map[string][]domain.TeamMember, error) {
+	path := os.Getenv("TEAM_ROSTERS_FILE")
+	if path == "" {
+		return nil, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read team rosters: %w", err)
+	}
+
+	var rosters map[string][]domain.TeamMember
+	if err := json.Unmarshal(raw, &rosters); err != nil {
+		return nil, fmt.Errorf("failed to parse team rosters: %w", err)
+	}
+
+	for team, members := range rosters {
+		for _, member := range members {
+			if member.Name == "" {
+				return nil, fmt.Errorf("team %q has a member without a name", team)
+			}
+			if len([]rune(member.Name)) > domain.MaxAssigneeLength {
+				return nil, fmt.Errorf("team %q member %q exceeds %d characters", team, member.Name, domain.MaxAssigneeLength)
+			}
+		}
+	}
+
+	return rosters, nil
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTeamRosters(t *testing.T) {
+	writeRosters := func(t *testing.T, content string) {
+		path := filepath.Join(t.TempDir(), "rosters.json")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		t.Setenv("TEAM_ROSTERS_FILE", path)
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		rosters, err := LoadTeamRosters()
+		assert.NoError(t, err)
+		assert.Nil(t, rosters)
+	})
+
+	t.Run("valid rosters", func(t *testing.T) {
+		writeRosters(t, `{"database": [{"name": "alice", "available": true}, {"name": "bob", "available": false}]}`)
+
+		rosters, err := LoadTeamRosters()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]domain.TeamMember{
+			"database": {{Name: "alice", Available: true}, {Name: "bob", Available: false}},
+		}, rosters)
+	})
+
+	t.Run("member without a name", func(t *testing.T) {
+		writeRosters(t, `{"database": [{"available": true}]}`)
+
+		_, err := LoadTeamRosters()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadConfidenceFloor reads AI_CONFIDENCE_FLOOR, the AI confidence below which a classification
+// is not trusted (default domain.LowConfidenceThreshold, 0 trusts every classification), and
+// AI_CONFIDENCE_FLOOR_SEVERITY, the severity such incidents get instead (default Medium).
+func LoadConfidenceFloor() (domain.ConfidenceFloor, error) {
+	floor := domain.ConfidenceFloor{Floor: domain.LowConfidenceThreshold}
+
+	if value := os.Getenv("AI_CONFIDENCE_FLOOR"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return domain.ConfidenceFloor{}, fmt.Errorf("AI_CONFIDENCE_FLOOR must be a number: %w", err)
+		}
+		if parsed < 0 || parsed > 1 {
+			return domain.ConfidenceFloor{}, fmt.Errorf("AI_CONFIDENCE_FLOOR must be between 0 and 1, got %g", parsed)
+		}
+		floor.Floor = parsed
+	}
+
+	severity, ok := domain.CanonicalValue(domain.Severities, getEnv("AI_CONFIDENCE_FLOOR_SEVERITY", domain.DefaultSeverity))
+	if !ok {
+		return domain.ConfidenceFloor{}, fmt.Errorf("AI_CONFIDENCE_FLOOR_SEVERITY must be one of %s", strings.Join(domain.Severities, ", "))
+	}
+	floor.Severity = severity
+
+	return floor, nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfidenceFloor(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		floor, err := LoadConfidenceFloor()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ConfidenceFloor{Floor: domain.LowConfidenceThreshold, Severity: domain.DefaultSeverity}, floor)
+	})
+
+	t.Run("custom floor and severity", func(t *testing.T) {
+		t.Setenv("AI_CONFIDENCE_FLOOR", "0.6")
+		t.Setenv("AI_CONFIDENCE_FLOOR_SEVERITY", "high")
+
+		floor, err := LoadConfidenceFloor()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ConfidenceFloor{Floor: 0.6, Severity: "High"}, floor)
+	})
+
+	t.Run("floor out of range", func(t *testing.T) {
+		t.Setenv("AI_CONFIDENCE_FLOOR", "1.5")
+
+		_, err := LoadConfidenceFloor()
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown severity", func(t *testing.T) {
+		t.Setenv("AI_CONFIDENCE_FLOOR_SEVERITY", "Urgent")
+
+		_, err := LoadConfidenceFloor()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadCustomFieldSchema reads the allowed custom fields from the JSON file named by
+// CUSTOM_FIELDS_SCHEMA_FILE, an object mapping each key to "string", "number" or "boolean".
+// It returns nil, accepting any custom field, when the variable is unset.
+func LoadCustomFieldSchema() (domain.CustomFieldSchema, error) {
+	path := os.Getenv("CUSTOM_FIELDS_SCHEMA_FILE")
+	if path == "" {
+		return nil, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom fields schema: %w", err)
+	}
+
+	var schema domain.CustomFieldSchema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse custom fields schema: %w", err)
+	}
+
+	for key, fieldType := range schema {
+		if !domain.ValidCustomFieldKey(key) {
+			return nil, fmt.Errorf("custom fields schema has invalid key %q", key)
+		}
+		switch fieldType {
+		case domain.CustomFieldString, domain.CustomFieldNumber, domain.CustomFieldBoolean:
+		default:
+			return nil, fmt.Errorf("custom field %q has unknown type %q", key, fieldType)
+		}
+	}
+
+	if schema == nil {
+		schema = domain.CustomFieldSchema{}
+	}
+	return schema, nil
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCustomFieldSchema(t *testing.T) {
+	writeSchema := func(t *testing.T, content string) {
+		path := filepath.Join(t.TempDir(), "custom_fields.json")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		t.Setenv("CUSTOM_FIELDS_SCHEMA_FILE", path)
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		schema, err := LoadCustomFieldSchema()
+		assert.NoError(t, err)
+		assert.Nil(t, schema)
+	})
+
+	t.Run("valid schema", func(t *testing.T) {
+		writeSchema(t, `{"region": "string", "customer_impact": "boolean"}`)
+
+		schema, err := LoadCustomFieldSchema()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CustomFieldSchema{"region": "string", "customer_impact": "boolean"}, schema)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		writeSchema(t, `{"region": "enum"}`)
+
+		_, err := LoadCustomFieldSchema()
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		writeSchema(t, `{"region'": "string"}`)
+
+		_, err := LoadCustomFieldSchema()
+		assert.Error(t, err)
+	})
+}
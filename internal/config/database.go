@@ -1,14 +1,49 @@
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"incident-triage-assistant/internal/clock"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Backoff bounds between connection attempts while waiting for the database
+const (
+	initialConnectBackoff = 500 * time.Millisecond
+	maxConnectBackoff     = 5 * time.Second
+)
+
+// DB_TLS_MODE values. TLSModeCustom verifies the server against the CA certificate in DB_TLS_CA.
+const (
+	TLSModeFalse      = "false"
+	TLSModeSkipVerify = "skip-verify"
+	TLSModeTrue       = "true"
+	TLSModeCustom     = "custom"
 )
 
+// DefaultDBParams are the DSN parameters used unless DB_PARAMS overrides them: full UTF-8,
+// times read and written in UTC, and bounded dial and read waits
+const DefaultDBParams = "charset=utf8mb4&loc=UTC&timeout=10s&readTimeout=30s"
+
+// requiredDBParams are DSN parameters the repositories rely on, which DB_PARAMS cannot change
+var requiredDBParams = []struct{ key, value string }{
+	{"parseTime", "true"},
+	{"multiStatements", "true"},
+}
+
+// customTLSConfigName is the name the DB_TLS_CA configuration is registered under with the driver
+const customTLSConfigName = "custom"
+
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
 	Host     string
@@ -16,38 +51,243 @@ type DatabaseConfig struct {
 	User     string
 	Password string
 	DBName   string
+	ReadHost string
+	ReadPort string
+
+	// TLSMode is one of the TLSMode values; empty connects without TLS
+	TLSMode string
+	TLSCA   string
+
+	// Params are extra DSN parameters in query string form, merged over DefaultDBParams
+	Params string
+
+	// ConnectTimeout is how long to keep retrying the initial ping before giving up
+	ConnectTimeout time.Duration
 }
 
 // NewDatabaseConfig creates a new database configuration from environment variables
-func NewDatabaseConfig() *DatabaseConfig {
+func NewDatabaseConfig() (*DatabaseConfig, error) {
+	connectTimeout, err := getEnvDuration("DB_CONNECT_TIMEOUT", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
 	return &DatabaseConfig{
 		Host:     getEnv("DB_HOST", "localhost"),
 		Port:     getEnv("DB_PORT", "3306"),
 		User:     getEnv("DB_USER", "root"),
 		Password: getEnv("DB_PASSWORD", "password"),
 		DBName:   getEnv("DB_NAME", "incident_triage"),
+		ReadHost: os.Getenv("DB_READ_HOST"),
+		ReadPort: os.Getenv("DB_READ_PORT"),
+		TLSMode:  os.Getenv("DB_TLS_MODE"),
+		TLSCA:    os.Getenv("DB_TLS_CA"),
+		Params:   os.Getenv("DB_PARAMS"),
+
+		ConnectTimeout: connectTimeout,
+	}, nil
+}
+
+// Connect establishes the writer and reader connection pools to the MySQL database.
+// When no read replica is configured the reader is the writer pool.
+func (c *DatabaseConfig) Connect() (writer *sql.DB, reader *sql.DB, err error) {
+	if err := c.configureTLS(); err != nil {
+		return nil, nil, err
+	}
+
+	writer, err = c.open(c.Host, c.Port)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Println("Successfully connected to MySQL database")
+
+	if c.ReadHost == "" {
+		return writer, writer, nil
+	}
+
+	readPort := c.ReadPort
+	if readPort == "" {
+		readPort = c.Port
+	}
+
+	reader, err = c.open(c.ReadHost, readPort)
+	if err != nil {
+		writer.Close()
+		return nil, nil, fmt.Errorf("read replica: %w", err)
+	}
+	log.Println("Successfully connected to MySQL read replica")
+
+	return writer, reader, nil
+}
+
+// configureTLS validates the TLS mode and, for TLSModeCustom, registers a TLS configuration
+// trusting the CA certificate in TLSCA
+func (c *DatabaseConfig) configureTLS() error {
+	switch c.TLSMode {
+	case "", TLSModeFalse, TLSModeSkipVerify, TLSModeTrue:
+		return nil
+	case TLSModeCustom:
+	default:
+		return fmt.Errorf("DB_TLS_MODE must be false, skip-verify, true or custom, got %q", c.TLSMode)
+	}
+
+	if c.TLSCA == "" {
+		return fmt.Errorf("DB_TLS_CA is required when DB_TLS_MODE is custom")
+	}
+	pem, err := os.ReadFile(c.TLSCA)
+	if err != nil {
+		return fmt.Errorf("failed to read DB_TLS_CA: %w", err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return fmt.Errorf("DB_TLS_CA %s contains no PEM certificates", c.TLSCA)
+	}
+	if err := mysql.RegisterTLSConfig(customTLSConfigName, &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}); err != nil {
+		return fmt.Errorf("failed to register database TLS config: %w", err)
 	}
+	return nil
 }
 
-// Connect establishes a connection to the MySQL database
-func (c *DatabaseConfig) Connect() (*sql.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&multiStatements=true",
-		c.User, c.Password, c.Host, c.Port, c.DBName)
+// dsn builds the data source name of the given MySQL host: the required parameters, then
+// DefaultDBParams merged with Params in key order, then the TLS mode. The result is checked
+// to parse so a bad DB_PARAMS fails at startup with a clear error.
+func (c *DatabaseConfig) dsn(host, port string) (string, error) {
+	params, err := c.params()
+	if err != nil {
+		return "", err
+	}
+
+	query := make([]string, 0, len(requiredDBParams)+len(params)+1)
+	for _, required := range requiredDBParams {
+		query = append(query, required.key+"="+required.value)
+	}
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		query = append(query, key+"="+url.QueryEscape(params.Get(key)))
+	}
+
+	switch c.TLSMode {
+	case TLSModeCustom:
+		query = append(query, "tls="+customTLSConfigName)
+	case "":
+	default:
+		query = append(query, "tls="+c.TLSMode)
+	}
+
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?%s", c.User, c.Password, host, port, c.DBName, strings.Join(query, "&"))
+	if _, err := mysql.ParseDSN(dsn); err != nil {
+		return "", fmt.Errorf("invalid DB_PARAMS: %w", err)
+	}
+	return dsn, nil
+}
+
+// params merges Params over DefaultDBParams, rejecting changes to the required parameters and
+// TLS, which is configured by DB_TLS_MODE
+func (c *DatabaseConfig) params() (url.Values, error) {
+	params, err := url.ParseQuery(DefaultDBParams)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := url.ParseQuery(c.Params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_PARAMS: %w", err)
+	}
+	for key := range overrides {
+		value := overrides.Get(key)
+		if key == "tls" {
+			return nil, fmt.Errorf("DB_PARAMS must not set tls, use DB_TLS_MODE")
+		}
+		for _, required := range requiredDBParams {
+			if key == required.key && value != required.value {
+				return nil, fmt.Errorf("DB_PARAMS must not change %s, which must be %s", key, required.value)
+			}
+		}
+		params.Set(key, value)
+	}
+
+	for _, required := range requiredDBParams {
+		params.Del(required.key)
+	}
+	return params, nil
+}
+
+// open opens and pings a connection pool to the given MySQL host
+func (c *DatabaseConfig) open(host, port string) (*sql.DB, error) {
+	dsn, err := c.dsn(host, port)
+	if err != nil {
+		return nil, err
+	}
 
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
 
-	// Test the connection
-	if err := db.Ping(); err != nil {
+	// Test the connection, waiting for the database to come up
+	if err := pingWithRetry(db, c.ConnectTimeout, clock.Real{}, time.Sleep); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Println("Successfully connected to MySQL database")
 	return db, nil
 }
 
+// pinger is the part of *sql.DB needed to check connectivity
+type pinger interface {
+	Ping() error
+}
+
+// pingWithRetry pings until it succeeds or timeout has elapsed, backing off exponentially
+// between attempts. The last ping error is returned when the timeout is exhausted.
+func pingWithRetry(db pinger, timeout time.Duration, clk clock.Clock, sleep func(time.Duration)) error {
+	deadline := clk.Now().Add(timeout)
+	backoff := initialConnectBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := db.Ping()
+		if err == nil {
+			return nil
+		}
+
+		remaining := deadline.Sub(clk.Now())
+		if remaining <= 0 {
+			return err
+		}
+		if backoff > remaining {
+			backoff = remaining
+		}
+
+		log.Printf("Database not ready (attempt %d): %v; retrying in %s", attempt, err, backoff)
+		sleep(backoff)
+
+		backoff *= 2
+		if backoff > maxConnectBackoff {
+			backoff = maxConnectBackoff
+		}
+	}
+}
+
+// getEnvDuration gets a duration environment variable such as "30s" with a fallback default
+// value. A value that is not a duration is an error.
+func getEnvDuration(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration such as 30s: %w", key, err)
+	}
+	return parsed, nil
+}
+
 // getEnv gets an environment variable with a fallback default value
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
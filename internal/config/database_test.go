@@ -0,0 +1,214 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyPinger fails a fixed number of pings before succeeding
+type flakyPinger struct {
+	failures int
+	calls    int
+}
+
+func (p *flakyPinger) Ping() error {
+	p.calls++
+	if p.calls <= p.failures {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestPingWithRetry_SucceedsOnceDatabaseIsUp(t *testing.T) {
+	db := &flakyPinger{failures: 3}
+	clk := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	var waits []time.Duration
+
+	err := pingWithRetry(db, 30*time.Second, clk, func(d time.Duration) {
+		waits = append(waits, d)
+		clk.Advance(d)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, db.calls)
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second}, waits)
+}
+
+func TestPingWithRetry_GivesUpAfterTimeout(t *testing.T) {
+	db := &flakyPinger{failures: 1000}
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	clk := clock.NewMock(start)
+
+	err := pingWithRetry(db, 10*time.Second, clk, clk.Advance)
+
+	assert.EqualError(t, err, "connection refused")
+	assert.Equal(t, start.Add(10*time.Second), clk.Now())
+}
+
+func TestPingWithRetry_ZeroTimeoutTriesOnce(t *testing.T) {
+	db := &flakyPinger{failures: 1}
+	clk := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	err := pingWithRetry(db, 0, clk, clk.Advance)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, db.calls)
+}
+
+func TestNewDatabaseConfig_ConnectTimeout(t *testing.T) {
+	os.Setenv("DB_CONNECT_TIMEOUT", "2m")
+	defer os.Unsetenv("DB_CONNECT_TIMEOUT")
+
+	cfg, err := NewDatabaseConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, cfg.ConnectTimeout)
+
+	os.Setenv("DB_CONNECT_TIMEOUT", "soon")
+	_, err = NewDatabaseConfig()
+	assert.EqualError(t, err, `DB_CONNECT_TIMEOUT must be a duration such as 30s: time: invalid duration "soon"`)
+
+	os.Unsetenv("DB_CONNECT_TIMEOUT")
+	cfg, err = NewDatabaseConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, cfg.ConnectTimeout)
+}
+
+func TestDatabaseConfig_DSN(t *testing.T) {
+	const base = "root:pw@tcp(db:3306)/incidents?parseTime=true&multiStatements=true&charset=utf8mb4&loc=UTC&readTimeout=30s&timeout=10s"
+	tests := []struct {
+		mode     string
+		expected string
+	}{
+		{mode: "", expected: base},
+		{mode: TLSModeFalse, expected: base + "&tls=false"},
+		{mode: TLSModeSkipVerify, expected: base + "&tls=skip-verify"},
+		{mode: TLSModeTrue, expected: base + "&tls=true"},
+	}
+
+	for _, tt := range tests {
+		t.Run("mode "+tt.mode, func(t *testing.T) {
+			c := &DatabaseConfig{User: "root", Password: "pw", DBName: "incidents", TLSMode: tt.mode}
+			dsn, err := c.dsn("db", "3306")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, dsn)
+		})
+	}
+}
+
+func TestDatabaseConfig_DSNParams(t *testing.T) {
+	t.Run("overrides defaults and adds parameters", func(t *testing.T) {
+		c := &DatabaseConfig{User: "root", Password: "pw", DBName: "incidents",
+			Params: "loc=Europe/Paris&timeout=5s&interpolateParams=true&parseTime=true"}
+
+		dsn, err := c.dsn("db", "3306")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "root:pw@tcp(db:3306)/incidents?parseTime=true&multiStatements=true&charset=utf8mb4&"+
+			"interpolateParams=true&loc=Europe%2FParis&readTimeout=30s&timeout=5s", dsn)
+
+		parsed, err := mysql.ParseDSN(dsn)
+		assert.NoError(t, err)
+		assert.Equal(t, "Europe/Paris", parsed.Loc.String())
+		assert.Equal(t, 5*time.Second, parsed.Timeout)
+		assert.Equal(t, 30*time.Second, parsed.ReadTimeout)
+		assert.True(t, parsed.ParseTime)
+		assert.True(t, parsed.MultiStatements)
+		assert.True(t, parsed.InterpolateParams)
+	})
+
+	invalid := []struct {
+		params   string
+		expected string
+	}{
+		{params: "parseTime=false", expected: "DB_PARAMS must not change parseTime, which must be true"},
+		{params: "multiStatements=false", expected: "DB_PARAMS must not change multiStatements, which must be true"},
+		{params: "tls=true", expected: "DB_PARAMS must not set tls, use DB_TLS_MODE"},
+		{params: "timeout=soon", expected: "invalid DB_PARAMS"},
+		{params: "loc=Nowhere/Atlantis", expected: "invalid DB_PARAMS"},
+		{params: "charset=%zz", expected: "invalid DB_PARAMS"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.params, func(t *testing.T) {
+			_, err := (&DatabaseConfig{User: "root", DBName: "incidents", Params: tt.params}).dsn("db", "3306")
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestDatabaseConfig_ConfigureTLS(t *testing.T) {
+	t.Run("unknown mode", func(t *testing.T) {
+		err := (&DatabaseConfig{TLSMode: "required"}).configureTLS()
+		assert.EqualError(t, err, `DB_TLS_MODE must be false, skip-verify, true or custom, got "required"`)
+	})
+
+	t.Run("custom without a CA", func(t *testing.T) {
+		err := (&DatabaseConfig{TLSMode: TLSModeCustom}).configureTLS()
+		assert.EqualError(t, err, "DB_TLS_CA is required when DB_TLS_MODE is custom")
+	})
+
+	t.Run("custom with a missing CA file", func(t *testing.T) {
+		err := (&DatabaseConfig{TLSMode: TLSModeCustom, TLSCA: filepath.Join(t.TempDir(), "ca.pem")}).configureTLS()
+		assert.ErrorContains(t, err, "failed to read DB_TLS_CA")
+	})
+
+	t.Run("custom with a file that is not PEM", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ca.pem")
+		assert.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0o600))
+
+		err := (&DatabaseConfig{TLSMode: TLSModeCustom, TLSCA: path}).configureTLS()
+		assert.ErrorContains(t, err, "contains no PEM certificates")
+	})
+
+	t.Run("custom with a CA", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ca.pem")
+		assert.NoError(t, os.WriteFile(path, selfSignedCertPEM(t), 0o600))
+
+		c := &DatabaseConfig{User: "root", DBName: "incidents", TLSMode: TLSModeCustom, TLSCA: path}
+		assert.NoError(t, c.configureTLS())
+
+		dsn, err := c.dsn("db", "3306")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasSuffix(dsn, "&tls=custom"))
+	})
+
+	t.Run("modes without a CA", func(t *testing.T) {
+		for _, mode := range []string{"", TLSModeFalse, TLSModeSkipVerify, TLSModeTrue} {
+			assert.NoError(t, (&DatabaseConfig{TLSMode: mode}).configureTLS())
+		}
+	})
+}
+
+// selfSignedCertPEM returns a throwaway self-signed CA certificate in PEM form
+func selfSignedCertPEM(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
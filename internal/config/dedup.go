@@ -0,0 +1,53 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadDedupWindows reads DEDUP_WINDOW, how far back duplicate checks look (0, the default,
+// looks back forever), and the per-service overrides in the JSON file named by
+// DEDUP_WINDOWS_FILE, an object mapping each affected service to a duration such as "10m"
+func LoadDedupWindows() (domain.DedupWindows, error) {
+	window, err := getEnvDuration("DEDUP_WINDOW", 0)
+	if err != nil {
+		return domain.DedupWindows{}, err
+	}
+	windows := domain.DedupWindows{Default: window}
+	if windows.Default < 0 {
+		return domain.DedupWindows{}, fmt.Errorf("DEDUP_WINDOW must not be negative, got %s", windows.Default)
+	}
+
+	path := os.Getenv("DEDUP_WINDOWS_FILE")
+	if path == "" {
+		return windows, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return domain.DedupWindows{}, fmt.Errorf("failed to read dedup windows: %w", err)
+	}
+
+	var overrides map[string]string
+	if err := json.Unmarshal(raw, &overrides); err != nil {
+		return domain.DedupWindows{}, fmt.Errorf("failed to parse dedup windows: %w", err)
+	}
+
+	windows.Services = make(map[string]time.Duration, len(overrides))
+	for service, value := range overrides {
+		window, err := time.ParseDuration(value)
+		if err != nil {
+			return domain.DedupWindows{}, fmt.Errorf("dedup window of %q: %w", service, err)
+		}
+		if window < 0 {
+			return domain.DedupWindows{}, fmt.Errorf("dedup window of %q must not be negative, got %s", service, window)
+		}
+		windows.Services[strings.ToLower(strings.TrimSpace(service))] = window
+	}
+	return windows, nil
+}
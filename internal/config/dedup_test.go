@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDedupWindows(t *testing.T) {
+	writeWindows := func(t *testing.T, content string) {
+		path := filepath.Join(t.TempDir(), "dedup_windows.json")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		t.Setenv("DEDUP_WINDOWS_FILE", path)
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		windows, err := LoadDedupWindows()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DedupWindows{}, windows)
+	})
+
+	t.Run("default and overrides", func(t *testing.T) {
+		t.Setenv("DEDUP_WINDOW", "15m")
+		writeWindows(t, `{"Batch-Reports": "6h", "payment-gateway": "2m"}`)
+
+		windows, err := LoadDedupWindows()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DedupWindows{
+			Default:  15 * time.Minute,
+			Services: map[string]time.Duration{"batch-reports": 6 * time.Hour, "payment-gateway": 2 * time.Minute},
+		}, windows)
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		writeWindows(t, `{"payment-gateway": "soon"}`)
+
+		_, err := LoadDedupWindows()
+		assert.Error(t, err)
+	})
+
+	t.Run("negative duration", func(t *testing.T) {
+		writeWindows(t, `{"payment-gateway": "-1m"}`)
+
+		_, err := LoadDedupWindows()
+		assert.Error(t, err)
+	})
+}
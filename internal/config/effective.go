@@ -0,0 +1,108 @@
+package config
+
+import (
+	"os"
+	"strconv"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// setting describes an environment variable read at startup and its default
+type setting struct {
+	name     string
+	fallback string
+	secret   bool
+}
+
+// settings lists every environment variable the server reads, with the default each loader
+// falls back to. Keep it in step with the loaders when adding a variable.
+var settings = []setting{
+	{name: "SERVER_PORT", fallback: "8080"},
+	{name: "SHUTDOWN_TIMEOUT", fallback: DefaultShutdownTimeout.String()},
+	{name: "DB_HOST", fallback: "localhost"},
+	{name: "DB_PORT", fallback: "3306"},
+	{name: "DB_USER", fallback: "root"},
+	{name: "DB_PASSWORD", fallback: "password", secret: true},
+	{name: "DB_NAME", fallback: "incident_triage"},
+	{name: "DB_READ_HOST"},
+	{name: "DB_READ_PORT"},
+	{name: "DB_CONNECT_TIMEOUT", fallback: "30s"},
+	{name: "DB_TLS_MODE"},
+	{name: "DB_TLS_CA"},
+	{name: "DB_PARAMS", fallback: DefaultDBParams},
+	{name: "AI_PROVIDER", fallback: domain.AIProviderOpenAI},
+	{name: "OPENAI_API_KEY", secret: true},
+	{name: "OPENAI_JSON_MODE", fallback: "true"},
+	{name: "OPENAI_MODEL", fallback: DefaultAIModel},
+	{name: "OPENAI_REFINE_MODELS"},
+	{name: "ANTHROPIC_API_KEY", secret: true},
+	{name: "OPENAI_PROMPT_TOKEN_BUDGET", fallback: strconv.Itoa(DefaultPromptTokenBudget)},
+	{name: "OPENAI_MAX_ATTEMPTS", fallback: strconv.Itoa(DefaultAIMaxAttempts)},
+	{name: "OPENAI_RETRY_BASE_DELAY", fallback: DefaultAIRetryBaseDelay.String()},
+	{name: "AI_RESPONSE_BUDGET", fallback: "0s"},
+	{name: "AI_CACHE_TTL", fallback: "0s"},
+	{name: "AI_CACHE_MAX_ENTRIES", fallback: "1000"},
+	{name: "AI_CACHE_NORMALIZE", fallback: DefaultAICacheNormalize},
+	{name: "AI_MAX_CONCURRENCY", fallback: "0"},
+	{name: "AI_CONFIDENCE_FLOOR", fallback: strconv.FormatFloat(domain.LowConfidenceThreshold, 'g', -1, 64)},
+	{name: "AI_CONFIDENCE_FLOOR_SEVERITY", fallback: domain.DefaultSeverity},
+	{name: "TRIAGE_MODE", fallback: domain.TriageOn},
+	{name: "TRIAGE_DEFAULT_SEVERITY", fallback: domain.DefaultSeverity},
+	{name: "TRIAGE_DEFAULT_CATEGORY", fallback: domain.DefaultCategory},
+	{name: "ADMIN_TOKEN", secret: true},
+	{name: "DEFAULT_PAGE_SIZE", fallback: "50"},
+	{name: "MAX_PAGE_SIZE", fallback: "200"},
+	{name: "PAGE_SIZE_OVERFLOW", fallback: PageSizeOverflowClamp},
+	{name: "MAX_TITLE_LENGTH", fallback: strconv.Itoa(domain.MaxTitleLength)},
+	{name: "MAX_DESCRIPTION_LENGTH", fallback: strconv.Itoa(domain.MaxDescriptionLength)},
+	{name: "MAX_AFFECTED_SERVICE_LENGTH", fallback: strconv.Itoa(domain.MaxAffectedServiceLength)},
+	{name: "CUSTOM_FIELDS_SCHEMA_FILE"},
+	{name: "CATEGORY_ROUTING_FILE"},
+	{name: "TEAM_ROSTERS_FILE"},
+	{name: "REDACT_PATTERNS_FILE"},
+	{name: "SERVICE_CATALOG_FILE"},
+	{name: "SERVICE_CATALOG_MODE", fallback: "soft"},
+	{name: "SERVICE_METADATA_FILE"},
+	{name: "SERVICE_METADATA_CACHE_TTL", fallback: "5m"},
+	{name: "FEATURE_FLAGS"},
+	{name: "STORM_LIMIT", fallback: "0"},
+	{name: "STORM_WINDOW", fallback: "1m"},
+	{name: "NOTIFY_DIGEST_INTERVAL", fallback: "0s"},
+	{name: "FOLLOW_UP_CHECK_INTERVAL", fallback: "1m"},
+	{name: "REPROCESS_INTERVAL", fallback: "5m"},
+	{name: "ACK_REMINDER_AFTER", fallback: "0s"},
+	{name: "ACK_REMINDER_REPEAT", fallback: "1h"},
+	{name: "ACK_REMINDER_CHECK_INTERVAL", fallback: "1m"},
+	{name: "TREND_ALERT_WINDOW", fallback: "1h"},
+	{name: "TREND_ALERT_THRESHOLD", fallback: "0"},
+	{name: "TREND_ALERT_SPIKE_FACTOR", fallback: "0"},
+	{name: "TREND_CHECK_INTERVAL", fallback: "5m"},
+	{name: "SLOW_QUERY_MS", fallback: "200"},
+	{name: "STRICT_UNIQUE_INCIDENTS", fallback: "false"},
+	{name: "DEDUP_WINDOW", fallback: "0s"},
+	{name: "DEDUP_WINDOWS_FILE"},
+	{name: "REANALYZE_ON_UPDATE", fallback: "always"},
+	{name: "REANALYZE_THRESHOLD", fallback: "0.2"},
+	{name: "RESPONSE_ENVELOPE", fallback: "false"},
+	{name: "DEBUG_TIMINGS", fallback: "false"},
+	{name: "ID_AS_STRING", fallback: "false"},
+	{name: "INCIDENT_REFERENCE_FORMAT", fallback: "INC-{year}-{seq}"},
+	{name: "INCIDENT_REFERENCE_DIGITS", fallback: "6"},
+}
+
+// EffectiveSettings reports the value of every setting and whether it came from the
+// environment or the default. Secrets that are set are shown as domain.RedactedValue.
+func EffectiveSettings() []domain.ConfigSetting {
+	resolved := make([]domain.ConfigSetting, len(settings))
+	for i, s := range settings {
+		value, source := s.fallback, domain.ConfigSourceDefault
+		if env := os.Getenv(s.name); env != "" {
+			value, source = env, domain.ConfigSourceEnv
+		}
+		if s.secret && value != "" {
+			value = domain.RedactedValue
+		}
+		resolved[i] = domain.ConfigSetting{Name: s.name, Value: value, Source: source}
+	}
+	return resolved
+}
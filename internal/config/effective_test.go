@@ -0,0 +1,36 @@
+package config
+
+import (
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectiveSettings(t *testing.T) {
+	t.Setenv("DB_HOST", "mysql.internal")
+	t.Setenv("DB_PASSWORD", "hunter2")
+	t.Setenv("OPENAI_API_KEY", "sk-secret")
+	t.Setenv("ADMIN_TOKEN", "")
+	t.Setenv("MAX_PAGE_SIZE", "")
+
+	resolved := map[string]domain.ConfigSetting{}
+	for _, s := range EffectiveSettings() {
+		resolved[s.Name] = s
+	}
+
+	t.Run("secrets are redacted", func(t *testing.T) {
+		assert.Equal(t, domain.ConfigSetting{Name: "DB_PASSWORD", Value: "***", Source: domain.ConfigSourceEnv}, resolved["DB_PASSWORD"])
+		assert.Equal(t, domain.ConfigSetting{Name: "OPENAI_API_KEY", Value: "***", Source: domain.ConfigSourceEnv}, resolved["OPENAI_API_KEY"])
+	})
+
+	t.Run("unset secrets stay empty", func(t *testing.T) {
+		assert.Equal(t, domain.ConfigSetting{Name: "ADMIN_TOKEN", Value: "", Source: domain.ConfigSourceDefault}, resolved["ADMIN_TOKEN"])
+	})
+
+	t.Run("env overrides default", func(t *testing.T) {
+		assert.Equal(t, domain.ConfigSetting{Name: "DB_HOST", Value: "mysql.internal", Source: domain.ConfigSourceEnv}, resolved["DB_HOST"])
+		assert.Equal(t, domain.ConfigSetting{Name: "MAX_PAGE_SIZE", Value: "200", Source: domain.ConfigSourceDefault}, resolved["MAX_PAGE_SIZE"])
+	})
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadFeatureFlags reads flag overrides from FEATURE_FLAGS, a comma-separated list of
+// name=true|false pairs such as "embeddings=false,ingest=true". Flags that are not listed
+// keep their defaults.
+func LoadFeatureFlags() (map[string]bool, error) {
+	overrides := map[string]bool{}
+
+	value := os.Getenv("FEATURE_FLAGS")
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, state, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("FEATURE_FLAGS entry %q must be name=true|false", pair)
+		}
+		name = strings.TrimSpace(name)
+		if _, known := domain.DefaultFeatureFlags[name]; !known {
+			return nil, fmt.Errorf("FEATURE_FLAGS references unknown flag %q (known: %s)", name, strings.Join(knownFlags(), ", "))
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(state))
+		if err != nil {
+			return nil, fmt.Errorf("FEATURE_FLAGS flag %q has invalid state %q", name, state)
+		}
+		overrides[name] = enabled
+	}
+
+	return overrides, nil
+}
+
+// knownFlags returns the names of all feature flags, sorted
+func knownFlags() []string {
+	names := make([]string, 0, len(domain.DefaultFeatureFlags))
+	for name := range domain.DefaultFeatureFlags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
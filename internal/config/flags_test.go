@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFeatureFlags(t *testing.T) {
+	t.Run("unset", func(t *testing.T) {
+		flags, err := LoadFeatureFlags()
+		assert.NoError(t, err)
+		assert.Empty(t, flags)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		t.Setenv("FEATURE_FLAGS", "embeddings=false, ingest=true")
+
+		flags, err := LoadFeatureFlags()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{domain.FlagEmbeddings: false, domain.FlagIngest: true}, flags)
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		t.Setenv("FEATURE_FLAGS", "notifications=true")
+
+		_, err := LoadFeatureFlags()
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		t.Setenv("FEATURE_FLAGS", "dedup=maybe")
+
+		_, err := LoadFeatureFlags()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoadNotifyDigestInterval reads NOTIFY_DIGEST_INTERVAL, how often Low and Medium incident
+// notifications are sent as one digest. Zero (the default) notifies every incident on its own.
+func LoadNotifyDigestInterval() (time.Duration, error) {
+	interval, err := getEnvDuration("NOTIFY_DIGEST_INTERVAL", 0)
+	if err != nil {
+		return 0, err
+	}
+	if interval < 0 {
+		return 0, fmt.Errorf("NOTIFY_DIGEST_INTERVAL must not be negative, got %s", interval)
+	}
+	return interval, nil
+}
+
+// LoadFollowUpCheckInterval reads FOLLOW_UP_CHECK_INTERVAL, how often due follow-up reminders
+// are looked for and sent (default 1m). Zero turns the reminders off.
+func LoadFollowUpCheckInterval() (time.Duration, error) {
+	interval, err := getEnvDuration("FOLLOW_UP_CHECK_INTERVAL", time.Minute)
+	if err != nil {
+		return 0, err
+	}
+	if interval < 0 {
+		return 0, fmt.Errorf("FOLLOW_UP_CHECK_INTERVAL must not be negative, got %s", interval)
+	}
+	return interval, nil
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadNotifyDigestInterval(t *testing.T) {
+	t.Run("off by default", func(t *testing.T) {
+		interval, err := LoadNotifyDigestInterval()
+		assert.NoError(t, err)
+		assert.Zero(t, interval)
+	})
+
+	t.Run("custom interval", func(t *testing.T) {
+		t.Setenv("NOTIFY_DIGEST_INTERVAL", "15m")
+
+		interval, err := LoadNotifyDigestInterval()
+		assert.NoError(t, err)
+		assert.Equal(t, 15*time.Minute, interval)
+	})
+
+	t.Run("negative interval", func(t *testing.T) {
+		t.Setenv("NOTIFY_DIGEST_INTERVAL", "-1m")
+
+		_, err := LoadNotifyDigestInterval()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadFollowUpCheckInterval(t *testing.T) {
+	t.Run("every minute by default", func(t *testing.T) {
+		interval, err := LoadFollowUpCheckInterval()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Minute, interval)
+	})
+
+	t.Run("off", func(t *testing.T) {
+		t.Setenv("FOLLOW_UP_CHECK_INTERVAL", "0")
+
+		interval, err := LoadFollowUpCheckInterval()
+		assert.NoError(t, err)
+		assert.Zero(t, interval)
+	})
+
+	t.Run("negative interval", func(t *testing.T) {
+		t.Setenv("FOLLOW_UP_CHECK_INTERVAL", "-1m")
+
+		_, err := LoadFollowUpCheckInterval()
+		assert.Error(t, err)
+	})
+}
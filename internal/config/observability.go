@@ -0,0 +1,19 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoadSlowQueryThreshold reads SLOW_QUERY_MS, the duration above which repository operations
+// are logged as slow. Zero disables slow query logging.
+func LoadSlowQueryThreshold() (time.Duration, error) {
+	ms, err := getEnvInt("SLOW_QUERY_MS", 200)
+	if err != nil {
+		return 0, err
+	}
+	if ms < 0 {
+		return 0, fmt.Errorf("SLOW_QUERY_MS must not be negative, got %d", ms)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
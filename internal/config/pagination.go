@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Page size overflow policies for PAGE_SIZE_OVERFLOW
+const (
+	PageSizeOverflowClamp  = "clamp"
+	PageSizeOverflowReject = "reject"
+)
+
+// PaginationConfig holds list pagination limits
+type PaginationConfig struct {
+	DefaultPageSize int
+	MaxPageSize     int
+	Overflow        string
+}
+
+// NewPaginationConfig creates a new pagination configuration from environment variables
+func NewPaginationConfig() (*PaginationConfig, error) {
+	defaultPageSize, err := getEnvInt("DEFAULT_PAGE_SIZE", 50)
+	if err != nil {
+		return nil, err
+	}
+
+	maxPageSize, err := getEnvInt("MAX_PAGE_SIZE", 200)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &PaginationConfig{
+		DefaultPageSize: defaultPageSize,
+		MaxPageSize:     maxPageSize,
+		Overflow:        getEnv("PAGE_SIZE_OVERFLOW", PageSizeOverflowClamp),
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate checks that the page sizes are positive and consistent
+func (c *PaginationConfig) Validate() error {
+	if c.DefaultPageSize < 1 {
+		return fmt.Errorf("DEFAULT_PAGE_SIZE must be at least 1, got %d", c.DefaultPageSize)
+	}
+	if c.MaxPageSize < c.DefaultPageSize {
+		return fmt.Errorf("DEFAULT_PAGE_SIZE (%d) must not exceed MAX_PAGE_SIZE (%d)", c.DefaultPageSize, c.MaxPageSize)
+	}
+	if c.Overflow != PageSizeOverflowClamp && c.Overflow != PageSizeOverflowReject {
+		return fmt.Errorf("PAGE_SIZE_OVERFLOW must be %q or %q, got %q", PageSizeOverflowClamp, PageSizeOverflowReject, c.Overflow)
+	}
+	return nil
+}
+
+// RejectOversized reports whether limits above the maximum should be rejected instead of clamped
+func (c *PaginationConfig) RejectOversized() bool {
+	return c.Overflow == PageSizeOverflowReject
+}
+
+// getEnvInt gets an integer environment variable with a fallback default value
+func getEnvInt(key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer: %w", key, err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPaginationConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := NewPaginationConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, 50, cfg.DefaultPageSize)
+		assert.Equal(t, 200, cfg.MaxPageSize)
+		assert.False(t, cfg.RejectOversized())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("DEFAULT_PAGE_SIZE", "25")
+		t.Setenv("MAX_PAGE_SIZE", "25")
+		t.Setenv("PAGE_SIZE_OVERFLOW", "reject")
+
+		cfg, err := NewPaginationConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, 25, cfg.DefaultPageSize)
+		assert.Equal(t, 25, cfg.MaxPageSize)
+		assert.True(t, cfg.RejectOversized())
+	})
+
+	t.Run("default above max", func(t *testing.T) {
+		t.Setenv("DEFAULT_PAGE_SIZE", "100")
+		t.Setenv("MAX_PAGE_SIZE", "50")
+
+		cfg, err := NewPaginationConfig()
+		assert.Error(t, err)
+		assert.Nil(t, cfg)
+	})
+
+	t.Run("non-numeric size", func(t *testing.T) {
+		t.Setenv("MAX_PAGE_SIZE", "lots")
+
+		_, err := NewPaginationConfig()
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown overflow policy", func(t *testing.T) {
+		t.Setenv("PAGE_SIZE_OVERFLOW", "truncate")
+
+		_, err := NewPaginationConfig()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadReanalysisPolicy reads REANALYZE_ON_UPDATE, when updates re-run the AI analysis:
+// "always" (default), "never" or "significant". In significant mode REANALYZE_THRESHOLD
+// (default 0.2) is the share of the title and description that must change.
+func LoadReanalysisPolicy() (domain.ReanalysisPolicy, error) {
+	policy := domain.ReanalysisPolicy{
+		Mode:      getEnv("REANALYZE_ON_UPDATE", domain.ReanalyzeAlways),
+		Threshold: domain.DefaultReanalysisThreshold,
+	}
+
+	switch policy.Mode {
+	case domain.ReanalyzeAlways, domain.ReanalyzeNever, domain.ReanalyzeSignificant:
+	default:
+		return domain.ReanalysisPolicy{}, fmt.Errorf("REANALYZE_ON_UPDATE must be always, never or significant, got %q", policy.Mode)
+	}
+
+	if value := os.Getenv("REANALYZE_THRESHOLD"); value != "" {
+		threshold, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return domain.ReanalysisPolicy{}, fmt.Errorf("REANALYZE_THRESHOLD must be a number: %w", err)
+		}
+		if threshold < 0 || threshold > 1 {
+			return domain.ReanalysisPolicy{}, fmt.Errorf("REANALYZE_THRESHOLD must be between 0 and 1, got %g", threshold)
+		}
+		policy.Threshold = threshold
+	}
+	return policy, nil
+}
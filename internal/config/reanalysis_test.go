@@ -0,0 +1,47 @@
+package config
+
+import (
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadReanalysisPolicy(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		policy, err := LoadReanalysisPolicy()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ReanalysisPolicy{Mode: domain.ReanalyzeAlways, Threshold: domain.DefaultReanalysisThreshold}, policy)
+	})
+
+	t.Run("significant with threshold", func(t *testing.T) {
+		t.Setenv("REANALYZE_ON_UPDATE", "significant")
+		t.Setenv("REANALYZE_THRESHOLD", "0.35")
+
+		policy, err := LoadReanalysisPolicy()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ReanalysisPolicy{Mode: domain.ReanalyzeSignificant, Threshold: 0.35}, policy)
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		t.Setenv("REANALYZE_ON_UPDATE", "sometimes")
+
+		_, err := LoadReanalysisPolicy()
+		assert.Error(t, err)
+	})
+
+	t.Run("threshold out of range", func(t *testing.T) {
+		t.Setenv("REANALYZE_THRESHOLD", "1.5")
+
+		_, err := LoadReanalysisPolicy()
+		assert.Error(t, err)
+	})
+
+	t.Run("threshold not a number", func(t *testing.T) {
+		t.Setenv("REANALYZE_THRESHOLD", "lots")
+
+		_, err := LoadReanalysisPolicy()
+		assert.Error(t, err)
+	})
+}
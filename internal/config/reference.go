@@ -0,0 +1,24 @@
+package config
+
+import (
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadReferenceFormat reads INCIDENT_REFERENCE_FORMAT, the pattern of human-friendly incident
+// references with {year} and {seq} placeholders (default INC-{year}-{seq}), and
+// INCIDENT_REFERENCE_DIGITS, the width the sequence number is zero-padded to (default 6)
+func LoadReferenceFormat() (domain.ReferenceFormat, error) {
+	digits, err := getEnvInt("INCIDENT_REFERENCE_DIGITS", domain.DefaultReferenceDigits)
+	if err != nil {
+		return domain.ReferenceFormat{}, err
+	}
+
+	format := domain.ReferenceFormat{
+		Pattern: getEnv("INCIDENT_REFERENCE_FORMAT", domain.DefaultReferencePattern),
+		Digits:  digits,
+	}
+	if err := format.Validate(); err != nil {
+		return domain.ReferenceFormat{}, err
+	}
+	return format, nil
+}
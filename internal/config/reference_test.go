@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadReferenceFormat(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		format, err := LoadReferenceFormat()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DefaultReferenceFormat, format)
+	})
+
+	t.Run("custom format", func(t *testing.T) {
+		t.Setenv("INCIDENT_REFERENCE_FORMAT", "OPS{year}/{seq}")
+		t.Setenv("INCIDENT_REFERENCE_DIGITS", "4")
+
+		format, err := LoadReferenceFormat()
+		assert.NoError(t, err)
+		assert.Equal(t, "OPS2024/0007", format.Format(2024, 7))
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		t.Setenv("INCIDENT_REFERENCE_FORMAT", "{year}{seq}")
+
+		_, err := LoadReferenceFormat()
+		assert.Error(t, err)
+	})
+
+	t.Run("non-numeric digits", func(t *testing.T) {
+		t.Setenv("INCIDENT_REFERENCE_DIGITS", "six")
+
+		_, err := LoadReferenceFormat()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoadReprocessInterval reads REPROCESS_INTERVAL, how often incidents whose AI analysis failed
+// or was lost are reanalyzed in the background (default 5m). Zero leaves them to the admin
+// reprocess endpoint.
+func LoadReprocessInterval() (time.Duration, error) {
+	interval, err := getEnvDuration("REPROCESS_INTERVAL", 5*time.Minute)
+	if err != nil {
+		return 0, err
+	}
+	if interval < 0 {
+		return 0, fmt.Errorf("REPROCESS_INTERVAL must not be negative, got %s", interval)
+	}
+	return interval, nil
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadReprocessInterval(t *testing.T) {
+	t.Run("every five minutes by default", func(t *testing.T) {
+		interval, err := LoadReprocessInterval()
+		assert.NoError(t, err)
+		assert.Equal(t, 5*time.Minute, interval)
+	})
+
+	t.Run("off", func(t *testing.T) {
+		t.Setenv("REPROCESS_INTERVAL", "0")
+
+		interval, err := LoadReprocessInterval()
+		assert.NoError(t, err)
+		assert.Zero(t, interval)
+	})
+
+	t.Run("negative interval", func(t *testing.T) {
+		t.Setenv("REPROCESS_INTERVAL", "-1m")
+
+		_, err := LoadReprocessInterval()
+		assert.Error(t, err)
+	})
+}
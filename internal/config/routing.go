@@ -0,0 +1,46 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// RoutingConfig maps incident categories to responsible teams
+type RoutingConfig struct {
+	Default *domain.TeamRoute           `json:"default"`
+	Routes  map[string]domain.TeamRoute `json:"routes"`
+}
+
+// LoadRoutingConfig reads the category routing map from the JSON file named by CATEGORY_ROUTING_FILE.
+// It returns nil when the variable is unset.
+func LoadRoutingConfig() (*RoutingConfig, error) {
+	path := os.Getenv("CATEGORY_ROUTING_FILE")
+	if path == "" {
+		return nil, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read routing file: %w", err)
+	}
+
+	var cfg RoutingConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse routing file: %w", err)
+	}
+
+	routes := make(map[string]domain.TeamRoute, len(cfg.Routes))
+	for category, route := range cfg.Routes {
+		canonical, ok := domain.CanonicalValue(domain.Categories, category)
+		if !ok {
+			return nil, fmt.Errorf("routing file references unknown category %q", category)
+		}
+		routes[canonical] = route
+	}
+	cfg.Routes = routes
+
+	return &cfg, nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadRedactPatterns reads redaction regexes from the file named by REDACT_PATTERNS_FILE,
+// one per line, ignoring blank lines and lines starting with '#'.
+// It returns nil when the variable is unset so callers can fall back to their defaults.
+func LoadRedactPatterns() ([]string, error) {
+	path := os.Getenv("REDACT_PATTERNS_FILE")
+	if path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open redact patterns file: %w", err)
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read redact patterns file: %w", err)
+	}
+
+	return patterns, nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadServiceCatalog reads the known affected services from the JSON array in the file named
+// by SERVICE_CATALOG_FILE. SERVICE_CATALOG_MODE selects "soft" (default), which only flags
+// unknown services, or "strict", which rejects them. It returns nil, accepting any service,
+// when the file variable is unset.
+func LoadServiceCatalog() (*domain.ServiceCatalog, error) {
+	path := os.Getenv("SERVICE_CATALOG_FILE")
+	if path == "" {
+		return nil, nil
+	}
+
+	catalog := &domain.ServiceCatalog{}
+	switch mode := getEnv("SERVICE_CATALOG_MODE", "soft"); mode {
+	case "soft":
+	case "strict":
+		catalog.Strict = true
+	default:
+		return nil, fmt.Errorf("SERVICE_CATALOG_MODE must be soft or strict, got %q", mode)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service catalog: %w", err)
+	}
+	if err := json.Unmarshal(raw, &catalog.Services); err != nil {
+		return nil, fmt.Errorf("failed to parse service catalog: %w", err)
+	}
+
+	for _, service := range catalog.Services {
+		if strings.TrimSpace(service) == "" {
+			return nil, fmt.Errorf("service catalog has a blank service name")
+		}
+	}
+	return catalog, nil
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadServiceCatalog(t *testing.T) {
+	writeCatalog := func(t *testing.T, content string) {
+		path := filepath.Join(t.TempDir(), "services.json")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		t.Setenv("SERVICE_CATALOG_FILE", path)
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		catalog, err := LoadServiceCatalog()
+		assert.NoError(t, err)
+		assert.Nil(t, catalog)
+	})
+
+	t.Run("soft by default", func(t *testing.T) {
+		writeCatalog(t, `["auth-service", "search-api"]`)
+
+		catalog, err := LoadServiceCatalog()
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.ServiceCatalog{Services: []string{"auth-service", "search-api"}}, catalog)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		writeCatalog(t, `["auth-service"]`)
+		t.Setenv("SERVICE_CATALOG_MODE", "strict")
+
+		catalog, err := LoadServiceCatalog()
+		assert.NoError(t, err)
+		assert.True(t, catalog.Strict)
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		writeCatalog(t, `["auth-service"]`)
+		t.Setenv("SERVICE_CATALOG_MODE", "lenient")
+
+		_, err := LoadServiceCatalog()
+		assert.Error(t, err)
+	})
+
+	t.Run("blank service", func(t *testing.T) {
+		writeCatalog(t, `["auth-service", " "]`)
+
+		_, err := LoadServiceCatalog()
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		writeCatalog(t, `{"auth-service": true}`)
+
+		_, err := LoadServiceCatalog()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadServiceMetadata(t *testing.T) {
+	writeMetadata := func(t *testing.T, content string) {
+		path := filepath.Join(t.TempDir(), "service_metadata.json")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		t.Setenv("SERVICE_METADATA_FILE", path)
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		services, ttl, err := LoadServiceMetadata()
+		assert.NoError(t, err)
+		assert.Nil(t, services)
+		assert.Equal(t, DefaultServiceMetadataCacheTTL, ttl)
+	})
+
+	t.Run("file and ttl", func(t *testing.T) {
+		writeMetadata(t, `{"auth-service": {"owner_team": "identity", "on_call": "#identity-oncall", "runbook_url": "https://runbooks.example.com/auth"}}`)
+		t.Setenv("SERVICE_METADATA_CACHE_TTL", "30s")
+
+		services, ttl, err := LoadServiceMetadata()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]domain.ServiceMetadata{
+			"auth-service": {OwnerTeam: "identity", OnCall: "#identity-oncall", RunbookURL: "https://runbooks.example.com/auth"},
+		}, services)
+		assert.Equal(t, 30*time.Second, ttl)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		writeMetadata(t, `["auth-service"]`)
+
+		_, _, err := LoadServiceMetadata()
+		assert.Error(t, err)
+	})
+
+	t.Run("blank service", func(t *testing.T) {
+		writeMetadata(t, `{" ": {"owner_team": "identity"}}`)
+
+		_, _, err := LoadServiceMetadata()
+		assert.Error(t, err)
+	})
+}
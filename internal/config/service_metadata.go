@@ -0,0 +1,50 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// DefaultServiceMetadataCacheTTL is how long service metadata lookups are remembered
+const DefaultServiceMetadataCacheTTL = 5 * time.Minute
+
+// LoadServiceMetadata reads the owner team, on-call and runbook URL of affected services from
+// the JSON object in the file named by SERVICE_METADATA_FILE, keyed by service name, and
+// SERVICE_METADATA_CACHE_TTL, how long lookups are cached (default 5m). It returns a nil map
+// when the file variable is unset.
+func LoadServiceMetadata() (map[string]domain.ServiceMetadata, time.Duration, error) {
+	ttl, err := getEnvDuration("SERVICE_METADATA_CACHE_TTL", DefaultServiceMetadataCacheTTL)
+	if err != nil {
+		return nil, 0, err
+	}
+	if ttl < 0 {
+		return nil, 0, fmt.Errorf("SERVICE_METADATA_CACHE_TTL must not be negative, got %s", ttl)
+	}
+
+	path := os.Getenv("SERVICE_METADATA_FILE")
+	if path == "" {
+		return nil, ttl, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read service metadata: %w", err)
+	}
+
+	var services map[string]domain.ServiceMetadata
+	if err := json.Unmarshal(raw, &services); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse service metadata: %w", err)
+	}
+
+	for service := range services {
+		if strings.TrimSpace(service) == "" {
+			return nil, 0, fmt.Errorf("service metadata has a blank service name")
+		}
+	}
+	return services, ttl, nil
+}
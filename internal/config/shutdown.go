@@ -0,0 +1,22 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultShutdownTimeout is how long in-flight requests get to finish on shutdown by default
+const DefaultShutdownTimeout = 10 * time.Second
+
+// LoadShutdownTimeout reads SHUTDOWN_TIMEOUT, how long the server waits for in-flight requests
+// to finish after SIGINT or SIGTERM before closing their connections
+func LoadShutdownTimeout() (time.Duration, error) {
+	timeout, err := getEnvDuration("SHUTDOWN_TIMEOUT", DefaultShutdownTimeout)
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("SHUTDOWN_TIMEOUT must be positive, got %s", timeout)
+	}
+	return timeout, nil
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadShutdownTimeout(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		timeout, err := LoadShutdownTimeout()
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultShutdownTimeout, timeout)
+	})
+
+	t.Run("custom timeout", func(t *testing.T) {
+		t.Setenv("SHUTDOWN_TIMEOUT", "30s")
+
+		timeout, err := LoadShutdownTimeout()
+		assert.NoError(t, err)
+		assert.Equal(t, 30*time.Second, timeout)
+	})
+
+	t.Run("zero timeout", func(t *testing.T) {
+		t.Setenv("SHUTDOWN_TIMEOUT", "0s")
+
+		_, err := LoadShutdownTimeout()
+		assert.Error(t, err)
+	})
+	t.Run("not a duration", func(t *testing.T) {
+		t.Setenv("SHUTDOWN_TIMEOUT", "ten seconds")
+
+		_, err := LoadShutdownTimeout()
+		assert.ErrorContains(t, err, "SHUTDOWN_TIMEOUT must be a duration")
+	})
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoadStormLimit reads STORM_LIMIT, the number of incidents one affected service may create
+// per STORM_WINDOW (default 1m) before further creates are suppressed into an alert storm
+// incident. Zero disables storm suppression.
+func LoadStormLimit() (int, time.Duration, error) {
+	limit, err := getEnvInt("STORM_LIMIT", 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	if limit < 0 {
+		return 0, 0, fmt.Errorf("STORM_LIMIT must not be negative, got %d", limit)
+	}
+
+	window, err := getEnvDuration("STORM_WINDOW", time.Minute)
+	if err != nil {
+		return 0, 0, err
+	}
+	if window <= 0 {
+		return 0, 0, fmt.Errorf("STORM_WINDOW must be positive, got %s", window)
+	}
+	return limit, window, nil
+}
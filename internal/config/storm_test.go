@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadStormLimit(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		limit, window, err := LoadStormLimit()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, limit)
+		assert.Equal(t, time.Minute, window)
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("STORM_LIMIT", "20")
+		t.Setenv("STORM_WINDOW", "5m")
+
+		limit, window, err := LoadStormLimit()
+		assert.NoError(t, err)
+		assert.Equal(t, 20, limit)
+		assert.Equal(t, 5*time.Minute, window)
+	})
+
+	t.Run("negative limit", func(t *testing.T) {
+		t.Setenv("STORM_LIMIT", "-1")
+
+		_, _, err := LoadStormLimit()
+		assert.Error(t, err)
+	})
+
+	t.Run("non-positive window", func(t *testing.T) {
+		t.Setenv("STORM_LIMIT", "20")
+		t.Setenv("STORM_WINDOW", "0s")
+
+		_, _, err := LoadStormLimit()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadSeverityTrend reads the alerts about spikes in High and Critical incidents:
+// TREND_ALERT_WINDOW, the sliding window they are counted in (default 1h), TREND_ALERT_THRESHOLD,
+// the count within a window above which an alert is sent (default 0, no threshold),
+// TREND_ALERT_SPIKE_FACTOR, how many times the prior window's count is a spike (default 0, no
+// spike check), and TREND_CHECK_INTERVAL, how often the trend is checked (default 5m, 0 turns
+// the alerts off). Without a threshold or a spike factor no alerts are sent.
+func LoadSeverityTrend() (domain.TrendPolicy, time.Duration, error) {
+	window, err := getEnvDuration("TREND_ALERT_WINDOW", time.Hour)
+	if err != nil {
+		return domain.TrendPolicy{}, 0, err
+	}
+	if window <= 0 {
+		return domain.TrendPolicy{}, 0, fmt.Errorf("TREND_ALERT_WINDOW must be positive, got %s", window)
+	}
+
+	threshold, err := getEnvInt("TREND_ALERT_THRESHOLD", 0)
+	if err != nil {
+		return domain.TrendPolicy{}, 0, err
+	}
+	if threshold < 0 {
+		return domain.TrendPolicy{}, 0, fmt.Errorf("TREND_ALERT_THRESHOLD must not be negative, got %d", threshold)
+	}
+
+	var factor float64
+	if value := os.Getenv("TREND_ALERT_SPIKE_FACTOR"); value != "" {
+		factor, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return domain.TrendPolicy{}, 0, fmt.Errorf("TREND_ALERT_SPIKE_FACTOR must be a number: %w", err)
+		}
+		// A factor of one or less would call a steady or falling rate a spike
+		if factor != 0 && factor <= 1 {
+			return domain.TrendPolicy{}, 0, fmt.Errorf("TREND_ALERT_SPIKE_FACTOR must be 0 or greater than 1, got %g", factor)
+		}
+	}
+
+	every, err := getEnvDuration("TREND_CHECK_INTERVAL", 5*time.Minute)
+	if err != nil {
+		return domain.TrendPolicy{}, 0, err
+	}
+	if every < 0 {
+		return domain.TrendPolicy{}, 0, fmt.Errorf("TREND_CHECK_INTERVAL must not be negative, got %s", every)
+	}
+
+	return domain.TrendPolicy{Window: window, Threshold: threshold, SpikeFactor: factor}, every, nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSeverityTrend(t *testing.T) {
+	t.Run("off by default", func(t *testing.T) {
+		policy, every, err := LoadSeverityTrend()
+		assert.NoError(t, err)
+		assert.False(t, policy.Enabled())
+		assert.Equal(t, time.Hour, policy.Window)
+		assert.Equal(t, 5*time.Minute, every)
+	})
+
+	t.Run("threshold and spike factor", func(t *testing.T) {
+		t.Setenv("TREND_ALERT_WINDOW", "30m")
+		t.Setenv("TREND_ALERT_THRESHOLD", "10")
+		t.Setenv("TREND_ALERT_SPIKE_FACTOR", "2.5")
+		t.Setenv("TREND_CHECK_INTERVAL", "1m")
+
+		policy, every, err := LoadSeverityTrend()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TrendPolicy{Window: 30 * time.Minute, Threshold: 10, SpikeFactor: 2.5}, policy)
+		assert.Equal(t, time.Minute, every)
+	})
+
+	t.Run("zero window", func(t *testing.T) {
+		t.Setenv("TREND_ALERT_WINDOW", "0")
+
+		_, _, err := LoadSeverityTrend()
+		assert.Error(t, err)
+	})
+
+	t.Run("negative threshold", func(t *testing.T) {
+		t.Setenv("TREND_ALERT_THRESHOLD", "-1")
+
+		_, _, err := LoadSeverityTrend()
+		assert.Error(t, err)
+	})
+
+	t.Run("spike factor of one", func(t *testing.T) {
+		t.Setenv("TREND_ALERT_SPIKE_FACTOR", "1")
+
+		_, _, err := LoadSeverityTrend()
+		assert.Error(t, err)
+	})
+
+	t.Run("spike factor not a number", func(t *testing.T) {
+		t.Setenv("TREND_ALERT_SPIKE_FACTOR", "double")
+
+		_, _, err := LoadSeverityTrend()
+		assert.Error(t, err)
+	})
+}
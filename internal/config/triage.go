@@ -0,0 +1,30 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadTriagePolicy reads TRIAGE_MODE, "on" (default) to classify incidents with the AI or
+// "off" to never call it. With triage off, TRIAGE_DEFAULT_SEVERITY (default Medium) and
+// TRIAGE_DEFAULT_CATEGORY (default Software) classify incidents whose request has no
+// severity or category.
+func LoadTriagePolicy() (domain.TriagePolicy, error) {
+	mode := getEnv("TRIAGE_MODE", domain.TriageOn)
+	if mode != domain.TriageOn && mode != domain.TriageOff {
+		return domain.TriagePolicy{}, fmt.Errorf("TRIAGE_MODE must be on or off, got %q", mode)
+	}
+
+	severity, ok := domain.CanonicalValue(domain.Severities, getEnv("TRIAGE_DEFAULT_SEVERITY", domain.DefaultSeverity))
+	if !ok {
+		return domain.TriagePolicy{}, fmt.Errorf("TRIAGE_DEFAULT_SEVERITY must be one of %s", strings.Join(domain.Severities, ", "))
+	}
+	category, ok := domain.CanonicalValue(domain.Categories, getEnv("TRIAGE_DEFAULT_CATEGORY", domain.DefaultCategory))
+	if !ok {
+		return domain.TriagePolicy{}, fmt.Errorf("TRIAGE_DEFAULT_CATEGORY must be one of %s", strings.Join(domain.Categories, ", "))
+	}
+
+	return domain.TriagePolicy{Mode: mode, DefaultSeverity: severity, DefaultCategory: category}, nil
+}
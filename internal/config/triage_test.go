@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTriagePolicy(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		policy, err := LoadTriagePolicy()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TriagePolicy{Mode: domain.TriageOn, DefaultSeverity: "Medium", DefaultCategory: "Software"}, policy)
+		assert.False(t, policy.Off())
+	})
+
+	t.Run("off with defaults", func(t *testing.T) {
+		t.Setenv("TRIAGE_MODE", "off")
+		t.Setenv("TRIAGE_DEFAULT_SEVERITY", "low")
+		t.Setenv("TRIAGE_DEFAULT_CATEGORY", "Application")
+
+		policy, err := LoadTriagePolicy()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TriagePolicy{Mode: domain.TriageOff, DefaultSeverity: "Low", DefaultCategory: "Application"}, policy)
+		assert.True(t, policy.Off())
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		t.Setenv("TRIAGE_MODE", "manual")
+
+		_, err := LoadTriagePolicy()
+		assert.Error(t, err)
+	})
+
+	t.Run("default outside the taxonomy", func(t *testing.T) {
+		t.Setenv("TRIAGE_DEFAULT_SEVERITY", "Urgent")
+
+		_, err := LoadTriagePolicy()
+		assert.Error(t, err)
+	})
+}
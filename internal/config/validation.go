@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// LoadFieldLimits reads the incident field length limits from environment variables,
+// defaulting to the incidents table column lengths
+func LoadFieldLimits() (domain.FieldLimits, error) {
+	limits := domain.DefaultFieldLimits
+
+	settings := []struct {
+		key   string
+		value *int
+	}{
+		{"MAX_TITLE_LENGTH", &limits.Title},
+		{"MAX_DESCRIPTION_LENGTH", &limits.Description},
+		{"MAX_AFFECTED_SERVICE_LENGTH", &limits.AffectedService},
+	}
+
+	for _, s := range settings {
+		value, err := getEnvInt(s.key, *s.value)
+		if err != nil {
+			return domain.FieldLimits{}, err
+		}
+		if value < 1 {
+			return domain.FieldLimits{}, fmt.Errorf("%s must be at least 1, got %d", s.key, value)
+		}
+		*s.value = value
+	}
+
+	return limits, nil
+}
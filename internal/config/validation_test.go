@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFieldLimits(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		limits, err := LoadFieldLimits()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DefaultFieldLimits, limits)
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		t.Setenv("MAX_TITLE_LENGTH", "120")
+		t.Setenv("MAX_DESCRIPTION_LENGTH", "5000")
+
+		limits, err := LoadFieldLimits()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FieldLimits{Title: 120, Description: 5000, AffectedService: domain.MaxAffectedServiceLength}, limits)
+	})
+
+	t.Run("non-positive value", func(t *testing.T) {
+		t.Setenv("MAX_AFFECTED_SERVICE_LENGTH", "0")
+
+		_, err := LoadFieldLimits()
+		assert.Error(t, err)
+	})
+}
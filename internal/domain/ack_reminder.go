@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Urgencies of an acknowledgment reminder, growing with how long the incident has waited
+const (
+	AckUrgencyNormal   = "normal"
+	AckUrgencyHigh     = "high"
+	AckUrgencyCritical = "critical"
+)
+
+// AckReminderPolicy decides when an incident nobody picked up is reminded about: once it has
+// been unassigned for After, and again every Repeat while it stays unassigned. The zero value
+// sends no reminders.
+type AckReminderPolicy struct {
+	After  time.Duration
+	Repeat time.Duration
+}
+
+// Enabled reports whether reminders are sent
+func (p AckReminderPolicy) Enabled() bool {
+	return p.After > 0
+}
+
+// Urgency grades a reminder by the incident's age: high from twice the After threshold on and
+// critical from four times
+func (p AckReminderPolicy) Urgency(age time.Duration) string {
+	switch {
+	case age >= 4*p.After:
+		return AckUrgencyCritical
+	case age >= 2*p.After:
+		return AckUrgencyHigh
+	default:
+		return AckUrgencyNormal
+	}
+}
+
+// AckReminder records the reminders sent about an unassigned incident
+type AckReminder struct {
+	IncidentID     int
+	Reminders      int
+	LastRemindedAt *time.Time
+}
+
+// AckReminderRepository finds unassigned incidents due a reminder and throttles the reminders
+type AckReminderRepository interface {
+	// DueAckReminders returns up to limit incidents that are not false positives, have no
+	// assignee, were created before createdBefore and were not reminded about since
+	// remindedBefore, oldest first
+	DueAckReminders(ctx context.Context, createdBefore, remindedBefore time.Time, limit int) ([]*AckReminder, error)
+	RecordAckReminder(ctx context.Context, incidentID int, remindedAt time.Time) error
+}
+
+// AckReminderNotifier delivers reminders about incidents nobody has picked up
+type AckReminderNotifier interface {
+	NotifyUnacknowledged(incident *Incident, reminder *AckReminder, urgency string)
+}
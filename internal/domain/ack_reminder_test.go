@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAckReminderPolicy_Urgency(t *testing.T) {
+	policy := AckReminderPolicy{After: 30 * time.Minute, Repeat: time.Hour}
+
+	assert.True(t, policy.Enabled())
+	assert.False(t, AckReminderPolicy{}.Enabled())
+	assert.Equal(t, AckUrgencyNormal, policy.Urgency(30*time.Minute))
+	assert.Equal(t, AckUrgencyNormal, policy.Urgency(59*time.Minute))
+	assert.Equal(t, AckUrgencyHigh, policy.Urgency(time.Hour))
+	assert.Equal(t, AckUrgencyCritical, policy.Urgency(2*time.Hour))
+}
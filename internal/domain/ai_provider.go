@@ -0,0 +1,14 @@
+package domain
+
+// AI providers that can analyze incidents, selected with AI_PROVIDER
+const (
+	// AIProviderOpenAI analyzes incidents with the OpenAI chat completions API
+	AIProviderOpenAI = "openai"
+	// AIProviderAnthropic is reserved for Anthropic models and does not analyze incidents yet
+	AIProviderAnthropic = "anthropic"
+	// AIProviderMock returns a fixed analysis without calling any API, for local development
+	AIProviderMock = "mock"
+)
+
+// AIProviders lists every AI provider
+var AIProviders = []string{AIProviderOpenAI, AIProviderAnthropic, AIProviderMock}
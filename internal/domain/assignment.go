@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+// TeamMember is one person on a team roster
+type TeamMember struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+}
+
+// Assigner picks the assignee of a new incident routed to a team. It returns an empty name
+// when the team has nobody to assign.
+type Assigner interface {
+	Assign(ctx context.Context, team string) (string, error)
+}
+
+// RotationRepository persists round-robin rotation state so assignment continues where it
+// left off after a restart
+type RotationRepository interface {
+	// Next advances the team's rotation and returns its new position, starting at 0
+	Next(ctx context.Context, team string) (int, error)
+}
@@ -0,0 +1,36 @@
+package domain
+
+// Analysis statuses of an incident
+const (
+	// AnalysisComplete means the AI fields hold the result of an analysis
+	AnalysisComplete = "complete"
+	// AnalysisFailed means the analysis failed and the AI fields hold defaults until it is retried
+	AnalysisFailed = "failed"
+	// AnalysisPending means the analysis outlived the create budget; the AI fields hold defaults
+	// until it finishes in the background
+	AnalysisPending = "pending"
+)
+
+// ReprocessResult counts the outcome of retrying failed analyses
+type ReprocessResult struct {
+	Attempted    int `json:"attempted"`
+	Fixed        int `json:"fixed"`
+	StillFailing int `json:"still_failing"`
+}
+
+// Outcomes of one item of a batch create
+const (
+	BatchCreated        = "created"
+	BatchAnalysisFailed = "analysis_failed"
+	BatchRejected       = "rejected"
+)
+
+// BatchItemResult reports what happened to one item of a batch create. Index is the
+// item's position in the request.
+type BatchItemResult struct {
+	Index    int          `json:"index"`
+	Outcome  string       `json:"outcome"`
+	Incident *Incident    `json:"incident,omitempty"`
+	Error    string       `json:"error,omitempty"`
+	Fields   []FieldError `json:"fields,omitempty"`
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxSuggestDistance is the largest edit distance at which a known service is offered as
+// a "did you mean" suggestion
+const maxSuggestDistance = 3
+
+// ServiceCatalog lists the known affected services. A strict catalog rejects unknown
+// services; otherwise they are accepted and only flagged. A nil catalog knows every service.
+type ServiceCatalog struct {
+	Services []string
+	Strict   bool
+}
+
+// Knows reports whether service is in the catalog, ignoring case and surrounding space
+func (c *ServiceCatalog) Knows(service string) bool {
+	if c == nil {
+		return true
+	}
+	service = strings.ToLower(strings.TrimSpace(service))
+	for _, known := range c.Services {
+		if strings.ToLower(known) == service {
+			return true
+		}
+	}
+	return false
+}
+
+// Suggest returns the known service closest to service, or "" when none is close enough
+func (c *ServiceCatalog) Suggest(service string) string {
+	if c == nil {
+		return ""
+	}
+	service = strings.ToLower(strings.TrimSpace(service))
+
+	best, bestDistance := "", maxSuggestDistance+1
+	for _, known := range c.Services {
+		if d := editDistance(service, strings.ToLower(known)); d < bestDistance {
+			best, bestDistance = known, d
+		}
+	}
+	return best
+}
+
+// Check returns the violation for an unknown service in a strict catalog, or nil. Blank
+// services are left to the required check.
+func (c *ServiceCatalog) Check(service string) *FieldError {
+	if c == nil || !c.Strict || strings.TrimSpace(service) == "" || c.Knows(service) {
+		return nil
+	}
+
+	message := fmt.Sprintf("affected_service %q is not a known service", service)
+	if suggestion := c.Suggest(service); suggestion != "" {
+		message += fmt.Sprintf("; did you mean %q?", suggestion)
+	}
+	return &FieldError{Field: "affected_service", Rule: RuleUnknown, Message: message}
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
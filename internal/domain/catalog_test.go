@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceCatalog(t *testing.T) {
+	catalog := &ServiceCatalog{Services: []string{"auth-service", "billing-service", "search-api"}}
+
+	t.Run("nil catalog knows every service", func(t *testing.T) {
+		var none *ServiceCatalog
+		assert.True(t, none.Knows("anything"))
+		assert.Equal(t, "", none.Suggest("anything"))
+		assert.Nil(t, none.Check("anything"))
+	})
+
+	t.Run("knows ignores case and space", func(t *testing.T) {
+		assert.True(t, catalog.Knows(" Auth-Service "))
+		assert.False(t, catalog.Knows("auth"))
+	})
+
+	t.Run("suggests the closest service", func(t *testing.T) {
+		assert.Equal(t, "auth-service", catalog.Suggest("auth-servce"))
+		assert.Equal(t, "search-api", catalog.Suggest("Search_API"))
+		assert.Equal(t, "", catalog.Suggest("payments"))
+	})
+
+	t.Run("soft catalog never rejects", func(t *testing.T) {
+		assert.Nil(t, catalog.Check("payments"))
+	})
+
+	t.Run("strict catalog rejects with a suggestion", func(t *testing.T) {
+		strict := &ServiceCatalog{Services: catalog.Services, Strict: true}
+
+		assert.Nil(t, strict.Check("auth-service"))
+		assert.Nil(t, strict.Check("  "))
+		assert.Equal(t, &FieldError{
+			Field:   "affected_service",
+			Rule:    RuleUnknown,
+			Message: `affected_service "auth-servce" is not a known service; did you mean "auth-service"?`,
+		}, strict.Check("auth-servce"))
+		assert.Equal(t, `affected_service "payments" is not a known service`, strict.Check("payments").Message)
+	})
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("auth", "auth"))
+	assert.Equal(t, 1, editDistance("auth-servce", "auth-service"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 4, editDistance("", "auth"))
+}
@@ -0,0 +1,31 @@
+package domain
+
+// Ways the similarity of two compared incidents is computed
+const (
+	// SimilarityEmbedding is the cosine similarity of the stored embeddings
+	SimilarityEmbedding = "embedding"
+	// SimilarityKeywords is the share of description keywords the incidents have in common
+	SimilarityKeywords = "keywords"
+)
+
+// FieldDifference is a field whose value differs between two compared incidents
+type FieldDifference struct {
+	Field string      `json:"field"`
+	A     interface{} `json:"a"`
+	B     interface{} `json:"b"`
+}
+
+// IncidentComparison sets two incidents side by side to help decide whether they are duplicates
+type IncidentComparison struct {
+	A *Incident `json:"a"`
+	B *Incident `json:"b"`
+
+	// Differences lists the compared fields whose values differ, and Same those that match
+	Differences []*FieldDifference `json:"differences"`
+	Same        []string           `json:"same"`
+
+	// Similarity scores the descriptions from 0 (unrelated) to 1 (identical), computed as
+	// SimilarityMethod says
+	Similarity       float64 `json:"similarity"`
+	SimilarityMethod string  `json:"similarity_method"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+// ConfidenceFloor decides what happens to AI classifications the AI is unsure of: below Floor
+// the severity is replaced by the safe Severity and the incident needs review. The zero value
+// trusts every classification.
+type ConfidenceFloor struct {
+	Floor    float64
+	Severity string
+}
+
+// Below reports whether confidence is under the floor. Classifications without a confidence,
+// e.g. with triage off, are never below it.
+func (f ConfidenceFloor) Below(confidence *float64) bool {
+	return confidence != nil && *confidence < f.Floor
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfidenceFloor_Below(t *testing.T) {
+	confidence := func(v float64) *float64 { return &v }
+	floor := ConfidenceFloor{Floor: 0.4, Severity: "High"}
+
+	tests := []struct {
+		name       string
+		floor      ConfidenceFloor
+		confidence *float64
+		expected   bool
+	}{
+		{name: "below", floor: floor, confidence: confidence(0.2), expected: true},
+		{name: "at the floor", floor: floor, confidence: confidence(0.4), expected: false},
+		{name: "above", floor: floor, confidence: confidence(0.9), expected: false},
+		{name: "no confidence", floor: floor, confidence: nil, expected: false},
+		{name: "zero value trusts everything", floor: ConfidenceFloor{}, confidence: confidence(0), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.floor.Below(tt.confidence))
+		})
+	}
+}
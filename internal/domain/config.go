@@ -0,0 +1,18 @@
+package domain
+
+// Sources of a ConfigSetting value
+const (
+	ConfigSourceEnv     = "env"
+	ConfigSourceDefault = "default"
+)
+
+// RedactedValue replaces the value of secret settings
+const RedactedValue = "***"
+
+// ConfigSetting is one resolved configuration value and where it came from.
+// An empty default value means the setting is off or unset.
+type ConfigSetting struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// Value types accepted in a CustomFieldSchema
+const (
+	CustomFieldString  = "string"
+	CustomFieldNumber  = "number"
+	CustomFieldBoolean = "boolean"
+)
+
+// customFieldKeyPattern restricts custom field keys to identifiers, which keeps them safe
+// to embed in JSON paths
+var customFieldKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,63}$`)
+
+// ValidCustomFieldKey reports whether key may be used as a custom field name
+func ValidCustomFieldKey(key string) bool {
+	return customFieldKeyPattern.MatchString(key)
+}
+
+// CustomFieldSchema maps the allowed custom field keys to their value type.
+// A nil schema accepts any valid key with a scalar value.
+type CustomFieldSchema map[string]string
+
+// Allows reports whether key is accepted by the schema
+func (s CustomFieldSchema) Allows(key string) bool {
+	if !ValidCustomFieldKey(key) {
+		return false
+	}
+	if s == nil {
+		return true
+	}
+	_, ok := s[key]
+	return ok
+}
+
+// validate appends a violation for every unknown key or mistyped value, in key order
+func (s CustomFieldSchema) validate(fields []FieldError, values map[string]interface{}) []FieldError {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := "custom_fields." + key
+		if !s.Allows(key) {
+			fields = append(fields, FieldError{
+				Field:   name,
+				Rule:    RuleUnknown,
+				Message: fmt.Sprintf("%s is not an allowed custom field", key),
+			})
+			continue
+		}
+
+		actual := customFieldType(values[key])
+		expected, typed := s[key]
+		if actual == "" || (typed && actual != expected) {
+			want := expected
+			if !typed {
+				want = "a string, number or boolean"
+			}
+			fields = append(fields, FieldError{
+				Field:   name,
+				Rule:    RuleType,
+				Message: fmt.Sprintf("%s must be %s", key, want),
+			})
+		}
+	}
+
+	return fields
+}
+
+// customFieldType returns the schema type of a decoded JSON value, or "" for non-scalars
+func customFieldType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return CustomFieldString
+	case float64:
+		return CustomFieldNumber
+	case bool:
+		return CustomFieldBoolean
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateIncidentRequest_ValidateCustomFields(t *testing.T) {
+	request := func(fields map[string]interface{}) *CreateIncidentRequest {
+		return &CreateIncidentRequest{Title: "Title", Description: "Description", AffectedService: "Service", CustomFields: fields}
+	}
+
+	t.Run("any scalar without a schema", func(t *testing.T) {
+		req := request(map[string]interface{}{"region": "eu", "users": float64(12), "paging": true})
+		assert.NoError(t, req.ValidateWith(DefaultFieldLimits, nil))
+	})
+
+	t.Run("non-scalars and bad keys without a schema", func(t *testing.T) {
+		req := request(map[string]interface{}{"nested": map[string]interface{}{}, "bad-key": "x"})
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, req.ValidateWith(DefaultFieldLimits, nil), &validationErr)
+		assert.Equal(t, []FieldError{
+			{Field: "custom_fields.bad-key", Rule: RuleUnknown, Message: "bad-key is not an allowed custom field"},
+			{Field: "custom_fields.nested", Rule: RuleType, Message: "nested must be a string, number or boolean"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("schema restricts keys and types", func(t *testing.T) {
+		schema := CustomFieldSchema{"region": CustomFieldString, "customer_impact": CustomFieldBoolean}
+		assert.NoError(t, request(map[string]interface{}{"region": "eu", "customer_impact": true}).ValidateWith(DefaultFieldLimits, schema))
+
+		req := request(map[string]interface{}{"region": float64(1), "team": "payments"})
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, req.ValidateWith(DefaultFieldLimits, schema), &validationErr)
+		assert.Equal(t, []FieldError{
+			{Field: "custom_fields.region", Rule: RuleType, Message: "region must be string"},
+			{Field: "custom_fields.team", Rule: RuleUnknown, Message: "team is not an allowed custom field"},
+		}, validationErr.Fields)
+	})
+}
+
+func TestIncidentFilter_KeyIncludesCustomFields(t *testing.T) {
+	eu := &IncidentFilter{CustomFields: map[string]string{"region": "eu"}}
+	us := &IncidentFilter{CustomFields: map[string]string{"region": "us"}}
+
+	assert.False(t, eu.IsEmpty())
+	assert.Equal(t, "severity=;category=;cf.region=eu", eu.Key())
+	assert.NotEqual(t, eu.Key(), us.Key())
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// DedupWindows bounds how far back a create looks for a duplicate, per affected service.
+// A zero window matches duplicates of any age.
+type DedupWindows struct {
+	Default time.Duration
+
+	// Services overrides Default for the affected services it lists, keyed in lower case
+	Services map[string]time.Duration
+}
+
+// For returns the window of service, falling back to Default
+func (w DedupWindows) For(service string) time.Duration {
+	if window, ok := w.Services[strings.ToLower(strings.TrimSpace(service))]; ok {
+		return window
+	}
+	return w.Default
+}
+
+// Since returns the earliest creation time a duplicate of service may have at now, or the
+// zero time when its window is unbounded
+func (w DedupWindows) Since(service string, now time.Time) time.Time {
+	window := w.For(service)
+	if window <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-window)
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupWindows(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	windows := DedupWindows{Default: time.Hour, Services: map[string]time.Duration{"payment-gateway": 2 * time.Minute, "archive": 0}}
+
+	assert.Equal(t, 2*time.Minute, windows.For("Payment-Gateway"))
+	assert.Equal(t, time.Hour, windows.For("storage"))
+	assert.Equal(t, now.Add(-2*time.Minute), windows.Since("payment-gateway", now))
+	assert.True(t, windows.Since("archive", now).IsZero())
+	assert.True(t, DedupWindows{}.Since("storage", now).IsZero())
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "errors"
+
+// ErrDuplicate is returned when a write violates a unique constraint
+var ErrDuplicate = errors.New("incident already exists")
+
+// ErrNotFound is returned when an incident does not exist and never did
+var ErrNotFound = errors.New("incident not found")
+
+// ErrDeleted is returned when an incident existed but has been deleted and archived
+var ErrDeleted = errors.New("incident deleted")
+
+// ErrFalsePositive is returned when changing an incident already marked as a false positive
+var ErrFalsePositive = errors.New("incident marked as false positive")
+
+// ErrAnalysisChanged is returned when an incident's analysis was saved after the incident was
+// read for an update, which would otherwise overwrite it
+var ErrAnalysisChanged = errors.New("incident analysis changed")
+
+// ErrTriageOff is returned when an AI analysis is requested while AI triage is off
+var ErrTriageOff = errors.New("AI triage is off")
+
+// ErrStepNotFound is returned when an incident has no runbook step with the given ID
+var ErrStepNotFound = errors.New("runbook step not found")
+
+// ErrStepDone is returned when completing a runbook step that is already done
+var ErrStepDone = errors.New("runbook step already done")
+
+// ErrRunbooksUnavailable is returned when runbook steps are requested but no runbook storage is configured
+var ErrRunbooksUnavailable = errors.New("runbooks are not configured")
@@ -0,0 +1,26 @@
+package domain
+
+// Types of incident change events
+const (
+	EventCreated = "incident.created"
+	EventUpdated = "incident.updated"
+	EventDeleted = "incident.deleted"
+)
+
+// IncidentEvent describes a change to an incident. Incident is nil on delete.
+type IncidentEvent struct {
+	Type     string    `json:"type"`
+	ID       int       `json:"id"`
+	Incident *Incident `json:"incident,omitempty"`
+}
+
+// EventPublisher receives incident change events after each mutation
+type EventPublisher interface {
+	Publish(event *IncidentEvent)
+}
+
+// EventSubscriber hands out live feeds of incident change events. The returned function
+// ends the subscription.
+type EventSubscriber interface {
+	Subscribe() (<-chan *IncidentEvent, func())
+}
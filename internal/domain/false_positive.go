@@ -0,0 +1,17 @@
+package domain
+
+import "strings"
+
+// FalsePositiveRequest is the body of a request marking an incident as a false positive
+type FalsePositiveRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Validate requires a reason that fits the false_positive_reason column
+func (r *FalsePositiveRequest) Validate() error {
+	r.Reason = strings.TrimSpace(r.Reason)
+	if fields := validateText(nil, "reason", r.Reason, MaxFalsePositiveReasonLength); len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
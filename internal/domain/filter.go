@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IncidentFilter restricts incident listings; empty fields do not filter
+type IncidentFilter struct {
+	Severities []string
+	Categories []string
+
+	// CustomFields matches incidents whose custom field values equal the given strings
+	CustomFields map[string]string
+
+	// Priorities matches the effective priority, the override or else the computed priority
+	Priorities []string
+
+	// MinAffectedUsers matches incidents affecting at least this many users; incidents
+	// without a count never match
+	MinAffectedUsers *int
+
+	// IncludeFalsePositive lists incidents marked as false positives, which are hidden by default
+	IncludeFalsePositive bool
+
+	// Query is a parsed query expression the incidents must also match
+	Query QueryNode
+}
+
+// IsEmpty reports whether the filter is the default listing of every incident that is not a
+// false positive
+func (f *IncidentFilter) IsEmpty() bool {
+	return f == nil || (len(f.Severities) == 0 && len(f.Categories) == 0 && len(f.CustomFields) == 0 && len(f.Priorities) == 0 && f.MinAffectedUsers == nil && !f.IncludeFalsePositive && f.Query == nil)
+}
+
+// CustomFieldKeys returns the custom field keys of the filter in sorted order
+func (f *IncidentFilter) CustomFieldKeys() []string {
+	keys := make([]string, 0, len(f.CustomFields))
+	for key := range f.CustomFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Key returns a stable string identifying the filter, for use in cache keys
+func (f *IncidentFilter) Key() string {
+	if f.IsEmpty() {
+		return ""
+	}
+	key := "severity=" + strings.Join(f.Severities, ",") + ";category=" + strings.Join(f.Categories, ",")
+	for _, name := range f.CustomFieldKeys() {
+		key += ";cf." + name + "=" + f.CustomFields[name]
+	}
+	if len(f.Priorities) > 0 {
+		key += ";priority=" + strings.Join(f.Priorities, ",")
+	}
+	if f.MinAffectedUsers != nil {
+		key += ";min_affected_users=" + strconv.Itoa(*f.MinAffectedUsers)
+	}
+	if f.IncludeFalsePositive {
+		key += ";false_positive=true"
+	}
+	if f.Query != nil {
+		key += ";q=" + f.Query.String()
+	}
+	return key
+}
+
+// ListVersion summarizes the state of a filtered incident listing so clients can detect changes
+// cheaply. Checksum covers the ID and update time of every incident. AsOf is the start of the
+// period the listing's age fields are current for.
+type ListVersion struct {
+	Count        int
+	MaxUpdatedAt time.Time
+	Checksum     uint64
+	AsOf         time.Time
+}
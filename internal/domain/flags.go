@@ -0,0 +1,31 @@
+package domain
+
+// Feature flag names
+const (
+	// FlagEmbeddings stores an embedding for each new incident for similarity search
+	FlagEmbeddings = "embeddings"
+	// FlagDedup looks up existing duplicates when previewing an incident
+	FlagDedup = "dedup"
+	// FlagDryRun accepts ?dry_run=true on create
+	FlagDryRun = "dry_run"
+	// FlagIngest accepts monitoring webhooks on POST /incidents/ingest/:source
+	FlagIngest = "ingest"
+	// FlagSuggestLinks suggests related recent incidents in the create response
+	FlagSuggestLinks = "suggest_links"
+)
+
+// DefaultFeatureFlags holds every known flag with its default state, which matches the
+// behavior before the flag existed
+var DefaultFeatureFlags = map[string]bool{
+	FlagEmbeddings:   true,
+	FlagDedup:        true,
+	FlagDryRun:       true,
+	FlagIngest:       true,
+	FlagSuggestLinks: false,
+}
+
+// FeatureFlags reports which optional features are enabled
+type FeatureFlags interface {
+	Enabled(flag string) bool
+	All() map[string]bool
+}
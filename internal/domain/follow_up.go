@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bounds of a follow-up interval: frequent enough to matter, rare enough not to be noise
+const (
+	MinFollowUpInterval = 15 * time.Minute
+	MaxFollowUpInterval = 7 * 24 * time.Hour
+)
+
+// ErrFollowUpsUnavailable is returned when follow-ups are requested but no follow-up storage is configured
+var ErrFollowUpsUnavailable = errors.New("follow-ups are not configured")
+
+// FollowUp is a recurring reminder to the assignee of an incident, sent every Interval from
+// NextAt on until it is cleared or the incident is closed as a false positive or deleted
+type FollowUp struct {
+	IncidentID   int           `json:"incident_id"`
+	Interval     time.Duration `json:"-"`
+	NextAt       time.Time     `json:"next_at"`
+	Nudges       int           `json:"nudges"`
+	LastNudgedAt *time.Time    `json:"last_nudged_at,omitempty"`
+}
+
+// MarshalJSON writes the interval as a duration such as "4h0m0s"
+func (f *FollowUp) MarshalJSON() ([]byte, error) {
+	type plain FollowUp
+	return json.Marshal(struct {
+		*plain
+		Interval string `json:"interval"`
+	}{plain: (*plain)(f), Interval: f.Interval.String()})
+}
+
+// FollowUpRequest is the body of a request scheduling follow-ups on an incident
+type FollowUpRequest struct {
+	// Interval is a duration such as "4h"; empty stops the follow-ups
+	Interval string `json:"interval"`
+
+	// Duration is the parsed Interval, set by Validate
+	Duration time.Duration `json:"-"`
+}
+
+// Validate parses the interval, which must be empty or within the follow-up bounds
+func (r *FollowUpRequest) Validate() error {
+	r.Interval = strings.TrimSpace(r.Interval)
+	var fields []FieldError
+	r.Duration, fields = validateFollowUpInterval(nil, "interval", r.Interval)
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// validateFollowUpInterval parses an optional follow-up interval, appending a violation when it
+// is not a duration within MinFollowUpInterval and MaxFollowUpInterval
+func validateFollowUpInterval(fields []FieldError, name, value string) (time.Duration, []FieldError) {
+	if value == "" {
+		return 0, fields
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, append(fields, FieldError{
+			Field:   name,
+			Rule:    RuleType,
+			Message: fmt.Sprintf("%s must be a duration such as 4h", name),
+		})
+	}
+	if interval < MinFollowUpInterval {
+		return 0, append(fields, FieldError{
+			Field:   name,
+			Rule:    RuleMin,
+			Message: fmt.Sprintf("%s must be at least %s", name, MinFollowUpInterval),
+		})
+	}
+	if interval > MaxFollowUpInterval {
+		return 0, append(fields, FieldError{
+			Field:   name,
+			Rule:    RuleMax,
+			Message: fmt.Sprintf("%s must be at most %s", name, MaxFollowUpInterval),
+		})
+	}
+	return interval, fields
+}
+
+// FollowUpRepository stores the follow-up schedules of incidents
+type FollowUpRepository interface {
+	// SetFollowUp schedules follow-ups, replacing the interval and next time of an existing
+	// schedule while keeping its nudge count
+	SetFollowUp(ctx context.Context, followUp *FollowUp) error
+	ClearFollowUp(ctx context.Context, incidentID int) error
+	// GetFollowUp returns nil without an error when the incident has no follow-up
+	GetFollowUp(ctx context.Context, incidentID int) (*FollowUp, error)
+	// DueFollowUps returns up to limit follow-ups due at now, earliest first
+	DueFollowUps(ctx context.Context, now time.Time, limit int) ([]*FollowUp, error)
+	RecordNudge(ctx context.Context, incidentID int, nudgedAt, nextAt time.Time) error
+}
+
+// FollowUpNotifier delivers follow-up reminders
+type FollowUpNotifier interface {
+	NotifyFollowUp(incident *Incident, followUp *FollowUp)
+}
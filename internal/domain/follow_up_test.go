@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollowUpRequest_Validate(t *testing.T) {
+	req := &FollowUpRequest{Interval: " 4h "}
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, 4*time.Hour, req.Duration)
+
+	req = &FollowUpRequest{}
+	assert.NoError(t, req.Validate())
+	assert.Zero(t, req.Duration)
+
+	for _, interval := range []string{"soon", "5m", "30d", "200h"} {
+		req = &FollowUpRequest{Interval: interval}
+		assert.Error(t, req.Validate(), interval)
+	}
+}
+
+func TestFollowUp_MarshalJSON(t *testing.T) {
+	followUp := &FollowUp{IncidentID: 7, Interval: 4 * time.Hour, NextAt: time.Date(2024, 6, 1, 16, 0, 0, 0, time.UTC)}
+
+	body, err := json.Marshal(followUp)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"incident_id":7,"interval":"4h0m0s","next_at":"2024-06-01T16:00:00Z","nudges":0}`, string(body))
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// History field names for tracked incident changes
+const (
+	FieldTitle           = "title"
+	FieldAffectedService = "affected_service"
+	FieldAISeverity      = "ai_severity"
+	FieldAICategory      = "ai_category"
+	FieldFalsePositive   = "false_positive_reason"
+	FieldAssignee        = "assignee"
+	FieldPriority        = "priority_override"
+	FieldRunbookStep     = "runbook_step"
+	FieldFollowUp        = "follow_up"
+)
+
+// Actors recorded on history entries
+const (
+	ActorAI    = "ai"
+	ActorAPI   = "api"
+	ActorAdmin = "admin"
+	// ActorSystem records changes made by the server on its own schedule
+	ActorSystem = "system"
+)
+
+// HistoryFields lists the tracked incident fields that history can be filtered on
+var HistoryFields = []string{FieldTitle, FieldAffectedService, FieldAISeverity, FieldAICategory, FieldFalsePositive, FieldAssignee, FieldPriority, FieldRunbookStep, FieldFollowUp}
+
+// HistoryActors lists the actors that history can be filtered on
+var HistoryActors = []string{ActorAI, ActorAPI, ActorAdmin, ActorSystem}
+
+// HistoryEntry records a single field change on an incident
+type HistoryEntry struct {
+	ID         int       `json:"id" db:"id"`
+	IncidentID int       `json:"incident_id" db:"incident_id"`
+	Field      string    `json:"field" db:"field"`
+	OldValue   string    `json:"old_value" db:"old_value"`
+	NewValue   string    `json:"new_value" db:"new_value"`
+	Actor      string    `json:"actor" db:"actor"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+
+	// IncidentTitle is the title of the incident, filled in by listings across incidents
+	IncidentTitle string `json:"incident_title,omitempty" db:"-"`
+}
+
+// HistoryFilter narrows a listing of history across all incidents. Zero values match everything;
+// From is inclusive and To is exclusive.
+type HistoryFilter struct {
+	Actor string
+	Field string
+	From  time.Time
+	To    time.Time
+}
+
+// HistoryPage is one page of history across all incidents, newest first. NextCursor is the
+// position of the next page, or nil on the last page.
+type HistoryPage struct {
+	Entries    []*HistoryEntry
+	NextCursor *Cursor
+}
+
+// HistoryRepository defines the interface for incident change history storage
+type HistoryRepository interface {
+	AddEntries(ctx context.Context, entries []*HistoryEntry) error
+	GetByIncident(ctx context.Context, incidentID int, field string) ([]*HistoryEntry, error)
+	List(ctx context.Context, filter HistoryFilter, after *Cursor, limit int) ([]*HistoryEntry, error)
+}
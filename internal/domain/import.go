@@ -0,0 +1,26 @@
+package domain
+
+// Outcomes of one row of a CSV import
+const (
+	ImportCreated  = "created"
+	ImportRejected = "rejected"
+)
+
+// ImportRow is one validated row of a CSV import
+type ImportRow struct {
+	Line    int
+	Request *CreateIncidentRequest
+
+	// Severity and Category come from the CSV and are kept when AI analysis is skipped
+	Severity string
+	Category string
+}
+
+// ImportRowResult reports what happened to one row of a CSV import
+type ImportRowResult struct {
+	Line       int          `json:"line"`
+	Outcome    string       `json:"outcome"`
+	IncidentID int          `json:"incident_id,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	Fields     []FieldError `json:"fields,omitempty"`
+}
@@ -1,12 +1,14 @@
 package domain
 
 import (
+	"context"
 	"time"
 )
 
 // Incident represents an IT incident with AI-generated insights
 type Incident struct {
 	ID              int       `json:"id" db:"id"`
+	Reference       string    `json:"reference,omitempty" db:"reference"`
 	Title           string    `json:"title" db:"title"`
 	Description     string    `json:"description" db:"description"`
 	AffectedService string    `json:"affected_service" db:"affected_service"`
@@ -14,6 +16,98 @@ type Incident struct {
 	AICategory      string    `json:"ai_category" db:"ai_category"`
 	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+
+	// AISuggestedAction is the AI's optional first remediation step
+	AISuggestedAction string `json:"ai_suggested_action,omitempty" db:"ai_suggested_action"`
+
+	// AIReasoning is the AI's optional short explanation of the severity and category
+	AIReasoning string `json:"ai_reasoning,omitempty" db:"ai_reasoning"`
+
+	// AIInputTruncated is set when the description was shortened to fit the AI prompt budget,
+	// so the analysis did not see all of it
+	AIInputTruncated bool `json:"ai_input_truncated,omitempty" db:"ai_input_truncated"`
+
+	// AIConfidence is the AI's confidence in its classification, from 0 to 1. It is unset when
+	// the severity and category did not come from an AI analysis.
+	AIConfidence *float64 `json:"ai_confidence,omitempty" db:"ai_confidence"`
+
+	// Assignee is the person working the incident, set by the client or by team rotation
+	Assignee string `json:"assignee,omitempty" db:"assignee"`
+
+	// AffectedUsers is the optional number of users affected, the incident's blast radius
+	AffectedUsers *int `json:"affected_users,omitempty" db:"affected_users"`
+
+	// PriorityOverride is a priority set by a responder that replaces the computed one
+	PriorityOverride string `json:"priority_override,omitempty" db:"priority_override"`
+
+	// AnalysisStatus records whether the AI fields come from an analysis or are defaults
+	// awaiting a retry
+	AnalysisStatus string `json:"analysis_status" db:"analysis_status"`
+
+	// FalsePositiveReason is set once the incident is marked a false positive, which is final
+	// and hides it from the default list and the triage queue
+	FalsePositiveReason string `json:"false_positive_reason,omitempty" db:"false_positive_reason"`
+
+	// CustomFields holds team-specific metadata such as region or customer_impact
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty" db:"custom_fields"`
+
+	// Priority is the effective priority: the override when set, otherwise computed from
+	// severity and affected users at read time
+	Priority string `json:"priority,omitempty" db:"-"`
+
+	// RunbookCompletion is the percentage of runbook steps done, reported by a single-incident
+	// read when the incident has steps
+	RunbookCompletion *int `json:"runbook_completion,omitempty" db:"-"`
+
+	// Team is resolved from the category routing map at read time and not persisted
+	Team *TeamRoute `json:"team,omitempty" db:"-"`
+
+	// AgeSeconds and AgeHuman report how long the incident has been open, computed from
+	// CreatedAt and the server clock at read time
+	AgeSeconds int64  `json:"age_seconds" db:"-"`
+	AgeHuman   string `json:"age_human" db:"-"`
+
+	// UnknownService is set at read time when the affected service is missing from the
+	// configured service catalog
+	UnknownService bool `json:"unknown_service,omitempty" db:"-"`
+
+	// ServiceMetadata is looked up from the service directory at read time and is absent for
+	// unknown services
+	ServiceMetadata *ServiceMetadata `json:"service_metadata,omitempty" db:"-"`
+
+	// LowConfidence is set at read time when AIConfidence is below LowConfidenceThreshold, so
+	// operators know to double-check the triage
+	LowConfidence bool `json:"low_confidence,omitempty" db:"-"`
+
+	// NeedsReview is set at read time when AIConfidence is below the configured confidence
+	// floor, in which case the severity is the floor's safe default rather than the AI's
+	NeedsReview bool `json:"needs_review,omitempty" db:"-"`
+
+	// Suppressed marks the alert storm incident returned by a create that was folded into it
+	Suppressed bool `json:"suppressed,omitempty" db:"-"`
+
+	// SuggestedLinks lists likely related incidents; it is only set by create when the
+	// suggest_links flag is enabled
+	SuggestedLinks []*SuggestedLink `json:"suggested_links,omitempty" db:"-"`
+
+	// Timings is set by create for debugging and only returned when requested
+	Timings *CreateTimings `json:"timings,omitempty" db:"-"`
+}
+
+// IncidentSummary is the compact projection of an incident used by list views
+type IncidentSummary struct {
+	ID         int       `json:"id"`
+	Title      string    `json:"title"`
+	AISeverity string    `json:"ai_severity"`
+	AICategory string    `json:"ai_category"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CreateTimings breaks down where the time of an incident create went
+type CreateTimings struct {
+	AIMs    int64 `json:"ai_ms"`
+	DBMs    int64 `json:"db_ms"`
+	TotalMs int64 `json:"total_ms"`
 }
 
 // CreateIncidentRequest represents the request to create a new incident
@@ -21,33 +115,136 @@ type CreateIncidentRequest struct {
 	Title           string `json:"title" validate:"required"`
 	Description     string `json:"description" validate:"required"`
 	AffectedService string `json:"affected_service" validate:"required"`
+
+	// CustomFields is optional; on update, omitting it keeps the stored values
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+
+	// Assignee is optional; new incidents without one are assigned by team rotation when
+	// rosters are configured, and on update omitting it keeps the stored assignee
+	Assignee string `json:"assignee,omitempty"`
+
+	// AffectedUsers is optional and must not be negative; on update omitting it keeps the
+	// stored count
+	AffectedUsers *int `json:"affected_users,omitempty"`
+
+	// Severity and Category are optional and only used when AI triage is off; they must be
+	// values of the taxonomy
+	Severity string `json:"severity,omitempty"`
+	Category string `json:"category,omitempty"`
+
+	// FollowUpInterval optionally schedules recurring reminders to the assignee on create,
+	// e.g. "4h"; it is ignored on update
+	FollowUpInterval string `json:"follow_up_interval,omitempty"`
+
+	// AllowDuplicate bypasses strict uniqueness for this create; it is set from ?allow_duplicate=true
+	AllowDuplicate bool `json:"-"`
 }
 
 // IncidentRepository defines the interface for incident data operations
 type IncidentRepository interface {
-	Create(incident *Incident) error
-	GetByID(id int) (*Incident, error)
-	GetAll() ([]*Incident, error)
-	Update(incident *Incident) error
-	Delete(id int) error
+	Create(ctx context.Context, incident *Incident) error
+	CreateUnique(ctx context.Context, incident *Incident, since time.Time) error
+	CreateBatch(ctx context.Context, incidents []*Incident) error
+	GetByID(ctx context.Context, id int) (*Incident, error)
+	// GetByIDForWrite reads like GetByID but never from a replica, for an incident about to be changed
+	GetByIDForWrite(ctx context.Context, id int) (*Incident, error)
+	GetIDByReference(ctx context.Context, reference string) (int, error)
+	FindDuplicate(ctx context.Context, title, affectedService string, since time.Time) (*Incident, error)
+	GetAll(ctx context.Context) ([]*Incident, error)
+	GetAllFiltered(ctx context.Context, filter *IncidentFilter) ([]*Incident, error)
+	GetAllPaginated(ctx context.Context, filter *IncidentFilter, limit, offset int) ([]*Incident, error)
+	Search(ctx context.Context, term string, limit, offset int) (*SearchPage, error)
+	GetAllSummary(ctx context.Context, filter *IncidentFilter, limit, offset int) ([]*IncidentSummary, error)
+	MaxUpdatedAt(ctx context.Context, filter *IncidentFilter) (*ListVersion, error)
+	MaxUpdatedAtFromWriter(ctx context.Context, filter *IncidentFilter) (*ListVersion, error)
+	GetQueue(ctx context.Context, limit, offset int) ([]*Incident, error)
+	StreamAll(ctx context.Context, filter *IncidentFilter, fn func(*Incident) error) error
+	GetPageAfterID(ctx context.Context, filter *IncidentFilter, afterID, limit int) ([]*Incident, error)
+	GetUnfinishedAnalyses(ctx context.Context, pendingBefore time.Time, limit int) ([]*Incident, error)
+	GetRecent(ctx context.Context, since time.Time, excludeID, limit int) ([]*Incident, error)
+	GetIDsBySeverity(ctx context.Context, severity string, afterID, limit int) ([]int, error)
+	UpdateSeverity(ctx context.Context, ids []int, severity string, updatedAt time.Time) error
+	MarkFalsePositive(ctx context.Context, id int, reason string, updatedAt time.Time) error
+	// SetStormCount updates only the storm count custom field of an alert storm incident
+	SetStormCount(ctx context.Context, id, count int, updatedAt time.Time) error
+	SetPriorityOverride(ctx context.Context, id int, priority string, updatedAt time.Time) error
+	Reassign(ctx context.Context, from, to string, scope ReassignScope, updatedAt time.Time) ([]int, error)
+	CountDistribution(ctx context.Context, filter *IncidentFilter) ([]*DistributionCount, error)
+	CountQualityIssues(ctx context.Context) ([]*QualityCount, error)
+	TrimWhitespace(ctx context.Context, updatedAt time.Time) (int, error)
+	Update(ctx context.Context, incident *Incident, analysisStatus string) error
+	SaveAnalysis(ctx context.Context, incident *Incident, status string) (bool, error)
+	Delete(ctx context.Context, id int, purgedBy string) error
 }
 
-// AIService defines the interface for AI-powered incident analysis
+// AIService defines the interface for AI-powered incident analysis. Implementations give up
+// when ctx is done.
 type AIService interface {
-	AnalyzeIncident(title, description, affectedService string) (*IncidentAnalysis, error)
+	AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*IncidentAnalysis, error)
 }
 
 // IncidentUseCase defines the interface for incident business logic
 type IncidentUseCase interface {
-	CreateIncident(req *CreateIncidentRequest) (*Incident, error)
-	GetIncident(id int) (*Incident, error)
-	GetAllIncidents() ([]*Incident, error)
-	UpdateIncident(id int, req *CreateIncidentRequest) (*Incident, error)
-	DeleteIncident(id int) error
+	CreateIncident(ctx context.Context, req *CreateIncidentRequest) (*Incident, error)
+	CreateIncidentsBatch(ctx context.Context, reqs []*CreateIncidentRequest) []*BatchItemResult
+	PreviewIncident(ctx context.Context, req *CreateIncidentRequest) (*IncidentPreview, error)
+	ImportIncidents(ctx context.Context, rows []*ImportRow, analyze bool) ([]*ImportRowResult, error)
+	GetIncident(ctx context.Context, id int) (*Incident, error)
+	ResolveReference(ctx context.Context, reference string) (int, error)
+	GetAllIncidents(ctx context.Context, filter *IncidentFilter) ([]*Incident, error)
+	GetAllIncidentsPaginated(ctx context.Context, filter *IncidentFilter, limit, offset int) ([]*Incident, error)
+	SearchIncidents(ctx context.Context, term string, limit, offset int) (*SearchPage, error)
+	GetIncidentSummaries(ctx context.Context, filter *IncidentFilter, limit, offset int) ([]*IncidentSummary, error)
+	GetListVersion(ctx context.Context, filter *IncidentFilter) (*ListVersion, error)
+	GetCurrentListVersion(ctx context.Context, filter *IncidentFilter) (*ListVersion, error)
+	GetTriageQueue(ctx context.Context, limit, offset int) ([]*Incident, error)
+	UpdateIncident(ctx context.Context, id int, req *CreateIncidentRequest) (*Incident, error)
+	DeleteIncident(ctx context.Context, id int) error
+	ReprocessFailedAnalyses(ctx context.Context, limit int) (*ReprocessResult, error)
+	RemapSeverity(ctx context.Context, mapping map[string]string, dryRun bool) (*RemapResult, error)
+	MarkFalsePositive(ctx context.Context, id int, reason string) (*Incident, error)
+	SetPriorityOverride(ctx context.Context, id int, priority string) (*Incident, error)
+	ReassignIncidents(ctx context.Context, from, to string, scope ReassignScope) (*ReassignResult, error)
+	FindSimilarIncidents(ctx context.Context, id int, limit int) ([]*SimilarIncident, error)
+	CompareIncidents(ctx context.Context, aID, bID int) (*IncidentComparison, error)
+	ExportIncidents(ctx context.Context, filter *IncidentFilter, fn func(*Incident) error) error
+	ExportIncidentArchive(ctx context.Context, filter *IncidentFilter, fn func(*ExportedIncident) error) error
+	ExportIncidentsPage(ctx context.Context, filter *IncidentFilter, afterID, limit int) (*ExportPage, error)
+	GetDistribution(ctx context.Context, filter *IncidentFilter) ([]*DistributionCount, error)
+	CheckDataQuality(ctx context.Context, fix string) (*QualityReport, error)
+	GetSeverityHistory(ctx context.Context, id int) ([]*HistoryEntry, error)
+	GetHistory(ctx context.Context, filter HistoryFilter, after *Cursor, limit int) (*HistoryPage, error)
+	AddRunbookStep(ctx context.Context, incidentID int, step string) (*RunbookStep, error)
+	ListRunbookSteps(ctx context.Context, incidentID int) ([]*RunbookStep, error)
+	CompleteRunbookStep(ctx context.Context, incidentID, stepID int, doneBy string) (*RunbookStep, error)
+	SetFollowUp(ctx context.Context, incidentID int, interval time.Duration) (*FollowUp, error)
+	GetFollowUp(ctx context.Context, incidentID int) (*FollowUp, error)
+}
+
+// Outcomes of a dry-run create
+const (
+	PreviewCreated   = "created"
+	PreviewDuplicate = "duplicate"
+)
+
+// IncidentPreview describes what creating an incident would do, without writing anything
+type IncidentPreview struct {
+	Incident    *Incident `json:"incident"`
+	Outcome     string    `json:"outcome"`
+	DuplicateOf *int      `json:"duplicate_of,omitempty"`
 }
 
 // IncidentAnalysis represents the AI-generated analysis of an incident
 type IncidentAnalysis struct {
-	Severity string `json:"severity"`
-	Category string `json:"category"`
+	Severity        string `json:"severity"`
+	Category        string `json:"category"`
+	SuggestedAction string `json:"suggested_action,omitempty"`
+	Reasoning       string `json:"reasoning,omitempty"`
+
+	// Confidence is how sure the AI is of the classification, from 0 to 1. It is nil for
+	// analyses that did not come from the AI.
+	Confidence *float64 `json:"confidence,omitempty"`
+
+	// InputTruncated reports that the description was shortened to fit the prompt budget
+	InputTruncated bool `json:"-"`
 }
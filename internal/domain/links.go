@@ -0,0 +1,21 @@
+package domain
+
+// Reasons an existing incident is suggested as related to a new one
+const (
+	LinkReasonSimilar     = "similar"
+	LinkReasonKeywords    = "keywords"
+	LinkReasonSameService = "same_service"
+)
+
+// SuggestedLink is an existing incident that is likely related to a newly created one. It is
+// only a suggestion for responders to confirm; nothing is linked automatically.
+type SuggestedLink struct {
+	IncidentID      int      `json:"incident_id"`
+	Title           string   `json:"title"`
+	AffectedService string   `json:"affected_service"`
+	Reasons         []string `json:"reasons"`
+
+	// Score is the embedding similarity when the reasons include similar, and the share of
+	// title keywords in common otherwise
+	Score float64 `json:"score"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// DigestSeverities lists the severities whose notifications are batched into digests when
+// digests are on; other severities are notified immediately
+var DigestSeverities = []string{"Low", "Medium"}
+
+// IncidentNotifier is told about new incidents, one at a time or batched into a digest
+type IncidentNotifier interface {
+	NotifyIncident(incident *Incident)
+	NotifyDigest(digest *IncidentDigest)
+}
+
+// ReviewNotifier tells the triage channel about incidents whose AI classification was below
+// the confidence floor and needs a human to check it
+type ReviewNotifier interface {
+	NotifyNeedsReview(incident *Incident)
+}
+
+// IncidentDigest summarizes the incidents created during [Since, Until), grouped by service
+type IncidentDigest struct {
+	Since    time.Time
+	Until    time.Time
+	Services []*ServiceDigest
+}
+
+// ServiceDigest is the incidents of one affected service in a digest, oldest first
+type ServiceDigest struct {
+	Service   string
+	Incidents []*Incident
+}
+
+// Count returns the number of incidents in the digest
+func (d *IncidentDigest) Count() int {
+	count := 0
+	for _, service := range d.Services {
+		count += len(service.Incidents)
+	}
+	return count
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// ExportPage is one bounded chunk of a paged export. NextAfterID is the keyset position to
+// resume from, or zero when the export is complete.
+type ExportPage struct {
+	Incidents   []*Incident
+	NextAfterID int
+}
+
+// ExportedIncident is an incident in an archival export together with its change history,
+// oldest change first
+type ExportedIncident struct {
+	*Incident
+	History []*HistoryEntry `json:"history"`
+}
+
+// Cursor identifies a position in a keyset-paginated listing ordered by creation time
+type Cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int       `json:"id"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Priorities lists the business priorities, most urgent first
+var Priorities = []string{"P1", "P2", "P3", "P4"}
+
+// HighImpactUsers is the affected user count from which an incident's priority is raised one level
+const HighImpactUsers = 1000
+
+// ComputePriority combines severity and blast radius into a priority: Critical is P1, High P2,
+// Medium P3 and Low P4, raised one level (up to P1) when at least HighImpactUsers users are
+// affected. Severities outside the taxonomy rank as Low.
+func ComputePriority(severity string, affectedUsers *int) string {
+	level := len(Priorities) - 1
+	for i, s := range Severities {
+		if s == severity {
+			level = len(Severities) - 1 - i
+		}
+	}
+
+	if affectedUsers != nil && *affectedUsers >= HighImpactUsers && level > 0 {
+		level--
+	}
+	return Priorities[level]
+}
+
+// PriorityRequest is the body of a request setting or, with an empty priority, clearing the
+// priority override of an incident
+type PriorityRequest struct {
+	Priority string `json:"priority"`
+}
+
+// Validate requires an empty priority or one of Priorities, rewriting it to its canonical case
+func (r *PriorityRequest) Validate() error {
+	r.Priority = strings.TrimSpace(r.Priority)
+	if r.Priority == "" {
+		return nil
+	}
+
+	priority, ok := CanonicalValue(Priorities, r.Priority)
+	if !ok {
+		return &ValidationError{Fields: []FieldError{{
+			Field:   "priority",
+			Rule:    RuleOneOf,
+			Message: fmt.Sprintf("priority must be one of %s, or empty to clear the override", strings.Join(Priorities, ", ")),
+		}}}
+	}
+	r.Priority = priority
+	return nil
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputePriority(t *testing.T) {
+	few, many := 10, HighImpactUsers
+
+	tests := []struct {
+		severity      string
+		affectedUsers *int
+		expected      string
+	}{
+		{"Critical", nil, "P1"},
+		{"High", nil, "P2"},
+		{"Medium", &few, "P3"},
+		{"Low", nil, "P4"},
+		{"Medium", &many, "P2"},
+		{"Critical", &many, "P1"},
+		{"Unknown", nil, "P4"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ComputePriority(tt.severity, tt.affectedUsers), "%s with %v users", tt.severity, tt.affectedUsers)
+	}
+}
+
+func TestPriorityRequest_Validate(t *testing.T) {
+	req := &PriorityRequest{Priority: " p2 "}
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, "P2", req.Priority)
+
+	req = &PriorityRequest{Priority: ""}
+	assert.NoError(t, req.Validate())
+
+	req = &PriorityRequest{Priority: "urgent"}
+	assert.Error(t, req.Validate())
+}
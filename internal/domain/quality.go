@@ -0,0 +1,36 @@
+package domain
+
+// Data quality issues reported by the quality check
+const (
+	QualityMissingDescription     = "missing_description"
+	QualityPlaceholderDescription = "placeholder_description"
+	QualityInvalidSeverity        = "invalid_severity"
+	QualityInvalidCategory        = "invalid_category"
+	QualityShortTitle             = "short_title"
+	QualityUntrimmed              = "untrimmed_whitespace"
+)
+
+// QualityFixTrim is the quality fix that trims surrounding whitespace from text fields
+const QualityFixTrim = "trim"
+
+// MinTitleLength is the title length, in characters after trimming, below which a title is
+// reported as suspiciously short
+const MinTitleLength = 5
+
+// PlaceholderDescriptions are descriptions, compared trimmed and in lower case, that say
+// nothing about the incident
+var PlaceholderDescriptions = []string{"-", ".", "n/a", "na", "none", "tbd", "todo", "test", "placeholder", "see title", "lorem ipsum"}
+
+// QualityCount is the number of incidents with one data quality issue
+type QualityCount struct {
+	Issue string `json:"issue"`
+	Count int    `json:"count"`
+}
+
+// QualityReport lists the incidents with each data quality issue, in a fixed order, and how
+// many incidents a requested fix changed
+type QualityReport struct {
+	Issues []*QualityCount `json:"issues"`
+	Fix    string          `json:"fix,omitempty"`
+	Fixed  int             `json:"fixed"`
+}
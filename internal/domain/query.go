@@ -0,0 +1,442 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Fields that incident query expressions can test
+const (
+	QueryFieldSeverity      = "severity"
+	QueryFieldCategory      = "category"
+	QueryFieldPriority      = "priority"
+	QueryFieldService       = "service"
+	QueryFieldAssignee      = "assignee"
+	QueryFieldTitle         = "title"
+	QueryFieldCreatedAt     = "created_at"
+	QueryFieldAffectedUsers = "affected_users"
+)
+
+// Operators of incident query expressions. QueryOpMatch is equality, except on title where it
+// matches titles containing the value.
+const (
+	QueryOpMatch        = ":"
+	QueryOpGreater      = ">"
+	QueryOpGreaterEqual = ">="
+	QueryOpLess         = "<"
+	QueryOpLessEqual    = "<="
+)
+
+// Bounds keeping a query expression, and the SQL compiled from it, small
+const (
+	MaxQueryLength = 1000
+	MaxQueryTerms  = 32
+)
+
+// queryFieldOps lists the operators each field accepts
+var queryFieldOps = map[string][]string{
+	QueryFieldSeverity:      {QueryOpMatch},
+	QueryFieldCategory:      {QueryOpMatch},
+	QueryFieldPriority:      {QueryOpMatch},
+	QueryFieldService:       {QueryOpMatch},
+	QueryFieldAssignee:      {QueryOpMatch},
+	QueryFieldTitle:         {QueryOpMatch},
+	QueryFieldCreatedAt:     {QueryOpGreater, QueryOpGreaterEqual, QueryOpLess, QueryOpLessEqual},
+	QueryFieldAffectedUsers: {QueryOpMatch, QueryOpGreater, QueryOpGreaterEqual, QueryOpLess, QueryOpLessEqual},
+}
+
+// QueryFields lists the fields query expressions can test
+var QueryFields = []string{
+	QueryFieldSeverity, QueryFieldCategory, QueryFieldPriority, QueryFieldService,
+	QueryFieldAssignee, QueryFieldTitle, QueryFieldCreatedAt, QueryFieldAffectedUsers,
+}
+
+// QueryNode is a parsed incident query expression: a *QueryAnd, *QueryOr, *QueryNot or *QueryTerm
+type QueryNode interface {
+	// String renders the node canonically, so equivalent spellings render the same
+	String() string
+}
+
+// QueryAnd matches incidents matching both sides
+type QueryAnd struct {
+	Left, Right QueryNode
+}
+
+func (n *QueryAnd) String() string { return "(" + n.Left.String() + " AND " + n.Right.String() + ")" }
+
+// QueryOr matches incidents matching either side
+type QueryOr struct {
+	Left, Right QueryNode
+}
+
+func (n *QueryOr) String() string { return "(" + n.Left.String() + " OR " + n.Right.String() + ")" }
+
+// QueryNot matches incidents not matching its operand
+type QueryNot struct {
+	Operand QueryNode
+}
+
+func (n *QueryNot) String() string { return "NOT " + n.Operand.String() }
+
+// QueryTerm compares one field with a value. Value is the canonical text of the value; Time and
+// Number hold it parsed for created_at and affected_users.
+type QueryTerm struct {
+	Field  string
+	Op     string
+	Value  string
+	Time   time.Time
+	Number int
+}
+
+func (n *QueryTerm) String() string { return n.Field + n.Op + strconv.Quote(n.Value) }
+
+// QueryError reports why a query expression is invalid and where. Position is the 1-based
+// character offset of Token, the offending token, which is empty at the end of the query.
+type QueryError struct {
+	Position int
+	Token    string
+	Message  string
+}
+
+func (e *QueryError) Error() string {
+	if e.Token == "" {
+		return e.Message + " at end of query"
+	}
+	return fmt.Sprintf("%s at position %d (%q)", e.Message, e.Position, e.Token)
+}
+
+// ParseQuery parses an incident query expression such as
+//
+//	severity:Critical AND (category:Database OR service:auth) AND created_at>2024-01-01
+//
+// Terms are <field><operator><value>, where values with spaces or parentheses are double-quoted.
+// Terms combine with AND, OR and NOT (case-insensitive) and parentheses; AND binds tighter than
+// OR. Dates without a time are midnight UTC.
+func ParseQuery(input string) (QueryNode, error) {
+	if utf8.RuneCountInString(input) > MaxQueryLength {
+		return nil, &QueryError{Position: 1, Message: fmt.Sprintf("query must be at most %d characters", MaxQueryLength)}
+	}
+
+	p := &queryParser{input: input}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.next(); tok.kind != tokenEnd {
+		return nil, p.errorAt(tok, "expected AND or OR")
+	}
+	return node, nil
+}
+
+// queryTokenKind classifies the tokens of a query expression
+type queryTokenKind int
+
+const (
+	tokenEnd queryTokenKind = iota
+	tokenWord
+	tokenOpen
+	tokenClose
+)
+
+// queryToken is a lexed token and its byte offset in the input
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+// queryParser is a recursive descent parser over the query text. Values are lexed separately
+// from other tokens since they may contain operator characters, as in 2024-01-01T10:00:00Z.
+type queryParser struct {
+	input  string
+	pos    int
+	peeked *queryToken
+	terms  int
+}
+
+// parseOr parses: and (OR and)*
+func (p *queryParser) parseOr() (QueryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &QueryOr{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary (AND unary)*
+func (p *queryParser) parseAnd() (QueryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("AND") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &QueryAnd{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseUnary parses: NOT unary | ( or ) | term
+func (p *queryParser) parseUnary() (QueryNode, error) {
+	if p.peekKeyword("NOT") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &QueryNot{Operand: operand}, nil
+	}
+
+	tok := p.next()
+	switch tok.kind {
+	case tokenOpen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenClose {
+			return nil, p.errorAt(closing, "expected )")
+		}
+		return node, nil
+	case tokenWord:
+		return p.parseTerm(tok)
+	default:
+		return nil, p.errorAt(tok, "expected a term such as severity:High")
+	}
+}
+
+// parseTerm parses the operator and value following the field token of a term
+func (p *queryParser) parseTerm(field queryToken) (QueryNode, error) {
+	name := strings.ToLower(field.text)
+	ops, ok := queryFieldOps[name]
+	if !ok {
+		return nil, p.errorAt(field, "unknown field, expected one of "+strings.Join(QueryFields, ", "))
+	}
+
+	p.terms++
+	if p.terms > MaxQueryTerms {
+		return nil, p.errorAt(field, fmt.Sprintf("query must have at most %d terms", MaxQueryTerms))
+	}
+
+	opPos := p.skipSpace()
+	op := p.lexOperator()
+	if op == "" {
+		return nil, p.errorAt(p.tokenAt(opPos), "expected an operator after "+name)
+	}
+	if !containsString(ops, op) {
+		return nil, p.errorAt(queryToken{kind: tokenWord, text: op, pos: opPos},
+			fmt.Sprintf("operator %s is not supported for %s, expected one of %s", op, name, strings.Join(ops, " ")))
+	}
+
+	value, err := p.lexValue()
+	if err != nil {
+		return nil, err
+	}
+	return p.newTerm(name, op, value)
+}
+
+// newTerm validates and canonicalizes the value of a term
+func (p *queryParser) newTerm(field, op string, value queryToken) (*QueryTerm, error) {
+	term := &QueryTerm{Field: field, Op: op, Value: value.text}
+
+	switch field {
+	case QueryFieldSeverity, QueryFieldCategory, QueryFieldPriority:
+		allowed := map[string][]string{
+			QueryFieldSeverity: Severities,
+			QueryFieldCategory: Categories,
+			QueryFieldPriority: Priorities,
+		}[field]
+		canonical, ok := CanonicalValue(allowed, value.text)
+		if !ok {
+			return nil, p.errorAt(value, fmt.Sprintf("invalid %s, expected one of %s", field, strings.Join(allowed, ", ")))
+		}
+		term.Value = canonical
+	case QueryFieldCreatedAt:
+		t, err := parseQueryTime(value.text)
+		if err != nil {
+			return nil, p.errorAt(value, "invalid created_at, expected a date such as 2024-01-01 or an RFC 3339 timestamp")
+		}
+		term.Time = t
+		term.Value = t.Format(time.RFC3339)
+	case QueryFieldAffectedUsers:
+		n, err := strconv.Atoi(value.text)
+		if err != nil || n < 0 {
+			return nil, p.errorAt(value, "invalid affected_users, expected a non-negative integer")
+		}
+		term.Number = n
+		term.Value = strconv.Itoa(n)
+	}
+	return term, nil
+}
+
+// parseQueryTime parses a date, as midnight UTC, or an RFC 3339 timestamp
+func parseQueryTime(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+// peekKeyword reports whether the next token is the given keyword, in any case
+func (p *queryParser) peekKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokenWord && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *queryParser) peek() queryToken {
+	if p.peeked == nil {
+		tok := p.lex()
+		p.peeked = &tok
+	}
+	return *p.peeked
+}
+
+func (p *queryParser) next() queryToken {
+	tok := p.peek()
+	p.peeked = nil
+	return tok
+}
+
+// lex reads the next parenthesis or word. A word ends at whitespace, a parenthesis, a quote or
+// an operator character, so "severity:High" lexes as the word severity followed by an operator.
+func (p *queryParser) lex() queryToken {
+	start := p.skipSpace()
+	if start >= len(p.input) {
+		return queryToken{kind: tokenEnd, pos: start}
+	}
+
+	switch p.input[start] {
+	case '(':
+		p.pos++
+		return queryToken{kind: tokenOpen, text: "(", pos: start}
+	case ')':
+		p.pos++
+		return queryToken{kind: tokenClose, text: ")", pos: start}
+	}
+
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if unicode.IsSpace(r) || strings.ContainsRune(`()":<>=!`, r) {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		// A stray quote or operator character: consume it so it is reported as the token
+		_, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		p.pos += size
+	}
+	return queryToken{kind: tokenWord, text: p.input[start:p.pos], pos: start}
+}
+
+// lexOperator reads an operator directly after a field, or returns "" when there is none
+func (p *queryParser) lexOperator() string {
+	for _, op := range []string{QueryOpGreaterEqual, QueryOpLessEqual, QueryOpMatch, QueryOpGreater, QueryOpLess} {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+// lexValue reads the value of a term: a double-quoted string, in which \" and \\ are escapes,
+// or the text up to the next whitespace or parenthesis
+func (p *queryParser) lexValue() (queryToken, error) {
+	start := p.skipSpace()
+	if start >= len(p.input) || p.input[start] == '(' || p.input[start] == ')' {
+		return queryToken{}, p.errorAt(p.tokenAt(start), "expected a value")
+	}
+
+	if p.input[start] != '"' {
+		for p.pos < len(p.input) {
+			r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+			if unicode.IsSpace(r) || r == '(' || r == ')' {
+				break
+			}
+			p.pos += size
+		}
+		return queryToken{kind: tokenWord, text: p.input[start:p.pos], pos: start}, nil
+	}
+
+	var value strings.Builder
+	p.pos++
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			if value.Len() == 0 {
+				return queryToken{}, p.errorAt(queryToken{kind: tokenWord, text: `""`, pos: start}, "expected a value")
+			}
+			return queryToken{kind: tokenWord, text: value.String(), pos: start}, nil
+		case c == '\\' && p.pos+1 < len(p.input):
+			value.WriteByte(p.input[p.pos+1])
+			p.pos += 2
+		default:
+			value.WriteByte(c)
+			p.pos++
+		}
+	}
+	return queryToken{}, p.errorAt(queryToken{kind: tokenWord, text: p.input[start:], pos: start}, "unterminated quoted value")
+}
+
+// skipSpace advances past whitespace and returns the new position
+func (p *queryParser) skipSpace() int {
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		p.pos += size
+	}
+	return p.pos
+}
+
+// tokenAt returns the token starting at pos without consuming it, for error reporting
+func (p *queryParser) tokenAt(pos int) queryToken {
+	saved, savedPeek := p.pos, p.peeked
+	p.pos, p.peeked = pos, nil
+	tok := p.lex()
+	p.pos, p.peeked = saved, savedPeek
+	return tok
+}
+
+// errorAt reports message at tok
+func (p *queryParser) errorAt(tok queryToken, message string) *QueryError {
+	return &QueryError{
+		Position: utf8.RuneCountInString(p.input[:tok.pos]) + 1,
+		Token:    tok.text,
+		Message:  message,
+	}
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
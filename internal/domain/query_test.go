@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "single term", input: "severity:critical", expected: `severity:"Critical"`},
+		{
+			name:     "precedence and parentheses",
+			input:    "severity:Critical AND (category:Database OR service:auth) AND created_at>2024-01-01",
+			expected: `((severity:"Critical" AND (category:"Database" OR service:"auth")) AND created_at>"2024-01-01T00:00:00Z")`,
+		},
+		{
+			name:     "AND binds tighter than OR",
+			input:    "service:auth or severity:High and priority:p1",
+			expected: `(service:"auth" OR (severity:"High" AND priority:"P1"))`,
+		},
+		{name: "negation", input: "NOT assignee:alice", expected: `NOT assignee:"alice"`},
+		{name: "quoted value", input: `title:"connection \"timeout\""`, expected: `title:"connection \"timeout\""`},
+		{name: "timestamp value", input: "created_at<=2024-06-01T10:00:00+02:00", expected: `created_at<="2024-06-01T08:00:00Z"`},
+		{name: "spaces around the operator", input: "affected_users >= 500", expected: `affected_users>="500"`},
+		{name: "nested parentheses", input: "((service:auth))", expected: `service:"auth"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := ParseQuery(tt.input)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, node.String())
+		})
+	}
+}
+
+func TestParseQuery_ParsedValues(t *testing.T) {
+	node, err := ParseQuery("created_at>=2024-01-01 AND affected_users>10")
+	assert.NoError(t, err)
+
+	and := node.(*QueryAnd)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), and.Left.(*QueryTerm).Time)
+	assert.Equal(t, 10, and.Right.(*QueryTerm).Number)
+}
+
+func TestParseQuery_Malformed(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		position int
+		token    string
+	}{
+		{name: "unknown field", input: "severity:High AND owner:bob", position: 19, token: "owner"},
+		{name: "unsupported operator", input: "severity>High", position: 9, token: ">"},
+		{name: "unknown operator", input: "severity=High", position: 9, token: "="},
+		{name: "missing operator", input: "severity High", position: 10, token: "High"},
+		{name: "unknown severity", input: "severity:Urgent", position: 10, token: "Urgent"},
+		{name: "invalid date", input: "created_at>yesterday", position: 12, token: "yesterday"},
+		{name: "negative count", input: "affected_users>-1", position: 16, token: "-1"},
+		{name: "missing value", input: "service:", position: 9, token: ""},
+		{name: "empty quoted value", input: `service:""`, position: 9, token: `""`},
+		{name: "unterminated quote", input: `title:"disk`, position: 7, token: `"disk`},
+		{name: "unclosed parenthesis", input: "(service:auth", position: 14, token: ""},
+		{name: "stray closing parenthesis", input: "service:auth)", position: 13, token: ")"},
+		{name: "missing conjunction", input: "service:auth severity:High", position: 14, token: "severity"},
+		{name: "dangling AND", input: "service:auth AND", position: 17, token: ""},
+		{name: "leading operator", input: ":High", position: 1, token: ":"},
+		{name: "SQL in a field", input: "1=1;DROP:x", position: 1, token: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQuery(tt.input)
+
+			var queryErr *QueryError
+			if assert.True(t, errors.As(err, &queryErr), "expected a QueryError, got %v", err) {
+				assert.Equal(t, tt.position, queryErr.Position)
+				assert.Equal(t, tt.token, queryErr.Token)
+			}
+		})
+	}
+}
+
+func TestParseQuery_Limits(t *testing.T) {
+	long := "title:\"" + string(make([]byte, MaxQueryLength)) + "\""
+	_, err := ParseQuery(long)
+	assert.Error(t, err)
+
+	many := "service:a"
+	for i := 0; i < MaxQueryTerms; i++ {
+		many += " OR service:a"
+	}
+	_, err = ParseQuery(many)
+	assert.ErrorContains(t, err, "at most")
+}
+
+func TestQueryError_Error(t *testing.T) {
+	_, err := ParseQuery("severity:High AND owner:bob")
+	assert.EqualError(t, err, `unknown field, expected one of severity, category, priority, service, assignee, title, created_at, affected_users at position 19 ("owner")`)
+
+	_, err = ParseQuery("service:auth AND")
+	assert.EqualError(t, err, "expected a term such as severity:High at end of query")
+}
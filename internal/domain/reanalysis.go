@@ -0,0 +1,63 @@
+package domain
+
+import "strings"
+
+// Reanalysis modes deciding when an update re-runs the AI analysis
+const (
+	ReanalyzeAlways      = "always"
+	ReanalyzeNever       = "never"
+	ReanalyzeSignificant = "significant"
+)
+
+// DefaultReanalysisThreshold is the share of the normalized title and description that must
+// change for ReanalyzeSignificant to re-run the analysis
+const DefaultReanalysisThreshold = 0.2
+
+// ReanalysisPolicy decides whether an update re-runs the AI analysis. The zero value always does.
+type ReanalysisPolicy struct {
+	Mode      string
+	Threshold float64
+}
+
+// Reanalyze reports whether updating before with req should re-run the AI analysis. In
+// significant mode an incident whose analysis failed is always reanalyzed; otherwise the
+// share of the normalized title and description that changed must reach the threshold.
+func (p ReanalysisPolicy) Reanalyze(before *Incident, req *CreateIncidentRequest) bool {
+	switch p.Mode {
+	case ReanalyzeNever:
+		return false
+	case ReanalyzeSignificant:
+		if before.AnalysisStatus == AnalysisFailed {
+			return true
+		}
+		return changedShare(analyzedText(before.Title, before.Description), analyzedText(req.Title, req.Description)) >= p.Threshold
+	default:
+		return true
+	}
+}
+
+// analyzedText normalizes the text the analysis depends on: lower case with whitespace runs collapsed
+func analyzedText(title, description string) []rune {
+	return []rune(strings.ToLower(strings.Join(strings.Fields(title+"\n"+description), " ")))
+}
+
+// changedShare estimates how much of b differs from a as the length of the span between
+// their common prefix and suffix over the longer length. It is linear in the text length,
+// so it stays cheap for long descriptions.
+func changedShare(a, b []rune) float64 {
+	longest := max(len(a), len(b))
+	if longest == 0 {
+		return 0
+	}
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	return float64(longest-prefix-suffix) / float64(longest)
+}
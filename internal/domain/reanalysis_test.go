@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReanalysisPolicy_Reanalyze(t *testing.T) {
+	before := &Incident{Title: "Disk full", Description: "Root volume on db-1 is at 100% and writes are failing", AnalysisStatus: AnalysisComplete}
+	cosmetic := &CreateIncidentRequest{Title: "Disk  full", Description: "Root volume on db-1 is at 100% and writes are failing.", AffectedService: "database"}
+	rewritten := &CreateIncidentRequest{Title: "Disk full", Description: "Replication lag on db-2 is growing past ten minutes", AffectedService: "database"}
+
+	tests := []struct {
+		name     string
+		policy   ReanalysisPolicy
+		before   *Incident
+		req      *CreateIncidentRequest
+		expected bool
+	}{
+		{name: "zero value always", policy: ReanalysisPolicy{}, before: before, req: cosmetic, expected: true},
+		{name: "always", policy: ReanalysisPolicy{Mode: ReanalyzeAlways}, before: before, req: cosmetic, expected: true},
+		{name: "never", policy: ReanalysisPolicy{Mode: ReanalyzeNever}, before: before, req: rewritten, expected: false},
+		{name: "significant skips cosmetic edits", policy: ReanalysisPolicy{Mode: ReanalyzeSignificant, Threshold: 0.2}, before: before, req: cosmetic, expected: false},
+		{name: "significant reanalyzes rewrites", policy: ReanalysisPolicy{Mode: ReanalyzeSignificant, Threshold: 0.2}, before: before, req: rewritten, expected: true},
+		{
+			name:     "significant retries failed analyses",
+			policy:   ReanalysisPolicy{Mode: ReanalyzeSignificant, Threshold: 0.2},
+			before:   &Incident{Title: before.Title, Description: before.Description, AnalysisStatus: AnalysisFailed},
+			req:      cosmetic,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.policy.Reanalyze(tt.before, tt.req))
+		})
+	}
+}
+
+func TestChangedShare(t *testing.T) {
+	assert.Equal(t, 0.0, changedShare(nil, nil))
+	assert.Equal(t, 0.0, changedShare([]rune("same"), []rune("same")))
+	assert.Equal(t, 1.0, changedShare([]rune("abc"), []rune("xyz")))
+	assert.Equal(t, 0.25, changedShare([]rune("abcd"), []rune("abXd")))
+	assert.Equal(t, 0.2, changedShare([]rune("abcd"), []rune("abcde")))
+}
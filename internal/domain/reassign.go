@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReassignRequest moves every open incident of one assignee to another, optionally only
+// those with a given severity or affected service
+type ReassignRequest struct {
+	From            string `json:"from"`
+	To              string `json:"to"`
+	Severity        string `json:"severity,omitempty"`
+	AffectedService string `json:"affected_service,omitempty"`
+}
+
+// Validate requires distinct from and to assignees that fit the assignee column and a known
+// severity when one is given, rewriting it to its canonical case
+func (r *ReassignRequest) Validate() error {
+	r.From = strings.TrimSpace(r.From)
+	r.To = strings.TrimSpace(r.To)
+	r.Severity = strings.TrimSpace(r.Severity)
+	r.AffectedService = strings.TrimSpace(r.AffectedService)
+
+	fields := validateText(nil, "from", r.From, MaxAssigneeLength)
+	fields = validateText(fields, "to", r.To, MaxAssigneeLength)
+	if r.From != "" && r.From == r.To {
+		fields = append(fields, FieldError{Field: "to", Rule: RuleDistinct, Message: "to must differ from from"})
+	}
+
+	if r.Severity != "" {
+		canonical, ok := CanonicalValue(Severities, r.Severity)
+		if !ok {
+			fields = append(fields, FieldError{
+				Field:   "severity",
+				Rule:    RuleOneOf,
+				Message: fmt.Sprintf("severity must be one of %s", strings.Join(Severities, ", ")),
+			})
+		}
+		r.Severity = canonical
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// ReassignScope narrows a bulk reassignment; empty values match every incident
+type ReassignScope struct {
+	Severity        string
+	AffectedService string
+}
+
+// ReassignResult reports which incidents a bulk reassignment moved
+type ReassignResult struct {
+	Reassigned  int   `json:"reassigned"`
+	IncidentIDs []int `json:"incident_ids"`
+}
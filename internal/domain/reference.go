@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Placeholders of a reference pattern
+const (
+	ReferenceYear     = "{year}"
+	ReferenceSequence = "{seq}"
+)
+
+// Defaults of the incident reference format, rendering references like INC-2024-000123
+const (
+	DefaultReferencePattern = "INC-" + ReferenceYear + "-" + ReferenceSequence
+	DefaultReferenceDigits  = 6
+)
+
+// MaxReferenceLength is the size of the reference column
+const MaxReferenceLength = 32
+
+// DefaultReferenceFormat is the reference format used unless configured otherwise
+var DefaultReferenceFormat = ReferenceFormat{Pattern: DefaultReferencePattern, Digits: DefaultReferenceDigits}
+
+// ReferenceFormat renders the human-friendly reference of an incident from the year it was
+// created and its sequence number within that year
+type ReferenceFormat struct {
+	// Pattern holds ReferenceSequence and optionally ReferenceYear
+	Pattern string
+	// Digits is the width the sequence number is zero-padded to
+	Digits int
+}
+
+// Format renders the reference of the seq-th incident of year
+func (f ReferenceFormat) Format(year, seq int) string {
+	return strings.NewReplacer(
+		ReferenceYear, strconv.Itoa(year),
+		ReferenceSequence, fmt.Sprintf("%0*d", f.Digits, seq),
+	).Replace(f.Pattern)
+}
+
+// Regexp matches, ignoring case, every reference the format renders. A sequence number may
+// outgrow its padding.
+func (f ReferenceFormat) Regexp() *regexp.Regexp {
+	expr := strings.NewReplacer(
+		regexp.QuoteMeta(ReferenceYear), `\d{4}`,
+		regexp.QuoteMeta(ReferenceSequence), fmt.Sprintf(`\d{%d,}`, f.Digits),
+	).Replace(regexp.QuoteMeta(f.Pattern))
+	return regexp.MustCompile(`(?i)^` + expr + `$`)
+}
+
+// Validate requires the sequence placeholder, a padding width of 1 to 10 digits, and text
+// other than digits outside the placeholders, so references never look like numeric IDs
+func (f ReferenceFormat) Validate() error {
+	if !strings.Contains(f.Pattern, ReferenceSequence) {
+		return fmt.Errorf("reference pattern %q must contain %s", f.Pattern, ReferenceSequence)
+	}
+	if f.Digits < 1 || f.Digits > 10 {
+		return fmt.Errorf("reference digits must be between 1 and 10, got %d", f.Digits)
+	}
+
+	literal := strings.NewReplacer(ReferenceYear, "", ReferenceSequence, "").Replace(f.Pattern)
+	if strings.IndexFunc(literal, func(r rune) bool { return !unicode.IsDigit(r) }) < 0 {
+		return fmt.Errorf("reference pattern %q must contain text other than digits, to tell references from IDs", f.Pattern)
+	}
+
+	if sample := f.Format(9999, 1); len(sample) > MaxReferenceLength {
+		return fmt.Errorf("references like %q are longer than %d characters", sample, MaxReferenceLength)
+	}
+	return nil
+}
+
+// SequenceRepository hands out incident reference sequence numbers
+type SequenceRepository interface {
+	// Next advances the year's sequence and returns its new value, starting at 1
+	Next(ctx context.Context, year int) (int, error)
+}
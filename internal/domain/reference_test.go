@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferenceFormat_Format(t *testing.T) {
+	assert.Equal(t, "INC-2024-000123", DefaultReferenceFormat.Format(2024, 123))
+	assert.Equal(t, "INC-2024-1234567", DefaultReferenceFormat.Format(2024, 1234567))
+
+	custom := ReferenceFormat{Pattern: "{seq}@OPS", Digits: 3}
+	assert.Equal(t, "042@OPS", custom.Format(2024, 42))
+}
+
+func TestReferenceFormat_Regexp(t *testing.T) {
+	re := DefaultReferenceFormat.Regexp()
+
+	assert.True(t, re.MatchString("INC-2024-000123"))
+	assert.True(t, re.MatchString("inc-2024-000123"))
+	assert.True(t, re.MatchString("INC-2024-1234567"))
+	assert.False(t, re.MatchString("INC-2024-123"))
+	assert.False(t, re.MatchString("123"))
+	assert.False(t, re.MatchString("XINC-2024-000123"))
+
+	custom := ReferenceFormat{Pattern: "OPS.{seq}", Digits: 2}.Regexp()
+	assert.True(t, custom.MatchString("OPS.07"))
+	assert.False(t, custom.MatchString("OPSx07"))
+}
+
+func TestReferenceFormat_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		format ReferenceFormat
+		valid  bool
+	}{
+		{"default", DefaultReferenceFormat, true},
+		{"without year", ReferenceFormat{Pattern: "INC-{seq}", Digits: 6}, true},
+		{"without sequence", ReferenceFormat{Pattern: "INC-{year}", Digits: 6}, false},
+		{"digits only", ReferenceFormat{Pattern: "{year}0{seq}", Digits: 6}, false},
+		{"no padding", ReferenceFormat{Pattern: "INC-{seq}", Digits: 0}, false},
+		{"too long", ReferenceFormat{Pattern: "INCIDENT-REFERENCE-{year}-{seq}", Digits: 10}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.format.Validate()
+		if tt.valid {
+			assert.NoError(t, err, tt.name)
+		} else {
+			assert.Error(t, err, tt.name)
+		}
+	}
+}
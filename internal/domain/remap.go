@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RemapSeverityRequest maps old severity values to values of the current taxonomy
+type RemapSeverityRequest struct {
+	Mapping map[string]string `json:"mapping"`
+}
+
+// Validate checks that the mapping is non-empty and that every new value is a current
+// severity, rewriting new values to their canonical case. Old values are free-form because
+// they may come from a retired taxonomy.
+func (r *RemapSeverityRequest) Validate() error {
+	var fields []FieldError
+	if len(r.Mapping) == 0 {
+		fields = append(fields, FieldError{Field: "mapping", Rule: RuleRequired, Message: "mapping is required"})
+	}
+
+	olds := make([]string, 0, len(r.Mapping))
+	for old := range r.Mapping {
+		olds = append(olds, old)
+	}
+	sort.Strings(olds)
+
+	for _, old := range olds {
+		field := "mapping." + old
+		if strings.TrimSpace(old) == "" {
+			fields = append(fields, FieldError{Field: field, Rule: RuleRequired, Message: "old severity must not be empty"})
+			continue
+		}
+		canonical, ok := CanonicalValue(Severities, r.Mapping[old])
+		if !ok {
+			fields = append(fields, FieldError{
+				Field:   field,
+				Rule:    RuleOneOf,
+				Message: fmt.Sprintf("%s must map to one of %s", old, strings.Join(Severities, ", ")),
+			})
+			continue
+		}
+		r.Mapping[old] = canonical
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// RemapResult reports how many incidents a severity remap changed, or would change in a dry run
+type RemapResult struct {
+	DryRun   bool           `json:"dry_run"`
+	Remapped map[string]int `json:"remapped"`
+	Total    int            `json:"total"`
+}
@@ -0,0 +1,12 @@
+package domain
+
+// TeamRoute identifies the team and channel responsible for an incident
+type TeamRoute struct {
+	Team    string `json:"team"`
+	Channel string `json:"channel"`
+}
+
+// TeamRouter resolves the responsible team for an incident category
+type TeamRouter interface {
+	Route(category string) *TeamRoute
+}
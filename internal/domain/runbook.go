@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// MaxRunbookStepLength matches the runbook_steps.step column
+const MaxRunbookStepLength = 500
+
+// RunbookStep is one remediation step attached to an incident
+type RunbookStep struct {
+	ID         int        `json:"id" db:"id"`
+	IncidentID int        `json:"incident_id" db:"incident_id"`
+	Step       string     `json:"step" db:"step"`
+	Done       bool       `json:"done" db:"done"`
+	DoneBy     string     `json:"done_by,omitempty" db:"done_by"`
+	DoneAt     *time.Time `json:"done_at,omitempty" db:"done_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// RunbookStepRequest is the body of a request adding a runbook step to an incident
+type RunbookStepRequest struct {
+	Step string `json:"step"`
+}
+
+// Validate requires a step that fits the step column
+func (r *RunbookStepRequest) Validate() error {
+	r.Step = strings.TrimSpace(r.Step)
+	if fields := validateText(nil, "step", r.Step, MaxRunbookStepLength); len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// CompleteStepRequest is the body of a request marking a runbook step done
+type CompleteStepRequest struct {
+	DoneBy string `json:"done_by"`
+}
+
+// Validate requires the responder who did the step, fitting the done_by column
+func (r *CompleteStepRequest) Validate() error {
+	r.DoneBy = strings.TrimSpace(r.DoneBy)
+	if fields := validateText(nil, "done_by", r.DoneBy, MaxAssigneeLength); len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// RunbookCompletion returns the percentage of done steps, rounded down, or nil without steps
+func RunbookCompletion(total, done int) *int {
+	if total == 0 {
+		return nil
+	}
+	percent := done * 100 / total
+	return &percent
+}
+
+// RunbookRepository stores the runbook steps of incidents
+type RunbookRepository interface {
+	AddStep(ctx context.Context, step *RunbookStep) error
+	GetSteps(ctx context.Context, incidentID int) ([]*RunbookStep, error)
+	// CompleteStep marks a step of an incident done, returning ErrStepNotFound when the
+	// incident has no such step and ErrStepDone when it is already done
+	CompleteStep(ctx context.Context, incidentID, stepID int, doneBy string, doneAt time.Time) error
+	CountSteps(ctx context.Context, incidentID int) (total, done int, err error)
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunbookCompletion(t *testing.T) {
+	assert.Nil(t, RunbookCompletion(0, 0))
+	assert.Equal(t, 0, *RunbookCompletion(2, 0))
+	assert.Equal(t, 66, *RunbookCompletion(3, 2))
+	assert.Equal(t, 100, *RunbookCompletion(4, 4))
+}
+
+func TestCompleteStepRequest_Validate(t *testing.T) {
+	req := &CompleteStepRequest{DoneBy: " alice "}
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, "alice", req.DoneBy)
+
+	req = &CompleteStepRequest{}
+	assert.Error(t, req.Validate())
+}
@@ -0,0 +1,11 @@
+package domain
+
+// MaxSearchTermLength caps the length of a full-text search term
+const MaxSearchTermLength = 200
+
+// SearchPage is one page of incidents whose title or description mention a search term,
+// newest first. Total counts every matching incident, not just those on the page.
+type SearchPage struct {
+	Incidents []*Incident
+	Total     int
+}
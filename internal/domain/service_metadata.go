@@ -0,0 +1,14 @@
+package domain
+
+// ServiceMetadata describes who owns an affected service and where to start when it breaks
+type ServiceMetadata struct {
+	OwnerTeam  string `json:"owner_team,omitempty"`
+	OnCall     string `json:"on_call,omitempty"`
+	RunbookURL string `json:"runbook_url,omitempty"`
+}
+
+// ServiceDirectory looks up the metadata of an affected service. Unknown services yield nil
+// metadata and no error.
+type ServiceDirectory interface {
+	Lookup(service string) (*ServiceMetadata, error)
+}
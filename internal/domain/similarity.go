@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// ErrEmbeddingsUnavailable is returned when similarity search is requested but no embedding provider is configured
+var ErrEmbeddingsUnavailable = errors.New("embeddings are not configured")
+
+// SimilarIncident represents an incident that is similar to another one, with its similarity score
+type SimilarIncident struct {
+	Incident *Incident `json:"incident"`
+	Score    float64   `json:"score"`
+}
+
+// SimilarityMatch represents a raw nearest-neighbour match returned by a SimilaritySearcher
+type SimilarityMatch struct {
+	IncidentID int
+	Score      float64
+}
+
+// EmbeddingService defines the interface for computing text embeddings
+type EmbeddingService interface {
+	EmbedText(ctx context.Context, text string) ([]float32, error)
+}
+
+// EmbeddingRepository defines the interface for incident embedding storage
+type EmbeddingRepository interface {
+	SaveEmbedding(ctx context.Context, incidentID int, embedding []float32) error
+	GetEmbedding(ctx context.Context, incidentID int) ([]float32, error)
+	DeleteEmbedding(ctx context.Context, incidentID int) error
+}
+
+// SimilaritySearcher defines the interface for nearest-neighbour search over stored embeddings.
+// The MySQL implementation is a brute-force scan; a vector database can implement it later.
+type SimilaritySearcher interface {
+	FindNearest(ctx context.Context, embedding []float32, excludeID int, limit int) ([]*SimilarityMatch, error)
+}
+
+// CosineSimilarity computes the cosine similarity of two vectors, returning 0 for mismatched or zero vectors
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,9 @@
+package domain
+
+// DistributionCount is the number of incidents sharing one value of a field,
+// e.g. Field "ai_severity", Value "High"
+type DistributionCount struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
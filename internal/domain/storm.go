@@ -0,0 +1,10 @@
+package domain
+
+// StormCountField is the custom field of an alert storm incident holding the number of
+// incidents suppressed into it
+const StormCountField = "storm_count"
+
+// StormNotifier is told when an affected service starts an alert storm
+type StormNotifier interface {
+	NotifyStorm(storm *Incident)
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "strings"
+
+// Severities lists the valid incident severity levels, least to most severe
+var Severities = []string{"Low", "Medium", "High", "Critical"}
+
+// Categories lists the valid incident categories
+var Categories = []string{"Network", "Software", "Hardware", "Security", "Database", "Application", "Infrastructure"}
+
+// DefaultSeverity and DefaultCategory are used when no valid AI classification is available,
+// matching the incidents table column defaults
+const (
+	DefaultSeverity = "Medium"
+	DefaultCategory = "Software"
+)
+
+const (
+	// DefaultConfidence is the confidence of an AI analysis that does not state one
+	DefaultConfidence = 0.5
+
+	// LowConfidenceThreshold is the confidence below which an AI classification is flagged
+	// for review
+	LowConfidenceThreshold = 0.4
+)
+
+// CanonicalValue returns the entry of values matching value case-insensitively
+func CanonicalValue(values []string, value string) (string, bool) {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return v, true
+		}
+	}
+	return "", false
+}
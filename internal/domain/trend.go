@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// SevereSeverities are the severities whose rate is watched for spikes
+var SevereSeverities = []string{"High", "Critical"}
+
+// TrendPolicy decides when the rate of severe incidents is alarming: more than Threshold of
+// them created within the last Window, or at least SpikeFactor times as many as in the Window
+// before. A zero Threshold or SpikeFactor leaves out that check, and the zero value sends no
+// alerts.
+type TrendPolicy struct {
+	Window      time.Duration
+	Threshold   int
+	SpikeFactor float64
+}
+
+// Enabled reports whether trend alerts are sent
+func (p TrendPolicy) Enabled() bool {
+	return p.Window > 0 && (p.Threshold > 0 || p.SpikeFactor > 0)
+}
+
+// Alarming reports whether count severe incidents in the current window, after previous in the
+// prior one, call for an alert. An empty prior window counts as one incident, so a handful of
+// severe incidents after a quiet window is not a spike of infinite size.
+func (p TrendPolicy) Alarming(count, previous int) bool {
+	if p.Threshold > 0 && count > p.Threshold {
+		return true
+	}
+	if p.SpikeFactor > 0 && count > previous {
+		return float64(count) >= p.SpikeFactor*float64(max(previous, 1))
+	}
+	return false
+}
+
+// TrendAlert reports a spike in severe incidents: Count created from Since until Until, after
+// PreviousCount in the window of the same length before
+type TrendAlert struct {
+	Count         int
+	PreviousCount int
+	Since         time.Time
+	Until         time.Time
+}
+
+// SevereIncidentCounter counts the severe incidents created in a time range
+type SevereIncidentCounter interface {
+	// CountSevereIncidents counts the incidents that are not false positives, have one of the
+	// SevereSeverities and were created at or after since and before until
+	CountSevereIncidents(ctx context.Context, since, until time.Time) (int, error)
+}
+
+// TrendNotifier delivers alerts about spikes in severe incidents
+type TrendNotifier interface {
+	NotifyTrend(alert *TrendAlert)
+}
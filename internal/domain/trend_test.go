@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrendPolicy(t *testing.T) {
+	assert.False(t, TrendPolicy{}.Enabled())
+	assert.False(t, TrendPolicy{Window: time.Hour}.Enabled())
+
+	threshold := TrendPolicy{Window: time.Hour, Threshold: 5}
+	assert.True(t, threshold.Enabled())
+	assert.False(t, threshold.Alarming(5, 0))
+	assert.True(t, threshold.Alarming(6, 10))
+
+	spike := TrendPolicy{Window: time.Hour, SpikeFactor: 2}
+	assert.True(t, spike.Enabled())
+	assert.False(t, spike.Alarming(5, 3))
+	assert.True(t, spike.Alarming(6, 3))
+	assert.False(t, spike.Alarming(1, 0))
+	assert.True(t, spike.Alarming(2, 0))
+	assert.False(t, spike.Alarming(0, 0))
+}
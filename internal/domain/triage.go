@@ -0,0 +1,34 @@
+package domain
+
+// Triage modes deciding whether incidents are classified by the AI
+const (
+	TriageOn  = "on"
+	TriageOff = "off"
+)
+
+// TriagePolicy decides how incidents are classified. The zero value runs the AI analysis; in
+// off mode the AI is never called and the severity and category come from the request, or
+// from the defaults when the request leaves them out.
+type TriagePolicy struct {
+	Mode            string
+	DefaultSeverity string
+	DefaultCategory string
+}
+
+// Off reports whether the AI analysis is disabled
+func (p TriagePolicy) Off() bool {
+	return p.Mode == TriageOff
+}
+
+// Analysis returns the classification of req in off mode: its own severity and category,
+// canonicalized, falling back to the defaults
+func (p TriagePolicy) Analysis(req *CreateIncidentRequest) *IncidentAnalysis {
+	analysis := &IncidentAnalysis{Severity: p.DefaultSeverity, Category: p.DefaultCategory}
+	if severity, ok := CanonicalValue(Severities, req.Severity); ok {
+		analysis.Severity = severity
+	}
+	if category, ok := CanonicalValue(Categories, req.Category); ok {
+		analysis.Category = category
+	}
+	return analysis
+}
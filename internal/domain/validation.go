@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validation rule names reported in FieldError.Rule
+const (
+	RuleRequired = "required"
+	RuleMax      = "max"
+	RuleUnknown  = "unknown"
+	RuleType     = "type"
+	RuleOneOf    = "oneof"
+	RuleDistinct = "distinct"
+	RuleMin      = "min"
+)
+
+// Default field length limits matching the incidents table column definitions
+const (
+	MaxTitleLength           = 255
+	MaxDescriptionLength     = 65535
+	MaxAffectedServiceLength = 100
+
+	// MaxSuggestedActionLength caps the AI suggested action to the ai_suggested_action column
+	MaxSuggestedActionLength = 500
+
+	// MaxReasoningLength caps the AI reasoning to the ai_reasoning column
+	MaxReasoningLength = 500
+
+	// MaxAssigneeLength matches the assignee column
+	MaxAssigneeLength = 100
+
+	// MaxFalsePositiveReasonLength matches the false_positive_reason column
+	MaxFalsePositiveReasonLength = 500
+)
+
+// FieldLimits holds the maximum length, in characters, of each incident text field
+type FieldLimits struct {
+	Title           int
+	Description     int
+	AffectedService int
+}
+
+// DefaultFieldLimits are the limits used when none are configured
+var DefaultFieldLimits = FieldLimits{
+	Title:           MaxTitleLength,
+	Description:     MaxDescriptionLength,
+	AffectedService: MaxAffectedServiceLength,
+}
+
+// FitWithin returns an error naming the first limit that exceeds the corresponding column
+// limit, so configured validation can never accept values the database would reject
+func (l FieldLimits) FitWithin(columns FieldLimits) error {
+	checks := []struct {
+		field         string
+		limit, column int
+	}{
+		{"title", l.Title, columns.Title},
+		{"description", l.Description, columns.Description},
+		{"affected_service", l.AffectedService, columns.AffectedService},
+	}
+
+	for _, c := range checks {
+		if c.limit > c.column {
+			return fmt.Errorf("%s limit %d exceeds the column length %d", c.field, c.limit, c.column)
+		}
+	}
+	return nil
+}
+
+// FieldError describes a single field validation failure
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationError aggregates every field validation failure of a request
+type ValidationError struct {
+	Fields []FieldError `json:"fields"`
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		messages[i] = f.Message
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Validate checks every field of the request against the default limits, accepting any custom fields
+func (r *CreateIncidentRequest) Validate() error {
+	return r.ValidateWith(DefaultFieldLimits, nil)
+}
+
+// ValidateWith checks every field of the request and returns a *ValidationError listing all violations
+func (r *CreateIncidentRequest) ValidateWith(limits FieldLimits, schema CustomFieldSchema) error {
+	var fields []FieldError
+	fields = validateText(fields, "title", r.Title, limits.Title)
+	fields = validateText(fields, "description", r.Description, limits.Description)
+	fields = validateText(fields, "affected_service", r.AffectedService, limits.AffectedService)
+	if utf8.RuneCountInString(r.Assignee) > MaxAssigneeLength {
+		fields = append(fields, FieldError{
+			Field:   "assignee",
+			Rule:    RuleMax,
+			Message: fmt.Sprintf("assignee must be at most %d characters", MaxAssigneeLength),
+		})
+	}
+	if r.AffectedUsers != nil && *r.AffectedUsers < 0 {
+		fields = append(fields, FieldError{
+			Field:   "affected_users",
+			Rule:    RuleMin,
+			Message: "affected_users must not be negative",
+		})
+	}
+	fields = validateChoice(fields, "severity", r.Severity, Severities)
+	fields = validateChoice(fields, "category", r.Category, Categories)
+	_, fields = validateFollowUpInterval(fields, "follow_up_interval", r.FollowUpInterval)
+	fields = schema.validate(fields, r.CustomFields)
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// validateChoice appends a violation when an optional value is set but not one of allowed
+func validateChoice(fields []FieldError, name, value string, allowed []string) []FieldError {
+	if value == "" {
+		return fields
+	}
+	if _, ok := CanonicalValue(allowed, value); !ok {
+		return append(fields, FieldError{
+			Field:   name,
+			Rule:    RuleOneOf,
+			Message: fmt.Sprintf("%s must be one of %s", name, strings.Join(allowed, ", ")),
+		})
+	}
+	return fields
+}
+
+// validateText appends required and maximum length violations for a text field
+func validateText(fields []FieldError, name, value string, maxLength int) []FieldError {
+	if strings.TrimSpace(value) == "" {
+		return append(fields, FieldError{
+			Field:   name,
+			Rule:    RuleRequired,
+			Message: fmt.Sprintf("%s is required", name),
+		})
+	}
+
+	if utf8.RuneCountInString(value) > maxLength {
+		return append(fields, FieldError{
+			Field:   name,
+			Rule:    RuleMax,
+			Message: fmt.Sprintf("%s must be at most %d characters", name, maxLength),
+		})
+	}
+
+	return fields
+}
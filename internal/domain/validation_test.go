@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateIncidentRequest_Validate(t *testing.T) {
+	t.Run("valid request", func(t *testing.T) {
+		req := &CreateIncidentRequest{Title: "Title", Description: "Description", AffectedService: "Service"}
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("reports every violation", func(t *testing.T) {
+		req := &CreateIncidentRequest{
+			Title:           "",
+			Description:     " ",
+			AffectedService: strings.Repeat("s", MaxAffectedServiceLength+1),
+		}
+
+		err := req.Validate()
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []FieldError{
+			{Field: "title", Rule: RuleRequired, Message: "title is required"},
+			{Field: "description", Rule: RuleRequired, Message: "description is required"},
+			{Field: "affected_service", Rule: RuleMax, Message: "affected_service must be at most 100 characters"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("length boundary", func(t *testing.T) {
+		req := &CreateIncidentRequest{Title: strings.Repeat("é", MaxTitleLength), Description: "Description", AffectedService: "Service"}
+		assert.NoError(t, req.Validate())
+
+		req.Title += "é"
+		assert.Error(t, req.Validate())
+	})
+
+	t.Run("configured limits", func(t *testing.T) {
+		limits := FieldLimits{Title: 10, Description: 20, AffectedService: 5}
+		req := &CreateIncidentRequest{
+			Title:           strings.Repeat("t", 10),
+			Description:     strings.Repeat("d", 20),
+			AffectedService: strings.Repeat("s", 5),
+		}
+		assert.NoError(t, req.ValidateWith(limits, nil))
+
+		req.Title += "t"
+		req.Description += "d"
+		req.AffectedService += "s"
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, req.ValidateWith(limits, nil), &validationErr)
+		assert.Len(t, validationErr.Fields, 3)
+	})
+
+	t.Run("affected users must not be negative", func(t *testing.T) {
+		users := 0
+		req := &CreateIncidentRequest{Title: "Title", Description: "Description", AffectedService: "Service", AffectedUsers: &users}
+		assert.NoError(t, req.Validate())
+
+		users = -5
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, req.Validate(), &validationErr)
+		assert.Equal(t, []FieldError{{Field: "affected_users", Rule: RuleMin, Message: "affected_users must not be negative"}}, validationErr.Fields)
+	})
+
+	t.Run("severity and category must be in the taxonomy", func(t *testing.T) {
+		req := &CreateIncidentRequest{Title: "Title", Description: "Description", AffectedService: "Service", Severity: "high", Category: "Database"}
+		assert.NoError(t, req.Validate())
+
+		req.Severity = "Urgent"
+		req.Category = "Power"
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, req.Validate(), &validationErr)
+		assert.Equal(t, []string{"severity", "category"}, []string{validationErr.Fields[0].Field, validationErr.Fields[1].Field})
+		assert.Equal(t, RuleOneOf, validationErr.Fields[0].Rule)
+	})
+}
+
+func TestFieldLimits_FitWithin(t *testing.T) {
+	columns := FieldLimits{Title: 255, Description: 65535, AffectedService: 100}
+
+	assert.NoError(t, DefaultFieldLimits.FitWithin(columns))
+	assert.NoError(t, FieldLimits{Title: 100, Description: 1000, AffectedService: 50}.FitWithin(columns))
+	assert.EqualError(t, FieldLimits{Title: 256, Description: 1000, AffectedService: 50}.FitWithin(columns),
+		"title limit 256 exceeds the column length 255")
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AdminTokenHeader is the request header carrying the admin API token
+const AdminTokenHeader = "X-Admin-Token"
+
+// RequireAdmin returns middleware that only lets through requests carrying the configured admin token.
+// An empty token disables admin access entirely.
+func RequireAdmin(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if token == "" {
+				return echo.NewHTTPError(http.StatusForbidden, "Admin access is not configured")
+			}
+
+			if c.Request().Header.Get(AdminTokenHeader) == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Admin token required")
+			}
+
+			if !isAdmin(c, token) {
+				return echo.NewHTTPError(http.StatusForbidden, "Invalid admin token")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// isAdmin reports whether the request carries the configured admin token. It is always false
+// when no token is configured.
+func isAdmin(c echo.Context, token string) bool {
+	provided := c.Request().Header.Get(AdminTokenHeader)
+	if token == "" || provided == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"net/http"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetEffectiveConfig handles GET /admin/config
+func (h *IncidentHandler) GetEffectiveConfig(c echo.Context) error {
+	settings := h.settings
+	if settings == nil {
+		settings = []domain.ConfigSetting{}
+	}
+
+	return h.respond(c, http.StatusOK, settings, nil, map[string]interface{}{"settings": settings})
+}
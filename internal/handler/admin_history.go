@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetHistory handles GET /admin/history. It lists changes across all incidents, newest first,
+// filtered by actor, field and a [from, to) RFC 3339 time range, and paginated by cursor.
+func (h *IncidentHandler) GetHistory(c echo.Context) error {
+	page, err := parsePageParams(c, h.listLimits)
+	if err != nil {
+		return err
+	}
+	if page.Offset != 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid offset: history is paginated by cursor")
+	}
+
+	filter, err := parseHistoryFilter(c)
+	if err != nil {
+		return err
+	}
+
+	history, err := h.incidentUseCase.GetHistory(c.Request().Context(), *filter, page.Cursor, page.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve history: "+err.Error())
+	}
+
+	var nextCursor string
+	if history.NextCursor != nil {
+		nextCursor = encodeCursor(history.NextCursor)
+	}
+
+	meta := map[string]interface{}{
+		"count":       len(history.Entries),
+		"limit":       page.Limit,
+		"next_cursor": nextCursor,
+	}
+	return h.respond(c, http.StatusOK, history.Entries, meta, map[string]interface{}{
+		"entries":     history.Entries,
+		"count":       len(history.Entries),
+		"limit":       page.Limit,
+		"next_cursor": nextCursor,
+	})
+}
+
+// parseHistoryFilter parses the actor, field, from and to query parameters of a history listing
+func parseHistoryFilter(c echo.Context) (*domain.HistoryFilter, error) {
+	filter := &domain.HistoryFilter{}
+
+	var err error
+	if filter.Actor, err = parseChoiceParam(c, "actor", domain.HistoryActors); err != nil {
+		return nil, err
+	}
+	if filter.Field, err = parseChoiceParam(c, "field", domain.HistoryFields); err != nil {
+		return nil, err
+	}
+	if filter.From, err = parseTimeParam(c, "from"); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseTimeParam(c, "to"); err != nil {
+		return nil, err
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid time range: from must be before to")
+	}
+
+	return filter, nil
+}
+
+// parseChoiceParam parses a single-valued query parameter restricted to allowed, returning the
+// canonical value or "" when the parameter is absent
+func parseChoiceParam(c echo.Context, name string, allowed []string) (string, error) {
+	raw := strings.TrimSpace(c.QueryParam(name))
+	if raw == "" {
+		return "", nil
+	}
+
+	value, ok := domain.CanonicalValue(allowed, raw)
+	if !ok {
+		return "", echo.NewHTTPError(http.StatusBadRequest,
+			fmt.Sprintf("Invalid %s %q: must be one of %s", name, raw, strings.Join(allowed, ", ")))
+	}
+	return value, nil
+}
+
+// parseTimeParam parses an RFC 3339 query parameter, returning the zero time when it is absent
+func parseTimeParam(c echo.Context, name string) (time.Time, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid %s: must be an RFC 3339 timestamp", name))
+	}
+	return t, nil
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetHistory(t *testing.T) {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	cursor := &domain.Cursor{ID: 40, CreatedAt: from}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockIncidentUseCase)
+		expectedStatus int
+	}{
+		{
+			name:  "unfiltered",
+			query: "",
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("GetHistory", mock.Anything, domain.HistoryFilter{}, (*domain.Cursor)(nil), 50).
+					Return(&domain.HistoryPage{Entries: []*domain.HistoryEntry{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "actor filter is canonicalized",
+			query: "?actor=Admin",
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("GetHistory", mock.Anything, domain.HistoryFilter{Actor: domain.ActorAdmin}, (*domain.Cursor)(nil), 50).
+					Return(&domain.HistoryPage{Entries: []*domain.HistoryEntry{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "field filter with time range and cursor",
+			query: "?field=ai_severity&from=2024-06-01T00:00:00Z&to=2024-07-01T00:00:00Z&limit=10&cursor=" + encodeCursor(cursor),
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("GetHistory", mock.Anything, domain.HistoryFilter{Field: domain.FieldAISeverity, From: from, To: to}, cursor, 10).
+					Return(&domain.HistoryPage{Entries: []*domain.HistoryEntry{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown actor",
+			query:          "?actor=robot",
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown field",
+			query:          "?field=description",
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "malformed time",
+			query:          "?from=yesterday",
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "inverted range",
+			query:          "?from=2024-07-01T00:00:00Z&to=2024-06-01T00:00:00Z",
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "offset is not supported",
+			query:          "?offset=10",
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
+			tt.setupMock(mockUC)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/history"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			err := handler.GetHistory(e.NewContext(req, rec))
+			if err != nil {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+			} else {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetHistory_NextCursor(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	next := &domain.Cursor{ID: 12, CreatedAt: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+	entries := []*domain.HistoryEntry{
+		{ID: 12, IncidentID: 3, IncidentTitle: "Checkout errors", Field: domain.FieldAISeverity, OldValue: "Low", NewValue: "High", Actor: domain.ActorAdmin},
+	}
+	mockUC.On("GetHistory", mock.Anything, domain.HistoryFilter{}, (*domain.Cursor)(nil), 1).
+		Return(&domain.HistoryPage{Entries: entries, NextCursor: next}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/history?limit=1", nil)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.GetHistory(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Entries    []*domain.HistoryEntry `json:"entries"`
+		NextCursor string                 `json:"next_cursor"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Len(t, body.Entries, 1)
+	assert.Equal(t, "Checkout errors", body.Entries[0].IncidentTitle)
+	assert.Equal(t, encodeCursor(next), body.NextCursor)
+	mockUC.AssertExpectations(t)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	tests := []struct {
+		name           string
+		configured     string
+		provided       string
+		expectedStatus int
+	}{
+		{name: "valid token", configured: "secret", provided: "secret", expectedStatus: http.StatusOK},
+		{name: "missing token", configured: "secret", provided: "", expectedStatus: http.StatusUnauthorized},
+		{name: "wrong token", configured: "secret", provided: "guess", expectedStatus: http.StatusForbidden},
+		{name: "admin not configured", configured: "", provided: "anything", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if tt.provided != "" {
+				req.Header.Set(AdminTokenHeader, tt.provided)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			next := func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}
+
+			err := RequireAdmin(tt.configured)(next)(c)
+
+			if err != nil {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+			} else {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxBatchSize caps the number of incidents in one batch create
+const maxBatchSize = 100
+
+// batchCreateRequest is the body of POST /incidents/batch
+type batchCreateRequest struct {
+	Incidents []*domain.CreateIncidentRequest `json:"incidents"`
+}
+
+// CreateIncidentsBatch handles POST /incidents/batch. Every item gets a result: created,
+// created with a failed analysis (saved with defaults for reprocessing), or rejected with the
+// reason. Invalid items are rejected without affecting the others.
+func (h *IncidentHandler) CreateIncidentsBatch(c echo.Context) error {
+	var body batchCreateRequest
+	if err := c.Bind(&body); err != nil {
+		return bindFailed(err)
+	}
+	if len(body.Incidents) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "incidents must not be empty")
+	}
+	if len(body.Incidents) > maxBatchSize {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("A batch may contain at most %d incidents", maxBatchSize))
+	}
+
+	results := make([]*domain.BatchItemResult, len(body.Incidents))
+	var valid []*domain.CreateIncidentRequest
+	var positions []int
+	for i, req := range body.Incidents {
+		if req == nil {
+			results[i] = &domain.BatchItemResult{Index: i, Outcome: domain.BatchRejected, Error: "incident must be an object"}
+			continue
+		}
+		if err := h.validate(req); err != nil {
+			result := &domain.BatchItemResult{Index: i, Outcome: domain.BatchRejected, Error: "Validation failed"}
+			var validationErr *domain.ValidationError
+			if errors.As(err, &validationErr) {
+				result.Fields = validationErr.Fields
+			}
+			results[i] = result
+			continue
+		}
+		req.AllowDuplicate = c.QueryParam("allow_duplicate") == "true"
+		valid = append(valid, req)
+		positions = append(positions, i)
+	}
+
+	if len(valid) > 0 {
+		for _, result := range h.incidentUseCase.CreateIncidentsBatch(c.Request().Context(), valid) {
+			result.Index = positions[result.Index]
+			results[result.Index] = result
+		}
+	}
+
+	counts := map[string]interface{}{
+		domain.BatchCreated:        0,
+		domain.BatchAnalysisFailed: 0,
+		domain.BatchRejected:       0,
+	}
+	for _, result := range results {
+		counts[result.Outcome] = counts[result.Outcome].(int) + 1
+	}
+
+	legacy := map[string]interface{}{"results": results}
+	for outcome, count := range counts {
+		legacy[outcome] = count
+	}
+	return h.respond(c, http.StatusOK, results, counts, legacy)
+}
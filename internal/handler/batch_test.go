@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateIncidentsBatch(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	// Items 0 and 2 are valid and reach the use case as its items 0 and 1
+	mockUC.On("CreateIncidentsBatch", mock.Anything, mock.MatchedBy(func(reqs []*domain.CreateIncidentRequest) bool {
+		return len(reqs) == 2 && reqs[0].Title == "Outage" && reqs[1].Title == "Latency"
+	})).Return([]*domain.BatchItemResult{
+		{Index: 0, Outcome: domain.BatchCreated, Incident: &domain.Incident{ID: 1, Title: "Outage", AnalysisStatus: domain.AnalysisComplete}},
+		{Index: 1, Outcome: domain.BatchAnalysisFailed, Incident: &domain.Incident{ID: 2, Title: "Latency", AnalysisStatus: domain.AnalysisFailed}, Error: "AI unavailable"},
+	})
+
+	body := `{"incidents": [
+		{"title": "Outage", "description": "API down", "affected_service": "api"},
+		{"title": "", "description": "No title", "affected_service": "api"},
+		{"title": "Latency", "description": "p99 at 4s", "affected_service": "api"}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/incidents/batch", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.CreateIncidentsBatch(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Results        []domain.BatchItemResult `json:"results"`
+		Created        int                      `json:"created"`
+		AnalysisFailed int                      `json:"analysis_failed"`
+		Rejected       int                      `json:"rejected"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Created)
+	assert.Equal(t, 1, response.AnalysisFailed)
+	assert.Equal(t, 1, response.Rejected)
+
+	assert.Len(t, response.Results, 3)
+	assert.Equal(t, domain.BatchCreated, response.Results[0].Outcome)
+	assert.Equal(t, 1, response.Results[1].Index)
+	assert.Equal(t, domain.BatchRejected, response.Results[1].Outcome)
+	assert.Equal(t, "title", response.Results[1].Fields[0].Field)
+	assert.Equal(t, 2, response.Results[2].Index)
+	assert.Equal(t, domain.BatchAnalysisFailed, response.Results[2].Outcome)
+	assert.Equal(t, domain.AnalysisFailed, response.Results[2].Incident.AnalysisStatus)
+}
+
+func TestCreateIncidentsBatch_RejectsBadBatches(t *testing.T) {
+	tooMany := make([]string, maxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = `{"title": "t", "description": "d", "affected_service": "s"}`
+	}
+
+	for name, body := range map[string]string{
+		"empty":    `{"incidents": []}`,
+		"too many": `{"incidents": [` + strings.Join(tooMany, ",") + `]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
+
+			req := httptest.NewRequest(http.MethodPost, "/incidents/batch", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			err := handler.CreateIncidentsBatch(e.NewContext(req, httptest.NewRecorder()))
+
+			httpErr, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			mockUC.AssertNotCalled(t, "CreateIncidentsBatch", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// bindFailed converts a request body bind error into a 400 response saying what is wrong: the
+// offending field and the JSON type it expects for a type mismatch, or where the JSON is malformed
+func bindFailed(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		expected := jsonType(typeErr.Type)
+		if typeErr.Field == "" {
+			return echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("Invalid request body: expected %s, got %s", expected, typeErr.Value))
+		}
+
+		return echo.NewHTTPError(http.StatusBadRequest, map[string]interface{}{
+			"message": "Invalid request body",
+			"fields": []domain.FieldError{{
+				Field:   typeErr.Field,
+				Rule:    domain.RuleType,
+				Message: fmt.Sprintf("%s must be %s, got %s", typeErr.Field, expected, typeErr.Value),
+			}},
+		})
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return echo.NewHTTPError(http.StatusBadRequest,
+			fmt.Sprintf("Invalid request body: malformed JSON at offset %d: %s", syntaxErr.Offset, syntaxErr.Error()))
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: JSON ends unexpectedly")
+	}
+
+	return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+}
+
+// jsonType names the JSON type that decodes into a Go type, with its article
+func jsonType(t reflect.Type) string {
+	if t == nil {
+		return "a JSON value"
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "an integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a non-negative integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	default:
+		return "a JSON value"
+	}
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateIncident_BindErrors(t *testing.T) {
+	post := func(body string) error {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/incidents", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		mockUC := new(MockIncidentUseCase)
+		err := NewIncidentHandler(mockUC).CreateIncident(e.NewContext(req, rec))
+		mockUC.AssertNotCalled(t, "CreateIncident", mock.Anything, mock.Anything)
+		return err
+	}
+
+	t.Run("number where a string is expected", func(t *testing.T) {
+		err := post(`{"title": 42, "description": "5xx from checkout", "affected_service": "checkout"}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			assert.Equal(t, map[string]interface{}{
+				"message": "Invalid request body",
+				"fields": []domain.FieldError{{
+					Field:   "title",
+					Rule:    domain.RuleType,
+					Message: "title must be a string, got number",
+				}},
+			}, httpErr.Message)
+		}
+	})
+
+	t.Run("string where an integer is expected", func(t *testing.T) {
+		err := post(`{"title": "Checkout errors", "affected_users": "many"}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		if assert.True(t, ok) {
+			fields := httpErr.Message.(map[string]interface{})["fields"].([]domain.FieldError)
+			assert.Equal(t, "affected_users must be an integer, got string", fields[0].Message)
+		}
+	})
+
+	t.Run("wrong top-level type", func(t *testing.T) {
+		err := post(`["Checkout errors"]`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			assert.Equal(t, "Invalid request body: expected an object, got array", httpErr.Message)
+		}
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		err := post(`{"title": "Checkout errors",}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			assert.Contains(t, httpErr.Message, "malformed JSON at offset")
+		}
+	})
+
+	t.Run("truncated JSON", func(t *testing.T) {
+		err := post(`{"title": "Checkout`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		if assert.True(t, ok) {
+			assert.Equal(t, "Invalid request body: JSON ends unexpectedly", httpErr.Message)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CompareIncidents handles GET /incidents/compare?a=<id>&b=<id>
+func (h *IncidentHandler) CompareIncidents(c echo.Context) error {
+	a, err := compareParam(c, "a")
+	if err != nil {
+		return err
+	}
+	b, err := compareParam(c, "b")
+	if err != nil {
+		return err
+	}
+	if a == b {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid comparison: a and b must be different incidents")
+	}
+
+	comparison, err := h.incidentUseCase.CompareIncidents(c.Request().Context(), a, b)
+	if err != nil {
+		if httpErr := h.missingIncident(c, err); httpErr != nil {
+			return httpErr
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to compare incidents: "+err.Error())
+	}
+
+	return h.respond(c, http.StatusOK, comparison, nil, comparison)
+}
+
+// compareParam parses the required incident ID query parameter name
+func compareParam(c echo.Context, name string) (int, error) {
+	id, err := strconv.Atoi(c.QueryParam(name))
+	if err != nil || id <= 0 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid %s: must be an incident ID", name))
+	}
+	return id, nil
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCompareIncidents(t *testing.T) {
+	comparison := &domain.IncidentComparison{
+		A:                &domain.Incident{ID: 1, AISeverity: "High"},
+		B:                &domain.Incident{ID: 2, AISeverity: "Critical"},
+		Differences:      []*domain.FieldDifference{{Field: "ai_severity", A: "High", B: "Critical"}},
+		Same:             []string{"title"},
+		Similarity:       0.5,
+		SimilarityMethod: domain.SimilarityKeywords,
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockIncidentUseCase)
+		expectedStatus int
+	}{
+		{
+			name:  "compares both incidents",
+			query: "?a=1&b=2",
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("CompareIncidents", mock.Anything, 1, 2).Return(comparison, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing b",
+			query:          "?a=1",
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "same incident twice",
+			query:          "?a=3&b=3",
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "missing incident",
+			query: "?a=1&b=9",
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("CompareIncidents", mock.Anything, 1, 9).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
+			tt.setupMock(mockUC)
+
+			req := httptest.NewRequest(http.MethodGet, "/incidents/compare"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			err := handler.CompareIncidents(e.NewContext(req, rec))
+			if err != nil {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+			} else {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCompareIncidents_Body(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	mockUC.On("CompareIncidents", mock.Anything, 1, 2).Return(&domain.IncidentComparison{
+		A:                &domain.Incident{ID: 1},
+		B:                &domain.Incident{ID: 2},
+		Differences:      []*domain.FieldDifference{{Field: "assignee", A: "alice", B: ""}},
+		Same:             []string{},
+		Similarity:       0.93,
+		SimilarityMethod: domain.SimilarityEmbedding,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/compare?a=1&b=2", nil)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.CompareIncidents(e.NewContext(req, rec)))
+
+	var body struct {
+		Differences []domain.FieldDifference `json:"differences"`
+		Similarity  float64                  `json:"similarity"`
+		Method      string                   `json:"similarity_method"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, []domain.FieldDifference{{Field: "assignee", A: "alice", B: ""}}, body.Differences)
+	assert.Equal(t, 0.93, body.Similarity)
+	assert.Equal(t, domain.SimilarityEmbedding, body.Method)
+}
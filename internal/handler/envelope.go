@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// envelope is the uniform success body used when the response envelope is enabled
+type envelope struct {
+	Data interface{}            `json:"data"`
+	Meta map[string]interface{} `json:"meta,omitempty"`
+}
+
+// respond writes a successful response. With the envelope enabled the body is {"data": ..., "meta": ...};
+// otherwise legacy is written unchanged so existing clients keep the shape they rely on.
+// With IDs as strings enabled, incident IDs in either shape are written as JSON strings.
+func (h *IncidentHandler) respond(c echo.Context, status int, data interface{}, meta map[string]interface{}, legacy interface{}) error {
+	body := legacy
+	if h.envelope {
+		body = envelope{Data: data, Meta: meta}
+	}
+
+	if h.idAsString {
+		var err error
+		body, err = stringifyIDs(body)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to encode response: "+err.Error())
+		}
+	}
+	return c.JSON(status, body)
+}
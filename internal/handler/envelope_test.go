@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResponseEnvelope(t *testing.T) {
+	incident := &domain.Incident{ID: 1, Title: "Test Incident"}
+
+	get := func(opts ...Option) map[string]interface{} {
+		e := echo.New()
+		mockUC := new(MockIncidentUseCase)
+		handler := NewIncidentHandler(mockUC, opts...)
+		mockUC.On("GetListVersion", mock.Anything, &domain.IncidentFilter{}).Return(&domain.ListVersion{Count: 1}, nil)
+		mockUC.On("GetAllIncidentsPaginated", mock.Anything, &domain.IncidentFilter{}, 50, 0).Return([]*domain.Incident{incident}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/incidents", nil)
+		rec := httptest.NewRecorder()
+		assert.NoError(t, handler.GetAllIncidents(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body
+	}
+
+	t.Run("legacy shape", func(t *testing.T) {
+		body := get()
+		assert.Len(t, body["incidents"], 1)
+		assert.Equal(t, float64(1), body["count"])
+		assert.NotContains(t, body, "data")
+	})
+
+	t.Run("envelope", func(t *testing.T) {
+		body := get(WithResponseEnvelope(true))
+		assert.Len(t, body["data"], 1)
+		assert.Equal(t, map[string]interface{}{"count": float64(1), "total": float64(1), "limit": float64(50), "offset": float64(0)}, body["meta"])
+		assert.NotContains(t, body, "incidents")
+	})
+}
+
+func TestResponseEnvelope_SingleIncident(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC, WithResponseEnvelope(true))
+	mockUC.On("GetIncident", mock.Anything, 1).Return(&domain.Incident{ID: 1, Title: "Test Incident"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	assert.NoError(t, handler.GetIncident(c))
+
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "Test Incident", body["data"].(map[string]interface{})["title"])
+	assert.NotContains(t, body, "meta")
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// HTTP caching headers not defined by echo
+const (
+	headerETag        = "ETag"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// listETag builds a weak ETag from a listing's version and the key of the filter that produced it
+func listETag(version *domain.ListVersion, key string) string {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return fmt.Sprintf(`W/"%d-%d-%x-%d-%x"`, version.Count, version.MaxUpdatedAt.UnixNano(), version.Checksum, version.AsOf.Unix(), h.Sum64())
+}
+
+// etagMatches reports whether an If-None-Match header value matches the ETag
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+// exportManifestEntry describes one incident file in a ZIP export
+type exportManifestEntry struct {
+	ID         int       `json:"id"`
+	Reference  string    `json:"reference,omitempty"`
+	Title      string    `json:"title"`
+	AISeverity string    `json:"ai_severity"`
+	AICategory string    `json:"ai_category"`
+	Priority   string    `json:"priority"`
+	CreatedAt  time.Time `json:"created_at"`
+	File       string    `json:"file"`
+}
+
+// ExportIncidentsZip handles GET /incidents/export.zip. It accepts the filters of the list
+// endpoint and writes one file per incident, including its change history, and a manifest.
+func (h *IncidentHandler) ExportIncidentsZip(c echo.Context) error {
+	filter, err := parseIncidentFilter(c, h.customFields)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "application/zip")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="incidents-%s.zip"`, time.Now().UTC().Format("20060102-150405")))
+	res.WriteHeader(http.StatusOK)
+
+	archive := zip.NewWriter(res)
+	manifest := []exportManifestEntry{}
+
+	err = h.incidentUseCase.ExportIncidentArchive(ctx, filter, func(exported *domain.ExportedIncident) error {
+		incident := exported.Incident
+		name := fmt.Sprintf("incident-%d.json", incident.ID)
+		if err := writeZipJSON(archive, name, exported); err != nil {
+			return err
+		}
+		res.Flush()
+
+		manifest = append(manifest, exportManifestEntry{
+			ID:         incident.ID,
+			Reference:  incident.Reference,
+			Title:      incident.Title,
+			AISeverity: incident.AISeverity,
+			AICategory: incident.AICategory,
+			Priority:   incident.Priority,
+			CreatedAt:  incident.CreatedAt,
+			File:       name,
+		})
+		return nil
+	})
+	if err != nil {
+		// Headers are already sent, so the truncated archive is the only signal left to the client
+		logging.FromContext(ctx).Error("Failed to export incidents", "error", err)
+		return nil
+	}
+
+	if err := writeZipJSON(archive, "manifest.json", map[string]interface{}{
+		"exported_at": time.Now().UTC(),
+		"count":       len(manifest),
+		"incidents":   manifest,
+	}); err != nil {
+		logging.FromContext(ctx).Error("Failed to write export manifest", "error", err)
+		return nil
+	}
+
+	if err := archive.Close(); err != nil {
+		logging.FromContext(ctx).Error("Failed to finalize export archive", "error", err)
+	}
+	return nil
+}
+
+// csvExportHeader is the header row of the detail section of a CSV export
+var csvExportHeader = []string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at"}
+
+// exportPageLimits bounds the number of rows in one page of a paged export
+var exportPageLimits = pageLimits{Default: 1000, Max: 10000}
+
+// headerNextPageToken carries the continuation token of a paged export; it is absent on the last page
+const headerNextPageToken = "X-Next-Page-Token"
+
+// ExportIncidentsCSV handles GET /incidents/export.csv. It accepts the filters of the list
+// endpoint. With ?summary=true the detail rows are preceded by a field,value,count section and
+// a blank line.
+//
+// Passing page_token (empty for the first page) switches to paged mode: at most limit rows
+// are returned and X-Next-Page-Token holds the token of the next page. The summary is only
+// written on the first page.
+func (h *IncidentHandler) ExportIncidentsCSV(c echo.Context) error {
+	filter, err := parseIncidentFilter(c, h.customFields)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	var page *domain.ExportPage
+	firstPage := true
+	if c.QueryParams().Has("page_token") {
+		afterID, err := decodePageToken(c.QueryParam("page_token"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid page token")
+		}
+		params, err := parsePageParams(c, exportPageLimits)
+		if err != nil {
+			return err
+		}
+
+		page, err = h.incidentUseCase.ExportIncidentsPage(ctx, filter, afterID, params.Limit)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to export incidents: "+err.Error())
+		}
+		firstPage = afterID == 0
+	}
+
+	var distribution []*domain.DistributionCount
+	if c.QueryParam("summary") == "true" && firstPage {
+		var err error
+		distribution, err = h.incidentUseCase.GetDistribution(ctx, filter)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to summarize incidents: "+err.Error())
+		}
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="incidents-%s.csv"`, time.Now().UTC().Format("20060102-150405")))
+	if page != nil && page.NextAfterID != 0 {
+		res.Header().Set(headerNextPageToken, encodePageToken(page.NextAfterID))
+	}
+	res.WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(res)
+	if distribution != nil {
+		w.Write([]string{"field", "value", "count"})
+		for _, d := range distribution {
+			w.Write([]string{d.Field, d.Value, strconv.Itoa(d.Count)})
+		}
+		// A lone empty field encodes as a blank line separating the sections
+		w.Write([]string{""})
+	}
+
+	w.Write(csvExportHeader)
+	if page != nil {
+		for _, incident := range page.Incidents {
+			w.Write(csvExportRow(incident))
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			logging.FromContext(ctx).Error("Failed to write CSV export page", "error", err)
+		}
+		return nil
+	}
+
+	err = h.incidentUseCase.ExportIncidents(ctx, filter, func(incident *domain.Incident) error {
+		w.Write(csvExportRow(incident))
+		w.Flush()
+		res.Flush()
+		return w.Error()
+	})
+	if err != nil {
+		// Headers are already sent, so the truncated file is the only signal left to the client
+		logging.FromContext(ctx).Error("Failed to export incidents", "error", err)
+		return nil
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		logging.FromContext(ctx).Error("Failed to finalize CSV export", "error", err)
+	}
+	return nil
+}
+
+// csvExportRow formats an incident as a detail row of a CSV export
+func csvExportRow(incident *domain.Incident) []string {
+	return []string{
+		strconv.Itoa(incident.ID),
+		incident.Title,
+		incident.Description,
+		incident.AffectedService,
+		incident.AISeverity,
+		incident.AICategory,
+		incident.CreatedAt.UTC().Format(time.RFC3339),
+		incident.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// xlsxExportHeader is the header row of the incidents sheet of an XLSX export
+var xlsxExportHeader = []string{"ID", "Reference", "Title", "Description", "Affected Service", "Severity", "Category", "Priority", "Assignee", "Created At", "Updated At"}
+
+// xlsxTimeLayout formats timestamps in XLSX exports
+const xlsxTimeLayout = "2006-01-02 15:04:05"
+
+// ExportIncidentsXLSX handles GET /incidents/export.xlsx. It accepts the filters of the list
+// endpoint and returns an incidents sheet and a summary sheet of severity and category counts.
+// The workbook is buffered rather than streamed: column widths are sized to the longest value
+// and precede the rows in the file, and a failure can still be reported as an error.
+func (h *IncidentHandler) ExportIncidentsXLSX(c echo.Context) error {
+	filter, err := parseIncidentFilter(c, h.customFields)
+	if err != nil {
+		return err
+	}
+
+	incidents, err := h.incidentUseCase.GetAllIncidents(c.Request().Context(), filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to export incidents: "+err.Error())
+	}
+
+	details := &xlsxSheet{name: "Incidents", header: xlsxExportHeader}
+	severities := map[string]int{}
+	categories := map[string]int{}
+	for _, incident := range incidents {
+		details.rows = append(details.rows, []xlsxCell{
+			xlsxNumber(incident.ID),
+			xlsxText(incident.Reference),
+			xlsxText(incident.Title),
+			xlsxText(incident.Description),
+			xlsxText(incident.AffectedService),
+			xlsxText(incident.AISeverity),
+			xlsxText(incident.AICategory),
+			xlsxText(incident.Priority),
+			xlsxText(incident.Assignee),
+			xlsxText(incident.CreatedAt.UTC().Format(xlsxTimeLayout)),
+			xlsxText(incident.UpdatedAt.UTC().Format(xlsxTimeLayout)),
+		})
+		severities[incident.AISeverity]++
+		categories[incident.AICategory]++
+	}
+
+	summary := &xlsxSheet{name: "Summary", header: []string{"Field", "Value", "Count"}}
+	summary.rows = append(summary.rows, summaryRows("ai_severity", domain.Severities, severities)...)
+	summary.rows = append(summary.rows, summaryRows("ai_category", domain.Categories, categories)...)
+
+	var buf bytes.Buffer
+	if err := writeXLSX(&buf, []*xlsxSheet{details, summary}); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to export incidents: "+err.Error())
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="incidents-%s.xlsx"`, time.Now().UTC().Format("20060102-150405")))
+	return c.Blob(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buf.Bytes())
+}
+
+// summaryRows returns the field,value,count rows of the values counted, in taxonomy order
+// followed by any values outside the taxonomy in alphabetical order
+func summaryRows(field string, taxonomy []string, counts map[string]int) [][]xlsxCell {
+	values := []string{}
+	known := map[string]bool{}
+	for _, value := range taxonomy {
+		known[value] = true
+		if counts[value] > 0 {
+			values = append(values, value)
+		}
+	}
+	var others []string
+	for value := range counts {
+		if !known[value] {
+			others = append(others, value)
+		}
+	}
+	sort.Strings(others)
+	values = append(values, others...)
+
+	rows := make([][]xlsxCell, len(values))
+	for i, value := range values {
+		rows[i] = []xlsxCell{xlsxText(field), xlsxText(value), xlsxNumber(counts[value])}
+	}
+	return rows
+}
+
+// writeZipJSON adds a JSON-encoded entry to a ZIP archive
+func writeZipJSON(archive *zip.Writer, name string, value interface{}) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", name, err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,343 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportIncidentsZip(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	changedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	exported := []*domain.ExportedIncident{
+		{Incident: &domain.Incident{ID: 1, Reference: "INC-1", Title: "Test Incident 1", AISeverity: "High", AICategory: "Network", Priority: "P2"}, History: []*domain.HistoryEntry{}},
+		{Incident: &domain.Incident{ID: 2, Reference: "INC-2", Title: "Test Incident 2", AISeverity: "High", AICategory: "Software", Priority: "P2"}, History: []*domain.HistoryEntry{
+			{ID: 7, IncidentID: 2, Field: domain.FieldAISeverity, OldValue: "Low", NewValue: "High", Actor: domain.ActorAI, CreatedAt: changedAt},
+		}},
+	}
+	mockUC.On("ExportIncidentArchive", mock.Anything, mock.MatchedBy(func(filter *domain.IncidentFilter) bool {
+		return len(filter.Severities) == 1 && filter.Severities[0] == "High"
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*domain.ExportedIncident) error)
+		for _, incident := range exported {
+			assert.NoError(t, fn(incident))
+		}
+	}).Return(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/export.zip?severity=High", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Test
+	err := handler.ExportIncidentsZip(c)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/zip", rec.Header().Get(echo.HeaderContentType))
+
+	body := rec.Body.Bytes()
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.NoError(t, err)
+
+	files := map[string][]byte{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		files[f.Name] = content
+	}
+
+	assert.Len(t, files, 3)
+
+	var incident struct {
+		domain.Incident
+		History []*domain.HistoryEntry `json:"history"`
+	}
+	assert.NoError(t, json.Unmarshal(files["incident-2.json"], &incident))
+	assert.Equal(t, "Test Incident 2", incident.Title)
+	assert.Equal(t, "INC-2", incident.Reference)
+	if assert.Len(t, incident.History, 1) {
+		assert.Equal(t, "Low", incident.History[0].OldValue)
+	}
+
+	var manifest struct {
+		Count     int                   `json:"count"`
+		Incidents []exportManifestEntry `json:"incidents"`
+	}
+	assert.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+	assert.Equal(t, 2, manifest.Count)
+	assert.Equal(t, "incident-1.json", manifest.Incidents[0].File)
+	assert.Equal(t, "INC-1", manifest.Incidents[0].Reference)
+	assert.Equal(t, "P2", manifest.Incidents[0].Priority)
+
+	mockUC.AssertExpectations(t)
+}
+
+func TestExportIncidentsZip_InvalidFilter(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/export.zip?severity=Urgent", nil)
+	rec := httptest.NewRecorder()
+	err := handler.ExportIncidentsZip(e.NewContext(req, rec))
+
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentType))
+	mockUC.AssertNotCalled(t, "ExportIncidentArchive", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportIncidentsCSV(t *testing.T) {
+	createdAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	incidents := []*domain.Incident{
+		{ID: 1, Title: "Login, slow", Description: "Auth latency", AffectedService: "auth", AISeverity: "High", AICategory: "Network", CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+	detail := "id,title,description,affected_service,ai_severity,ai_category,created_at,updated_at\n" +
+		"1,\"Login, slow\",Auth latency,auth,High,Network,2024-06-01T12:00:00Z,2024-06-01T12:00:00Z\n"
+
+	tests := []struct {
+		name      string
+		query     string
+		setupMock func(*MockIncidentUseCase)
+		expected  string
+	}{
+		{
+			name:      "plain export",
+			query:     "",
+			setupMock: func(m *MockIncidentUseCase) {},
+			expected:  detail,
+		},
+		{
+			name:  "summary precedes the detail rows",
+			query: "?summary=true&category=Network",
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("GetDistribution", mock.Anything, mock.MatchedBy(func(filter *domain.IncidentFilter) bool {
+					return len(filter.Categories) == 1 && filter.Categories[0] == "Network"
+				})).Return([]*domain.DistributionCount{
+					{Field: domain.FieldAISeverity, Value: "High", Count: 1},
+					{Field: domain.FieldAICategory, Value: "Network", Count: 1},
+				}, nil)
+			},
+			expected: "field,value,count\nai_severity,High,1\nai_category,Network,1\n\n" + detail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
+			tt.setupMock(mockUC)
+			mockUC.On("ExportIncidents", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				fn := args.Get(2).(func(*domain.Incident) error)
+				for _, incident := range incidents {
+					assert.NoError(t, fn(incident))
+				}
+			}).Return(nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/incidents/export.csv"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			assert.NoError(t, handler.ExportIncidentsCSV(e.NewContext(req, rec)))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "text/csv", rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, tt.expected, rec.Body.String())
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExportIncidentsCSV_Paged(t *testing.T) {
+	createdAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("first page returns a continuation token", func(t *testing.T) {
+		e := echo.New()
+		mockUC := new(MockIncidentUseCase)
+		handler := NewIncidentHandler(mockUC)
+
+		mockUC.On("ExportIncidentsPage", mock.Anything, mock.MatchedBy(func(filter *domain.IncidentFilter) bool {
+			return len(filter.Severities) == 1 && filter.Severities[0] == "Critical"
+		}), 0, 2).Return(&domain.ExportPage{
+			Incidents: []*domain.Incident{
+				{ID: 1, Title: "Outage", CreatedAt: createdAt, UpdatedAt: createdAt},
+				{ID: 2, Title: "Latency", CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+			NextAfterID: 2,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/incidents/export.csv?page_token=&limit=2&severity=Critical", nil)
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, handler.ExportIncidentsCSV(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, encodePageToken(2), rec.Header().Get(headerNextPageToken))
+		assert.Contains(t, rec.Body.String(), "2,Latency")
+		mockUC.AssertNotCalled(t, "ExportIncidents", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("last page has no token", func(t *testing.T) {
+		e := echo.New()
+		mockUC := new(MockIncidentUseCase)
+		handler := NewIncidentHandler(mockUC)
+
+		mockUC.On("ExportIncidentsPage", mock.Anything, mock.Anything, 2, exportPageLimits.Default).Return(&domain.ExportPage{
+			Incidents: []*domain.Incident{{ID: 3, Title: "Disk full", CreatedAt: createdAt, UpdatedAt: createdAt}},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/incidents/export.csv?summary=true&page_token="+encodePageToken(2), nil)
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, handler.ExportIncidentsCSV(e.NewContext(req, rec)))
+		assert.Empty(t, rec.Header().Get(headerNextPageToken))
+		assert.Contains(t, rec.Body.String(), "3,Disk full")
+		mockUC.AssertNotCalled(t, "GetDistribution", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		e := echo.New()
+		handler := NewIncidentHandler(new(MockIncidentUseCase))
+
+		req := httptest.NewRequest(http.MethodGet, "/incidents/export.csv?page_token=not-a-token", nil)
+		err := handler.ExportIncidentsCSV(e.NewContext(req, httptest.NewRecorder()))
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	})
+}
+
+// xlsxRow is a parsed worksheet row; inline strings and numbers both end up in Text
+type xlsxRow struct {
+	Cells []struct {
+		Ref  string `xml:"r,attr"`
+		Text string `xml:"is>t"`
+		Num  string `xml:"v"`
+	} `xml:"c"`
+}
+
+// readXLSX returns the sheet names of a workbook and the cell values of each sheet's rows
+func readXLSX(t *testing.T, body []byte) ([]string, map[string][][]string) {
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.NoError(t, err)
+
+	files := map[string][]byte{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		assert.NoError(t, err)
+		files[f.Name], err = io.ReadAll(r)
+		assert.NoError(t, err)
+	}
+
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	assert.NoError(t, xml.Unmarshal(files["xl/workbook.xml"], &workbook))
+
+	names := []string{}
+	sheets := map[string][][]string{}
+	for i, sheet := range workbook.Sheets {
+		names = append(names, sheet.Name)
+
+		var worksheet struct {
+			Rows []xlsxRow `xml:"sheetData>row"`
+		}
+		assert.NoError(t, xml.Unmarshal(files[fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)], &worksheet))
+		for _, row := range worksheet.Rows {
+			values := []string{}
+			for _, cell := range row.Cells {
+				values = append(values, cell.Text+cell.Num)
+			}
+			sheets[sheet.Name] = append(sheets[sheet.Name], values)
+		}
+	}
+	return names, sheets
+}
+
+func TestExportIncidentsXLSX(t *testing.T) {
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	incidents := []*domain.Incident{
+		{ID: 1, Reference: "INC-2024-000001", Title: "DB down", Description: "Primary <unreachable> & lagging", AffectedService: "orders",
+			AISeverity: "High", AICategory: "Database", Priority: "P2", CreatedAt: created, UpdatedAt: created},
+		{ID: 2, Title: "Disk full", AffectedService: "storage", AISeverity: "High", AICategory: "Hardware", CreatedAt: created, UpdatedAt: created},
+		{ID: 3, Title: "Slow page", AffectedService: "web", AISeverity: "Low", AICategory: "Database", CreatedAt: created, UpdatedAt: created},
+	}
+
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+	mockUC.On("GetAllIncidents", mock.Anything, &domain.IncidentFilter{Severities: []string{"High", "Low"}}).Return(incidents, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/export.xlsx?severity=high,low", nil)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.ExportIncidentsXLSX(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), ".xlsx")
+
+	names, sheets := readXLSX(t, rec.Body.Bytes())
+	assert.Equal(t, []string{"Incidents", "Summary"}, names)
+
+	rows := sheets["Incidents"]
+	if assert.Len(t, rows, 4) {
+		assert.Equal(t, xlsxExportHeader, rows[0])
+		assert.Equal(t, []string{"1", "INC-2024-000001", "DB down", "Primary <unreachable> & lagging", "orders",
+			"High", "Database", "P2", "", "2024-06-01 12:00:00", "2024-06-01 12:00:00"}, rows[1])
+		assert.Equal(t, "3", rows[3][0])
+	}
+
+	assert.Equal(t, [][]string{
+		{"Field", "Value", "Count"},
+		{"ai_severity", "Low", "1"},
+		{"ai_severity", "High", "2"},
+		{"ai_category", "Hardware", "1"},
+		{"ai_category", "Database", "2"},
+	}, sheets["Summary"])
+	mockUC.AssertExpectations(t)
+}
+
+func TestExportIncidentsXLSX_InvalidFilter(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/export.xlsx?severity=urgent", nil)
+	rec := httptest.NewRecorder()
+
+	err := handler.ExportIncidentsXLSX(e.NewContext(req, rec))
+
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	mockUC.AssertNotCalled(t, "GetAllIncidents", mock.Anything, mock.Anything)
+}
+
+func TestXLSXColumn(t *testing.T) {
+	assert.Equal(t, "A", xlsxColumn(0))
+	assert.Equal(t, "Z", xlsxColumn(25))
+	assert.Equal(t, "AA", xlsxColumn(26))
+	assert.Equal(t, "BA", xlsxColumn(52))
+}
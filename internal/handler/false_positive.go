@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MarkFalsePositive handles POST /incidents/:id/false-positive
+func (h *IncidentHandler) MarkFalsePositive(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid incident ID")
+	}
+
+	var req domain.FalsePositiveRequest
+	if err := c.Bind(&req); err != nil {
+		return bindFailed(err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return validationFailed(err)
+	}
+
+	incident, err := h.incidentUseCase.MarkFalsePositive(c.Request().Context(), id, req.Reason)
+	if err != nil {
+		if httpErr := h.missingIncident(c, err); httpErr != nil {
+			return httpErr
+		}
+		if errors.Is(err, domain.ErrFalsePositive) {
+			return echo.NewHTTPError(http.StatusConflict, "Incident is already marked as a false positive")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to mark incident as false positive: "+err.Error())
+	}
+
+	message := "Incident marked as false positive"
+	return h.respond(c, http.StatusOK, incident, map[string]interface{}{"message": message}, map[string]interface{}{
+		"message":  message,
+		"incident": incident,
+	})
+}
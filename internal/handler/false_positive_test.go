@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMarkFalsePositive(t *testing.T) {
+	post := func(handler *IncidentHandler, body string) (*httptest.ResponseRecorder, error) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/incidents/7/false-positive", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("7")
+		return rec, handler.MarkFalsePositive(c)
+	}
+
+	t.Run("marks the incident", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("MarkFalsePositive", mock.Anything, 7, "Synthetic probe").
+			Return(&domain.Incident{ID: 7, FalsePositiveReason: "Synthetic probe"}, nil)
+
+		rec, err := post(NewIncidentHandler(mockUC), `{"reason": "  Synthetic probe "}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"false_positive_reason":"Synthetic probe"`)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("reason is required", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+
+		_, err := post(NewIncidentHandler(mockUC), `{"reason": " "}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+		mockUC.AssertNotCalled(t, "MarkFalsePositive", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("already marked", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("MarkFalsePositive", mock.Anything, 7, "Synthetic probe").Return(nil, domain.ErrFalsePositive)
+
+		_, err := post(NewIncidentHandler(mockUC), `{"reason": "Synthetic probe"}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, httpErr.Code)
+	})
+
+	t.Run("missing incident", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("MarkFalsePositive", mock.Anything, 7, "Synthetic probe").Return(nil, domain.ErrNotFound)
+
+		_, err := post(NewIncidentHandler(mockUC), `{"reason": "Synthetic probe"}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+	})
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// customFieldParamPrefix prefixes query parameters that filter on a custom field, e.g. cf.region=eu
+const customFieldParamPrefix = "cf."
+
+// parseIncidentFilter parses the severity, category, priority, cf.<key>, min_affected_users, q and
+// include_false_positive query parameters.
+// Severity, category and priority accept a single value or a comma-separated list validated against the taxonomy;
+// custom field keys must be allowed by the schema.
+func parseIncidentFilter(c echo.Context, schema domain.CustomFieldSchema) (*domain.IncidentFilter, error) {
+	severities, err := parseListParam(c, "severity", domain.Severities)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := parseListParam(c, "category", domain.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	priorities, err := parseListParam(c, "priority", domain.Priorities)
+	if err != nil {
+		return nil, err
+	}
+
+	customFields, err := parseCustomFieldParams(c, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	minAffectedUsers, err := parseMinAffectedUsers(c)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := parseQueryParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.IncidentFilter{
+		Severities:           severities,
+		Categories:           categories,
+		Priorities:           priorities,
+		CustomFields:         customFields,
+		MinAffectedUsers:     minAffectedUsers,
+		IncludeFalsePositive: c.QueryParam("include_false_positive") == "true",
+		Query:                query,
+	}, nil
+}
+
+// parseQueryParam parses the q query parameter, a query expression such as
+// severity:Critical AND (category:Database OR service:auth)
+func parseQueryParam(c echo.Context) (domain.QueryNode, error) {
+	if !c.QueryParams().Has("q") {
+		return nil, nil
+	}
+
+	raw := c.QueryParam("q")
+	if strings.TrimSpace(raw) == "" {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid q: expression must not be empty")
+	}
+
+	query, err := domain.ParseQuery(raw)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid q: "+err.Error())
+	}
+	return query, nil
+}
+
+// parseCustomFieldParams collects the cf.<key>=<value> query parameters
+func parseCustomFieldParams(c echo.Context, schema domain.CustomFieldSchema) (map[string]string, error) {
+	var fields map[string]string
+	for name, values := range c.QueryParams() {
+		if !strings.HasPrefix(name, customFieldParamPrefix) {
+			continue
+		}
+
+		key := strings.TrimPrefix(name, customFieldParamPrefix)
+		if !schema.Allows(key) {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid custom field filter %q", key))
+		}
+		if len(values) != 1 || values[0] == "" {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid %s: exactly one value is required", name))
+		}
+
+		if fields == nil {
+			fields = map[string]string{}
+		}
+		fields[key] = values[0]
+	}
+	return fields, nil
+}
+
+// parseMinAffectedUsers parses the min_affected_users query parameter, a non-negative integer
+func parseMinAffectedUsers(c echo.Context) (*int, error) {
+	if !c.QueryParams().Has("min_affected_users") {
+		return nil, nil
+	}
+
+	value, err := strconv.Atoi(c.QueryParam("min_affected_users"))
+	if err != nil || value < 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid min_affected_users: must be a non-negative integer")
+	}
+	return &value, nil
+}
+
+// parseListParam parses a comma-separated query parameter, returning canonical values.
+// An absent parameter yields nil; a present but empty list or any unknown value is rejected with 400.
+func parseListParam(c echo.Context, name string, allowed []string) ([]string, error) {
+	if !c.QueryParams().Has(name) {
+		return nil, nil
+	}
+
+	var values []string
+	seen := map[string]bool{}
+	for _, raw := range strings.Split(c.QueryParam(name), ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		value, ok := domain.CanonicalValue(allowed, raw)
+		if !ok {
+			return nil, echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("Invalid %s %q: must be one of %s", name, raw, strings.Join(allowed, ", ")))
+		}
+
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid %s: at least one value is required", name))
+	}
+
+	return values, nil
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// featureEnabled reports whether a flag is on, using the flag defaults when none are configured
+func (h *IncidentHandler) featureEnabled(flag string) bool {
+	if h.flags == nil {
+		return domain.DefaultFeatureFlags[flag]
+	}
+	return h.flags.Enabled(flag)
+}
+
+// GetFeatureFlags handles GET /admin/flags
+func (h *IncidentHandler) GetFeatureFlags(c echo.Context) error {
+	flags := domain.DefaultFeatureFlags
+	if h.flags != nil {
+		flags = h.flags.All()
+	}
+
+	return h.respond(c, http.StatusOK, flags, nil, map[string]interface{}{"flags": flags})
+}
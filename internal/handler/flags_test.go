@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetFeatureFlags(t *testing.T) {
+	e := echo.New()
+	handler := NewIncidentHandler(new(MockIncidentUseCase), WithFeatureFlags(usecase.NewStaticFlags(map[string]bool{domain.FlagIngest: false})))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/flags", nil)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.GetFeatureFlags(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Flags map[string]bool `json:"flags"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, map[string]bool{
+		domain.FlagEmbeddings:   true,
+		domain.FlagDedup:        true,
+		domain.FlagDryRun:       true,
+		domain.FlagIngest:       false,
+		domain.FlagSuggestLinks: false,
+	}, body.Flags)
+}
+
+func TestGetEffectiveConfig(t *testing.T) {
+	e := echo.New()
+	settings := []domain.ConfigSetting{
+		{Name: "DB_HOST", Value: "mysql.internal", Source: domain.ConfigSourceEnv},
+		{Name: "DB_PASSWORD", Value: domain.RedactedValue, Source: domain.ConfigSourceEnv},
+	}
+	handler := NewIncidentHandler(new(MockIncidentUseCase), WithEffectiveConfig(settings))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/config", nil)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.GetEffectiveConfig(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Settings []domain.ConfigSetting `json:"settings"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, settings, body.Settings)
+}
+
+func TestFeatureFlags_GateHandlers(t *testing.T) {
+	flags := usecase.NewStaticFlags(map[string]bool{domain.FlagDryRun: false, domain.FlagIngest: false})
+
+	t.Run("dry run disabled", func(t *testing.T) {
+		e := echo.New()
+		mockUC := new(MockIncidentUseCase)
+		handler := NewIncidentHandler(mockUC, WithFeatureFlags(flags))
+
+		jsonBody, _ := json.Marshal(map[string]interface{}{
+			"title":            "Disk full",
+			"description":      "Root volume at 100%",
+			"affected_service": "storage",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/incidents?dry_run=true", bytes.NewReader(jsonBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		err := handler.CreateIncident(e.NewContext(req, httptest.NewRecorder()))
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		mockUC.AssertNotCalled(t, "PreviewIncident", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ingest disabled", func(t *testing.T) {
+		e := echo.New()
+		mockUC := new(MockIncidentUseCase)
+		handler := NewIncidentHandler(mockUC, WithFeatureFlags(flags))
+
+		req := httptest.NewRequest(http.MethodPost, "/incidents/ingest/datadog", strings.NewReader(`{}`))
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.SetParamNames("source")
+		c.SetParamValues("datadog")
+
+		err := handler.IngestIncident(c)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+		mockUC.AssertNotCalled(t, "CreateIncident", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SetFollowUp handles PUT /incidents/:id/follow-up. An empty interval stops the reminders.
+func (h *IncidentHandler) SetFollowUp(c echo.Context) error {
+	id, err := h.incidentID(c)
+	if err != nil {
+		return err
+	}
+
+	var req domain.FollowUpRequest
+	if err := c.Bind(&req); err != nil {
+		return bindFailed(err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return validationFailed(err)
+	}
+
+	followUp, err := h.incidentUseCase.SetFollowUp(c.Request().Context(), id, req.Duration)
+	if err != nil {
+		if errors.Is(err, domain.ErrFalsePositive) {
+			return echo.NewHTTPError(http.StatusConflict, "Cannot follow up an incident marked as a false positive")
+		}
+		return h.followUpFailed(c, err, "Failed to set follow-up: ")
+	}
+
+	message := "Follow-up scheduled"
+	if followUp == nil {
+		message = "Follow-up stopped"
+	}
+	return h.respond(c, http.StatusOK, followUp, map[string]interface{}{"message": message}, map[string]interface{}{
+		"message":   message,
+		"follow_up": followUp,
+	})
+}
+
+// GetFollowUp handles GET /incidents/:id/follow-up
+func (h *IncidentHandler) GetFollowUp(c echo.Context) error {
+	id, err := h.incidentID(c)
+	if err != nil {
+		return err
+	}
+
+	followUp, err := h.incidentUseCase.GetFollowUp(c.Request().Context(), id)
+	if err != nil {
+		return h.followUpFailed(c, err, "Failed to retrieve follow-up: ")
+	}
+	if followUp == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "No follow-up scheduled")
+	}
+
+	return h.respond(c, http.StatusOK, followUp, nil, followUp)
+}
+
+// followUpFailed maps the errors shared by the follow-up endpoints, falling back to a 500
+// whose message starts with prefix
+func (h *IncidentHandler) followUpFailed(c echo.Context, err error, prefix string) error {
+	if httpErr := h.missingIncident(c, err); httpErr != nil {
+		return httpErr
+	}
+	if errors.Is(err, domain.ErrFollowUpsUnavailable) {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "Follow-ups are not available")
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, prefix+err.Error())
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetFollowUp(t *testing.T) {
+	put := func(handler *IncidentHandler, body string) (*httptest.ResponseRecorder, error) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/incidents/7/follow-up", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("7")
+		return rec, handler.SetFollowUp(c)
+	}
+
+	t.Run("schedules reminders", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("SetFollowUp", mock.Anything, 7, 4*time.Hour).
+			Return(&domain.FollowUp{IncidentID: 7, Interval: 4 * time.Hour}, nil)
+
+		rec, err := put(NewIncidentHandler(mockUC), `{"interval": "4h"}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"interval":"4h0m0s"`)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("empty interval stops reminders", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("SetFollowUp", mock.Anything, 7, time.Duration(0)).Return(nil, nil)
+
+		rec, err := put(NewIncidentHandler(mockUC), `{"interval": ""}`)
+
+		assert.NoError(t, err)
+		assert.Contains(t, rec.Body.String(), "Follow-up stopped")
+	})
+
+	t.Run("interval too short", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+
+		_, err := put(NewIncidentHandler(mockUC), `{"interval": "1m"}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+		mockUC.AssertNotCalled(t, "SetFollowUp", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("false positive", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("SetFollowUp", mock.Anything, 7, time.Hour).Return(nil, domain.ErrFalsePositive)
+
+		_, err := put(NewIncidentHandler(mockUC), `{"interval": "1h"}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, httpErr.Code)
+	})
+}
+
+func TestGetFollowUp_None(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+	mockUC.On("GetFollowUp", mock.Anything, 7).Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/7/follow-up", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+
+	err := handler.GetFollowUp(c)
+
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
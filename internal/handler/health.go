@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HealthChecker reports whether an external dependency is usable
+type HealthChecker interface {
+	CheckHealth() error
+}
+
+// AIHealthHandler reports AI provider reachability, caching the result so frequent
+// probes do not hit the provider on every request
+type AIHealthHandler struct {
+	checker HealthChecker
+	ttl     time.Duration
+	clock   clock.Clock
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	lastErr   error
+}
+
+// NewAIHealthHandler creates a handler that re-checks the provider at most once per ttl
+func NewAIHealthHandler(checker HealthChecker, ttl time.Duration, clk clock.Clock) *AIHealthHandler {
+	return &AIHealthHandler{checker: checker, ttl: ttl, clock: clk}
+}
+
+// Check handles GET /health/ai. An unreachable provider is reported as degraded with 503;
+// the rest of the API keeps serving.
+func (h *AIHealthHandler) Check(c echo.Context) error {
+	checkedAt, err := h.result()
+	if err != nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{
+			"status":     "degraded",
+			"error":      err.Error(),
+			"checked_at": checkedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":     "healthy",
+		"checked_at": checkedAt,
+	})
+}
+
+// result returns the cached check, refreshing it once the ttl has passed
+func (h *AIHealthHandler) result() (time.Time, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.clock.Now()
+	if h.checkedAt.IsZero() || now.Sub(h.checkedAt) >= h.ttl {
+		h.lastErr = h.checker.CheckHealth()
+		h.checkedAt = now
+	}
+	return h.checkedAt, h.lastErr
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHealthChecker is a mock implementation of HealthChecker
+type MockHealthChecker struct {
+	mock.Mock
+}
+
+func (m *MockHealthChecker) CheckHealth() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func TestAIHealthHandler_Check(t *testing.T) {
+	e := echo.New()
+	checker := new(MockHealthChecker)
+	clk := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	handler := NewAIHealthHandler(checker, 30*time.Second, clk)
+
+	probe := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/health/ai", nil)
+		assert.NoError(t, handler.Check(e.NewContext(req, rec)))
+		return rec
+	}
+
+	checker.On("CheckHealth").Return(nil).Once()
+	assert.Equal(t, http.StatusOK, probe().Code)
+
+	// Within the ttl the cached result is served without another check
+	clk.Advance(10 * time.Second)
+	assert.Equal(t, http.StatusOK, probe().Code)
+	checker.AssertNumberOfCalls(t, "CheckHealth", 1)
+
+	// Once the ttl passes the provider is checked again
+	checker.On("CheckHealth").Return(errors.New("AI provider unreachable")).Once()
+	clk.Advance(30 * time.Second)
+	rec := probe()
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"degraded"`)
+	checker.AssertNumberOfCalls(t, "CheckHealth", 2)
+}
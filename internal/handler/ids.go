@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// idKeys are the JSON keys holding incident IDs, rendered as strings when IDs as strings are
+// enabled. Keys ending in idListSuffix, such as incident_ids, hold lists of IDs.
+var idKeys = map[string]bool{
+	"id":           true,
+	"incident_id":  true,
+	"duplicate_of": true,
+}
+
+// idListSuffix ends the JSON keys holding lists of IDs
+const idListSuffix = "_ids"
+
+// opaqueKeys hold client-defined values that are returned exactly as stored, even under keys
+// that look like IDs
+var opaqueKeys = map[string]bool{
+	"custom_fields": true,
+}
+
+// stringifyIDs re-encodes a response body with every incident ID as a JSON string, so clients
+// that parse numbers as doubles cannot lose precision
+func stringifyIDs(body interface{}) (interface{}, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+
+	return stringifyIDValues(tree, false), nil
+}
+
+// stringifyIDValues walks a decoded JSON tree, converting numbers found under an ID key,
+// directly or in a list
+func stringifyIDValues(value interface{}, isID bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if opaqueKeys[key] {
+				continue
+			}
+			v[key] = stringifyIDValues(child, idKeys[key] || strings.HasSuffix(key, idListSuffix))
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = stringifyIDValues(child, isID)
+		}
+	case json.Number:
+		if isID {
+			return v.String()
+		}
+	}
+	return value
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIDAsString(t *testing.T) {
+	createdAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	incident := &domain.Incident{
+		ID:              9007199254740993,
+		Title:           "Disk full",
+		Description:     "Root volume at 100%",
+		AffectedService: "storage",
+		AISeverity:      "High",
+		AICategory:      "Hardware",
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+		AgeSeconds:      45,
+		AgeHuman:        "45s",
+		AnalysisStatus:  domain.AnalysisComplete,
+	}
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{
+			name: "numeric IDs",
+			expected: `{"id":9007199254740993,"title":"Disk full","description":"Root volume at 100%","affected_service":"storage",` +
+				`"ai_severity":"High","ai_category":"Hardware","created_at":"2024-06-01T12:00:00Z","updated_at":"2024-06-01T12:00:00Z",` +
+				`"analysis_status":"complete","age_seconds":45,"age_human":"45s"}`,
+		},
+		{
+			name: "string IDs",
+			opts: []Option{WithIDAsString(true)},
+			expected: `{"id":"9007199254740993","title":"Disk full","description":"Root volume at 100%","affected_service":"storage",` +
+				`"ai_severity":"High","ai_category":"Hardware","created_at":"2024-06-01T12:00:00Z","updated_at":"2024-06-01T12:00:00Z",` +
+				`"analysis_status":"complete","age_seconds":45,"age_human":"45s"}`,
+		},
+		{
+			name: "string IDs in envelope",
+			opts: []Option{WithIDAsString(true), WithResponseEnvelope(true)},
+			expected: `{"data":{"id":"9007199254740993","title":"Disk full","description":"Root volume at 100%","affected_service":"storage",` +
+				`"ai_severity":"High","ai_category":"Hardware","created_at":"2024-06-01T12:00:00Z","updated_at":"2024-06-01T12:00:00Z",` +
+				`"analysis_status":"complete","age_seconds":45,"age_human":"45s"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC, tt.opts...)
+			mockUC.On("GetIncident", mock.Anything, 1).Return(incident, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/incidents/1", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			assert.NoError(t, handler.GetIncident(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, tt.expected, rec.Body.String())
+		})
+	}
+}
+
+func TestStringifyIDs_NestedIDs(t *testing.T) {
+	duplicateOf := 42
+	body, err := stringifyIDs(map[string]interface{}{
+		"changes": []*domain.HistoryEntry{{ID: 3, IncidentID: 42}},
+		"preview": &domain.IncidentPreview{Incident: &domain.Incident{}, Outcome: domain.PreviewDuplicate, DuplicateOf: &duplicateOf},
+		"count":   1,
+	})
+
+	assert.NoError(t, err)
+	tree := body.(map[string]interface{})
+	change := tree["changes"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "3", change["id"])
+	assert.Equal(t, "42", change["incident_id"])
+	assert.Equal(t, "42", tree["preview"].(map[string]interface{})["duplicate_of"])
+	assert.Equal(t, json.Number("1"), tree["count"], "non-ID numbers stay numbers")
+}
+
+func TestIDAsString_IDLists(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC, WithIDAsString(true))
+	mockUC.On("ReassignIncidents", mock.Anything, "dana", "sam", domain.ReassignScope{}).
+		Return(&domain.ReassignResult{Reassigned: 2, IncidentIDs: []int{9007199254740993, 12}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/incidents/reassign", strings.NewReader(`{"from":"dana","to":"sam"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.ReassignIncidents(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"reassigned":2,"incident_ids":["9007199254740993","12"]}`, rec.Body.String())
+}
+
+func TestIDAsString_KeepsCustomFields(t *testing.T) {
+	createdAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	incident := &domain.Incident{
+		ID:              7,
+		Title:           "Disk full",
+		Description:     "Root volume at 100%",
+		AffectedService: "storage",
+		AISeverity:      "High",
+		AICategory:      "Hardware",
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+		CustomFields:    map[string]interface{}{"id": 41, "incident_id": 42, "ticket_ids": []interface{}{1, 2}},
+		AgeSeconds:      45,
+		AgeHuman:        "45s",
+		AnalysisStatus:  domain.AnalysisComplete,
+	}
+
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC, WithIDAsString(true))
+	mockUC.On("GetIncident", mock.Anything, 7).Return(incident, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/7", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+
+	assert.NoError(t, handler.GetIncident(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"id":"7","title":"Disk full","description":"Root volume at 100%","affected_service":"storage",`+
+		`"ai_severity":"High","ai_category":"Hardware","created_at":"2024-06-01T12:00:00Z","updated_at":"2024-06-01T12:00:00Z",`+
+		`"custom_fields":{"id":41,"incident_id":42,"ticket_ids":[1,2]},"analysis_status":"complete","age_seconds":45,"age_human":"45s"}`,
+		rec.Body.String())
+}
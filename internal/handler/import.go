@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxImportRows caps the number of data rows in one CSV import
+const maxImportRows = 1000
+
+// Columns accepted by POST /incidents/import
+const (
+	importTitle           = "title"
+	importDescription     = "description"
+	importAffectedService = "affected_service"
+	importSeverity        = "ai_severity"
+	importCategory        = "ai_category"
+	importAssignee        = "assignee"
+)
+
+// importColumns lists every accepted column; the first three are required
+var importColumns = []string{importTitle, importDescription, importAffectedService, importSeverity, importCategory, importAssignee}
+
+// ImportIncidents handles POST /incidents/import. The CSV is read from the multipart file
+// field "file", or from the raw request body. With ?analyze=false the ai_severity and
+// ai_category columns are required and kept instead of running AI analysis.
+func (h *IncidentHandler) ImportIncidents(c echo.Context) error {
+	analyze := c.QueryParam("analyze") != "false"
+
+	body, err := importBody(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid CSV upload")
+	}
+	defer body.Close()
+
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing CSV header row")
+	}
+	columns, err := parseImportHeader(header, analyze)
+	if err != nil {
+		return err
+	}
+
+	var rows []*domain.ImportRow
+	var rejected []*domain.ImportRowResult
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid CSV on line %d: %v", parseErr.StartLine, parseErr.Err))
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid CSV upload")
+		}
+		if len(rows)+len(rejected) == maxImportRows {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("Import exceeds %d rows", maxImportRows))
+		}
+
+		line, _ := reader.FieldPos(0)
+		row, fieldErrs := h.parseImportRow(line, columns, record, analyze)
+		if fieldErrs != nil {
+			rejected = append(rejected, &domain.ImportRowResult{Line: line, Outcome: domain.ImportRejected, Error: "Validation failed", Fields: fieldErrs})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	var results []*domain.ImportRowResult
+	if len(rows) > 0 {
+		results, err = h.incidentUseCase.ImportIncidents(c.Request().Context(), rows, analyze)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to import incidents: "+err.Error())
+		}
+	}
+	results = mergeImportResults(results, rejected)
+
+	created := 0
+	for _, result := range results {
+		if result.Outcome == domain.ImportCreated {
+			created++
+		}
+	}
+	counts := map[string]interface{}{"created": created, "rejected": len(results) - created}
+	return h.respond(c, http.StatusOK, results, counts, map[string]interface{}{
+		"results":  results,
+		"created":  counts["created"],
+		"rejected": counts["rejected"],
+	})
+}
+
+// importBody returns the uploaded CSV file, falling back to the request body
+func importBody(c echo.Context) (io.ReadCloser, error) {
+	if !strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		return c.Request().Body, nil
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	return file.Open()
+}
+
+// parseImportHeader maps each column name to its index, rejecting unknown, repeated and
+// missing columns with 400
+func parseImportHeader(header []string, analyze bool) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := domain.CanonicalValue(importColumns, name); !ok {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown CSV column: %q", name))
+		}
+		if _, ok := columns[name]; ok {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Duplicate CSV column: %q", name))
+		}
+		columns[name] = i
+	}
+
+	required := importColumns[:3]
+	if !analyze {
+		required = append(required[:3:3], importSeverity, importCategory)
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Missing CSV column: %q", name))
+		}
+	}
+	return columns, nil
+}
+
+// parseImportRow builds and validates the create request of one CSV row. When analysis is
+// skipped the severity and category must be valid taxonomy values.
+func (h *IncidentHandler) parseImportRow(line int, columns map[string]int, record []string, analyze bool) (*domain.ImportRow, []domain.FieldError) {
+	value := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := &domain.ImportRow{
+		Line: line,
+		Request: &domain.CreateIncidentRequest{
+			Title:           value(importTitle),
+			Description:     value(importDescription),
+			AffectedService: value(importAffectedService),
+			Assignee:        value(importAssignee),
+		},
+	}
+
+	var fieldErrs []domain.FieldError
+	if err := h.validate(row.Request); err != nil {
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) {
+			return nil, []domain.FieldError{{Message: err.Error()}}
+		}
+		fieldErrs = validationErr.Fields
+	}
+
+	if !analyze {
+		var ok bool
+		if row.Severity, ok = domain.CanonicalValue(domain.Severities, value(importSeverity)); !ok {
+			fieldErrs = append(fieldErrs, domain.FieldError{Field: importSeverity, Rule: domain.RuleOneOf, Message: "ai_severity must be one of " + strings.Join(domain.Severities, ", ")})
+		}
+		if row.Category, ok = domain.CanonicalValue(domain.Categories, value(importCategory)); !ok {
+			fieldErrs = append(fieldErrs, domain.FieldError{Field: importCategory, Rule: domain.RuleOneOf, Message: "ai_category must be one of " + strings.Join(domain.Categories, ", ")})
+		}
+	}
+
+	if fieldErrs != nil {
+		return nil, fieldErrs
+	}
+	return row, nil
+}
+
+// mergeImportResults interleaves the use case results and the rows rejected by validation
+// back into line order; both inputs are already ordered by line
+func mergeImportResults(results, rejected []*domain.ImportRowResult) []*domain.ImportRowResult {
+	merged := make([]*domain.ImportRowResult, 0, len(results)+len(rejected))
+	for len(results) > 0 || len(rejected) > 0 {
+		if len(rejected) == 0 || (len(results) > 0 && results[0].Line < rejected[0].Line) {
+			merged = append(merged, results[0])
+			results = results[1:]
+		} else {
+			merged = append(merged, rejected[0])
+			rejected = rejected[1:]
+		}
+	}
+	return merged
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// importCSV posts a CSV body to the import handler
+func importCSV(handler *IncidentHandler, query, body string) (*httptest.ResponseRecorder, error) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/incidents/import"+query, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, "text/csv")
+	rec := httptest.NewRecorder()
+	return rec, handler.ImportIncidents(e.NewContext(req, rec))
+}
+
+func TestImportIncidents_MixedRows(t *testing.T) {
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	body := "title,description,affected_service,ai_severity,ai_category\n" +
+		"Disk full,Root volume at 100%,storage,high,Hardware\n" +
+		"Slow queries,p99 at 4s,db,Urgent,Database\n" +
+		",Missing title,api,Low,Software\n" +
+		"\"Cert expiry\",\"Expires in\n3 days\",edge,Medium,Security\n"
+
+	mockUC.On("ImportIncidents", mock.Anything, mock.MatchedBy(func(rows []*domain.ImportRow) bool {
+		return len(rows) == 2 &&
+			rows[0].Line == 2 && rows[0].Severity == "High" && rows[0].Request.Title == "Disk full" &&
+			rows[1].Line == 5 && rows[1].Request.Description == "Expires in\n3 days"
+	}), false).Return([]*domain.ImportRowResult{
+		{Line: 2, Outcome: domain.ImportCreated, IncidentID: 7},
+		{Line: 5, Outcome: domain.ImportCreated, IncidentID: 8},
+	}, nil)
+
+	rec, err := importCSV(handler, "?analyze=false", body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Results  []*domain.ImportRowResult `json:"results"`
+		Created  int                       `json:"created"`
+		Rejected int                       `json:"rejected"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Created)
+	assert.Equal(t, 2, response.Rejected)
+
+	lines := make([]int, len(response.Results))
+	for i, result := range response.Results {
+		lines[i] = result.Line
+	}
+	assert.Equal(t, []int{2, 3, 4, 5}, lines)
+	assert.Equal(t, domain.ImportRejected, response.Results[1].Outcome)
+	assert.Equal(t, "ai_severity", response.Results[1].Fields[0].Field)
+	assert.Equal(t, "title", response.Results[2].Fields[0].Field)
+	mockUC.AssertExpectations(t)
+}
+
+func TestImportIncidents_MultipartUpload(t *testing.T) {
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, _ := form.CreateFormFile("file", "incidents.csv")
+	file.Write([]byte("title,description,affected_service\nOutage,API down,api\n"))
+	form.Close()
+
+	mockUC.On("ImportIncidents", mock.Anything, mock.MatchedBy(func(rows []*domain.ImportRow) bool {
+		return len(rows) == 1 && rows[0].Request.AffectedService == "api"
+	}), true).Return([]*domain.ImportRowResult{{Line: 2, Outcome: domain.ImportCreated, IncidentID: 1}}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/incidents/import", &body)
+	req.Header.Set(echo.HeaderContentType, form.FormDataContentType())
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.ImportIncidents(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockUC.AssertExpectations(t)
+}
+
+func TestImportIncidents_RejectsFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		body         string
+		expectedCode int
+		expectedMsg  string
+	}{
+		{
+			name:         "unknown column",
+			body:         "title,description,affected_service,priority\n",
+			expectedCode: http.StatusBadRequest,
+			expectedMsg:  `Unknown CSV column: "priority"`,
+		},
+		{
+			name:         "duplicate column",
+			body:         "title,description,affected_service,title\n",
+			expectedCode: http.StatusBadRequest,
+			expectedMsg:  `Duplicate CSV column: "title"`,
+		},
+		{
+			name:         "missing required column",
+			body:         "title,description\n",
+			expectedCode: http.StatusBadRequest,
+			expectedMsg:  `Missing CSV column: "affected_service"`,
+		},
+		{
+			name:         "severity required without analysis",
+			query:        "?analyze=false",
+			body:         "title,description,affected_service,ai_category\n",
+			expectedCode: http.StatusBadRequest,
+			expectedMsg:  `Missing CSV column: "ai_severity"`,
+		},
+		{
+			name:         "malformed row",
+			body:         "title,description,affected_service\nOutage,API down\n",
+			expectedCode: http.StatusBadRequest,
+			expectedMsg:  "Invalid CSV on line 2",
+		},
+		{
+			name:         "too many rows",
+			body:         "title,description,affected_service\n" + strings.Repeat("Outage,API down,api\n", maxImportRows+1),
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedMsg:  fmt.Sprintf("Import exceeds %d rows", maxImportRows),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
+
+			_, err := importCSV(handler, tt.query, tt.body)
+
+			httpErr, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expectedCode, httpErr.Code)
+			assert.Contains(t, httpErr.Message, tt.expectedMsg)
+			mockUC.AssertNotCalled(t, "ImportIncidents", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
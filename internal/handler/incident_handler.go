@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
 	"strconv"
 
 	"incident-triage-assistant/internal/domain"
@@ -9,95 +12,325 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// similarLimits bounds the number of similar incidents returned
+var similarLimits = pageLimits{Default: 5, Max: 20}
+
 // IncidentHandler handles HTTP requests for incident management
 type IncidentHandler struct {
 	incidentUseCase domain.IncidentUseCase
+	listLimits      pageLimits
+	fieldLimits     domain.FieldLimits
+	customFields    domain.CustomFieldSchema
+	catalog         *domain.ServiceCatalog
+	envelope        bool
+	debugTimings    bool
+	flags           domain.FeatureFlags
+	adminToken      string
+	idAsString      bool
+	events          domain.EventSubscriber
+	settings        []domain.ConfigSetting
+	references      *regexp.Regexp
+}
+
+// Option configures optional IncidentHandler settings
+type Option func(*IncidentHandler)
+
+// WithPageSizes sets the default and maximum page size of list endpoints.
+// Limits above the maximum are clamped unless rejectOversized is set.
+func WithPageSizes(defaultSize, maxSize int, rejectOversized bool) Option {
+	return func(h *IncidentHandler) {
+		h.listLimits = pageLimits{Default: defaultSize, Max: maxSize, RejectOversized: rejectOversized}
+	}
+}
+
+// WithFieldLimits sets the maximum lengths enforced on incident text fields
+func WithFieldLimits(limits domain.FieldLimits) Option {
+	return func(h *IncidentHandler) {
+		h.fieldLimits = limits
+	}
+}
+
+// WithCustomFieldSchema restricts custom fields to the keys and types of schema
+func WithCustomFieldSchema(schema domain.CustomFieldSchema) Option {
+	return func(h *IncidentHandler) {
+		h.customFields = schema
+	}
+}
+
+// WithServiceCatalog rejects affected services missing from a strict catalog
+func WithServiceCatalog(catalog *domain.ServiceCatalog) Option {
+	return func(h *IncidentHandler) {
+		h.catalog = catalog
+	}
+}
+
+// WithResponseEnvelope wraps every successful JSON response as {"data": ..., "meta": ...}
+func WithResponseEnvelope(enabled bool) Option {
+	return func(h *IncidentHandler) {
+		h.envelope = enabled
+	}
+}
+
+// WithDebugTimings allows clients to request the create latency breakdown with ?timing=true
+func WithDebugTimings(enabled bool) Option {
+	return func(h *IncidentHandler) {
+		h.debugTimings = enabled
+	}
+}
+
+// WithFeatureFlags sets the feature flags checked by the handler and reported to admins
+func WithFeatureFlags(flags domain.FeatureFlags) Option {
+	return func(h *IncidentHandler) {
+		h.flags = flags
+	}
+}
+
+// WithAdminToken lets requests carrying the admin token see that an incident was deleted (410)
+// rather than missing (404)
+func WithAdminToken(token string) Option {
+	return func(h *IncidentHandler) {
+		h.adminToken = token
+	}
+}
+
+// WithIDAsString writes incident IDs in responses as JSON strings instead of numbers
+func WithIDAsString(enabled bool) Option {
+	return func(h *IncidentHandler) {
+		h.idAsString = enabled
+	}
+}
+
+// WithEventStream enables the live incident stream, fed by events
+func WithEventStream(events domain.EventSubscriber) Option {
+	return func(h *IncidentHandler) {
+		h.events = events
+	}
+}
+
+// WithEffectiveConfig sets the redacted configuration reported to admins
+func WithEffectiveConfig(settings []domain.ConfigSetting) Option {
+	return func(h *IncidentHandler) {
+		h.settings = settings
+	}
+}
+
+// WithReferenceFormat accepts references in format wherever an incident ID is expected
+func WithReferenceFormat(format domain.ReferenceFormat) Option {
+	return func(h *IncidentHandler) {
+		h.references = format.Regexp()
+	}
 }
 
 // NewIncidentHandler creates a new incident handler
-func NewIncidentHandler(incidentUseCase domain.IncidentUseCase) *IncidentHandler {
-	return &IncidentHandler{
+func NewIncidentHandler(incidentUseCase domain.IncidentUseCase, opts ...Option) *IncidentHandler {
+	h := &IncidentHandler{
 		incidentUseCase: incidentUseCase,
+		listLimits:      pageLimits{Default: 50, Max: 200},
+		fieldLimits:     domain.DefaultFieldLimits,
+		references:      domain.DefaultReferenceFormat.Regexp(),
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // CreateIncident handles POST /incidents
 func (h *IncidentHandler) CreateIncident(c echo.Context) error {
 	var req domain.CreateIncidentRequest
 	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+		return bindFailed(err)
+	}
+
+	return h.createIncident(c, &req)
+}
+
+// createIncident validates a create request and runs it through the use case
+func (h *IncidentHandler) createIncident(c echo.Context, req *domain.CreateIncidentRequest) error {
+	if err := h.validate(req); err != nil {
+		return validationFailed(err)
 	}
 
-	// Basic validation
-	if req.Title == "" || req.Description == "" || req.AffectedService == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "Title, description, and affected service are required")
+	if c.QueryParam("dry_run") == "true" {
+		if !h.featureEnabled(domain.FlagDryRun) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Dry run is disabled")
+		}
+		preview, err := h.incidentUseCase.PreviewIncident(c.Request().Context(), req)
+		if isValidationError(err) {
+			return validationFailed(err)
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to preview incident: "+err.Error())
+		}
+		return h.respond(c, http.StatusOK, preview, map[string]interface{}{"dry_run": true}, preview)
 	}
 
-	incident, err := h.incidentUseCase.CreateIncident(&req)
+	req.AllowDuplicate = c.QueryParam("allow_duplicate") == "true"
+	incident, err := h.incidentUseCase.CreateIncident(c.Request().Context(), req)
 	if err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			return echo.NewHTTPError(http.StatusConflict, "Incident already exists")
+		}
+		if isValidationError(err) {
+			return validationFailed(err)
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create incident: "+err.Error())
 	}
 
-	return c.JSON(http.StatusCreated, map[string]interface{}{
-		"message":  "Incident created successfully",
+	if incident.Suppressed {
+		message := "Incident suppressed into alert storm"
+		return h.respond(c, http.StatusAccepted, incident, map[string]interface{}{"message": message}, map[string]interface{}{
+			"message":  message,
+			"incident": incident,
+		})
+	}
+
+	if !h.debugTimings || c.QueryParam("timing") != "true" {
+		incident.Timings = nil
+	}
+
+	message := "Incident created successfully"
+	return h.respond(c, http.StatusCreated, incident, map[string]interface{}{"message": message}, map[string]interface{}{
+		"message":  message,
 		"incident": incident,
 	})
 }
 
 // GetIncident handles GET /incidents/:id
 func (h *IncidentHandler) GetIncident(c echo.Context) error {
-	idStr := c.Param("id")
-	id, err := strconv.Atoi(idStr)
+	id, err := h.incidentID(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid incident ID")
+		return err
 	}
 
-	incident, err := h.incidentUseCase.GetIncident(id)
+	incident, err := h.incidentUseCase.GetIncident(c.Request().Context(), id)
 	if err != nil {
+		if httpErr := h.missingIncident(c, err); httpErr != nil {
+			return httpErr
+		}
 		return echo.NewHTTPError(http.StatusNotFound, "Incident not found")
 	}
 
-	return c.JSON(http.StatusOK, incident)
+	return h.respond(c, http.StatusOK, incident, nil, incident)
 }
 
-// GetAllIncidents handles GET /incidents
+// List projections selected by the fields query parameter
+const (
+	fieldsFull    = "full"
+	fieldsSummary = "summary"
+)
+
+// GetAllIncidents handles GET /incidents, returning one limit/offset page of the matching incidents
 func (h *IncidentHandler) GetAllIncidents(c echo.Context) error {
-	incidents, err := h.incidentUseCase.GetAllIncidents()
+	filter, err := parseIncidentFilter(c, h.customFields)
+	if err != nil {
+		return err
+	}
+
+	fields := c.QueryParam("fields")
+	if fields == "" {
+		fields = fieldsFull
+	}
+	if fields != fieldsFull && fields != fieldsSummary {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid fields: must be full or summary")
+	}
+
+	page, err := parsePageParams(c, h.listLimits)
+	if err != nil {
+		return err
+	}
+	if page.Cursor != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor: incidents are paginated by offset")
+	}
+
+	// A cached listing is only current when it matches the writer, since the replica may lag
+	key := fmt.Sprintf("%s|fields=%s|limit=%d|offset=%d", filter.Key(), fields, page.Limit, page.Offset)
+	if ifNoneMatch := c.Request().Header.Get(headerIfNoneMatch); ifNoneMatch != "" {
+		current, err := h.incidentUseCase.GetCurrentListVersion(c.Request().Context(), filter)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve incidents: "+err.Error())
+		}
+		if etag := listETag(current, key); etagMatches(ifNoneMatch, etag) {
+			c.Response().Header().Set(headerETag, etag)
+			return c.NoContent(http.StatusNotModified)
+		}
+	}
+
+	// The version is read from the replica before the page, so the page is never older than
+	// its ETag. It counts every matching incident, so it doubles as the total for the pager.
+	version, err := h.incidentUseCase.GetListVersion(c.Request().Context(), filter)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve incidents: "+err.Error())
 	}
+	c.Response().Header().Set(headerETag, listETag(version, key))
+
+	var incidents interface{}
+	var count int
+	if fields == fieldsSummary {
+		summaries, err := h.incidentUseCase.GetIncidentSummaries(c.Request().Context(), filter, page.Limit, page.Offset)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve incidents: "+err.Error())
+		}
+		incidents, count = summaries, len(summaries)
+	} else {
+		full, err := h.incidentUseCase.GetAllIncidentsPaginated(c.Request().Context(), filter, page.Limit, page.Offset)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve incidents: "+err.Error())
+		}
+		incidents, count = full, len(full)
+	}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{
+	meta := map[string]interface{}{
+		"count":  count,
+		"total":  version.Count,
+		"limit":  page.Limit,
+		"offset": page.Offset,
+	}
+	return h.respond(c, http.StatusOK, incidents, meta, map[string]interface{}{
 		"incidents": incidents,
-		"count":     len(incidents),
+		"count":     count,
+		"total":     version.Count,
+		"limit":     page.Limit,
+		"offset":    page.Offset,
 	})
 }
 
 // UpdateIncident handles PUT /incidents/:id
 func (h *IncidentHandler) UpdateIncident(c echo.Context) error {
-	idStr := c.Param("id")
-	id, err := strconv.Atoi(idStr)
+	id, err := h.incidentID(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid incident ID")
+		return err
 	}
 
 	var req domain.CreateIncidentRequest
 	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+		return bindFailed(err)
 	}
 
-	// Basic validation
-	if req.Title == "" || req.Description == "" || req.AffectedService == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "Title, description, and affected service are required")
+	if err := h.validate(&req); err != nil {
+		return validationFailed(err)
 	}
 
-	incident, err := h.incidentUseCase.UpdateIncident(id, &req)
+	incident, err := h.incidentUseCase.UpdateIncident(c.Request().Context(), id, &req)
 	if err != nil {
+		if httpErr := h.missingIncident(c, err); httpErr != nil {
+			return httpErr
+		}
+		if errors.Is(err, domain.ErrFalsePositive) {
+			return echo.NewHTTPError(http.StatusConflict, "Incident is marked as a false positive")
+		}
+		if errors.Is(err, domain.ErrAnalysisChanged) {
+			return echo.NewHTTPError(http.StatusConflict, "Incident analysis changed during the update, retry it")
+		}
+		if isValidationError(err) {
+			return validationFailed(err)
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update incident: "+err.Error())
 	}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"message":  "Incident updated successfully",
+	message := "Incident updated successfully"
+	return h.respond(c, http.StatusOK, incident, map[string]interface{}{"message": message}, map[string]interface{}{
+		"message":  message,
 		"incident": incident,
 	})
 }
@@ -110,13 +343,96 @@ func (h *IncidentHandler) DeleteIncident(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid incident ID")
 	}
 
-	err = h.incidentUseCase.DeleteIncident(id)
+	err = h.incidentUseCase.DeleteIncident(c.Request().Context(), id)
 	if err != nil {
+		if httpErr := h.missingIncident(c, err); httpErr != nil {
+			return httpErr
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete incident: "+err.Error())
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{
-		"message": "Incident deleted successfully",
+	message := "Incident deleted successfully"
+	return h.respond(c, http.StatusOK, nil, map[string]interface{}{"message": message}, map[string]string{
+		"message": message,
+	})
+}
+
+// GetTriageQueue handles GET /incidents/queue
+func (h *IncidentHandler) GetTriageQueue(c echo.Context) error {
+	page, err := parsePageParams(c, h.listLimits)
+	if err != nil {
+		return err
+	}
+	if page.Cursor != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor: the queue is paginated by offset")
+	}
+
+	incidents, err := h.incidentUseCase.GetTriageQueue(c.Request().Context(), page.Limit, page.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve triage queue: "+err.Error())
+	}
+
+	meta := map[string]interface{}{
+		"count":  len(incidents),
+		"limit":  page.Limit,
+		"offset": page.Offset,
+	}
+	return h.respond(c, http.StatusOK, incidents, meta, map[string]interface{}{
+		"incidents": incidents,
+		"count":     len(incidents),
+		"limit":     page.Limit,
+		"offset":    page.Offset,
+	})
+}
+
+// GetSimilarIncidents handles GET /incidents/:id/similar
+func (h *IncidentHandler) GetSimilarIncidents(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid incident ID")
+	}
+
+	page, err := parsePageParams(c, similarLimits)
+	if err != nil {
+		return err
+	}
+
+	similar, err := h.incidentUseCase.FindSimilarIncidents(c.Request().Context(), id, page.Limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmbeddingsUnavailable) {
+			return echo.NewHTTPError(http.StatusServiceUnavailable, "Similarity search is not available")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find similar incidents: "+err.Error())
+	}
+
+	return h.respond(c, http.StatusOK, similar, map[string]interface{}{"count": len(similar)}, map[string]interface{}{
+		"similar": similar,
+		"count":   len(similar),
+	})
+}
+
+// GetSeverityHistory handles GET /incidents/:id/severity-history
+func (h *IncidentHandler) GetSeverityHistory(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid incident ID")
+	}
+
+	changes, err := h.incidentUseCase.GetSeverityHistory(c.Request().Context(), id)
+	if err != nil {
+		if httpErr := h.missingIncident(c, err); httpErr != nil {
+			return httpErr
+		}
+		return echo.NewHTTPError(http.StatusNotFound, "Incident not found")
+	}
+
+	meta := map[string]interface{}{"incident_id": id, "count": len(changes)}
+	return h.respond(c, http.StatusOK, changes, meta, map[string]interface{}{
+		"incident_id": id,
+		"changes":     changes,
+		"count":       len(changes),
 	})
 }
 
@@ -126,3 +442,40 @@ func (h *IncidentHandler) HealthCheck(c echo.Context) error {
 		"status": "healthy",
 	})
 }
+
+// incidentID reads the :id path parameter, either a numeric incident ID or a reference in the
+// configured format, which is resolved to the ID of its incident
+func (h *IncidentHandler) incidentID(c echo.Context) (int, error) {
+	param := c.Param("id")
+	if id, err := strconv.Atoi(param); err == nil {
+		return id, nil
+	}
+	if !h.references.MatchString(param) {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Invalid incident ID")
+	}
+
+	id, err := h.incidentUseCase.ResolveReference(c.Request().Context(), param)
+	if err != nil {
+		if httpErr := h.missingIncident(c, err); httpErr != nil {
+			return 0, httpErr
+		}
+		return 0, echo.NewHTTPError(http.StatusInternalServerError, "Failed to resolve incident reference: "+err.Error())
+	}
+	return id, nil
+}
+
+// missingIncident maps domain.ErrNotFound to 404 and domain.ErrDeleted to 410 Gone for admins.
+// Other callers get 404 for deleted incidents too so they cannot probe which IDs existed.
+// It returns nil for any other error.
+func (h *IncidentHandler) missingIncident(c echo.Context, err error) error {
+	if errors.Is(err, domain.ErrDeleted) {
+		if isAdmin(c, h.adminToken) {
+			return echo.NewHTTPError(http.StatusGone, "Incident has been deleted")
+		}
+		return echo.NewHTTPError(http.StatusNotFound, "Incident not found")
+	}
+	if errors.Is(err, domain.ErrNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Incident not found")
+	}
+	return nil
+}
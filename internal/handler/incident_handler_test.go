@@ -2,10 +2,15 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"incident-triage-assistant/internal/domain"
 
@@ -19,43 +24,263 @@ type MockIncidentUseCase struct {
 	mock.Mock
 }
 
-func (m *MockIncidentUseCase) CreateIncident(req *domain.CreateIncidentRequest) (*domain.Incident, error) {
-	args := m.Called(req)
+func (m *MockIncidentUseCase) CreateIncident(ctx context.Context, req *domain.CreateIncidentRequest) (*domain.Incident, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Incident), args.Error(1)
 }
 
-func (m *MockIncidentUseCase) GetIncident(id int) (*domain.Incident, error) {
-	args := m.Called(id)
+func (m *MockIncidentUseCase) ImportIncidents(ctx context.Context, rows []*domain.ImportRow, analyze bool) ([]*domain.ImportRowResult, error) {
+	args := m.Called(ctx, rows, analyze)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ImportRowResult), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) GetIncident(ctx context.Context, id int) (*domain.Incident, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Incident), args.Error(1)
 }
 
-func (m *MockIncidentUseCase) GetAllIncidents() ([]*domain.Incident, error) {
-	args := m.Called()
+func (m *MockIncidentUseCase) ResolveReference(ctx context.Context, reference string) (int, error) {
+	args := m.Called(ctx, reference)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) GetAllIncidents(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.Incident, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) GetAllIncidentsPaginated(ctx context.Context, filter *domain.IncidentFilter, limit, offset int) ([]*domain.Incident, error) {
+	args := m.Called(ctx, filter, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*domain.Incident), args.Error(1)
 }
 
-func (m *MockIncidentUseCase) UpdateIncident(id int, req *domain.CreateIncidentRequest) (*domain.Incident, error) {
-	args := m.Called(id, req)
+func (m *MockIncidentUseCase) SearchIncidents(ctx context.Context, term string, limit, offset int) (*domain.SearchPage, error) {
+	args := m.Called(ctx, term, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SearchPage), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) GetIncidentSummaries(ctx context.Context, filter *domain.IncidentFilter, limit, offset int) ([]*domain.IncidentSummary, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.IncidentSummary), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) UpdateIncident(ctx context.Context, id int, req *domain.CreateIncidentRequest) (*domain.Incident, error) {
+	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Incident), args.Error(1)
 }
 
-func (m *MockIncidentUseCase) DeleteIncident(id int) error {
-	args := m.Called(id)
+func (m *MockIncidentUseCase) DeleteIncident(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockIncidentUseCase) FindSimilarIncidents(ctx context.Context, id int, limit int) ([]*domain.SimilarIncident, error) {
+	args := m.Called(ctx, id, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SimilarIncident), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) ExportIncidents(ctx context.Context, filter *domain.IncidentFilter, fn func(*domain.Incident) error) error {
+	args := m.Called(ctx, filter, fn)
+	return args.Error(0)
+}
+
+func (m *MockIncidentUseCase) ExportIncidentArchive(ctx context.Context, filter *domain.IncidentFilter, fn func(*domain.ExportedIncident) error) error {
+	args := m.Called(ctx, filter, fn)
 	return args.Error(0)
 }
 
+func (m *MockIncidentUseCase) RemapSeverity(ctx context.Context, mapping map[string]string, dryRun bool) (*domain.RemapResult, error) {
+	args := m.Called(ctx, mapping, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RemapResult), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) MarkFalsePositive(ctx context.Context, id int, reason string) (*domain.Incident, error) {
+	args := m.Called(ctx, id, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) SetPriorityOverride(ctx context.Context, id int, priority string) (*domain.Incident, error) {
+	args := m.Called(ctx, id, priority)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) CompareIncidents(ctx context.Context, aID, bID int) (*domain.IncidentComparison, error) {
+	args := m.Called(ctx, aID, bID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IncidentComparison), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) SetFollowUp(ctx context.Context, incidentID int, interval time.Duration) (*domain.FollowUp, error) {
+	args := m.Called(ctx, incidentID, interval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FollowUp), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) GetFollowUp(ctx context.Context, incidentID int) (*domain.FollowUp, error) {
+	args := m.Called(ctx, incidentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FollowUp), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) AddRunbookStep(ctx context.Context, incidentID int, step string) (*domain.RunbookStep, error) {
+	args := m.Called(ctx, incidentID, step)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RunbookStep), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) ListRunbookSteps(ctx context.Context, incidentID int) ([]*domain.RunbookStep, error) {
+	args := m.Called(ctx, incidentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.RunbookStep), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) CompleteRunbookStep(ctx context.Context, incidentID, stepID int, doneBy string) (*domain.RunbookStep, error) {
+	args := m.Called(ctx, incidentID, stepID, doneBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RunbookStep), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) ReassignIncidents(ctx context.Context, from, to string, scope domain.ReassignScope) (*domain.ReassignResult, error) {
+	args := m.Called(ctx, from, to, scope)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReassignResult), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) ReprocessFailedAnalyses(ctx context.Context, limit int) (*domain.ReprocessResult, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReprocessResult), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) CreateIncidentsBatch(ctx context.Context, reqs []*domain.CreateIncidentRequest) []*domain.BatchItemResult {
+	args := m.Called(ctx, reqs)
+	return args.Get(0).([]*domain.BatchItemResult)
+}
+
+func (m *MockIncidentUseCase) ExportIncidentsPage(ctx context.Context, filter *domain.IncidentFilter, afterID, limit int) (*domain.ExportPage, error) {
+	args := m.Called(ctx, filter, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ExportPage), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) GetSeverityHistory(ctx context.Context, id int) ([]*domain.HistoryEntry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.HistoryEntry), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) GetHistory(ctx context.Context, filter domain.HistoryFilter, after *domain.Cursor, limit int) (*domain.HistoryPage, error) {
+	args := m.Called(ctx, filter, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.HistoryPage), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) GetCurrentListVersion(ctx context.Context, filter *domain.IncidentFilter) (*domain.ListVersion, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ListVersion), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) GetListVersion(ctx context.Context, filter *domain.IncidentFilter) (*domain.ListVersion, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ListVersion), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) CheckDataQuality(ctx context.Context, fix string) (*domain.QualityReport, error) {
+	args := m.Called(ctx, fix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QualityReport), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) GetDistribution(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.DistributionCount, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DistributionCount), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) GetTriageQueue(ctx context.Context, limit, offset int) ([]*domain.Incident, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentUseCase) PreviewIncident(ctx context.Context, req *domain.CreateIncidentRequest) (*domain.IncidentPreview, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IncidentPreview), args.Error(1)
+}
+
 func TestCreateIncident(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -80,17 +305,30 @@ func TestCreateIncident(t *testing.T) {
 					AISeverity:      "Medium",
 					AICategory:      "Software",
 				}
-				mockUC.On("CreateIncident", mock.AnythingOfType("*domain.CreateIncidentRequest")).
+				mockUC.On("CreateIncident", mock.Anything, mock.AnythingOfType("*domain.CreateIncidentRequest")).
 					Return(expectedIncident, nil)
 			},
 		},
+		{
+			name: "duplicate incident",
+			requestBody: map[string]interface{}{
+				"title":            "Test Incident",
+				"description":      "Test Description",
+				"affected_service": "Test Service",
+			},
+			expectedStatus: http.StatusConflict,
+			setupMock: func(mockUC *MockIncidentUseCase) {
+				mockUC.On("CreateIncident", mock.Anything, mock.AnythingOfType("*domain.CreateIncidentRequest")).
+					Return(nil, fmt.Errorf("failed to create incident: %w", domain.ErrDuplicate))
+			},
+		},
 		{
 			name: "missing required fields",
 			requestBody: map[string]interface{}{
 				"title": "Test Incident",
 				// missing description and affected_service
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			setupMock:      func(mockUC *MockIncidentUseCase) {},
 		},
 		{
@@ -140,10 +378,73 @@ func TestCreateIncident(t *testing.T) {
 	}
 }
 
+func TestCreateIncident_ReportsAllValidationErrors(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	jsonBody, _ := json.Marshal(map[string]interface{}{
+		"title":            "   ",
+		"description":      "Test Description",
+		"affected_service": strings.Repeat("s", domain.MaxAffectedServiceLength+1),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/incidents", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Test
+	err := handler.CreateIncident(c)
+
+	// Assertions
+	he, ok := err.(*echo.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+
+	body := he.Message.(map[string]interface{})
+	fields := body["fields"].([]domain.FieldError)
+	assert.Len(t, fields, 2)
+	assert.Equal(t, "title", fields[0].Field)
+	assert.Equal(t, domain.RuleRequired, fields[0].Rule)
+	assert.Equal(t, "affected_service", fields[1].Field)
+	assert.Equal(t, domain.RuleMax, fields[1].Rule)
+
+	mockUC.AssertNotCalled(t, "CreateIncident", mock.Anything, mock.Anything)
+}
+
+func TestCreateIncident_RejectsInvalidSanitizedRequest(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	// The request passes validation, but redacting the token lengthens the affected service
+	mockUC.On("CreateIncident", mock.Anything, mock.Anything).Return(nil, &domain.ValidationError{Fields: []domain.FieldError{
+		{Field: "affected_service", Rule: domain.RuleMax, Message: "affected_service must be at most 100 characters"},
+	}})
+
+	jsonBody, _ := json.Marshal(map[string]interface{}{
+		"title":            "Login failures",
+		"description":      "Auth fails",
+		"affected_service": strings.Repeat("s", domain.MaxAffectedServiceLength-8) + " token:a",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/incidents", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	err := handler.CreateIncident(e.NewContext(req, httptest.NewRecorder()))
+
+	he, ok := err.(*echo.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+	fields := he.Message.(map[string]interface{})["fields"].([]domain.FieldError)
+	assert.Equal(t, "affected_service", fields[0].Field)
+}
+
 func TestGetIncident(t *testing.T) {
 	tests := []struct {
 		name           string
 		incidentID     string
+		adminToken     string
 		expectedStatus int
 		setupMock      func(*MockIncidentUseCase)
 	}{
@@ -160,7 +461,7 @@ func TestGetIncident(t *testing.T) {
 					AISeverity:      "Medium",
 					AICategory:      "Software",
 				}
-				mockUC.On("GetIncident", 1).Return(expectedIncident, nil)
+				mockUC.On("GetIncident", mock.Anything, 1).Return(expectedIncident, nil)
 			},
 		},
 		{
@@ -174,7 +475,32 @@ func TestGetIncident(t *testing.T) {
 			incidentID:     "999",
 			expectedStatus: http.StatusNotFound,
 			setupMock: func(mockUC *MockIncidentUseCase) {
-				mockUC.On("GetIncident", 999).Return(nil, assert.AnError)
+				mockUC.On("GetIncident", mock.Anything, 999).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:           "typed not found",
+			incidentID:     "999",
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockUC *MockIncidentUseCase) {
+				mockUC.On("GetIncident", mock.Anything, 999).Return(nil, fmt.Errorf("incident not found with id 999: %w", domain.ErrNotFound))
+			},
+		},
+		{
+			name:           "deleted incident hidden from non-admins",
+			incidentID:     "7",
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockUC *MockIncidentUseCase) {
+				mockUC.On("GetIncident", mock.Anything, 7).Return(nil, fmt.Errorf("incident 7 was deleted: %w", domain.ErrDeleted))
+			},
+		},
+		{
+			name:           "deleted incident is gone for admins",
+			incidentID:     "7",
+			adminToken:     "secret",
+			expectedStatus: http.StatusGone,
+			setupMock: func(mockUC *MockIncidentUseCase) {
+				mockUC.On("GetIncident", mock.Anything, 7).Return(nil, fmt.Errorf("incident 7 was deleted: %w", domain.ErrDeleted))
 			},
 		},
 	}
@@ -184,11 +510,14 @@ func TestGetIncident(t *testing.T) {
 			// Setup
 			e := echo.New()
 			mockUC := new(MockIncidentUseCase)
-			handler := NewIncidentHandler(mockUC)
+			handler := NewIncidentHandler(mockUC, WithAdminToken("secret"))
 
 			tt.setupMock(mockUC)
 
 			req := httptest.NewRequest(http.MethodGet, "/incidents/"+tt.incidentID, nil)
+			if tt.adminToken != "" {
+				req.Header.Set(AdminTokenHeader, tt.adminToken)
+			}
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("id")
@@ -236,7 +565,8 @@ func TestGetAllIncidents(t *testing.T) {
 		},
 	}
 
-	mockUC.On("GetAllIncidents").Return(expectedIncidents, nil)
+	mockUC.On("GetListVersion", mock.Anything, &domain.IncidentFilter{}).Return(&domain.ListVersion{Count: 2}, nil)
+	mockUC.On("GetAllIncidentsPaginated", mock.Anything, &domain.IncidentFilter{}, 50, 0).Return(expectedIncidents, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/incidents", nil)
 	rec := httptest.NewRecorder()
@@ -258,25 +588,723 @@ func TestGetAllIncidents(t *testing.T) {
 	mockUC.AssertExpectations(t)
 }
 
-func TestHealthCheck(t *testing.T) {
-	// Setup
-	e := echo.New()
-	mockUC := new(MockIncidentUseCase)
-	handler := NewIncidentHandler(mockUC)
+func TestGetAllIncidents_Pagination(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedLimit  int
+		expectedOffset int
+		expectedStatus int
+	}{
+		{name: "second page", query: "?limit=10&offset=10", expectedLimit: 10, expectedOffset: 10, expectedStatus: http.StatusOK},
+		{name: "limit clamped to the maximum", query: "?limit=1000", expectedLimit: 200, expectedOffset: 0, expectedStatus: http.StatusOK},
+		{name: "negative offset", query: "?offset=-5", expectedStatus: http.StatusBadRequest},
+		{name: "non-numeric limit", query: "?limit=all", expectedStatus: http.StatusBadRequest},
+	}
 
-	req := httptest.NewRequest(http.MethodGet, "/health", nil)
-	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
 
-	// Test
-	err := handler.HealthCheck(c)
+			if tt.expectedStatus == http.StatusOK {
+				mockUC.On("GetListVersion", mock.Anything, &domain.IncidentFilter{}).Return(&domain.ListVersion{Count: 42}, nil)
+				mockUC.On("GetAllIncidentsPaginated", mock.Anything, &domain.IncidentFilter{}, tt.expectedLimit, tt.expectedOffset).
+					Return([]*domain.Incident{{ID: 1}}, nil)
+			}
 
-	// Assertions
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusOK, rec.Code)
+			req := httptest.NewRequest(http.MethodGet, "/incidents"+tt.query, nil)
+			rec := httptest.NewRecorder()
 
-	var response map[string]string
-	err = json.Unmarshal(rec.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.Equal(t, "healthy", response["status"])
+			err := handler.GetAllIncidents(e.NewContext(req, rec))
+
+			if tt.expectedStatus != http.StatusOK {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+				return
+			}
+			assert.NoError(t, err)
+
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, float64(1), response["count"])
+			assert.Equal(t, float64(42), response["total"])
+			assert.Equal(t, float64(tt.expectedLimit), response["limit"])
+			assert.Equal(t, float64(tt.expectedOffset), response["offset"])
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetSimilarIncidents(t *testing.T) {
+	tests := []struct {
+		name           string
+		incidentID     string
+		query          string
+		expectedStatus int
+		setupMock      func(*MockIncidentUseCase)
+	}{
+		{
+			name:           "default limit",
+			incidentID:     "1",
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockUC *MockIncidentUseCase) {
+				similar := []*domain.SimilarIncident{
+					{Incident: &domain.Incident{ID: 2, Title: "Similar Incident"}, Score: 0.92},
+				}
+				mockUC.On("FindSimilarIncidents", mock.Anything, 1, 5).Return(similar, nil)
+			},
+		},
+		{
+			name:           "custom limit",
+			incidentID:     "1",
+			query:          "?limit=10",
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockUC *MockIncidentUseCase) {
+				mockUC.On("FindSimilarIncidents", mock.Anything, 1, 10).Return([]*domain.SimilarIncident{}, nil)
+			},
+		},
+		{
+			name:           "limit too large is clamped",
+			incidentID:     "1",
+			query:          "?limit=100",
+			expectedStatus: http.StatusOK,
+			setupMock: func(mockUC *MockIncidentUseCase) {
+				mockUC.On("FindSimilarIncidents", mock.Anything, 1, 20).Return([]*domain.SimilarIncident{}, nil)
+			},
+		},
+		{
+			name:           "non-numeric limit",
+			incidentID:     "1",
+			query:          "?limit=abc",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockUC *MockIncidentUseCase) {},
+		},
+		{
+			name:           "invalid incident ID",
+			incidentID:     "invalid",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(mockUC *MockIncidentUseCase) {},
+		},
+		{
+			name:           "embeddings not configured",
+			incidentID:     "1",
+			expectedStatus: http.StatusServiceUnavailable,
+			setupMock: func(mockUC *MockIncidentUseCase) {
+				mockUC.On("FindSimilarIncidents", mock.Anything, 1, 5).Return(nil, domain.ErrEmbeddingsUnavailable)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
+
+			tt.setupMock(mockUC)
+
+			req := httptest.NewRequest(http.MethodGet, "/incidents/"+tt.incidentID+"/similar"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.incidentID)
+
+			// Test
+			err := handler.GetSimilarIncidents(c)
+
+			// Assertions
+			if err != nil {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+			} else {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetSeverityHistory(t *testing.T) {
+	tests := []struct {
+		name           string
+		incidentID     string
+		expectedStatus int
+		expectedCount  float64
+		setupMock      func(*MockIncidentUseCase)
+	}{
+		{
+			name:           "incident with changes",
+			incidentID:     "1",
+			expectedStatus: http.StatusOK,
+			expectedCount:  1,
+			setupMock: func(mockUC *MockIncidentUseCase) {
+				mockUC.On("GetSeverityHistory", mock.Anything, 1).Return([]*domain.HistoryEntry{
+					{IncidentID: 1, Field: domain.FieldAISeverity, OldValue: "Low", NewValue: "High", Actor: domain.ActorAI},
+				}, nil)
+			},
+		},
+		{
+			name:           "incident without changes",
+			incidentID:     "2",
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+			setupMock: func(mockUC *MockIncidentUseCase) {
+				mockUC.On("GetSeverityHistory", mock.Anything, 2).Return([]*domain.HistoryEntry{}, nil)
+			},
+		},
+		{
+			name:           "incident not found",
+			incidentID:     "999",
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(mockUC *MockIncidentUseCase) {
+				mockUC.On("GetSeverityHistory", mock.Anything, 999).Return(nil, assert.AnError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
+
+			tt.setupMock(mockUC)
+
+			req := httptest.NewRequest(http.MethodGet, "/incidents/"+tt.incidentID+"/severity-history", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.incidentID)
+
+			// Test
+			err := handler.GetSeverityHistory(c)
+
+			// Assertions
+			if err != nil {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+			} else {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var response map[string]interface{}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.NotNil(t, response["changes"])
+				assert.Equal(t, tt.expectedCount, response["count"])
+			}
+
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAllIncidents_Filters(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedFilter *domain.IncidentFilter
+		expectedStatus int
+	}{
+		{
+			name:           "single severity",
+			query:          "?severity=High",
+			expectedFilter: &domain.IncidentFilter{Severities: []string{"High"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "multiple severities and categories",
+			query:          "?severity=High,critical&category=Database,%20Network",
+			expectedFilter: &domain.IncidentFilter{Severities: []string{"High", "Critical"}, Categories: []string{"Database", "Network"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "include false positives",
+			query:          "?include_false_positive=true",
+			expectedFilter: &domain.IncidentFilter{IncludeFalsePositive: true},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "duplicate values collapsed",
+			query:          "?severity=High,High",
+			expectedFilter: &domain.IncidentFilter{Severities: []string{"High"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown severity",
+			query:          "?severity=High,Urgent",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty severity list",
+			query:          "?severity=,",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown category",
+			query:          "?category=Plumbing",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "custom field",
+			query:          "?severity=High&cf.region=eu",
+			expectedFilter: &domain.IncidentFilter{Severities: []string{"High"}, CustomFields: map[string]string{"region": "eu"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "custom field outside the schema",
+			query:          "?cf.team=payments",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty custom field value",
+			query:          "?cf.region=",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "minimum affected users",
+			query:          "?severity=High&min_affected_users=500",
+			expectedFilter: &domain.IncidentFilter{Severities: []string{"High"}, MinAffectedUsers: func() *int { v := 500; return &v }()},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "effective priority",
+			query:          "?priority=p1,P2",
+			expectedFilter: &domain.IncidentFilter{Priorities: []string{"P1", "P2"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown priority",
+			query:          "?priority=P5",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative minimum affected users",
+			query:          "?min_affected_users=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "non-numeric minimum affected users",
+			query:          "?min_affected_users=many",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "query expression",
+			query: "?q=" + url.QueryEscape("severity:critical AND service:auth"),
+			expectedFilter: &domain.IncidentFilter{Query: &domain.QueryAnd{
+				Left:  &domain.QueryTerm{Field: "severity", Op: ":", Value: "Critical"},
+				Right: &domain.QueryTerm{Field: "service", Op: ":", Value: "auth"},
+			}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "malformed query expression",
+			query:          "?q=" + url.QueryEscape("severity:High AND (owner:bob"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty query expression",
+			query:          "?q=%20",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC, WithCustomFieldSchema(domain.CustomFieldSchema{"region": domain.CustomFieldString}))
+
+			if tt.expectedFilter != nil {
+				mockUC.On("GetListVersion", mock.Anything, tt.expectedFilter).Return(&domain.ListVersion{}, nil)
+				mockUC.On("GetAllIncidentsPaginated", mock.Anything, tt.expectedFilter, 50, 0).Return([]*domain.Incident{}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/incidents"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Test
+			err := handler.GetAllIncidents(c)
+
+			// Assertions
+			if err != nil {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+			} else {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAllIncidents_SummaryProjection(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	summaries := []*domain.IncidentSummary{{ID: 1, Title: "Test Incident 1", AISeverity: "High", AICategory: "Network"}}
+	mockUC.On("GetListVersion", mock.Anything, &domain.IncidentFilter{}).Return(&domain.ListVersion{Count: 1}, nil)
+	mockUC.On("GetIncidentSummaries", mock.Anything, &domain.IncidentFilter{}, 50, 0).Return(summaries, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents?fields=summary", nil)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.GetAllIncidents(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Incidents []map[string]interface{} `json:"incidents"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Len(t, response.Incidents, 1)
+	assert.Equal(t, "High", response.Incidents[0]["ai_severity"])
+	assert.NotContains(t, response.Incidents[0], "description")
+	mockUC.AssertNotCalled(t, "GetAllIncidents", mock.Anything, mock.Anything)
+}
+
+func TestGetAllIncidents_InvalidFields(t *testing.T) {
+	e := echo.New()
+	handler := NewIncidentHandler(new(MockIncidentUseCase))
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents?fields=everything", nil)
+	err := handler.GetAllIncidents(e.NewContext(req, httptest.NewRecorder()))
+
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+func TestGetAllIncidents_ETag(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	version := &domain.ListVersion{Count: 2, MaxUpdatedAt: updatedAt, Checksum: 0xbeef, AsOf: updatedAt}
+
+	listWith := func(query, ifNoneMatch string, current *domain.ListVersion) (*httptest.ResponseRecorder, *MockIncidentUseCase) {
+		e := echo.New()
+		mockUC := new(MockIncidentUseCase)
+		handler := NewIncidentHandler(mockUC)
+
+		mockUC.On("GetCurrentListVersion", mock.Anything, mock.Anything).Return(current, nil)
+		mockUC.On("GetListVersion", mock.Anything, mock.Anything).Return(version, nil)
+		mockUC.On("GetAllIncidentsPaginated", mock.Anything, mock.Anything, 50, 0).Return([]*domain.Incident{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/incidents"+query, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set(headerIfNoneMatch, ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		assert.NoError(t, handler.GetAllIncidents(e.NewContext(req, rec)))
+		return rec, mockUC
+	}
+
+	// First request returns the list and its ETag without asking the writer
+	rec, mockUC := listWith("", "", version)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get(headerETag)
+	assert.True(t, strings.HasPrefix(etag, `W/"`))
+	mockUC.AssertNotCalled(t, "GetCurrentListVersion", mock.Anything, mock.Anything)
+
+	// Matching If-None-Match returns 304 without loading the list
+	rec, mockUC = listWith("", etag, version)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	mockUC.AssertNotCalled(t, "GetAllIncidentsPaginated", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// A different filter over identical data yields a different ETag
+	rec, _ = listWith("?severity=High", etag, version)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, etag, rec.Header().Get(headerETag))
+
+	changes := map[string]*domain.ListVersion{
+		"an edit within the same second":   {Count: 2, MaxUpdatedAt: updatedAt, Checksum: 0xcafe, AsOf: updatedAt},
+		"a write the replica has not seen": {Count: 3, MaxUpdatedAt: updatedAt.Add(time.Millisecond), Checksum: 0xbeef, AsOf: updatedAt},
+		"ages moving on":                   {Count: 2, MaxUpdatedAt: updatedAt, Checksum: 0xbeef, AsOf: updatedAt.Add(time.Minute)},
+	}
+	for name, current := range changes {
+		t.Run(name, func(t *testing.T) {
+			rec, _ := listWith("", etag, current)
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
+
+func TestEtagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`W/"1-2-3"`, `W/"1-2-3"`))
+	assert.True(t, etagMatches(`"1-2-3"`, `W/"1-2-3"`))
+	assert.True(t, etagMatches(`"a", W/"1-2-3"`, `W/"1-2-3"`))
+	assert.True(t, etagMatches(`*`, `W/"1-2-3"`))
+	assert.False(t, etagMatches(``, `W/"1-2-3"`))
+	assert.False(t, etagMatches(`W/"1-2-4"`, `W/"1-2-3"`))
+}
+
+func TestHealthCheck(t *testing.T) {
+	// Setup
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Test
+	err := handler.HealthCheck(c)
+
+	// Assertions
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response map[string]string
+	err = json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "healthy", response["status"])
+}
+
+func TestGetTriageQueue(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockIncidentUseCase)
+		expectedStatus int
+	}{
+		{
+			name:  "default page",
+			query: "",
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("GetTriageQueue", mock.Anything, 50, 0).Return([]*domain.Incident{{ID: 3, AISeverity: "Critical"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "explicit page",
+			query: "?limit=10&offset=20",
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("GetTriageQueue", mock.Anything, 10, 20).Return([]*domain.Incident{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "cursor is not supported",
+			query:          "?cursor=" + encodeCursor(&domain.Cursor{ID: 1, CreatedAt: time.Now()}),
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
+			tt.setupMock(mockUC)
+
+			req := httptest.NewRequest(http.MethodGet, "/incidents/queue"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			err := handler.GetTriageQueue(e.NewContext(req, rec))
+			if err != nil {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+			} else {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateIncident_DryRun(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	duplicateOf := 42
+	mockUC.On("PreviewIncident", mock.Anything, mock.AnythingOfType("*domain.CreateIncidentRequest")).Return(&domain.IncidentPreview{
+		Incident:    &domain.Incident{Title: "Disk full", AISeverity: "High"},
+		Outcome:     domain.PreviewDuplicate,
+		DuplicateOf: &duplicateOf,
+	}, nil)
+
+	jsonBody, _ := json.Marshal(map[string]interface{}{
+		"title":            "Disk full",
+		"description":      "Root volume at 100%",
+		"affected_service": "storage",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/incidents?dry_run=true", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.CreateIncident(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"outcome":"duplicate"`)
+	assert.Contains(t, rec.Body.String(), `"duplicate_of":42`)
+	mockUC.AssertNotCalled(t, "CreateIncident", mock.Anything, mock.Anything)
+}
+
+func TestCreateIncident_Timings(t *testing.T) {
+	tests := []struct {
+		name        string
+		debug       bool
+		query       string
+		wantTimings bool
+	}{
+		{name: "debug disabled", debug: false, query: "?timing=true", wantTimings: false},
+		{name: "not requested", debug: true, query: "", wantTimings: false},
+		{name: "requested", debug: true, query: "?timing=true", wantTimings: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC, WithDebugTimings(tt.debug))
+
+			mockUC.On("CreateIncident", mock.Anything, mock.AnythingOfType("*domain.CreateIncidentRequest")).Return(&domain.Incident{
+				ID:      1,
+				Title:   "Disk full",
+				Timings: &domain.CreateTimings{AIMs: 1200, DBMs: 30, TotalMs: 1230},
+			}, nil)
+
+			jsonBody, _ := json.Marshal(map[string]interface{}{
+				"title":            "Disk full",
+				"description":      "Root volume at 100%",
+				"affected_service": "storage",
+			})
+			req := httptest.NewRequest(http.MethodPost, "/incidents"+tt.query, bytes.NewReader(jsonBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			assert.NoError(t, handler.CreateIncident(e.NewContext(req, rec)))
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			if tt.wantTimings {
+				assert.Contains(t, rec.Body.String(), `"timings":{"ai_ms":1200,"db_ms":30,"total_ms":1230}`)
+			} else {
+				assert.NotContains(t, rec.Body.String(), `"timings"`)
+			}
+		})
+	}
+}
+
+func TestCreateIncident_AllowDuplicate(t *testing.T) {
+	for _, allow := range []bool{false, true} {
+		e := echo.New()
+		mockUC := new(MockIncidentUseCase)
+		handler := NewIncidentHandler(mockUC)
+
+		mockUC.On("CreateIncident", mock.Anything, mock.MatchedBy(func(req *domain.CreateIncidentRequest) bool {
+			return req.AllowDuplicate == allow
+		})).Return(nil, domain.ErrDuplicate)
+
+		jsonBody, _ := json.Marshal(map[string]interface{}{
+			"title":            "Disk full",
+			"description":      "Root volume at 100%",
+			"affected_service": "storage",
+		})
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/incidents?allow_duplicate=%t", allow), bytes.NewReader(jsonBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		err := handler.CreateIncident(e.NewContext(req, httptest.NewRecorder()))
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, httpErr.Code)
+		mockUC.AssertExpectations(t)
+	}
+}
+
+func TestCreateIncident_ServiceCatalog(t *testing.T) {
+	services := []string{"auth-service", "search-api"}
+	create := func(handler *IncidentHandler, service string) (*httptest.ResponseRecorder, error) {
+		jsonBody, _ := json.Marshal(map[string]interface{}{
+			"title":            "Login failures",
+			"description":      "Users cannot sign in",
+			"affected_service": service,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/incidents", bytes.NewReader(jsonBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		return rec, handler.CreateIncident(echo.New().NewContext(req, rec))
+	}
+
+	t.Run("soft mode accepts unknown services", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("CreateIncident", mock.Anything, mock.AnythingOfType("*domain.CreateIncidentRequest")).
+			Return(&domain.Incident{ID: 1, AffectedService: "auth-servce", UnknownService: true}, nil)
+		handler := NewIncidentHandler(mockUC, WithServiceCatalog(&domain.ServiceCatalog{Services: services}))
+
+		rec, err := create(handler, "auth-servce")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"unknown_service":true`)
+	})
+
+	t.Run("strict mode rejects unknown services with a suggestion", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		handler := NewIncidentHandler(mockUC, WithServiceCatalog(&domain.ServiceCatalog{Services: services, Strict: true}))
+
+		_, err := create(handler, "auth-servce")
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, he.Code)
+
+		fields := he.Message.(map[string]interface{})["fields"].([]domain.FieldError)
+		assert.Equal(t, []domain.FieldError{{
+			Field:   "affected_service",
+			Rule:    domain.RuleUnknown,
+			Message: `affected_service "auth-servce" is not a known service; did you mean "auth-service"?`,
+		}}, fields)
+		mockUC.AssertNotCalled(t, "CreateIncident", mock.Anything, mock.Anything)
+	})
+
+	t.Run("strict mode accepts known services", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("CreateIncident", mock.Anything, mock.AnythingOfType("*domain.CreateIncidentRequest")).
+			Return(&domain.Incident{ID: 1, AffectedService: "Auth-Service"}, nil)
+		handler := NewIncidentHandler(mockUC, WithServiceCatalog(&domain.ServiceCatalog{Services: services, Strict: true}))
+
+		rec, err := create(handler, "Auth-Service")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+}
+
+func TestCreateIncident_SuppressedByStorm(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	storm := &domain.Incident{ID: 99, Title: "Alert storm: checkout", AffectedService: "checkout", Suppressed: true, CustomFields: map[string]interface{}{domain.StormCountField: 3}}
+	mockUC.On("CreateIncident", mock.Anything, mock.AnythingOfType("*domain.CreateIncidentRequest")).Return(storm, nil)
+
+	jsonBody, _ := json.Marshal(map[string]interface{}{
+		"title":            "5xx",
+		"description":      "Checkout errors",
+		"affected_service": "checkout",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/incidents", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.CreateIncident(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var response struct {
+		Message  string          `json:"message"`
+		Incident domain.Incident `json:"incident"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "Incident suppressed into alert storm", response.Message)
+	assert.True(t, response.Incident.Suppressed)
+	assert.Equal(t, float64(3), response.Incident.CustomFields[domain.StormCountField])
 }
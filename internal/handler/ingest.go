@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// payloadTransformer maps a monitoring tool's webhook body into a create request
+type payloadTransformer func(body []byte) (*domain.CreateIncidentRequest, error)
+
+// ingestTransformers registers the sources accepted by POST /incidents/ingest/:source.
+// Adding a source means writing a transformer and registering it here.
+var ingestTransformers = map[string]payloadTransformer{
+	"datadog":   transformDatadog,
+	"pagerduty": transformPagerDuty,
+}
+
+// IngestIncident handles POST /incidents/ingest/:source
+func (h *IncidentHandler) IngestIncident(c echo.Context) error {
+	if !h.featureEnabled(domain.FlagIngest) {
+		return echo.NewHTTPError(http.StatusForbidden, "Ingestion is disabled")
+	}
+
+	transform, ok := ingestTransformers[strings.ToLower(c.Param("source"))]
+	if !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, "Unknown ingest source")
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	req, err := transform(body)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid "+c.Param("source")+" payload")
+	}
+
+	return h.createIncident(c, req)
+}
+
+// datadogPayload is the subset of a Datadog webhook body used for triage
+type datadogPayload struct {
+	Title string          `json:"title"`
+	Body  string          `json:"body"`
+	Tags  json.RawMessage `json:"tags"`
+}
+
+// transformDatadog maps a Datadog webhook. The affected service comes from the service: tag.
+func transformDatadog(body []byte) (*domain.CreateIncidentRequest, error) {
+	var payload datadogPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	return &domain.CreateIncidentRequest{
+		Title:           payload.Title,
+		Description:     firstNonEmpty(payload.Body, payload.Title),
+		AffectedService: datadogTag(payload.Tags, "service"),
+	}, nil
+}
+
+// datadogTag returns the value of a key:value tag. Datadog sends tags either as a
+// comma-separated string or as an array depending on the webhook template.
+func datadogTag(raw json.RawMessage, key string) string {
+	var tags []string
+	var joined string
+	if err := json.Unmarshal(raw, &joined); err == nil {
+		tags = strings.Split(joined, ",")
+	} else {
+		_ = json.Unmarshal(raw, &tags)
+	}
+
+	for _, tag := range tags {
+		name, value, found := strings.Cut(strings.TrimSpace(tag), ":")
+		if found && name == key {
+			return value
+		}
+	}
+	return ""
+}
+
+// pagerDutyPayload is the subset of a PagerDuty v3 webhook body used for triage
+type pagerDutyPayload struct {
+	Event struct {
+		Data struct {
+			Title   string `json:"title"`
+			Summary string `json:"summary"`
+			Body    struct {
+				Details string `json:"details"`
+			} `json:"body"`
+			Service struct {
+				Summary string `json:"summary"`
+			} `json:"service"`
+		} `json:"data"`
+	} `json:"event"`
+}
+
+// transformPagerDuty maps a PagerDuty v3 incident webhook
+func transformPagerDuty(body []byte) (*domain.CreateIncidentRequest, error) {
+	var payload pagerDutyPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	data := payload.Event.Data
+	title := firstNonEmpty(data.Title, data.Summary)
+	return &domain.CreateIncidentRequest{
+		Title:           title,
+		Description:     firstNonEmpty(data.Body.Details, title),
+		AffectedService: data.Service.Summary,
+	}, nil
+}
+
+// firstNonEmpty returns the first value that is not blank
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
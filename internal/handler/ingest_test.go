@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIngestTransformers(t *testing.T) {
+	tests := []struct {
+		source   string
+		payload  string
+		expected *domain.CreateIncidentRequest
+	}{
+		{
+			source: "datadog",
+			payload: `{
+				"id": "7260358491",
+				"title": "[Triggered] High error rate on checkout",
+				"body": "Error rate above 5% for the last 10 minutes",
+				"alert_type": "error",
+				"tags": "env:prod,service:checkout-api,team:payments"
+			}`,
+			expected: &domain.CreateIncidentRequest{
+				Title:           "[Triggered] High error rate on checkout",
+				Description:     "Error rate above 5% for the last 10 minutes",
+				AffectedService: "checkout-api",
+			},
+		},
+		{
+			source: "pagerduty",
+			payload: `{
+				"event": {
+					"id": "01DEN1HFLDO6Q4QAFKXN8WPDCJ",
+					"event_type": "incident.triggered",
+					"data": {
+						"id": "PGR0VU2",
+						"type": "incident",
+						"title": "Database connection pool exhausted",
+						"service": {"id": "PF9KMXH", "summary": "User Authentication Service"},
+						"body": {"details": "All connections in use for 3 minutes"}
+					}
+				}
+			}`,
+			expected: &domain.CreateIncidentRequest{
+				Title:           "Database connection pool exhausted",
+				Description:     "All connections in use for 3 minutes",
+				AffectedService: "User Authentication Service",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			req, err := ingestTransformers[tt.source]([]byte(tt.payload))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, req)
+		})
+	}
+}
+
+func TestDatadogTag_ArrayTags(t *testing.T) {
+	assert.Equal(t, "billing", datadogTag([]byte(`["env:prod","service:billing"]`), "service"))
+	assert.Equal(t, "", datadogTag(nil, "service"))
+}
+
+func TestIngestIncident(t *testing.T) {
+	tests := []struct {
+		name           string
+		source         string
+		payload        string
+		setupMock      func(*MockIncidentUseCase)
+		expectedStatus int
+	}{
+		{
+			name:    "known source runs the create flow",
+			source:  "datadog",
+			payload: `{"title":"Disk full","body":"Root volume at 100%","tags":"service:storage"}`,
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("CreateIncident", mock.Anything, &domain.CreateIncidentRequest{
+					Title:           "Disk full",
+					Description:     "Root volume at 100%",
+					AffectedService: "storage",
+				}).Return(&domain.Incident{ID: 1, Title: "Disk full"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "unknown source",
+			source:         "nagios",
+			payload:        `{}`,
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "malformed payload",
+			source:         "pagerduty",
+			payload:        `not json`,
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "payload missing required fields",
+			source:         "datadog",
+			payload:        `{"title":"Disk full"}`,
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
+			tt.setupMock(mockUC)
+
+			req := httptest.NewRequest(http.MethodPost, "/incidents/ingest/"+tt.source, strings.NewReader(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("source")
+			c.SetParamValues(tt.source)
+
+			err := handler.IngestIncident(c)
+			if err != nil {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+				mockUC.AssertNotCalled(t, "CreateIncident", mock.Anything, mock.Anything)
+			} else {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+				mockUC.AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// pageLimits bounds the limit query parameter of a paginated endpoint
+type pageLimits struct {
+	Default         int
+	Max             int
+	RejectOversized bool
+}
+
+// pageParams holds validated pagination query parameters
+type pageParams struct {
+	Limit  int
+	Offset int
+	Cursor *domain.Cursor
+}
+
+// parsePageParams parses the limit, offset and cursor query parameters.
+// The limit is clamped to [1, limits.Max], or rejected above the maximum when limits.RejectOversized is set;
+// malformed values, negative offsets and undecodable cursors are rejected with 400.
+func parsePageParams(c echo.Context, limits pageLimits) (*pageParams, error) {
+	params := &pageParams{Limit: limits.Default}
+
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid limit: must be an integer")
+		}
+		params.Limit = limit
+	}
+	if params.Limit < 1 {
+		params.Limit = 1
+	}
+	if params.Limit > limits.Max {
+		if limits.RejectOversized {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid limit: must not exceed %d", limits.Max))
+		}
+		params.Limit = limits.Max
+	}
+
+	if offsetStr := c.QueryParam("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid offset: must be an integer")
+		}
+		if offset < 0 {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid offset: must not be negative")
+		}
+		params.Offset = offset
+	}
+
+	if cursorStr := c.QueryParam("cursor"); cursorStr != "" {
+		cursor, err := decodeCursor(cursorStr)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+		params.Cursor = cursor
+	}
+
+	return params, nil
+}
+
+// encodeCursor serializes a cursor into an opaque URL-safe token
+func encodeCursor(cursor *domain.Cursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor parses a token produced by encodeCursor
+func decodeCursor(token string) (*domain.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor domain.Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, err
+	}
+
+	if cursor.ID < 1 || cursor.CreatedAt.IsZero() {
+		return nil, errors.New("cursor is missing its position")
+	}
+
+	return &cursor, nil
+}
+
+// pageToken is the keyset position carried by a paged export continuation token
+type pageToken struct {
+	AfterID int `json:"after_id"`
+}
+
+// encodePageToken serializes an export position into an opaque URL-safe token
+func encodePageToken(afterID int) string {
+	raw, _ := json.Marshal(pageToken{AfterID: afterID})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodePageToken parses a token produced by encodePageToken. An empty token starts from the beginning.
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+
+	var position pageToken
+	if err := json.Unmarshal(raw, &position); err != nil {
+		return 0, err
+	}
+
+	if position.AfterID < 1 {
+		return 0, errors.New("page token is missing its position")
+	}
+
+	return position.AfterID, nil
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePageParams(t *testing.T) {
+	validCursor := encodeCursor(&domain.Cursor{CreatedAt: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), ID: 42})
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedLimit  int
+		expectedOffset int
+		expectedCursor *domain.Cursor
+		expectedStatus int
+	}{
+		{name: "defaults", query: "", expectedLimit: 50},
+		{name: "explicit limit", query: "?limit=10", expectedLimit: 10},
+		{name: "minimum limit", query: "?limit=1", expectedLimit: 1},
+		{name: "maximum limit", query: "?limit=200", expectedLimit: 200},
+		{name: "zero limit clamped up", query: "?limit=0", expectedLimit: 1},
+		{name: "negative limit clamped up", query: "?limit=-5", expectedLimit: 1},
+		{name: "oversized limit clamped down", query: "?limit=201", expectedLimit: 200},
+		{name: "non-numeric limit", query: "?limit=ten", expectedStatus: http.StatusBadRequest},
+		{name: "zero offset", query: "?offset=0", expectedLimit: 50},
+		{name: "positive offset", query: "?offset=100", expectedLimit: 50, expectedOffset: 100},
+		{name: "negative offset", query: "?offset=-1", expectedStatus: http.StatusBadRequest},
+		{name: "non-numeric offset", query: "?offset=x", expectedStatus: http.StatusBadRequest},
+		{
+			name:           "valid cursor",
+			query:          "?cursor=" + validCursor,
+			expectedLimit:  50,
+			expectedCursor: &domain.Cursor{CreatedAt: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), ID: 42},
+		},
+		{name: "garbage cursor", query: "?cursor=not-a-cursor", expectedStatus: http.StatusBadRequest},
+		{name: "cursor without id", query: "?cursor=" + encodeCursor(&domain.Cursor{CreatedAt: time.Now()}), expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/incidents"+tt.query, nil)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			params, err := parsePageParams(c, pageLimits{Default: 50, Max: 200})
+
+			if tt.expectedStatus != 0 {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+				assert.Nil(t, params)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLimit, params.Limit)
+			assert.Equal(t, tt.expectedOffset, params.Offset)
+			assert.Equal(t, tt.expectedCursor, params.Cursor)
+		})
+	}
+}
+
+func TestParsePageParams_RejectOversized(t *testing.T) {
+	limits := pageLimits{Default: 20, Max: 100, RejectOversized: true}
+
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/incidents?limit=100", nil), httptest.NewRecorder())
+	params, err := parsePageParams(c, limits)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, params.Limit)
+
+	c = e.NewContext(httptest.NewRequest(http.MethodGet, "/incidents?limit=101", nil), httptest.NewRecorder())
+	params, err = parsePageParams(c, limits)
+	he, ok := err.(*echo.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, he.Code)
+	assert.Nil(t, params)
+
+	c = e.NewContext(httptest.NewRequest(http.MethodGet, "/incidents", nil), httptest.NewRecorder())
+	params, err = parsePageParams(c, limits)
+	assert.NoError(t, err)
+	assert.Equal(t, 20, params.Limit)
+}
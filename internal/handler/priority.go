@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SetPriorityOverride handles POST /incidents/:id/priority. An empty priority clears the override.
+func (h *IncidentHandler) SetPriorityOverride(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid incident ID")
+	}
+
+	var req domain.PriorityRequest
+	if err := c.Bind(&req); err != nil {
+		return bindFailed(err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return validationFailed(err)
+	}
+
+	incident, err := h.incidentUseCase.SetPriorityOverride(c.Request().Context(), id, req.Priority)
+	if err != nil {
+		if httpErr := h.missingIncident(c, err); httpErr != nil {
+			return httpErr
+		}
+		if errors.Is(err, domain.ErrFalsePositive) {
+			return echo.NewHTTPError(http.StatusConflict, "Cannot reprioritize an incident marked as a false positive")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to set incident priority: "+err.Error())
+	}
+
+	message := "Incident priority overridden"
+	if req.Priority == "" {
+		message = "Incident priority override cleared"
+	}
+	return h.respond(c, http.StatusOK, incident, map[string]interface{}{"message": message}, map[string]interface{}{
+		"message":  message,
+		"incident": incident,
+	})
+}
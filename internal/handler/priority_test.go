@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetPriorityOverride(t *testing.T) {
+	post := func(handler *IncidentHandler, body string) (*httptest.ResponseRecorder, error) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/incidents/7/priority", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("7")
+		return rec, handler.SetPriorityOverride(c)
+	}
+
+	t.Run("sets the canonical priority", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("SetPriorityOverride", mock.Anything, 7, "P1").
+			Return(&domain.Incident{ID: 7, AISeverity: "Low", PriorityOverride: "P1", Priority: "P1"}, nil)
+
+		rec, err := post(NewIncidentHandler(mockUC), `{"priority": " p1 "}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"priority_override":"P1"`)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("empty priority clears the override", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("SetPriorityOverride", mock.Anything, 7, "").Return(&domain.Incident{ID: 7, AISeverity: "Low", Priority: "P4"}, nil)
+
+		rec, err := post(NewIncidentHandler(mockUC), `{"priority": ""}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "override cleared")
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("unknown priority", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+
+		_, err := post(NewIncidentHandler(mockUC), `{"priority": "P0"}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+		mockUC.AssertNotCalled(t, "SetPriorityOverride", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("false positive", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("SetPriorityOverride", mock.Anything, 7, "P2").Return(nil, domain.ErrFalsePositive)
+
+		_, err := post(NewIncidentHandler(mockUC), `{"priority": "P2"}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, httpErr.Code)
+	})
+
+	t.Run("missing incident", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("SetPriorityOverride", mock.Anything, 7, "P2").Return(nil, domain.ErrNotFound)
+
+		_, err := post(NewIncidentHandler(mockUC), `{"priority": "P2"}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+	})
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"net/http"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetDataQuality handles GET /admin/incidents/quality. It reports how many incidents have
+// each data quality issue; ?fix=trim first trims surrounding whitespace from text fields.
+func (h *IncidentHandler) GetDataQuality(c echo.Context) error {
+	fix := c.QueryParam("fix")
+	if fix != "" && fix != domain.QualityFixTrim {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid fix: must be trim")
+	}
+
+	report, err := h.incidentUseCase.CheckDataQuality(c.Request().Context(), fix)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check data quality: "+err.Error())
+	}
+
+	return h.respond(c, http.StatusOK, report, nil, report)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetDataQuality(t *testing.T) {
+	issues := []*domain.QualityCount{
+		{Issue: domain.QualityMissingDescription, Count: 2},
+		{Issue: domain.QualityUntrimmed, Count: 0},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockIncidentUseCase)
+		expectedStatus int
+		expectedFixed  int
+	}{
+		{
+			name:  "report only",
+			query: "",
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("CheckDataQuality", mock.Anything, "").Return(&domain.QualityReport{Issues: issues}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "trim fix",
+			query: "?fix=trim",
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("CheckDataQuality", mock.Anything, domain.QualityFixTrim).Return(&domain.QualityReport{Issues: issues, Fix: domain.QualityFixTrim, Fixed: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFixed:  4,
+		},
+		{
+			name:           "unknown fix",
+			query:          "?fix=delete",
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
+			tt.setupMock(mockUC)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/incidents/quality"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			err := handler.GetDataQuality(e.NewContext(req, rec))
+			if err != nil {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+			} else {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+
+				var body domain.QualityReport
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, issues, body.Issues)
+				assert.Equal(t, tt.expectedFixed, body.Fixed)
+			}
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ReassignIncidents handles POST /incidents/reassign. Every incident assigned to from, except
+// false positives, moves to to, optionally only those with the given severity or affected service.
+func (h *IncidentHandler) ReassignIncidents(c echo.Context) error {
+	var req domain.ReassignRequest
+	if err := c.Bind(&req); err != nil {
+		return bindFailed(err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return validationFailed(err)
+	}
+
+	scope := domain.ReassignScope{Severity: req.Severity, AffectedService: req.AffectedService}
+	result, err := h.incidentUseCase.ReassignIncidents(c.Request().Context(), req.From, req.To, scope)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to reassign incidents: "+err.Error())
+	}
+
+	return h.respond(c, http.StatusOK, result, nil, result)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReassignIncidents(t *testing.T) {
+	post := func(handler *IncidentHandler, body string) (*httptest.ResponseRecorder, error) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/incidents/reassign", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		return rec, handler.ReassignIncidents(e.NewContext(req, rec))
+	}
+
+	t.Run("reassigns within the scope", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		scope := domain.ReassignScope{Severity: "Critical", AffectedService: "payment-gateway"}
+		mockUC.On("ReassignIncidents", mock.Anything, "alice", "bob", scope).
+			Return(&domain.ReassignResult{Reassigned: 2, IncidentIDs: []int{3, 8}}, nil)
+
+		rec, err := post(NewIncidentHandler(mockUC), `{"from": " alice ", "to": "bob", "severity": "critical", "affected_service": "payment-gateway"}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"reassigned": 2, "incident_ids": [3, 8]}`, rec.Body.String())
+		mockUC.AssertExpectations(t)
+	})
+
+	invalid := map[string]string{
+		"missing from":     `{"to": "bob"}`,
+		"missing to":       `{"from": "alice"}`,
+		"same assignee":    `{"from": "alice", "to": "alice"}`,
+		"unknown severity": `{"from": "alice", "to": "bob", "severity": "Urgent"}`,
+	}
+	for name, body := range invalid {
+		t.Run(name, func(t *testing.T) {
+			mockUC := new(MockIncidentUseCase)
+
+			_, err := post(NewIncidentHandler(mockUC), body)
+
+			httpErr, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+			mockUC.AssertNotCalled(t, "ReassignIncidents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
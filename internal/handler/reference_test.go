@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIncidentID_ByReference(t *testing.T) {
+	newContext := func(method, id, body string) (echo.Context, *httptest.ResponseRecorder) {
+		e := echo.New()
+		req := httptest.NewRequest(method, "/incidents/"+id, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		return c, rec
+	}
+
+	t.Run("get by numeric ID does not resolve a reference", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("GetIncident", mock.Anything, 42).Return(&domain.Incident{ID: 42, Reference: "INC-2024-000123"}, nil)
+
+		c, rec := newContext(http.MethodGet, "42", "")
+		assert.NoError(t, NewIncidentHandler(mockUC).GetIncident(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUC.AssertNotCalled(t, "ResolveReference", mock.Anything, mock.Anything)
+	})
+
+	t.Run("get by reference", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("ResolveReference", mock.Anything, "inc-2024-000123").Return(42, nil)
+		mockUC.On("GetIncident", mock.Anything, 42).Return(&domain.Incident{ID: 42, Reference: "INC-2024-000123"}, nil)
+
+		c, rec := newContext(http.MethodGet, "inc-2024-000123", "")
+		assert.NoError(t, NewIncidentHandler(mockUC).GetIncident(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"reference":"INC-2024-000123"`)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("unknown reference", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("ResolveReference", mock.Anything, "INC-2024-999999").Return(0, domain.ErrNotFound)
+
+		c, _ := newContext(http.MethodGet, "INC-2024-999999", "")
+		err := NewIncidentHandler(mockUC).GetIncident(c)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+		mockUC.AssertNotCalled(t, "GetIncident", mock.Anything, mock.Anything)
+	})
+
+	t.Run("configured format", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		handler := NewIncidentHandler(mockUC, WithReferenceFormat(domain.ReferenceFormat{Pattern: "OPS-{seq}", Digits: 4}))
+
+		c, _ := newContext(http.MethodGet, "INC-2024-000123", "")
+		err := handler.GetIncident(c)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+		mockUC.AssertNotCalled(t, "ResolveReference", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update by reference", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("ResolveReference", mock.Anything, "INC-2024-000123").Return(42, nil)
+		mockUC.On("UpdateIncident", mock.Anything, 42, mock.AnythingOfType("*domain.CreateIncidentRequest")).
+			Return(&domain.Incident{ID: 42, Reference: "INC-2024-000123", Title: "Checkout errors"}, nil)
+
+		c, rec := newContext(http.MethodPut, "INC-2024-000123",
+			`{"title": "Checkout errors", "description": "5xx from checkout", "affected_service": "checkout"}`)
+		assert.NoError(t, NewIncidentHandler(mockUC).UpdateIncident(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUC.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RemapSeverity handles POST /admin/incidents/remap-severity. With ?dry_run=true only the
+// number of incidents each mapping would change is returned.
+func (h *IncidentHandler) RemapSeverity(c echo.Context) error {
+	var req domain.RemapSeverityRequest
+	if err := c.Bind(&req); err != nil {
+		return bindFailed(err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return validationFailed(err)
+	}
+
+	result, err := h.incidentUseCase.RemapSeverity(c.Request().Context(), req.Mapping, c.QueryParam("dry_run") == "true")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to remap severities: "+err.Error())
+	}
+
+	return h.respond(c, http.StatusOK, result, nil, result)
+}
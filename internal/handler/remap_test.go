@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRemapSeverity(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	mockUC.On("RemapSeverity", mock.Anything, map[string]string{"Sev2": "High"}, true).
+		Return(&domain.RemapResult{DryRun: true, Remapped: map[string]int{"Sev2": 4}, Total: 4}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/incidents/remap-severity?dry_run=true", strings.NewReader(`{"mapping": {"Sev2": "high"}}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.RemapSeverity(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"dry_run": true, "remapped": {"Sev2": 4}, "total": 4}`, rec.Body.String())
+	mockUC.AssertExpectations(t)
+}
+
+func TestRemapSeverity_RejectsUnknownSeverity(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/incidents/remap-severity", strings.NewReader(`{"mapping": {"Medium": "Medium-High"}}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	err := handler.RemapSeverity(e.NewContext(req, httptest.NewRecorder()))
+
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+	mockUC.AssertNotCalled(t, "RemapSeverity", mock.Anything, mock.Anything, mock.Anything)
+}
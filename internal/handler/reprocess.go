@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// reprocessLimits bounds how many failed analyses one reprocess request retries
+var reprocessLimits = pageLimits{Default: 100, Max: 500}
+
+// ReprocessFailedAnalyses handles POST /admin/incidents/reprocess-failed
+func (h *IncidentHandler) ReprocessFailedAnalyses(c echo.Context) error {
+	params, err := parsePageParams(c, reprocessLimits)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.incidentUseCase.ReprocessFailedAnalyses(c.Request().Context(), params.Limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrTriageOff) {
+			return echo.NewHTTPError(http.StatusConflict, "AI triage is off")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to reprocess incidents: "+err.Error())
+	}
+
+	return h.respond(c, http.StatusOK, result, nil, result)
+}
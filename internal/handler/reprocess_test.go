@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReprocessFailedAnalyses(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	mockUC.On("ReprocessFailedAnalyses", mock.Anything, 500).Return(&domain.ReprocessResult{Attempted: 3, Fixed: 2, StillFailing: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/incidents/reprocess-failed?limit=1000", nil)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.ReprocessFailedAnalyses(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body domain.ReprocessResult
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, domain.ReprocessResult{Attempted: 3, Fixed: 2, StillFailing: 1}, body)
+	mockUC.AssertExpectations(t)
+}
+
+func TestReprocessFailedAnalyses_Error(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	mockUC.On("ReprocessFailedAnalyses", mock.Anything, 100).Return(nil, errors.New("database error"))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/incidents/reprocess-failed", nil)
+	err := handler.ReprocessFailedAnalyses(e.NewContext(req, httptest.NewRecorder()))
+
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+}
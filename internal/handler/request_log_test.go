@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/logging"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		provided string
+		keep     bool
+	}{
+		{name: "generated when missing", provided: ""},
+		{name: "caller ID kept", provided: "trace-7f3a", keep: true},
+		{name: "oversized caller ID replaced", provided: strings.Repeat("a", maxRequestIDLength+1)},
+		{name: "caller ID with spaces replaced", provided: "not an id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/incidents", nil)
+			if tt.provided != "" {
+				req.Header.Set(RequestIDHeader, tt.provided)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var seen string
+			next := func(c echo.Context) error {
+				seen = logging.RequestID(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			}
+
+			require.NoError(t, RequestID()(next)(c))
+
+			id := rec.Header().Get(RequestIDHeader)
+			assert.NotEmpty(t, id)
+			assert.Equal(t, id, seen)
+			if tt.keep {
+				assert.Equal(t, tt.provided, id)
+			} else {
+				assert.NotEqual(t, tt.provided, id)
+				assert.Len(t, id, 32)
+			}
+		})
+	}
+}
+
+func TestLogRequests(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&buf))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	e := echo.New()
+	e.Use(RequestID(), LogRequests())
+	e.GET("/incidents/:id", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, "Incident not found")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/42", nil)
+	req.Header.Set(RequestIDHeader, "trace-7f3a")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "WARN", line["level"])
+	assert.Equal(t, "request", line["msg"])
+	assert.Equal(t, "trace-7f3a", line[logging.RequestIDKey])
+	assert.Equal(t, http.MethodGet, line["method"])
+	assert.Equal(t, "/incidents/42", line["uri"])
+	assert.Equal(t, "/incidents/:id", line["route"])
+	assert.Equal(t, float64(http.StatusNotFound), line["status"])
+	assert.Contains(t, line["error"], "Incident not found")
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RouteNotFound answers requests to undefined paths with the JSON error shape
+func RouteNotFound(c echo.Context) error {
+	return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("No route for %s %s", c.Request().Method, c.Request().URL.Path))
+}
+
+// MethodNotAllowed answers a known path requested with an unsupported method with the JSON
+// error shape, listing the supported methods in the Allow header
+func MethodNotAllowed(c echo.Context) error {
+	if allow, ok := c.Get(echo.ContextKeyHeaderAllow).(string); ok && allow != "" {
+		c.Response().Header().Set(echo.HeaderAllow, allow)
+	}
+	return echo.NewHTTPError(http.StatusMethodNotAllowed, fmt.Sprintf("%s is not allowed on %s", c.Request().Method, c.Request().URL.Path))
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFallbackServer returns a server with the JSON fallbacks and the incident-by-ID routes
+func newFallbackServer(t *testing.T) *echo.Echo {
+	notFound, methodNotAllowed := echo.NotFoundHandler, echo.MethodNotAllowedHandler
+	echo.NotFoundHandler, echo.MethodNotAllowedHandler = RouteNotFound, MethodNotAllowed
+	t.Cleanup(func() {
+		echo.NotFoundHandler, echo.MethodNotAllowedHandler = notFound, methodNotAllowed
+	})
+
+	e := echo.New()
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/api/v1/incidents/:id", ok)
+	e.PUT("/api/v1/incidents/:id", ok)
+	e.DELETE("/api/v1/incidents/:id", ok)
+	return e
+}
+
+func TestRouteNotFound(t *testing.T) {
+	e := newFallbackServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/incidnets", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, map[string]interface{}{"message": "No route for GET /api/v1/incidnets"}, body)
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	e := newFallbackServer(t)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/incidents/1", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	allow := rec.Header().Get(echo.HeaderAllow)
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		assert.Contains(t, allow, method)
+	}
+
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, map[string]interface{}{"message": "PATCH is not allowed on /api/v1/incidents/1"}, body)
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AddRunbookStep handles POST /incidents/:id/runbook
+func (h *IncidentHandler) AddRunbookStep(c echo.Context) error {
+	id, err := h.incidentID(c)
+	if err != nil {
+		return err
+	}
+
+	var req domain.RunbookStepRequest
+	if err := c.Bind(&req); err != nil {
+		return bindFailed(err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return validationFailed(err)
+	}
+
+	step, err := h.incidentUseCase.AddRunbookStep(c.Request().Context(), id, req.Step)
+	if err != nil {
+		return h.runbookFailed(c, err, "Failed to add runbook step: ")
+	}
+
+	return h.respond(c, http.StatusCreated, step, map[string]interface{}{"message": "Runbook step added"}, map[string]interface{}{
+		"message": "Runbook step added",
+		"step":    step,
+	})
+}
+
+// ListRunbookSteps handles GET /incidents/:id/runbook. The completion is the percentage of
+// steps done, omitted while the runbook is empty.
+func (h *IncidentHandler) ListRunbookSteps(c echo.Context) error {
+	id, err := h.incidentID(c)
+	if err != nil {
+		return err
+	}
+
+	steps, err := h.incidentUseCase.ListRunbookSteps(c.Request().Context(), id)
+	if err != nil {
+		return h.runbookFailed(c, err, "Failed to retrieve runbook steps: ")
+	}
+
+	done := 0
+	for _, step := range steps {
+		if step.Done {
+			done++
+		}
+	}
+	completion := domain.RunbookCompletion(len(steps), done)
+
+	meta := map[string]interface{}{
+		"count":      len(steps),
+		"completion": completion,
+	}
+	return h.respond(c, http.StatusOK, steps, meta, map[string]interface{}{
+		"steps":      steps,
+		"count":      len(steps),
+		"completion": completion,
+	})
+}
+
+// CompleteRunbookStep handles POST /incidents/:id/runbook/:step/done
+func (h *IncidentHandler) CompleteRunbookStep(c echo.Context) error {
+	id, err := h.incidentID(c)
+	if err != nil {
+		return err
+	}
+
+	stepID, err := strconv.Atoi(c.Param("step"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid runbook step ID")
+	}
+
+	var req domain.CompleteStepRequest
+	if err := c.Bind(&req); err != nil {
+		return bindFailed(err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return validationFailed(err)
+	}
+
+	step, err := h.incidentUseCase.CompleteRunbookStep(c.Request().Context(), id, stepID, req.DoneBy)
+	if err != nil {
+		if errors.Is(err, domain.ErrStepNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Runbook step not found")
+		}
+		if errors.Is(err, domain.ErrStepDone) {
+			return echo.NewHTTPError(http.StatusConflict, "Runbook step is already done")
+		}
+		return h.runbookFailed(c, err, "Failed to complete runbook step: ")
+	}
+
+	return h.respond(c, http.StatusOK, step, map[string]interface{}{"message": "Runbook step done"}, map[string]interface{}{
+		"message": "Runbook step done",
+		"step":    step,
+	})
+}
+
+// runbookFailed maps the errors shared by the runbook endpoints, falling back to a 500 whose
+// message starts with prefix
+func (h *IncidentHandler) runbookFailed(c echo.Context, err error, prefix string) error {
+	if httpErr := h.missingIncident(c, err); httpErr != nil {
+		return httpErr
+	}
+	if errors.Is(err, domain.ErrRunbooksUnavailable) {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "Runbooks are not available")
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, prefix+err.Error())
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddRunbookStep(t *testing.T) {
+	post := func(handler *IncidentHandler, body string) (*httptest.ResponseRecorder, error) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/incidents/7/runbook", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("7")
+		return rec, handler.AddRunbookStep(c)
+	}
+
+	t.Run("adds a trimmed step", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("AddRunbookStep", mock.Anything, 7, "Fail over the primary").
+			Return(&domain.RunbookStep{ID: 1, IncidentID: 7, Step: "Fail over the primary"}, nil)
+
+		rec, err := post(NewIncidentHandler(mockUC), `{"step": "  Fail over the primary "}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"done":false`)
+		mockUC.AssertExpectations(t)
+	})
+
+	t.Run("empty step", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+
+		_, err := post(NewIncidentHandler(mockUC), `{"step": " "}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+		mockUC.AssertNotCalled(t, "AddRunbookStep", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing incident", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("AddRunbookStep", mock.Anything, 7, "Flush the CDN").Return(nil, domain.ErrNotFound)
+
+		_, err := post(NewIncidentHandler(mockUC), `{"step": "Flush the CDN"}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		mockUC := new(MockIncidentUseCase)
+		mockUC.On("AddRunbookStep", mock.Anything, 7, "Flush the CDN").Return(nil, domain.ErrRunbooksUnavailable)
+
+		_, err := post(NewIncidentHandler(mockUC), `{"step": "Flush the CDN"}`)
+
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusServiceUnavailable, httpErr.Code)
+	})
+}
+
+func TestListRunbookSteps(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+
+	mockUC.On("ListRunbookSteps", mock.Anything, 7).Return([]*domain.RunbookStep{
+		{ID: 1, IncidentID: 7, Step: "Fail over the primary", Done: true, DoneBy: "alice"},
+		{ID: 2, IncidentID: 7, Step: "Flush the CDN"},
+		{ID: 3, IncidentID: 7, Step: "Post a status update"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/7/runbook", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+
+	assert.NoError(t, handler.ListRunbookSteps(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"completion":33`)
+	assert.Contains(t, rec.Body.String(), `"count":3`)
+	mockUC.AssertExpectations(t)
+}
+
+func TestCompleteRunbookStep(t *testing.T) {
+	post := func(handler *IncidentHandler, step, body string) (*httptest.ResponseRecorder, error) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/incidents/7/runbook/"+step+"/done", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id", "step")
+		c.SetParamValues("7", step)
+		return rec, handler.CompleteRunbookStep(c)
+	}
+
+	tests := []struct {
+		name           string
+		step           string
+		body           string
+		setupMock      func(*MockIncidentUseCase)
+		expectedStatus int
+	}{
+		{
+			name: "marks the step done",
+			step: "2",
+			body: `{"done_by": "alice"}`,
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("CompleteRunbookStep", mock.Anything, 7, 2, "alice").
+					Return(&domain.RunbookStep{ID: 2, IncidentID: 7, Step: "Flush the CDN", Done: true, DoneBy: "alice"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing done_by",
+			step:           "2",
+			body:           `{}`,
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "invalid step ID",
+			step:           "abc",
+			body:           `{"done_by": "alice"}`,
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown step",
+			step: "9",
+			body: `{"done_by": "alice"}`,
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("CompleteRunbookStep", mock.Anything, 7, 9, "alice").Return(nil, domain.ErrStepNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "already done",
+			step: "2",
+			body: `{"done_by": "bob"}`,
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("CompleteRunbookStep", mock.Anything, 7, 2, "bob").Return(nil, domain.ErrStepDone)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := new(MockIncidentUseCase)
+			tt.setupMock(mockUC)
+
+			rec, err := post(NewIncidentHandler(mockUC), tt.step, tt.body)
+			if err != nil {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+			} else {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SearchIncidents handles GET /incidents/search?q=<term>, listing the incidents whose title or
+// description mention the term, newest first, paginated by limit and offset
+func (h *IncidentHandler) SearchIncidents(c echo.Context) error {
+	term := strings.TrimSpace(c.QueryParam("q"))
+	if term == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid q: a search term is required")
+	}
+	if utf8.RuneCountInString(term) > domain.MaxSearchTermLength {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid q: must be at most %d characters", domain.MaxSearchTermLength))
+	}
+
+	page, err := parsePageParams(c, h.listLimits)
+	if err != nil {
+		return err
+	}
+	if page.Cursor != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor: search results are paginated by offset")
+	}
+
+	results, err := h.incidentUseCase.SearchIncidents(c.Request().Context(), term, page.Limit, page.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to search incidents: "+err.Error())
+	}
+
+	meta := map[string]interface{}{
+		"count":  len(results.Incidents),
+		"total":  results.Total,
+		"limit":  page.Limit,
+		"offset": page.Offset,
+	}
+	return h.respond(c, http.StatusOK, results.Incidents, meta, map[string]interface{}{
+		"incidents": results.Incidents,
+		"count":     len(results.Incidents),
+		"total":     results.Total,
+		"limit":     page.Limit,
+		"offset":    page.Offset,
+	})
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchIncidents(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockIncidentUseCase)
+		expectedStatus int
+	}{
+		{
+			name:  "trimmed term",
+			query: "?q=" + url.QueryEscape("  timeout ") + "&limit=10&offset=20",
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("SearchIncidents", mock.Anything, "timeout", 10, 20).
+					Return(&domain.SearchPage{Incidents: []*domain.Incident{{ID: 3, Title: "Login timeout"}}, Total: 21}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing term",
+			query:          "",
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "whitespace-only term",
+			query:          "?q=%20%20",
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "term too long",
+			query:          "?q=" + strings.Repeat("a", domain.MaxSearchTermLength+1),
+			setupMock:      func(m *MockIncidentUseCase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "repository failure",
+			query: "?q=disk",
+			setupMock: func(m *MockIncidentUseCase) {
+				m.On("SearchIncidents", mock.Anything, "disk", 50, 0).Return(nil, errors.New("connection refused"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUC := new(MockIncidentUseCase)
+			handler := NewIncidentHandler(mockUC)
+			tt.setupMock(mockUC)
+
+			req := httptest.NewRequest(http.MethodGet, "/incidents/search"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			err := handler.SearchIncidents(e.NewContext(req, rec))
+			if err != nil {
+				he, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, he.Code)
+			} else {
+				assert.Equal(t, tt.expectedStatus, rec.Code)
+			}
+			if tt.expectedStatus == http.StatusBadRequest {
+				mockUC.AssertNotCalled(t, "SearchIncidents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockUC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearchIncidents_Response(t *testing.T) {
+	e := echo.New()
+	mockUC := new(MockIncidentUseCase)
+	handler := NewIncidentHandler(mockUC)
+	mockUC.On("SearchIncidents", mock.Anything, "timeout", 50, 0).
+		Return(&domain.SearchPage{Incidents: []*domain.Incident{{ID: 3}, {ID: 1}}, Total: 2}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/search?q=timeout", nil)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, handler.SearchIncidents(e.NewContext(req, rec)))
+
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Len(t, body["incidents"], 2)
+	assert.Equal(t, float64(2), body["count"])
+	assert.Equal(t, float64(2), body["total"])
+}
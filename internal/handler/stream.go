@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// streamKeepAlive is the interval of the comment lines that keep idle streams open through proxies
+const streamKeepAlive = 30 * time.Second
+
+// StreamIncidents handles GET /incidents/stream, pushing incident change events as
+// Server-Sent Events until the client disconnects. ?severity= accepts the same values as the
+// list endpoint; delete events carry no incident and are always sent.
+func (h *IncidentHandler) StreamIncidents(c echo.Context) error {
+	if h.events == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "Live updates are not enabled")
+	}
+
+	severities, err := parseListParam(c, "severity", domain.Severities)
+	if err != nil {
+		return err
+	}
+
+	events, unsubscribe := h.events.Subscribe()
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if !streamMatches(event, severities) {
+				continue
+			}
+			if err := h.writeEvent(res, event); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// streamMatches reports whether an event passes the severity filter of a stream
+func streamMatches(event *domain.IncidentEvent, severities []string) bool {
+	if len(severities) == 0 || event.Incident == nil {
+		return true
+	}
+	for _, severity := range severities {
+		if event.Incident.AISeverity == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// writeEvent writes one Server-Sent Event named after the event type
+func (h *IncidentHandler) writeEvent(res *echo.Response, event *domain.IncidentEvent) error {
+	var body interface{} = event
+	if h.idAsString {
+		var err error
+		if body, err = stringifyIDs(body); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitForSubscribers blocks until the broker has n open subscriptions
+func waitForSubscribers(t *testing.T, broker *usecase.Broker, n int) {
+	deadline := time.Now().Add(time.Second)
+	for broker.Subscribers() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d subscribers, have %d", n, broker.Subscribers())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestStreamIncidents(t *testing.T) {
+	broker := usecase.NewBroker()
+	handler := NewIncidentHandler(new(MockIncidentUseCase), WithEventStream(broker))
+
+	e := echo.New()
+	e.GET("/incidents/stream", handler.StreamIncidents)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/incidents/stream?severity=high")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get(echo.HeaderContentType))
+	waitForSubscribers(t, broker, 1)
+
+	broker.Publish(&domain.IncidentEvent{Type: domain.EventCreated, ID: 1, Incident: &domain.Incident{ID: 1, AISeverity: "Low"}})
+	broker.Publish(&domain.IncidentEvent{Type: domain.EventUpdated, ID: 2, Incident: &domain.Incident{ID: 2, AISeverity: "High"}})
+	broker.Publish(&domain.IncidentEvent{Type: domain.EventDeleted, ID: 3})
+
+	reader := bufio.NewReader(res.Body)
+	readEvent := func() []string {
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			assert.NoError(t, err)
+			line = strings.TrimSuffix(line, "\n")
+			if line == "" {
+				return lines
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	// The Low event is filtered out; deletes always pass
+	updated := readEvent()
+	assert.Equal(t, "event: incident.updated", updated[0])
+	assert.True(t, strings.HasPrefix(updated[1], `data: {"type":"incident.updated","id":2,"incident":{"id":2,`))
+	assert.Equal(t, []string{"event: incident.deleted", `data: {"type":"incident.deleted","id":3}`}, readEvent())
+
+	// Disconnecting ends the subscription
+	res.Body.Close()
+	waitForSubscribers(t, broker, 0)
+}
+
+func TestStreamIncidents_Disabled(t *testing.T) {
+	e := echo.New()
+	handler := NewIncidentHandler(new(MockIncidentUseCase))
+
+	req := httptest.NewRequest(http.MethodGet, "/incidents/stream", nil)
+	err := handler.StreamIncidents(e.NewContext(req, httptest.NewRecorder()))
+
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.Code)
+}
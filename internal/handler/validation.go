@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// validationFailed converts a validation error into a 422 response listing every field error
+func validationFailed(err error) error {
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return echo.NewHTTPError(http.StatusUnprocessableEntity, map[string]interface{}{
+		"message": "Validation failed",
+		"fields":  validationErr.Fields,
+	})
+}
+
+// isValidationError reports whether err is a *domain.ValidationError, e.g. one the use case
+// found in a request after sanitizing it
+func isValidationError(err error) bool {
+	var validationErr *domain.ValidationError
+	return errors.As(err, &validationErr)
+}
+
+// validate checks a create or update request against the configured field limits, custom
+// field schema and service catalog
+func (h *IncidentHandler) validate(req *domain.CreateIncidentRequest) error {
+	err := req.ValidateWith(h.fieldLimits, h.customFields)
+	unknown := h.catalog.Check(req.AffectedService)
+	if unknown == nil {
+		return err
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		validationErr.Fields = append(validationErr.Fields, *unknown)
+		return validationErr
+	}
+	if err != nil {
+		return err
+	}
+	return &domain.ValidationError{Fields: []domain.FieldError{*unknown}}
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// xlsxMinWidth and xlsxMaxWidth bound auto-sized column widths, in characters
+	xlsxMinWidth = 8
+	xlsxMaxWidth = 60
+)
+
+// xlsxCell is one cell of a worksheet: a number when numeric is set, otherwise inline text
+type xlsxCell struct {
+	text    string
+	numeric bool
+}
+
+// xlsxText and xlsxNumber build worksheet cells
+func xlsxText(text string) xlsxCell { return xlsxCell{text: text} }
+func xlsxNumber(n int) xlsxCell     { return xlsxCell{text: strconv.Itoa(n), numeric: true} }
+
+// xlsxSheet is a worksheet whose first row is a bold, frozen header
+type xlsxSheet struct {
+	name   string
+	header []string
+	rows   [][]xlsxCell
+}
+
+// writeXLSX writes a minimal Office Open XML workbook holding sheets. Every sheet is written
+// whole because column widths are sized to the longest value and precede the rows in the file.
+func writeXLSX(w io.Writer, sheets []*xlsxSheet) error {
+	archive := zip.NewWriter(w)
+
+	var overrides, workbookSheets, relationships strings.Builder
+	for i, sheet := range sheets {
+		n := i + 1
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbookSheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscape(sheet.name), n, n)
+		fmt.Fprintf(&relationships, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+	fmt.Fprintf(&relationships, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			overrides.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + workbookSheets.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			relationships.String() + `</Relationships>`},
+		// Style 1 is the header: bold on a light grey fill
+		{"xl/styles.xml", `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>` +
+			`<fill><patternFill patternType="solid"><fgColor rgb="FFD9D9D9"/><bgColor indexed="64"/></patternFill></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+			`<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/></cellXfs>` +
+			`</styleSheet>`},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, struct{ name, content string }{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+
+	for _, part := range parts {
+		f, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(f, xml.Header+part.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+
+	return archive.Close()
+}
+
+// xml renders the worksheet with a frozen header row and columns sized to their content
+func (s *xlsxSheet) xml() string {
+	widths := make([]int, len(s.header))
+	for i, title := range s.header {
+		widths[i] = utf8.RuneCountInString(title)
+	}
+	for _, row := range s.rows {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell.text); i < len(widths) && n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	b.WriteString(`<cols>`)
+	for i, width := range widths {
+		width += 2
+		if width < xlsxMinWidth {
+			width = xlsxMinWidth
+		}
+		if width > xlsxMaxWidth {
+			width = xlsxMaxWidth
+		}
+		fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width)
+	}
+	b.WriteString(`</cols><sheetData>`)
+
+	header := make([]xlsxCell, len(s.header))
+	for i, title := range s.header {
+		header[i] = xlsxText(title)
+	}
+	writeXLSXRow(&b, 1, header, 1)
+	for i, row := range s.rows {
+		writeXLSXRow(&b, i+2, row, 0)
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// writeXLSXRow renders row number n with every cell in style
+func writeXLSXRow(b *strings.Builder, n int, cells []xlsxCell, style int) {
+	fmt.Fprintf(b, `<row r="%d">`, n)
+	for i, cell := range cells {
+		ref := xlsxColumn(i) + strconv.Itoa(n)
+		styleAttr := ""
+		if style != 0 {
+			styleAttr = fmt.Sprintf(` s="%d"`, style)
+		}
+		if cell.numeric {
+			fmt.Fprintf(b, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr, cell.text)
+			continue
+		}
+		fmt.Fprintf(b, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, styleAttr, xlsxEscape(cell.text))
+	}
+	b.WriteString(`</row>`)
+}
+
+// xlsxColumn returns the column letters of a zero-based index: A, B, ..., Z, AA, ...
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// xlsxEscape escapes text for XML, replacing characters XML cannot hold
+func xlsxEscape(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+// RequestIDKey is the attribute that carries the request ID on log lines
+const RequestIDKey = "request_id"
+
+type requestIDKey struct{}
+
+// New creates a logger that writes one JSON object per line to w
+func New(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, nil))
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "" when there is none
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext returns the default logger, tagged with the request ID carried by ctx so
+// that lines logged while serving a request can be correlated with it
+func FromContext(ctx context.Context) *slog.Logger {
+	if id := RequestID(ctx); id != "" {
+		return slog.Default().With(RequestIDKey, id)
+	}
+	return slog.Default()
+}
@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureDefault routes the default logger to a buffer for the rest of the test
+func captureDefault(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(New(&buf))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestRequestID(t *testing.T) {
+	assert.Empty(t, RequestID(context.Background()))
+
+	ctx := WithRequestID(context.Background(), "abc123")
+	assert.Equal(t, "abc123", RequestID(ctx))
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("tags lines with the request ID", func(t *testing.T) {
+		buf := captureDefault(t)
+
+		FromContext(WithRequestID(context.Background(), "abc123")).Error("save failed", "incident_id", 7)
+
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+		assert.Equal(t, "ERROR", line["level"])
+		assert.Equal(t, "save failed", line["msg"])
+		assert.Equal(t, "abc123", line[RequestIDKey])
+		assert.Equal(t, float64(7), line["incident_id"])
+	})
+
+	t.Run("omits the request ID outside a request", func(t *testing.T) {
+		buf := captureDefault(t)
+
+		FromContext(context.Background()).Info("tick")
+
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+		assert.NotContains(t, line, RequestIDKey)
+	})
+}
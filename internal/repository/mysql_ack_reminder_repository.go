@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"incident-triage-assistant/internal/domain"
+	"time"
+)
+
+// MySQLAckReminderRepository implements the AckReminderRepository interface using MySQL
+type MySQLAckReminderRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLAckReminderRepository creates a new MySQL acknowledgment reminder repository. Reads go
+// to the writer too: a replica lagging behind a recorded reminder would send it twice.
+func NewMySQLAckReminderRepository(db *sql.DB) *MySQLAckReminderRepository {
+	return &MySQLAckReminderRepository{db: db}
+}
+
+// DueAckReminders retrieves up to limit unassigned incidents created before createdBefore and
+// not reminded about since remindedBefore, oldest first, with their reminder counts
+func (r *MySQLAckReminderRepository) DueAckReminders(ctx context.Context, createdBefore, remindedBefore time.Time, limit int) ([]*domain.AckReminder, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT i.id, COALESCE(a.reminders, 0), a.last_reminded_at
+		FROM incidents i
+		LEFT JOIN incident_ack_reminders a ON a.incident_id = i.id
+		WHERE (i.assignee IS NULL OR i.assignee = '') AND i.`+notFalsePositive+`
+			AND i.created_at < ? AND (a.last_reminded_at IS NULL OR a.last_reminded_at < ?)
+		ORDER BY i.created_at ASC, i.id ASC
+		LIMIT ?
+	`, createdBefore, remindedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due acknowledgment reminders: %w", err)
+	}
+	defer rows.Close()
+
+	reminders := []*domain.AckReminder{}
+	for rows.Next() {
+		reminder := &domain.AckReminder{}
+		var lastRemindedAt sql.NullTime
+		if err := rows.Scan(&reminder.IncidentID, &reminder.Reminders, &lastRemindedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan acknowledgment reminder: %w", err)
+		}
+		if lastRemindedAt.Valid {
+			reminder.LastRemindedAt = &lastRemindedAt.Time
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating acknowledgment reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// RecordAckReminder counts a reminder sent at remindedAt about an incident
+func (r *MySQLAckReminderRepository) RecordAckReminder(ctx context.Context, incidentID int, remindedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO incident_ack_reminders (incident_id, reminders, last_reminded_at)
+		VALUES (?, 1, ?)
+		ON DUPLICATE KEY UPDATE reminders = reminders + 1, last_reminded_at = VALUES(last_reminded_at)
+	`, incidentID, remindedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record acknowledgment reminder: %w", err)
+	}
+	return nil
+}
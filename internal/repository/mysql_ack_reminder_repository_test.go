@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLAckReminderRepository_DueAckReminders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLAckReminderRepository(db)
+	now := time.Now()
+	createdBefore, remindedBefore := now.Add(-30*time.Minute), now.Add(-time.Hour)
+
+	mock.ExpectQuery("SELECT i.id, COALESCE\\(a.reminders, 0\\), a.last_reminded_at FROM incidents i LEFT JOIN incident_ack_reminders a ON a.incident_id = i.id "+
+		"WHERE \\(i.assignee IS NULL OR i.assignee = ''\\) AND i.false_positive_reason IS NULL AND i.created_at < \\? AND \\(a.last_reminded_at IS NULL OR a.last_reminded_at < \\?\\) "+
+		"ORDER BY i.created_at ASC, i.id ASC LIMIT \\?").
+		WithArgs(createdBefore, remindedBefore, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "reminders", "last_reminded_at"}).
+			AddRow(7, 0, nil).
+			AddRow(8, 2, now.Add(-2*time.Hour)))
+
+	reminders, err := repo.DueAckReminders(context.Background(), createdBefore, remindedBefore, 100)
+	assert.NoError(t, err)
+	if assert.Len(t, reminders, 2) {
+		assert.Equal(t, 7, reminders[0].IncidentID)
+		assert.Nil(t, reminders[0].LastRemindedAt)
+		assert.Equal(t, 2, reminders[1].Reminders)
+		assert.Equal(t, now.Add(-2*time.Hour), *reminders[1].LastRemindedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLAckReminderRepository_RecordAckReminder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLAckReminderRepository(db)
+	now := time.Now()
+
+	mock.ExpectExec("INSERT INTO incident_ack_reminders \\(incident_id, reminders, last_reminded_at\\) VALUES \\(\\?, 1, \\?\\) "+
+		"ON DUPLICATE KEY UPDATE reminders = reminders \\+ 1, last_reminded_at = VALUES\\(last_reminded_at\\)").
+		WithArgs(7, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.RecordAckReminder(context.Background(), 7, now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
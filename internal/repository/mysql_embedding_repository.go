@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"incident-triage-assistant/internal/domain"
+	"math"
+	"sort"
+)
+
+// MySQLEmbeddingRepository stores incident embeddings in MySQL and performs a brute-force
+// cosine similarity search over them
+type MySQLEmbeddingRepository struct {
+	db     *sql.DB
+	reader *sql.DB
+}
+
+// NewMySQLEmbeddingRepository creates a new MySQL embedding repository
+func NewMySQLEmbeddingRepository(db *sql.DB) *MySQLEmbeddingRepository {
+	return &MySQLEmbeddingRepository{db: db, reader: db}
+}
+
+// NewMySQLEmbeddingRepositoryWithReader creates a new MySQL embedding repository that sends reads to a replica
+func NewMySQLEmbeddingRepositoryWithReader(writer, reader *sql.DB) *MySQLEmbeddingRepository {
+	return &MySQLEmbeddingRepository{db: writer, reader: reader}
+}
+
+// SaveEmbedding inserts or replaces the embedding of an incident
+func (r *MySQLEmbeddingRepository) SaveEmbedding(ctx context.Context, incidentID int, embedding []float32) error {
+	query := `
+		INSERT INTO incident_embeddings (incident_id, embedding)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE embedding = VALUES(embedding)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, incidentID, encodeEmbedding(embedding))
+	if err != nil {
+		return fmt.Errorf("failed to save embedding: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmbedding retrieves the embedding of an incident, returning nil if none is stored
+func (r *MySQLEmbeddingRepository) GetEmbedding(ctx context.Context, incidentID int) ([]float32, error) {
+	query := `SELECT embedding FROM incident_embeddings WHERE incident_id = ?`
+
+	var raw []byte
+	err := r.reader.QueryRowContext(ctx, query, incidentID).Scan(&raw)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get embedding: %w", err)
+	}
+
+	return decodeEmbedding(raw)
+}
+
+// DeleteEmbedding removes the embedding of an incident, if any
+func (r *MySQLEmbeddingRepository) DeleteEmbedding(ctx context.Context, incidentID int) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM incident_embeddings WHERE incident_id = ?`, incidentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete embedding: %w", err)
+	}
+
+	return nil
+}
+
+// FindNearest scans all stored embeddings and returns the most similar ones by cosine similarity
+func (r *MySQLEmbeddingRepository) FindNearest(ctx context.Context, embedding []float32, excludeID int, limit int) ([]*domain.SimilarityMatch, error) {
+	query := `SELECT incident_id, embedding FROM incident_embeddings WHERE incident_id <> ?`
+
+	rows, err := r.reader.QueryContext(ctx, query, excludeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	matches := []*domain.SimilarityMatch{}
+	for rows.Next() {
+		var id int
+		var raw []byte
+		if err := rows.Scan(&id, &raw); err != nil {
+			return nil, fmt.Errorf("failed to scan embedding: %w", err)
+		}
+
+		candidate, err := decodeEmbedding(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, &domain.SimilarityMatch{
+			IncidentID: id,
+			Score:      domain.CosineSimilarity(embedding, candidate),
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating embeddings: %w", err)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches, nil
+}
+
+// encodeEmbedding serializes an embedding as little-endian float32 values
+func encodeEmbedding(embedding []float32) []byte {
+	buf := make([]byte, 4*len(embedding))
+	for i, v := range embedding {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	return buf
+}
+
+// decodeEmbedding deserializes an embedding written by encodeEmbedding
+func decodeEmbedding(raw []byte) ([]float32, error) {
+	if len(raw)%4 != 0 {
+		return nil, fmt.Errorf("invalid embedding length %d", len(raw))
+	}
+
+	embedding := make([]float32, len(raw)/4)
+	for i := range embedding {
+		embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(raw[i*4:]))
+	}
+	return embedding, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLEmbeddingRepository_SaveEmbedding(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLEmbeddingRepository(db)
+	embedding := []float32{0.5, -1.25}
+
+	mock.ExpectExec("INSERT INTO incident_embeddings").
+		WithArgs(1, encodeEmbedding(embedding)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.SaveEmbedding(context.Background(), 1, embedding)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLEmbeddingRepository_DeleteEmbedding(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLEmbeddingRepository(db)
+
+	mock.ExpectExec("DELETE FROM incident_embeddings WHERE incident_id = ?").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.DeleteEmbedding(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLEmbeddingRepository_GetEmbedding(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLEmbeddingRepository(db)
+	embedding := []float32{0.5, -1.25}
+
+	mock.ExpectQuery("SELECT embedding FROM incident_embeddings WHERE incident_id = ?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"embedding"}).AddRow(encodeEmbedding(embedding)))
+
+	result, err := repo.GetEmbedding(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, embedding, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLEmbeddingRepository_GetEmbedding_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLEmbeddingRepository(db)
+
+	mock.ExpectQuery("SELECT embedding FROM incident_embeddings WHERE incident_id = ?").
+		WithArgs(999).
+		WillReturnRows(sqlmock.NewRows([]string{"embedding"}))
+
+	result, err := repo.GetEmbedding(context.Background(), 999)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLEmbeddingRepository_FindNearest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLEmbeddingRepository(db)
+
+	rows := sqlmock.NewRows([]string{"incident_id", "embedding"}).
+		AddRow(2, encodeEmbedding([]float32{0, 1})).
+		AddRow(3, encodeEmbedding([]float32{1, 0})).
+		AddRow(4, encodeEmbedding([]float32{1, 1}))
+
+	mock.ExpectQuery("SELECT incident_id, embedding FROM incident_embeddings WHERE incident_id <> ?").
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	matches, err := repo.FindNearest(context.Background(), []float32{1, 0}, 1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, matches, 2)
+	assert.Equal(t, 3, matches[0].IncidentID)
+	assert.InDelta(t, 1.0, matches[0].Score, 1e-9)
+	assert.Equal(t, 4, matches[1].IncidentID)
+	assert.InDelta(t, 0.7071, matches[1].Score, 1e-4)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
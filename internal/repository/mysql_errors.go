@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlErrDuplicateEntry is the MySQL error number for unique constraint violations
+const mysqlErrDuplicateEntry = 1062
+
+// mysqlErrDeadlock is the MySQL error number for a transaction rolled back by deadlock detection
+const mysqlErrDeadlock = 1213
+
+// isMySQLError reports whether err wraps a MySQL error with the given error number
+func isMySQLError(err error, number uint16) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == number
+}
+
+// isDuplicateEntry reports whether err is a unique constraint violation
+func isDuplicateEntry(err error) bool {
+	return isMySQLError(err, mysqlErrDuplicateEntry)
+}
+
+// isDeadlock reports whether err is a deadlock that rolled back the transaction
+func isDeadlock(err error) bool {
+	return isMySQLError(err, mysqlErrDeadlock)
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"incident-triage-assistant/internal/domain"
+	"time"
+)
+
+// MySQLFollowUpRepository implements the FollowUpRepository interface using MySQL
+type MySQLFollowUpRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLFollowUpRepository creates a new MySQL follow-up repository. Reads go to the writer
+// too: the scheduler must not nudge twice because a replica lags behind a recorded nudge.
+func NewMySQLFollowUpRepository(db *sql.DB) *MySQLFollowUpRepository {
+	return &MySQLFollowUpRepository{db: db}
+}
+
+// SetFollowUp inserts or reschedules the follow-up of an incident
+func (r *MySQLFollowUpRepository) SetFollowUp(ctx context.Context, followUp *domain.FollowUp) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO incident_follow_ups (incident_id, interval_seconds, next_at)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE interval_seconds = VALUES(interval_seconds), next_at = VALUES(next_at)
+	`, followUp.IncidentID, int64(followUp.Interval/time.Second), followUp.NextAt)
+	if err != nil {
+		return fmt.Errorf("failed to set follow-up: %w", err)
+	}
+	return nil
+}
+
+// ClearFollowUp stops the follow-ups of an incident; clearing none is not an error
+func (r *MySQLFollowUpRepository) ClearFollowUp(ctx context.Context, incidentID int) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM incident_follow_ups WHERE incident_id = ?`, incidentID); err != nil {
+		return fmt.Errorf("failed to clear follow-up: %w", err)
+	}
+	return nil
+}
+
+// GetFollowUp retrieves the follow-up of an incident, or nil when it has none
+func (r *MySQLFollowUpRepository) GetFollowUp(ctx context.Context, incidentID int) (*domain.FollowUp, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT incident_id, interval_seconds, next_at, nudges, last_nudged_at
+		FROM incident_follow_ups WHERE incident_id = ?
+	`, incidentID)
+
+	followUp, err := scanFollowUp(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow-up: %w", err)
+	}
+	return followUp, nil
+}
+
+// DueFollowUps retrieves up to limit follow-ups whose next reminder is due at now, earliest first
+func (r *MySQLFollowUpRepository) DueFollowUps(ctx context.Context, now time.Time, limit int) ([]*domain.FollowUp, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT incident_id, interval_seconds, next_at, nudges, last_nudged_at
+		FROM incident_follow_ups WHERE next_at <= ?
+		ORDER BY next_at ASC, incident_id ASC
+		LIMIT ?
+	`, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due follow-ups: %w", err)
+	}
+	defer rows.Close()
+
+	followUps := []*domain.FollowUp{}
+	for rows.Next() {
+		followUp, err := scanFollowUp(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan follow-up: %w", err)
+		}
+		followUps = append(followUps, followUp)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating follow-ups: %w", err)
+	}
+
+	return followUps, nil
+}
+
+// RecordNudge counts a reminder sent at nudgedAt and schedules the next one at nextAt
+func (r *MySQLFollowUpRepository) RecordNudge(ctx context.Context, incidentID int, nudgedAt, nextAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE incident_follow_ups SET nudges = nudges + 1, last_nudged_at = ?, next_at = ?
+		WHERE incident_id = ?
+	`, nudgedAt, nextAt, incidentID)
+	if err != nil {
+		return fmt.Errorf("failed to record follow-up nudge: %w", err)
+	}
+	return nil
+}
+
+// scanFollowUp scans one follow-up row
+func scanFollowUp(row rowScanner) (*domain.FollowUp, error) {
+	followUp := &domain.FollowUp{}
+	var intervalSeconds int64
+	var lastNudgedAt sql.NullTime
+	err := row.Scan(
+		&followUp.IncidentID,
+		&intervalSeconds,
+		&followUp.NextAt,
+		&followUp.Nudges,
+		&lastNudgedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	followUp.Interval = time.Duration(intervalSeconds) * time.Second
+	if lastNudgedAt.Valid {
+		followUp.LastNudgedAt = &lastNudgedAt.Time
+	}
+	return followUp, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLFollowUpRepository_SetFollowUp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLFollowUpRepository(db)
+	next := time.Now()
+
+	mock.ExpectExec("INSERT INTO incident_follow_ups \\(incident_id, interval_seconds, next_at\\) VALUES \\(\\?, \\?, \\?\\) ON DUPLICATE KEY UPDATE").
+		WithArgs(7, int64(14400), next).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SetFollowUp(context.Background(), &domain.FollowUp{IncidentID: 7, Interval: 4 * time.Hour, NextAt: next})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLFollowUpRepository_GetFollowUp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLFollowUpRepository(db)
+	now := time.Now()
+	query := "SELECT incident_id, interval_seconds, next_at, nudges, last_nudged_at FROM incident_follow_ups WHERE incident_id = \\?"
+
+	mock.ExpectQuery(query).WithArgs(7).WillReturnRows(
+		sqlmock.NewRows([]string{"incident_id", "interval_seconds", "next_at", "nudges", "last_nudged_at"}).
+			AddRow(7, 3600, now, 2, now))
+	mock.ExpectQuery(query).WithArgs(8).WillReturnRows(
+		sqlmock.NewRows([]string{"incident_id", "interval_seconds", "next_at", "nudges", "last_nudged_at"}))
+
+	followUp, err := repo.GetFollowUp(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, followUp.Interval)
+	assert.Equal(t, 2, followUp.Nudges)
+	assert.Equal(t, now, *followUp.LastNudgedAt)
+
+	followUp, err = repo.GetFollowUp(context.Background(), 8)
+	assert.NoError(t, err)
+	assert.Nil(t, followUp)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLFollowUpRepository_DueFollowUps(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLFollowUpRepository(db)
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT incident_id, interval_seconds, next_at, nudges, last_nudged_at FROM incident_follow_ups WHERE next_at <= \\? ORDER BY next_at ASC, incident_id ASC LIMIT \\?").
+		WithArgs(now, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"incident_id", "interval_seconds", "next_at", "nudges", "last_nudged_at"}).
+			AddRow(7, 3600, now, 0, nil))
+
+	followUps, err := repo.DueFollowUps(context.Background(), now, 100)
+	assert.NoError(t, err)
+	if assert.Len(t, followUps, 1) {
+		assert.Nil(t, followUps[0].LastNudgedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLFollowUpRepository_RecordNudge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLFollowUpRepository(db)
+	now := time.Now()
+
+	mock.ExpectExec("UPDATE incident_follow_ups SET nudges = nudges \\+ 1, last_nudged_at = \\?, next_at = \\? WHERE incident_id = \\?").
+		WithArgs(now, now.Add(time.Hour), 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.RecordNudge(context.Background(), 7, now, now.Add(time.Hour)))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
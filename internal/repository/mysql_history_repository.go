@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"incident-triage-assistant/internal/domain"
+	"strings"
+)
+
+// MySQLHistoryRepository implements the HistoryRepository interface using MySQL
+type MySQLHistoryRepository struct {
+	db     *sql.DB
+	reader *sql.DB
+}
+
+// NewMySQLHistoryRepository creates a new MySQL history repository
+func NewMySQLHistoryRepository(db *sql.DB) *MySQLHistoryRepository {
+	return &MySQLHistoryRepository{db: db, reader: db}
+}
+
+// NewMySQLHistoryRepositoryWithReader creates a new MySQL history repository that sends reads to a replica
+func NewMySQLHistoryRepositoryWithReader(writer, reader *sql.DB) *MySQLHistoryRepository {
+	return &MySQLHistoryRepository{db: writer, reader: reader}
+}
+
+// AddEntries inserts history entries in a single statement
+func (r *MySQLHistoryRepository) AddEntries(ctx context.Context, entries []*domain.HistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(entries))
+	args := make([]interface{}, 0, len(entries)*6)
+	for i, entry := range entries {
+		placeholders[i] = "(?, ?, ?, ?, ?, ?)"
+		args = append(args, entry.IncidentID, entry.Field, entry.OldValue, entry.NewValue, entry.Actor, entry.CreatedAt)
+	}
+
+	query := `
+		INSERT INTO incident_history (incident_id, field, old_value, new_value, actor, created_at)
+		VALUES ` + strings.Join(placeholders, ", ")
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to add history entries: %w", err)
+	}
+
+	return nil
+}
+
+// GetByIncident retrieves the history of an incident, oldest first, optionally restricted to one field
+func (r *MySQLHistoryRepository) GetByIncident(ctx context.Context, incidentID int, field string) ([]*domain.HistoryEntry, error) {
+	query := `
+		SELECT id, incident_id, field, old_value, new_value, actor, created_at
+		FROM incident_history WHERE incident_id = ?`
+	args := []interface{}{incidentID}
+
+	if field != "" {
+		query += ` AND field = ?`
+		args = append(args, field)
+	}
+	query += ` ORDER BY created_at ASC, id ASC`
+
+	rows, err := r.reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.HistoryEntry{}
+	for rows.Next() {
+		entry := &domain.HistoryEntry{}
+		var oldValue, newValue sql.NullString
+		err := rows.Scan(
+			&entry.ID,
+			&entry.IncidentID,
+			&entry.Field,
+			&oldValue,
+			&newValue,
+			&entry.Actor,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan history entry: %w", err)
+		}
+		entry.OldValue = oldValue.String
+		entry.NewValue = newValue.String
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating history: %w", err)
+	}
+
+	return entries, nil
+}
+
+// List retrieves up to limit history entries across all incidents, newest first, starting after
+// the keyset position after. Each entry carries the title of its incident, taken from the
+// archive once the incident has been deleted.
+func (r *MySQLHistoryRepository) List(ctx context.Context, filter domain.HistoryFilter, after *domain.Cursor, limit int) ([]*domain.HistoryEntry, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Actor != "" {
+		conditions = append(conditions, "h.actor = ?")
+		args = append(args, filter.Actor)
+	}
+	if filter.Field != "" {
+		conditions = append(conditions, "h.field = ?")
+		args = append(args, filter.Field)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "h.created_at >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "h.created_at < ?")
+		args = append(args, filter.To)
+	}
+	if after != nil {
+		conditions = append(conditions, "(h.created_at < ? OR (h.created_at = ? AND h.id < ?))")
+		args = append(args, after.CreatedAt, after.CreatedAt, after.ID)
+	}
+
+	query := `
+		SELECT h.id, h.incident_id, h.field, h.old_value, h.new_value, h.actor, h.created_at, COALESCE(i.title, a.title)
+		FROM incident_history h
+		LEFT JOIN incidents i ON i.id = h.incident_id
+		LEFT JOIN incident_archive a ON a.incident_id = h.incident_id`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY h.created_at DESC, h.id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := r.reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.HistoryEntry{}
+	for rows.Next() {
+		entry := &domain.HistoryEntry{}
+		var oldValue, newValue, title sql.NullString
+		err := rows.Scan(
+			&entry.ID,
+			&entry.IncidentID,
+			&entry.Field,
+			&oldValue,
+			&newValue,
+			&entry.Actor,
+			&entry.CreatedAt,
+			&title,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan history entry: %w", err)
+		}
+		entry.OldValue = oldValue.String
+		entry.NewValue = newValue.String
+		entry.IncidentTitle = title.String
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating history: %w", err)
+	}
+
+	return entries, nil
+}
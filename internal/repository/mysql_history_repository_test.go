@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLHistoryRepository_AddEntries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLHistoryRepository(db)
+	now := time.Now()
+
+	entries := []*domain.HistoryEntry{
+		{IncidentID: 1, Field: domain.FieldAISeverity, OldValue: "Low", NewValue: "High", Actor: domain.ActorAI, CreatedAt: now},
+		{IncidentID: 1, Field: domain.FieldTitle, OldValue: "Old", NewValue: "New", Actor: domain.ActorAPI, CreatedAt: now},
+	}
+
+	mock.ExpectExec("INSERT INTO incident_history \\(incident_id, field, old_value, new_value, actor, created_at\\) VALUES \\(\\?, \\?, \\?, \\?, \\?, \\?\\), \\(\\?, \\?, \\?, \\?, \\?, \\?\\)").
+		WithArgs(1, domain.FieldAISeverity, "Low", "High", domain.ActorAI, now, 1, domain.FieldTitle, "Old", "New", domain.ActorAPI, now).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+
+	err = repo.AddEntries(context.Background(), entries)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLHistoryRepository_AddEntries_Empty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLHistoryRepository(db)
+
+	err = repo.AddEntries(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLHistoryRepository_GetByIncident(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLHistoryRepository(db)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "incident_id", "field", "old_value", "new_value", "actor", "created_at"}).
+		AddRow(1, 7, domain.FieldAISeverity, "Low", "High", domain.ActorAI, now).
+		AddRow(2, 7, domain.FieldAISeverity, "High", nil, domain.ActorAI, now)
+
+	mock.ExpectQuery("SELECT id, incident_id, field, old_value, new_value, actor, created_at FROM incident_history WHERE incident_id = \\? AND field = \\? ORDER BY created_at ASC, id ASC").
+		WithArgs(7, domain.FieldAISeverity).
+		WillReturnRows(rows)
+
+	entries, err := repo.GetByIncident(context.Background(), 7, domain.FieldAISeverity)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "High", entries[0].NewValue)
+	assert.Equal(t, "", entries[1].NewValue)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLHistoryRepository_List(t *testing.T) {
+	now := time.Now()
+	columns := []string{"id", "incident_id", "field", "old_value", "new_value", "actor", "created_at", "title"}
+	selectHistory := "SELECT h.id, h.incident_id, h.field, h.old_value, h.new_value, h.actor, h.created_at, COALESCE\\(i.title, a.title\\) FROM incident_history h LEFT JOIN incidents i ON i.id = h.incident_id LEFT JOIN incident_archive a ON a.incident_id = h.incident_id"
+
+	t.Run("actor filter", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(selectHistory+" WHERE h.actor = \\? ORDER BY h.created_at DESC, h.id DESC LIMIT \\?").
+			WithArgs(domain.ActorAdmin, 10).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(5, 3, domain.FieldAISeverity, "Low", "High", domain.ActorAdmin, now, "Checkout errors").
+				AddRow(4, 9, domain.FieldAISeverity, "High", "Low", domain.ActorAdmin, now, nil))
+
+		entries, err := NewMySQLHistoryRepository(db).List(context.Background(), domain.HistoryFilter{Actor: domain.ActorAdmin}, nil, 10)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, "Checkout errors", entries[0].IncidentTitle)
+		assert.Equal(t, "", entries[1].IncidentTitle)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("field filter with range and cursor", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		from, to := now.Add(-time.Hour), now
+		after := &domain.Cursor{ID: 20, CreatedAt: now.Add(-time.Minute)}
+
+		mock.ExpectQuery(selectHistory+" WHERE h.field = \\? AND h.created_at >= \\? AND h.created_at < \\? AND \\(h.created_at < \\? OR \\(h.created_at = \\? AND h.id < \\?\\)\\) ORDER BY h.created_at DESC, h.id DESC LIMIT \\?").
+			WithArgs(domain.FieldAICategory, from, to, after.CreatedAt, after.CreatedAt, 20, 5).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		entries, err := NewMySQLHistoryRepository(db).List(context.Background(), domain.HistoryFilter{Field: domain.FieldAICategory, From: from, To: to}, after, 5)
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
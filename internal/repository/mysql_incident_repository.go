@@ -1,29 +1,284 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"incident-triage-assistant/internal/domain"
+	"strings"
+	"time"
 )
 
+// incidentColumns lists the incident columns in the order expected by scanIncident
+const incidentColumns = "id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence"
+
+// notFalsePositive excludes incidents marked as false positives
+const notFalsePositive = "false_positive_reason IS NULL"
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// queryRower is implemented by both *sql.DB and *sql.Tx
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// scanIncident scans a row selected with incidentColumns into an incident
+func scanIncident(row rowScanner) (*domain.Incident, error) {
+	incident := &domain.Incident{}
+	var customFields []byte
+	var suggestedAction, assignee, falsePositiveReason, reasoning, priorityOverride, reference sql.NullString
+	var affectedUsers sql.NullInt64
+	var confidence sql.NullFloat64
+	err := row.Scan(
+		&incident.ID,
+		&incident.Title,
+		&incident.Description,
+		&incident.AffectedService,
+		&incident.AISeverity,
+		&incident.AICategory,
+		&incident.CreatedAt,
+		&incident.UpdatedAt,
+		&customFields,
+		&suggestedAction,
+		&assignee,
+		&incident.AnalysisStatus,
+		&falsePositiveReason,
+		&reasoning,
+		&incident.AIInputTruncated,
+		&affectedUsers,
+		&priorityOverride,
+		&reference,
+		&confidence,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(customFields) > 0 {
+		if err := json.Unmarshal(customFields, &incident.CustomFields); err != nil {
+			return nil, fmt.Errorf("invalid custom fields: %w", err)
+		}
+	}
+	incident.AISuggestedAction = suggestedAction.String
+	incident.AIReasoning = reasoning.String
+	incident.Assignee = assignee.String
+	incident.FalsePositiveReason = falsePositiveReason.String
+	incident.PriorityOverride = priorityOverride.String
+	incident.Reference = reference.String
+	if confidence.Valid {
+		incident.AIConfidence = &confidence.Float64
+	}
+	if affectedUsers.Valid {
+		users := int(affectedUsers.Int64)
+		incident.AffectedUsers = &users
+	}
+	return incident, nil
+}
+
+// encodeCustomFields serializes custom fields for the JSON column, storing NULL when there are none
+func encodeCustomFields(fields map[string]interface{}) (interface{}, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode custom fields: %w", err)
+	}
+	return string(raw), nil
+}
+
+// nullIfUnset stores an optional number that was not given as NULL
+func nullIfUnset[T int | float64](value *T) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+// nullIfEmpty stores an empty optional text column as NULL
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// effectivePriority is the SQL form of an incident's effective priority: the override when set,
+// otherwise domain.ComputePriority of its severity and affected users. It returns the expression
+// and its arguments.
+func effectivePriority() (string, []interface{}) {
+	computed := fmt.Sprintf("CONCAT('P', GREATEST(1, LEAST(%d, %d - FIELD(ai_severity, %s)) - IF(affected_users >= ?, 1, 0)))",
+		len(domain.Priorities), len(domain.Severities)+1, placeholders(len(domain.Severities)))
+
+	args := make([]interface{}, 0, len(domain.Severities)+1)
+	for _, severity := range domain.Severities {
+		args = append(args, severity)
+	}
+	args = append(args, domain.HighImpactUsers)
+	return "COALESCE(priority_override, " + computed + ")", args
+}
+
+// buildFilterClause builds a parameterized WHERE clause for a filter, or an empty clause for an empty filter
+func buildFilterClause(filter *domain.IncidentFilter) (string, []interface{}) {
+	if filter.IsEmpty() {
+		return " WHERE " + notFalsePositive, nil
+	}
+
+	var conditions []string
+	var args []interface{}
+
+	addIn := func(column string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		conditions = append(conditions, column+" IN ("+placeholders(len(values))+")")
+		for _, v := range values {
+			args = append(args, v)
+		}
+	}
+
+	addIn("ai_severity", filter.Severities)
+	addIn("ai_category", filter.Categories)
+
+	for _, key := range filter.CustomFieldKeys() {
+		// Keys are interpolated into the JSON path, so anything but an identifier matches nothing
+		if !domain.ValidCustomFieldKey(key) {
+			conditions = append(conditions, "1 = 0")
+			continue
+		}
+		conditions = append(conditions, "custom_fields->>'$."+key+"' = ?")
+		args = append(args, filter.CustomFields[key])
+	}
+
+	if len(filter.Priorities) > 0 {
+		priority, priorityArgs := effectivePriority()
+		conditions = append(conditions, priority+" IN ("+placeholders(len(filter.Priorities))+")")
+		args = append(args, priorityArgs...)
+		for _, p := range filter.Priorities {
+			args = append(args, p)
+		}
+	}
+
+	if filter.MinAffectedUsers != nil {
+		conditions = append(conditions, "affected_users >= ?")
+		args = append(args, *filter.MinAffectedUsers)
+	}
+
+	if filter.Query != nil {
+		query, queryArgs := compileQuery(filter.Query)
+		conditions = append(conditions, query)
+		args = append(args, queryArgs...)
+	}
+
+	if !filter.IncludeFalsePositive {
+		conditions = append(conditions, notFalsePositive)
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// placeholders returns n comma-separated SQL placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
 // MySQLIncidentRepository implements the IncidentRepository interface using MySQL
 type MySQLIncidentRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	reader *sql.DB
 }
 
 // NewMySQLIncidentRepository creates a new MySQL incident repository
 func NewMySQLIncidentRepository(db *sql.DB) *MySQLIncidentRepository {
-	return &MySQLIncidentRepository{db: db}
+	return &MySQLIncidentRepository{db: db, reader: db}
+}
+
+// NewMySQLIncidentRepositoryWithReader creates a new MySQL incident repository that sends reads to a replica
+func NewMySQLIncidentRepositoryWithReader(writer, reader *sql.DB) *MySQLIncidentRepository {
+	return &MySQLIncidentRepository{db: writer, reader: reader}
 }
 
 // Create inserts a new incident into the database
-func (r *MySQLIncidentRepository) Create(incident *domain.Incident) error {
+func (r *MySQLIncidentRepository) Create(ctx context.Context, incident *domain.Incident) error {
+	return insertIncident(ctx, r.db, incident)
+}
+
+// CreateUnique inserts an incident unless one with the same title and affected service was
+// created at or after since (any time when since is zero), returning domain.ErrDuplicate in
+// that case. The check locks the matching index range until the insert commits, so
+// concurrent creates cannot both pass it. Two creates holding the same gap lock can deadlock
+// on their inserts; the loser is retried, and its retry sees the winner's row.
+func (r *MySQLIncidentRepository) CreateUnique(ctx context.Context, incident *domain.Incident, since time.Time) error {
+	var err error
+	for attempt := 0; attempt < createUniqueAttempts; attempt++ {
+		err = r.createUnique(ctx, incident, since)
+		if !isDeadlock(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("failed to create incident: deadlocked with a concurrent create: %w", domain.ErrDuplicate)
+}
+
+// createUniqueAttempts bounds how often CreateUnique retries a create that deadlocked
+const createUniqueAttempts = 3
+
+// createUnique runs one CreateUnique transaction
+func (r *MySQLIncidentRepository) createUnique(ctx context.Context, incident *domain.Incident, since time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin create: %w", err)
+	}
+	defer tx.Rollback()
+
+	where, args := duplicateClause(incident.Title, incident.AffectedService, since)
+	var existingID int
+	err = tx.QueryRowContext(ctx, `SELECT id FROM incidents`+where+` LIMIT 1 FOR UPDATE`, args...).Scan(&existingID)
+	if err == nil {
+		return fmt.Errorf("failed to create incident: duplicate of incident %d: %w", existingID, domain.ErrDuplicate)
+	}
+	if err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check for duplicate incident: %w", err)
+	}
+
+	if err := insertIncident(ctx, tx, incident); err != nil {
+		incident.ID = 0
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		incident.ID = 0
+		return fmt.Errorf("failed to commit create: %w", err)
+	}
+
+	return nil
+}
+
+// insertIncident inserts an incident and sets its ID
+func insertIncident(ctx context.Context, db execer, incident *domain.Incident) error {
 	query := `
-		INSERT INTO incidents (title, description, affected_service, ai_severity, ai_category, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO incidents (title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, ai_reasoning, ai_input_truncated, affected_users, reference, ai_confidence)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
-	
-	result, err := r.db.Exec(query,
+
+	customFields, err := encodeCustomFields(incident.CustomFields)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.ExecContext(ctx, query,
 		incident.Title,
 		incident.Description,
 		incident.AffectedService,
@@ -31,8 +286,20 @@ func (r *MySQLIncidentRepository) Create(incident *domain.Incident) error {
 		incident.AICategory,
 		incident.CreatedAt,
 		incident.UpdatedAt,
+		customFields,
+		nullIfEmpty(incident.AISuggestedAction),
+		nullIfEmpty(incident.Assignee),
+		incident.AnalysisStatus,
+		nullIfEmpty(incident.AIReasoning),
+		incident.AIInputTruncated,
+		nullIfUnset(incident.AffectedUsers),
+		nullIfEmpty(incident.Reference),
+		nullIfUnset(incident.AIConfidence),
 	)
 	if err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("failed to create incident: %w", domain.ErrDuplicate)
+		}
 		return fmt.Errorf("failed to create incident: %w", err)
 	}
 
@@ -45,28 +312,113 @@ func (r *MySQLIncidentRepository) Create(incident *domain.Incident) error {
 	return nil
 }
 
-// GetByID retrieves an incident by its ID
-func (r *MySQLIncidentRepository) GetByID(id int) (*domain.Incident, error) {
+// importBatchSize bounds the number of rows of one multi-row INSERT in CreateBatch
+const importBatchSize = 100
+
+// CreateBatch inserts incidents in a single transaction, importBatchSize rows per statement,
+// and sets their IDs. Nothing is inserted if any row fails.
+func (r *MySQLIncidentRepository) CreateBatch(ctx context.Context, incidents []*domain.Incident) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin batch create: %w", err)
+	}
+	defer tx.Rollback()
+
+	for start := 0; start < len(incidents); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(incidents) {
+			end = len(incidents)
+		}
+		if err := insertIncidents(ctx, tx, incidents[start:end]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit batch create: %w", err)
+	}
+
+	return nil
+}
+
+// insertIncidents inserts incidents with one multi-row INSERT and sets their IDs
+func insertIncidents(ctx context.Context, tx *sql.Tx, incidents []*domain.Incident) error {
+	placeholders := make([]string, len(incidents))
+	args := make([]interface{}, 0, len(incidents)*16)
+	for i, incident := range incidents {
+		customFields, err := encodeCustomFields(incident.CustomFields)
+		if err != nil {
+			return err
+		}
+
+		placeholders[i] = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+		args = append(args,
+			incident.Title,
+			incident.Description,
+			incident.AffectedService,
+			incident.AISeverity,
+			incident.AICategory,
+			incident.CreatedAt,
+			incident.UpdatedAt,
+			customFields,
+			nullIfEmpty(incident.AISuggestedAction),
+			nullIfEmpty(incident.Assignee),
+			incident.AnalysisStatus,
+			nullIfEmpty(incident.AIReasoning),
+			incident.AIInputTruncated,
+			nullIfUnset(incident.AffectedUsers),
+			nullIfEmpty(incident.Reference),
+			nullIfUnset(incident.AIConfidence),
+		)
+	}
+
 	query := `
-		SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at
+		INSERT INTO incidents (title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, ai_reasoning, ai_input_truncated, affected_users, reference, ai_confidence)
+		VALUES ` + strings.Join(placeholders, ", ")
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("failed to create incidents: %w", domain.ErrDuplicate)
+		}
+		return fmt.Errorf("failed to create incidents: %w", err)
+	}
+
+	// LastInsertId is the ID of the first row; InnoDB allocates the IDs of a
+	// multi-row insert consecutively
+	firstID, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	for i, incident := range incidents {
+		incident.ID = int(firstID) + i
+	}
+	return nil
+}
+
+// GetByID retrieves an incident by its ID from the reader
+func (r *MySQLIncidentRepository) GetByID(ctx context.Context, id int) (*domain.Incident, error) {
+	return r.getByID(ctx, r.reader, id)
+}
+
+// GetByIDForWrite retrieves an incident by its ID from the writer, so an incident about to be
+// changed is never read from a lagging replica
+func (r *MySQLIncidentRepository) GetByIDForWrite(ctx context.Context, id int) (*domain.Incident, error) {
+	return r.getByID(ctx, r.db, id)
+}
+
+// getByID retrieves an incident by its ID through q
+func (r *MySQLIncidentRepository) getByID(ctx context.Context, q queryRower, id int) (*domain.Incident, error) {
+	query := `
+		SELECT ` + incidentColumns + `
 		FROM incidents WHERE id = ?
 	`
-	
-	incident := &domain.Incident{}
-	err := r.db.QueryRow(query, id).Scan(
-		&incident.ID,
-		&incident.Title,
-		&incident.Description,
-		&incident.AffectedService,
-		&incident.AISeverity,
-		&incident.AICategory,
-		&incident.CreatedAt,
-		&incident.UpdatedAt,
-	)
-	
+
+	incident, err := scanIncident(q.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("incident not found with id %d", id)
+			return nil, r.missingIncident(ctx, q, id)
 		}
 		return nil, fmt.Errorf("failed to get incident: %w", err)
 	}
@@ -74,14 +426,126 @@ func (r *MySQLIncidentRepository) GetByID(id int) (*domain.Incident, error) {
 	return incident, nil
 }
 
+// missingIncident explains why an incident row is absent, checking the archive through q:
+// domain.ErrDeleted when the archive has a record of it, domain.ErrNotFound otherwise
+func (r *MySQLIncidentRepository) missingIncident(ctx context.Context, q queryRower, id int) error {
+	var archived bool
+	err := q.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM incident_archive WHERE incident_id = ?)`, id).Scan(&archived)
+	if err != nil {
+		return fmt.Errorf("failed to check incident archive: %w", err)
+	}
+
+	if archived {
+		return fmt.Errorf("incident %d was deleted: %w", id, domain.ErrDeleted)
+	}
+	return fmt.Errorf("incident not found with id %d: %w", id, domain.ErrNotFound)
+}
+
+// GetIDByReference returns the ID of the incident with a human-friendly reference
+func (r *MySQLIncidentRepository) GetIDByReference(ctx context.Context, reference string) (int, error) {
+	var id int
+	err := r.reader.QueryRowContext(ctx, `SELECT id FROM incidents WHERE reference = ?`, reference).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("incident not found with reference %q: %w", reference, domain.ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get incident by reference: %w", err)
+	}
+	return id, nil
+}
+
 // GetAll retrieves all incidents from the database
-func (r *MySQLIncidentRepository) GetAll() ([]*domain.Incident, error) {
+func (r *MySQLIncidentRepository) GetAll(ctx context.Context) ([]*domain.Incident, error) {
+	return r.GetAllFiltered(ctx, nil)
+}
+
+// FindDuplicate returns the most recent incident with the same title and affected service
+// created at or after since (any time when since is zero), or nil when there is none
+func (r *MySQLIncidentRepository) FindDuplicate(ctx context.Context, title, affectedService string, since time.Time) (*domain.Incident, error) {
+	where, args := duplicateClause(title, affectedService, since)
 	query := `
-		SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at
-		FROM incidents ORDER BY created_at DESC
+		SELECT ` + incidentColumns + `
+		FROM incidents` + where + `
+		ORDER BY created_at DESC LIMIT 1
 	`
-	
-	rows, err := r.db.Query(query)
+
+	incident, err := scanIncident(r.reader.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find duplicate incident: %w", err)
+	}
+
+	return incident, nil
+}
+
+// duplicateClause builds the WHERE clause matching duplicates of title and affectedService
+// created at or after since, or of any age when since is zero
+func duplicateClause(title, affectedService string, since time.Time) (string, []interface{}) {
+	where := " WHERE title = ? AND affected_service = ?"
+	args := []interface{}{title, affectedService}
+	if !since.IsZero() {
+		where += " AND created_at >= ?"
+		args = append(args, since)
+	}
+	return where, args
+}
+
+// GetAllFiltered retrieves the incidents matching a filter, newest first
+func (r *MySQLIncidentRepository) GetAllFiltered(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.Incident, error) {
+	where, args := buildFilterClause(filter)
+	query := `
+		SELECT ` + incidentColumns + `
+		FROM incidents` + where + ` ORDER BY created_at DESC
+	`
+	return r.queryIncidents(ctx, query, args...)
+}
+
+// GetAllPaginated retrieves up to limit incidents matching a filter after skipping offset,
+// newest first. Ties on created_at are broken by ID so pages do not overlap.
+func (r *MySQLIncidentRepository) GetAllPaginated(ctx context.Context, filter *domain.IncidentFilter, limit, offset int) ([]*domain.Incident, error) {
+	where, args := buildFilterClause(filter)
+	query := `
+		SELECT ` + incidentColumns + `
+		FROM incidents` + where + ` ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+	return r.queryIncidents(ctx, query, append(args, limit, offset)...)
+}
+
+// Search retrieves up to limit incidents after skipping offset whose title or description
+// contains term, newest first, with the number of matching incidents. The term is matched
+// literally: LIKE wildcards in it are escaped. False positives are left out.
+func (r *MySQLIncidentRepository) Search(ctx context.Context, term string, limit, offset int) (*domain.SearchPage, error) {
+	pattern := "%" + escapeLike(term) + "%"
+	where := " WHERE (title LIKE ? OR description LIKE ?) AND " + notFalsePositive
+
+	page := &domain.SearchPage{}
+	if err := r.reader.QueryRowContext(ctx, `SELECT COUNT(*) FROM incidents`+where, pattern, pattern).Scan(&page.Total); err != nil {
+		return nil, fmt.Errorf("failed to count search results: %w", err)
+	}
+	if page.Total == 0 {
+		page.Incidents = []*domain.Incident{}
+		return page, nil
+	}
+
+	query := `
+		SELECT ` + incidentColumns + `
+		FROM incidents` + where + ` ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+	incidents, err := r.queryIncidents(ctx, query, pattern, pattern, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	page.Incidents = incidents
+	return page, nil
+}
+
+// queryIncidents runs a query selecting incidentColumns on the reader and scans every row
+func (r *MySQLIncidentRepository) queryIncidents(ctx context.Context, query string, args ...interface{}) ([]*domain.Incident, error) {
+	rows, err := r.reader.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query incidents: %w", err)
 	}
@@ -89,17 +553,336 @@ func (r *MySQLIncidentRepository) GetAll() ([]*domain.Incident, error) {
 
 	var incidents []*domain.Incident
 	for rows.Next() {
-		incident := &domain.Incident{}
-		err := rows.Scan(
-			&incident.ID,
-			&incident.Title,
-			&incident.Description,
-			&incident.AffectedService,
-			&incident.AISeverity,
-			&incident.AICategory,
-			&incident.CreatedAt,
-			&incident.UpdatedAt,
-		)
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incidents: %w", err)
+	}
+
+	return incidents, nil
+}
+
+// GetAllSummary retrieves the compact projection of up to limit incidents matching a filter
+// after skipping offset, newest first, without reading the description or custom fields
+func (r *MySQLIncidentRepository) GetAllSummary(ctx context.Context, filter *domain.IncidentFilter, limit, offset int) ([]*domain.IncidentSummary, error) {
+	where, args := buildFilterClause(filter)
+	query := `
+		SELECT id, title, ai_severity, ai_category, created_at
+		FROM incidents` + where + ` ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.reader.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident summaries: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []*domain.IncidentSummary
+	for rows.Next() {
+		summary := &domain.IncidentSummary{}
+		if err := rows.Scan(&summary.ID, &summary.Title, &summary.AISeverity, &summary.AICategory, &summary.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan incident summary: %w", err)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incident summaries: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// ColumnLimits reads the character lengths of the incident text columns from the live schema
+func (r *MySQLIncidentRepository) ColumnLimits() (domain.FieldLimits, error) {
+	query := `
+		SELECT COLUMN_NAME, CHARACTER_MAXIMUM_LENGTH
+		FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'incidents'
+		AND COLUMN_NAME IN ('title', 'description', 'affected_service')
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return domain.FieldLimits{}, fmt.Errorf("failed to query column lengths: %w", err)
+	}
+	defer rows.Close()
+
+	var limits domain.FieldLimits
+	for rows.Next() {
+		var column string
+		var length int
+		if err := rows.Scan(&column, &length); err != nil {
+			return domain.FieldLimits{}, fmt.Errorf("failed to scan column length: %w", err)
+		}
+
+		switch column {
+		case "title":
+			limits.Title = length
+		case "description":
+			limits.Description = length
+		case "affected_service":
+			limits.AffectedService = length
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return domain.FieldLimits{}, fmt.Errorf("error iterating column lengths: %w", err)
+	}
+
+	return limits, nil
+}
+
+// GetQueue returns a page of the triage queue: most urgent effective priority first, then most
+// severe, then oldest first. Severities outside domain.Severities rank below Low, and false
+// positives are left out.
+func (r *MySQLIncidentRepository) GetQueue(ctx context.Context, limit, offset int) ([]*domain.Incident, error) {
+	priority, args := effectivePriority()
+	query := `
+		SELECT ` + incidentColumns + `
+		FROM incidents
+		WHERE ` + notFalsePositive + `
+		ORDER BY ` + priority + ` ASC, FIELD(ai_severity, ` + placeholders(len(domain.Severities)) + `) DESC, created_at ASC, id ASC
+		LIMIT ? OFFSET ?
+	`
+
+	for _, severity := range domain.Severities {
+		args = append(args, severity)
+	}
+	args = append(args, limit, offset)
+
+	rows, err := r.reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query triage queue: %w", err)
+	}
+	defer rows.Close()
+
+	incidents := []*domain.Incident{}
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating triage queue: %w", err)
+	}
+
+	return incidents, nil
+}
+
+// MaxUpdatedAt returns the number of incidents matching a filter, their latest update time and
+// a checksum of their IDs and update times, as read from the replica
+func (r *MySQLIncidentRepository) MaxUpdatedAt(ctx context.Context, filter *domain.IncidentFilter) (*domain.ListVersion, error) {
+	return maxUpdatedAt(ctx, r.reader, filter)
+}
+
+// MaxUpdatedAtFromWriter returns the same version as MaxUpdatedAt, read from the writer so it
+// reflects changes the replica has not caught up with yet
+func (r *MySQLIncidentRepository) MaxUpdatedAtFromWriter(ctx context.Context, filter *domain.IncidentFilter) (*domain.ListVersion, error) {
+	return maxUpdatedAt(ctx, r.db, filter)
+}
+
+// maxUpdatedAt reads the version of a filtered listing through q. The checksum changes when an
+// incident is edited, or one is deleted and another created, without moving the count or the
+// latest update time.
+func maxUpdatedAt(ctx context.Context, q queryRower, filter *domain.IncidentFilter) (*domain.ListVersion, error) {
+	where, args := buildFilterClause(filter)
+	query := `SELECT COUNT(*), MAX(updated_at), COALESCE(BIT_XOR(CRC32(CONCAT_WS(':', id, updated_at))), 0) FROM incidents` + where
+
+	var count int
+	var maxUpdatedAt sql.NullTime
+	var checksum uint64
+	if err := q.QueryRowContext(ctx, query, args...).Scan(&count, &maxUpdatedAt, &checksum); err != nil {
+		return nil, fmt.Errorf("failed to get incident list version: %w", err)
+	}
+
+	return &domain.ListVersion{Count: count, MaxUpdatedAt: maxUpdatedAt.Time, Checksum: checksum}, nil
+}
+
+// CountDistribution counts the incidents matching the filter per severity and per category in
+// a single query, ordered by field and then value, followed by the total number of affected
+// users as field "affected_users" with value "total"
+func (r *MySQLIncidentRepository) CountDistribution(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.DistributionCount, error) {
+	where, whereArgs := buildFilterClause(filter)
+	query := `
+		SELECT 'ai_severity' AS field, ai_severity AS value, COUNT(*) FROM incidents` + where + ` GROUP BY ai_severity
+		UNION ALL
+		SELECT 'ai_category' AS field, ai_category AS value, COUNT(*) FROM incidents` + where + ` GROUP BY ai_category
+		UNION ALL
+		SELECT 'affected_users' AS field, 'total' AS value, COALESCE(SUM(affected_users), 0) FROM incidents` + where + `
+		ORDER BY field DESC, value ASC
+	`
+
+	// The clause appears once per counted field
+	var args []interface{}
+	for i := 0; i < 3; i++ {
+		args = append(args, whereArgs...)
+	}
+
+	rows, err := r.reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count incident distribution: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []*domain.DistributionCount{}
+	for rows.Next() {
+		count := &domain.DistributionCount{}
+		if err := rows.Scan(&count.Field, &count.Value, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan incident distribution: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incident distribution: %w", err)
+	}
+
+	return counts, nil
+}
+
+// untrimmedCondition matches incidents whose title, description or affected service has
+// surrounding spaces
+const untrimmedCondition = "title <> TRIM(title) OR description <> TRIM(description) OR affected_service <> TRIM(affected_service)"
+
+// qualityCheck is the condition matching the incidents with one data quality issue
+type qualityCheck struct {
+	issue string
+	where string
+	args  []interface{}
+}
+
+// qualityChecks returns the data quality checks in report order
+func qualityChecks() []qualityCheck {
+	return []qualityCheck{
+		{issue: domain.QualityMissingDescription, where: "TRIM(description) = ''"},
+		{
+			issue: domain.QualityPlaceholderDescription,
+			where: "LOWER(TRIM(description)) IN (" + placeholders(len(domain.PlaceholderDescriptions)) + ")",
+			args:  stringArgs(domain.PlaceholderDescriptions),
+		},
+		{
+			issue: domain.QualityInvalidSeverity,
+			where: "ai_severity NOT IN (" + placeholders(len(domain.Severities)) + ")",
+			args:  stringArgs(domain.Severities),
+		},
+		{
+			issue: domain.QualityInvalidCategory,
+			where: "ai_category NOT IN (" + placeholders(len(domain.Categories)) + ")",
+			args:  stringArgs(domain.Categories),
+		},
+		{issue: domain.QualityShortTitle, where: "CHAR_LENGTH(TRIM(title)) < ?", args: []interface{}{domain.MinTitleLength}},
+		{issue: domain.QualityUntrimmed, where: untrimmedCondition},
+	}
+}
+
+// stringArgs converts values to query arguments
+func stringArgs(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
+
+// CountQualityIssues counts the incidents with each data quality issue, one query per issue,
+// including false positives
+func (r *MySQLIncidentRepository) CountQualityIssues(ctx context.Context) ([]*domain.QualityCount, error) {
+	checks := qualityChecks()
+	counts := make([]*domain.QualityCount, 0, len(checks))
+	for _, check := range checks {
+		count := &domain.QualityCount{Issue: check.issue}
+		if err := r.reader.QueryRowContext(ctx, `SELECT COUNT(*) FROM incidents WHERE `+check.where, check.args...).Scan(&count.Count); err != nil {
+			return nil, fmt.Errorf("failed to count %s incidents: %w", check.issue, err)
+		}
+		counts = append(counts, count)
+	}
+	return counts, nil
+}
+
+// TrimWhitespace trims surrounding spaces from the title, description and affected service of
+// every incident and returns how many incidents changed
+func (r *MySQLIncidentRepository) TrimWhitespace(ctx context.Context, updatedAt time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE incidents
+		SET title = TRIM(title), description = TRIM(description), affected_service = TRIM(affected_service), updated_at = ?
+		WHERE `+untrimmedCondition, updatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to trim incidents: %w", err)
+	}
+
+	trimmed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(trimmed), nil
+}
+
+// StreamAll calls fn for every incident matching the filter, oldest first, without loading the
+// full result set into memory. Iteration stops at the first error returned by fn.
+func (r *MySQLIncidentRepository) StreamAll(ctx context.Context, filter *domain.IncidentFilter, fn func(*domain.Incident) error) error {
+	where, args := buildFilterClause(filter)
+	query := `
+		SELECT ` + incidentColumns + `
+		FROM incidents` + where + ` ORDER BY id ASC
+	`
+
+	rows, err := r.reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query incidents: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan incident: %w", err)
+		}
+		if err := fn(incident); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating incidents: %w", err)
+	}
+
+	return nil
+}
+
+// GetPageAfterID returns up to limit incidents matching the filter with an ID greater than
+// afterID, oldest first, in the same order as StreamAll so paged exports see the same sequence
+func (r *MySQLIncidentRepository) GetPageAfterID(ctx context.Context, filter *domain.IncidentFilter, afterID, limit int) ([]*domain.Incident, error) {
+	where, args := buildFilterClause(filter)
+	if where == "" {
+		where = " WHERE id > ?"
+	} else {
+		where += " AND id > ?"
+	}
+	query := `
+		SELECT ` + incidentColumns + `
+		FROM incidents` + where + ` ORDER BY id ASC LIMIT ?
+	`
+
+	args = append(args, afterID, limit)
+	rows, err := r.reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident page: %w", err)
+	}
+	defer rows.Close()
+
+	var incidents []*domain.Incident
+	for rows.Next() {
+		incident, err := scanIncident(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan incident: %w", err)
 		}
@@ -113,22 +896,310 @@ func (r *MySQLIncidentRepository) GetAll() ([]*domain.Incident, error) {
 	return incidents, nil
 }
 
-// Update updates an existing incident in the database
-func (r *MySQLIncidentRepository) Update(incident *domain.Incident) error {
+// GetUnfinishedAnalyses returns up to limit incidents whose analysis failed, or has been
+// pending since before pendingBefore, oldest first. A pending analysis that old was lost, e.g.
+// to a restart, and will never be saved.
+func (r *MySQLIncidentRepository) GetUnfinishedAnalyses(ctx context.Context, pendingBefore time.Time, limit int) ([]*domain.Incident, error) {
+	query := `
+		SELECT ` + incidentColumns + `
+		FROM incidents
+		WHERE analysis_status = ? OR (analysis_status = ? AND updated_at < ?)
+		ORDER BY id ASC LIMIT ?
+	`
+
+	rows, err := r.reader.QueryContext(ctx, query, domain.AnalysisFailed, domain.AnalysisPending, pendingBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incidents by analysis status: %w", err)
+	}
+	defer rows.Close()
+
+	var incidents []*domain.Incident
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incidents: %w", err)
+	}
+
+	return incidents, nil
+}
+
+// GetRecent retrieves up to limit incidents created at or after since, newest first, skipping
+// excludeID and false positives
+func (r *MySQLIncidentRepository) GetRecent(ctx context.Context, since time.Time, excludeID, limit int) ([]*domain.Incident, error) {
+	query := `
+		SELECT ` + incidentColumns + `
+		FROM incidents WHERE created_at >= ? AND id <> ? AND ` + notFalsePositive + `
+		ORDER BY created_at DESC, id DESC LIMIT ?
+	`
+
+	rows, err := r.reader.QueryContext(ctx, query, since, excludeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent incidents: %w", err)
+	}
+	defer rows.Close()
+
+	var incidents []*domain.Incident
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incidents: %w", err)
+	}
+
+	return incidents, nil
+}
+
+// GetIDsBySeverity returns up to limit IDs greater than afterID of incidents with the given
+// severity, in ascending order. It reads from the primary so a remap sees every committed row.
+func (r *MySQLIncidentRepository) GetIDsBySeverity(ctx context.Context, severity string, afterID, limit int) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM incidents WHERE ai_severity = ? AND id > ? ORDER BY id ASC LIMIT ?`, severity, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incidents by severity: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan incident id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incident ids: %w", err)
+	}
+
+	return ids, nil
+}
+
+// UpdateSeverity sets the severity and updated_at of the incidents with the given IDs
+func (r *MySQLIncidentRepository) UpdateSeverity(ctx context.Context, ids []int, severity string, updatedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, 0, len(ids)+2)
+	args = append(args, severity, updatedAt)
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	query := `UPDATE incidents SET ai_severity = ?, updated_at = ? WHERE id IN (` + strings.Join(placeholders, ", ") + `)`
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to update incident severities: %w", err)
+	}
+
+	return nil
+}
+
+// Reassign moves the incidents assigned to from, except false positives, to the assignee to
+// in a single transaction and returns their IDs in ascending order
+func (r *MySQLIncidentRepository) Reassign(ctx context.Context, from, to string, scope domain.ReassignScope, updatedAt time.Time) ([]int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin reassignment: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `SELECT id FROM incidents WHERE assignee = ? AND ` + notFalsePositive
+	args := []interface{}{from}
+	if scope.Severity != "" {
+		query += ` AND ai_severity = ?`
+		args = append(args, scope.Severity)
+	}
+	if scope.AffectedService != "" {
+		query += ` AND affected_service = ?`
+		args = append(args, scope.AffectedService)
+	}
+	query += ` ORDER BY id ASC FOR UPDATE`
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incidents to reassign: %w", err)
+	}
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan incident id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incident ids: %w", err)
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	updateArgs := make([]interface{}, 0, len(ids)+2)
+	updateArgs = append(updateArgs, to, updatedAt)
+	for i, id := range ids {
+		placeholders[i] = "?"
+		updateArgs = append(updateArgs, id)
+	}
+
+	update := `UPDATE incidents SET assignee = ?, updated_at = ? WHERE id IN (` + strings.Join(placeholders, ", ") + `)`
+	if _, err := tx.ExecContext(ctx, update, updateArgs...); err != nil {
+		return nil, fmt.Errorf("failed to reassign incidents: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit reassignment: %w", err)
+	}
+
+	return ids, nil
+}
+
+// MarkFalsePositive records the reason an incident is a false positive. It returns
+// domain.ErrNotFound or domain.ErrDeleted for a missing incident and domain.ErrFalsePositive
+// when the incident is already marked.
+func (r *MySQLIncidentRepository) MarkFalsePositive(ctx context.Context, id int, reason string, updatedAt time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE incidents SET false_positive_reason = ?, updated_at = ?
+		WHERE id = ? AND false_positive_reason IS NULL
+	`, reason, updatedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark incident as false positive: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	var marked bool
+	err = r.db.QueryRowContext(ctx, `SELECT false_positive_reason IS NOT NULL FROM incidents WHERE id = ?`, id).Scan(&marked)
+	if err == sql.ErrNoRows {
+		return r.missingIncident(ctx, r.db, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check incident: %w", err)
+	}
+	return fmt.Errorf("incident %d: %w", id, domain.ErrFalsePositive)
+}
+
+// SetStormCount sets the storm count custom field of an alert storm incident, leaving its other
+// fields as responders edited them. It returns domain.ErrNotFound or domain.ErrDeleted for a
+// missing incident and domain.ErrFalsePositive when the incident is marked as a false positive.
+func (r *MySQLIncidentRepository) SetStormCount(ctx context.Context, id, count int, updatedAt time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE incidents SET custom_fields = JSON_SET(COALESCE(custom_fields, JSON_OBJECT()), '$.`+domain.StormCountField+`', ?), updated_at = ?
+		WHERE id = ? AND false_positive_reason IS NULL
+	`, count, updatedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to set storm count: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	var marked bool
+	err = r.db.QueryRowContext(ctx, `SELECT false_positive_reason IS NOT NULL FROM incidents WHERE id = ?`, id).Scan(&marked)
+	if err == sql.ErrNoRows {
+		return r.missingIncident(ctx, r.db, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check incident: %w", err)
+	}
+	if marked {
+		return fmt.Errorf("incident %d: %w", id, domain.ErrFalsePositive)
+	}
+	return nil
+}
+
+// SetPriorityOverride sets the priority override of an incident, clearing it when priority is
+// empty. It returns domain.ErrNotFound or domain.ErrDeleted for a missing incident and
+// domain.ErrFalsePositive when the incident is marked as a false positive.
+func (r *MySQLIncidentRepository) SetPriorityOverride(ctx context.Context, id int, priority string, updatedAt time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE incidents SET priority_override = ?, updated_at = ?
+		WHERE id = ? AND false_positive_reason IS NULL
+	`, nullIfEmpty(priority), updatedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to set incident priority: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	var marked bool
+	err = r.db.QueryRowContext(ctx, `SELECT false_positive_reason IS NOT NULL FROM incidents WHERE id = ?`, id).Scan(&marked)
+	if err == sql.ErrNoRows {
+		return r.missingIncident(ctx, r.db, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check incident: %w", err)
+	}
+	if marked {
+		return fmt.Errorf("incident %d: %w", id, domain.ErrFalsePositive)
+	}
+	return nil
+}
+
+// Update updates an existing incident in the database, provided its analysis status is still
+// analysisStatus. It returns domain.ErrAnalysisChanged when a background analysis or reprocess
+// saved its result since the incident was read, so the caller can reapply its edit.
+func (r *MySQLIncidentRepository) Update(ctx context.Context, incident *domain.Incident, analysisStatus string) error {
 	query := `
 		UPDATE incidents 
-		SET title = ?, description = ?, affected_service = ?, ai_severity = ?, ai_category = ?, updated_at = ?
-		WHERE id = ?
+		SET title = ?, description = ?, affected_service = ?, ai_severity = ?, ai_category = ?, updated_at = ?, custom_fields = ?, ai_suggested_action = ?, assignee = ?, analysis_status = ?, ai_reasoning = ?, ai_input_truncated = ?, affected_users = ?, ai_confidence = ?
+		WHERE id = ? AND analysis_status = ?
 	`
-	
-	result, err := r.db.Exec(query,
+
+	customFields, err := encodeCustomFields(incident.CustomFields)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
 		incident.Title,
 		incident.Description,
 		incident.AffectedService,
 		incident.AISeverity,
 		incident.AICategory,
 		incident.UpdatedAt,
+		customFields,
+		nullIfEmpty(incident.AISuggestedAction),
+		nullIfEmpty(incident.Assignee),
+		incident.AnalysisStatus,
+		nullIfEmpty(incident.AIReasoning),
+		incident.AIInputTruncated,
+		nullIfUnset(incident.AffectedUsers),
+		nullIfUnset(incident.AIConfidence),
 		incident.ID,
+		analysisStatus,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update incident: %w", err)
@@ -138,21 +1209,76 @@ func (r *MySQLIncidentRepository) Update(incident *domain.Incident) error {
 	if err != nil {
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("incident not found with id %d", incident.ID)
+	if rowsAffected > 0 {
+		return nil
 	}
 
+	// Nothing changed: the incident is gone, its analysis moved on, or the update was a no-op
+	var current string
+	err = r.db.QueryRowContext(ctx, `SELECT analysis_status FROM incidents WHERE id = ?`, incident.ID).Scan(&current)
+	if err == sql.ErrNoRows {
+		return r.missingIncident(ctx, r.db, incident.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check incident: %w", err)
+	}
+	if current != analysisStatus {
+		return fmt.Errorf("incident %d: %w", incident.ID, domain.ErrAnalysisChanged)
+	}
 	return nil
 }
 
-// Delete removes an incident from the database
-func (r *MySQLIncidentRepository) Delete(id int) error {
-	query := `DELETE FROM incidents WHERE id = ?`
-	
-	result, err := r.db.Exec(query, id)
+// SaveAnalysis writes the AI analysis fields and analysis status of incident, and its
+// assignee unless one was set meanwhile, provided its analysis status is still status. Other
+// fields are left alone so edits made while the analysis ran are kept. It reports false when
+// the status has changed, e.g. because an update reanalyzed the incident.
+func (r *MySQLIncidentRepository) SaveAnalysis(ctx context.Context, incident *domain.Incident, status string) (bool, error) {
+	query := `
+		UPDATE incidents
+		SET ai_severity = ?, ai_category = ?, ai_suggested_action = ?, ai_reasoning = ?, ai_input_truncated = ?, ai_confidence = ?, assignee = COALESCE(assignee, ?), analysis_status = ?, updated_at = ?
+		WHERE id = ? AND analysis_status = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		incident.AISeverity,
+		incident.AICategory,
+		nullIfEmpty(incident.AISuggestedAction),
+		nullIfEmpty(incident.AIReasoning),
+		incident.AIInputTruncated,
+		nullIfUnset(incident.AIConfidence),
+		nullIfEmpty(incident.Assignee),
+		incident.AnalysisStatus,
+		incident.UpdatedAt,
+		incident.ID,
+		status,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to delete incident: %w", err)
+		return false, fmt.Errorf("failed to save incident analysis: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// Delete removes an incident, first writing a compact row to incident_archive in the same
+// transaction so every hard delete leaves a provenance record
+func (r *MySQLIncidentRepository) Delete(ctx context.Context, id int, purgedBy string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin delete: %w", err)
+	}
+	defer tx.Rollback()
+
+	archive := `
+		INSERT INTO incident_archive (incident_id, title, ai_severity, created_at, deleted_at, purged_by)
+		SELECT id, title, ai_severity, created_at, NULL, ? FROM incidents WHERE id = ?
+	`
+	result, err := tx.ExecContext(ctx, archive, purgedBy, id)
+	if err != nil {
+		return fmt.Errorf("failed to archive incident: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -161,7 +1287,15 @@ func (r *MySQLIncidentRepository) Delete(id int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("incident not found with id %d", id)
+		return fmt.Errorf("incident not found with id %d: %w", id, domain.ErrNotFound)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM incidents WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete incident: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit delete: %w", err)
 	}
 
 	return nil
@@ -1,13 +1,17 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"testing"
 	"time"
 
 	"incident-triage-assistant/internal/domain"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,25 +23,171 @@ func TestMySQLIncidentRepository_Create(t *testing.T) {
 	repo := NewMySQLIncidentRepository(db)
 
 	incident := &domain.Incident{
+		Reference:       "INC-2024-000123",
 		Title:           "Test Incident",
 		Description:     "Test Description",
 		AffectedService: "Test Service",
 		AISeverity:      "Medium",
 		AICategory:      "Software",
+		AIConfidence:    floatPtr(0.35),
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
 	}
 
 	mock.ExpectExec("INSERT INTO incidents").
-		WithArgs(incident.Title, incident.Description, incident.AffectedService, incident.AISeverity, incident.AICategory, incident.CreatedAt, incident.UpdatedAt).
+		WithArgs(incident.Title, incident.Description, incident.AffectedService, incident.AISeverity, incident.AICategory, incident.CreatedAt, incident.UpdatedAt, nil, nil, nil, incident.AnalysisStatus, nil, false, nil, incident.Reference, 0.35).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.Create(incident)
+	err = repo.Create(context.Background(), incident)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, incident.ID)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestMySQLIncidentRepository_Create_Duplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	incident := &domain.Incident{
+		Title:           "Test Incident",
+		Description:     "Test Description",
+		AffectedService: "Test Service",
+		AISeverity:      "Medium",
+		AICategory:      "Software",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO incidents").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'x' for key 'incidents.uniq'"})
+
+	err = repo.Create(context.Background(), incident)
+	assert.ErrorIs(t, err, domain.ErrDuplicate)
+	assert.Equal(t, 0, incident.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_CreateUnique(t *testing.T) {
+	incident := func() *domain.Incident {
+		return &domain.Incident{Title: "Disk full", AffectedService: "storage", AISeverity: "High", AICategory: "Hardware"}
+	}
+
+	t.Run("no existing incident", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM incidents WHERE title = \\? AND affected_service = \\? LIMIT 1 FOR UPDATE").
+			WithArgs("Disk full", "storage").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec("INSERT INTO incidents").WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectCommit()
+
+		created := incident()
+		err = NewMySQLIncidentRepository(db).CreateUnique(context.Background(), created, time.Time{})
+		assert.NoError(t, err)
+		assert.Equal(t, 5, created.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM incidents WHERE title = \\? AND affected_service = \\? LIMIT 1 FOR UPDATE").
+			WithArgs("Disk full", "storage").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+		mock.ExpectRollback()
+
+		err = NewMySQLIncidentRepository(db).CreateUnique(context.Background(), incident(), time.Time{})
+		assert.ErrorIs(t, err, domain.ErrDuplicate)
+		assert.Contains(t, err.Error(), "duplicate of incident 42")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("deadlock is retried", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM incidents WHERE title = \\? AND affected_service = \\? LIMIT 1 FOR UPDATE").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec("INSERT INTO incidents").
+			WillReturnError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"})
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM incidents WHERE title = \\? AND affected_service = \\? LIMIT 1 FOR UPDATE").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(43))
+		mock.ExpectRollback()
+
+		created := incident()
+		err = NewMySQLIncidentRepository(db).CreateUnique(context.Background(), created, time.Time{})
+		assert.ErrorIs(t, err, domain.ErrDuplicate)
+		assert.Contains(t, err.Error(), "duplicate of incident 43")
+		assert.Equal(t, 0, created.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("repeated deadlocks are a duplicate", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		for i := 0; i < createUniqueAttempts; i++ {
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT id FROM incidents").WillReturnError(sql.ErrNoRows)
+			mock.ExpectExec("INSERT INTO incidents").
+				WillReturnError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"})
+			mock.ExpectRollback()
+		}
+
+		err = NewMySQLIncidentRepository(db).CreateUnique(context.Background(), incident(), time.Time{})
+		assert.ErrorIs(t, err, domain.ErrDuplicate)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("within a dedup window", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		since := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM incidents WHERE title = \\? AND affected_service = \\? AND created_at >= \\? LIMIT 1 FOR UPDATE").
+			WithArgs("Disk full", "storage", since).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec("INSERT INTO incidents").WillReturnResult(sqlmock.NewResult(6, 1))
+		mock.ExpectCommit()
+
+		err = NewMySQLIncidentRepository(db).CreateUnique(context.Background(), incident(), since)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMySQLIncidentRepository_Create_OtherMySQLError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	mock.ExpectExec("INSERT INTO incidents").
+		WillReturnError(&mysql.MySQLError{Number: 1406, Message: "Data too long for column 'title'"})
+
+	err = repo.Create(context.Background(), &domain.Incident{})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrDuplicate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMySQLIncidentRepository_GetByID(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -45,6 +195,7 @@ func TestMySQLIncidentRepository_GetByID(t *testing.T) {
 
 	repo := NewMySQLIncidentRepository(db)
 
+	affectedUsers := 1200
 	expectedIncident := &domain.Incident{
 		ID:              1,
 		Title:           "Test Incident",
@@ -52,18 +203,21 @@ func TestMySQLIncidentRepository_GetByID(t *testing.T) {
 		AffectedService: "Test Service",
 		AISeverity:      "Medium",
 		AICategory:      "Software",
+		AIReasoning:     "Stack traces point at the payment client",
+		AIConfidence:    floatPtr(0.72),
+		AffectedUsers:   &affectedUsers,
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at"}).
-		AddRow(expectedIncident.ID, expectedIncident.Title, expectedIncident.Description, expectedIncident.AffectedService, expectedIncident.AISeverity, expectedIncident.AICategory, expectedIncident.CreatedAt, expectedIncident.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+		AddRow(expectedIncident.ID, expectedIncident.Title, expectedIncident.Description, expectedIncident.AffectedService, expectedIncident.AISeverity, expectedIncident.AICategory, expectedIncident.CreatedAt, expectedIncident.UpdatedAt, nil, nil, nil, expectedIncident.AnalysisStatus, nil, expectedIncident.AIReasoning, false, int64(affectedUsers), nil, nil, 0.72)
 
-	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at FROM incidents WHERE id = ?").
+	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence FROM incidents WHERE id = ?").
 		WithArgs(1).
 		WillReturnRows(rows)
 
-	incident, err := repo.GetByID(1)
+	incident, err := repo.GetByID(context.Background(), 1)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedIncident, incident)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -76,14 +230,39 @@ func TestMySQLIncidentRepository_GetByID_NotFound(t *testing.T) {
 
 	repo := NewMySQLIncidentRepository(db)
 
-	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at FROM incidents WHERE id = ?").
+	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence FROM incidents WHERE id = ?").
 		WithArgs(999).
 		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM incident_archive WHERE incident_id = \\?\\)").
+		WithArgs(999).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
 
-	incident, err := repo.GetByID(999)
+	incident, err := repo.GetByID(context.Background(), 999)
 	assert.Error(t, err)
 	assert.Nil(t, incident)
 	assert.Contains(t, err.Error(), "incident not found with id 999")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_GetByID_Deleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence FROM incidents WHERE id = ?").
+		WithArgs(7).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM incident_archive WHERE incident_id = \\?\\)").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	incident, err := repo.GetByID(context.Background(), 7)
+	assert.Nil(t, incident)
+	assert.ErrorIs(t, err, domain.ErrDeleted)
+	assert.NotErrorIs(t, err, domain.ErrNotFound)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -117,15 +296,15 @@ func TestMySQLIncidentRepository_GetAll(t *testing.T) {
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at"})
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"})
 	for _, incident := range expectedIncidents {
-		rows.AddRow(incident.ID, incident.Title, incident.Description, incident.AffectedService, incident.AISeverity, incident.AICategory, incident.CreatedAt, incident.UpdatedAt)
+		rows.AddRow(incident.ID, incident.Title, incident.Description, incident.AffectedService, incident.AISeverity, incident.AICategory, incident.CreatedAt, incident.UpdatedAt, nil, nil, nil, incident.AnalysisStatus, nil, nil, false, nil, nil, nil, nil)
 	}
 
-	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at FROM incidents ORDER BY created_at DESC").
+	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence FROM incidents WHERE false_positive_reason IS NULL ORDER BY created_at DESC").
 		WillReturnRows(rows)
 
-	incidents, err := repo.GetAll()
+	incidents, err := repo.GetAll(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, expectedIncidents, incidents)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -145,15 +324,16 @@ func TestMySQLIncidentRepository_Update(t *testing.T) {
 		AffectedService: "Updated Service",
 		AISeverity:      "High",
 		AICategory:      "Network",
+		AffectedUsers:   intPtr(40),
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
 	}
 
-	mock.ExpectExec("UPDATE incidents SET title = \\?, description = \\?, affected_service = \\?, ai_severity = \\?, ai_category = \\?, updated_at = \\? WHERE id = \\?").
-		WithArgs(incident.Title, incident.Description, incident.AffectedService, incident.AISeverity, incident.AICategory, incident.UpdatedAt, incident.ID).
+	mock.ExpectExec("UPDATE incidents SET title = \\?, description = \\?, affected_service = \\?, ai_severity = \\?, ai_category = \\?, updated_at = \\?, custom_fields = \\?, ai_suggested_action = \\?, assignee = \\?, analysis_status = \\?, ai_reasoning = \\?, ai_input_truncated = \\?, affected_users = \\?, ai_confidence = \\? WHERE id = \\? AND analysis_status = \\?").
+		WithArgs(incident.Title, incident.Description, incident.AffectedService, incident.AISeverity, incident.AICategory, incident.UpdatedAt, nil, nil, nil, incident.AnalysisStatus, nil, false, 40, nil, incident.ID, domain.AnalysisComplete).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err = repo.Update(incident)
+	err = repo.Update(context.Background(), incident, domain.AnalysisComplete)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -176,16 +356,109 @@ func TestMySQLIncidentRepository_Update_NotFound(t *testing.T) {
 		UpdatedAt:       time.Now(),
 	}
 
-	mock.ExpectExec("UPDATE incidents SET title = \\?, description = \\?, affected_service = \\?, ai_severity = \\?, ai_category = \\?, updated_at = \\? WHERE id = \\?").
-		WithArgs(incident.Title, incident.Description, incident.AffectedService, incident.AISeverity, incident.AICategory, incident.UpdatedAt, incident.ID).
+	mock.ExpectExec("UPDATE incidents SET title = \\?, description = \\?, affected_service = \\?, ai_severity = \\?, ai_category = \\?, updated_at = \\?, custom_fields = \\?, ai_suggested_action = \\?, assignee = \\?, analysis_status = \\?, ai_reasoning = \\?, ai_input_truncated = \\?, affected_users = \\?, ai_confidence = \\? WHERE id = \\? AND analysis_status = \\?").
+		WithArgs(incident.Title, incident.Description, incident.AffectedService, incident.AISeverity, incident.AICategory, incident.UpdatedAt, nil, nil, nil, incident.AnalysisStatus, nil, false, nil, nil, incident.ID, domain.AnalysisComplete).
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT analysis_status FROM incidents WHERE id = \\?").
+		WithArgs(999).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM incident_archive WHERE incident_id = \\?\\)").
+		WithArgs(999).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
 
-	err = repo.Update(incident)
-	assert.Error(t, err)
+	err = repo.Update(context.Background(), incident, domain.AnalysisComplete)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
 	assert.Contains(t, err.Error(), "incident not found with id 999")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestMySQLIncidentRepository_Update_AnalysisChanged(t *testing.T) {
+	t.Run("an analysis saved since the read", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE incidents SET title = .* WHERE id = \\? AND analysis_status = \\?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT analysis_status FROM incidents WHERE id = \\?").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"analysis_status"}).AddRow(domain.AnalysisComplete))
+
+		err = NewMySQLIncidentRepository(db).Update(context.Background(), &domain.Incident{ID: 7, AnalysisStatus: domain.AnalysisPending}, domain.AnalysisPending)
+		assert.ErrorIs(t, err, domain.ErrAnalysisChanged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("an update that changed nothing", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE incidents SET title = .* WHERE id = \\? AND analysis_status = \\?").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT analysis_status FROM incidents WHERE id = \\?").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"analysis_status"}).AddRow(domain.AnalysisComplete))
+
+		err = NewMySQLIncidentRepository(db).Update(context.Background(), &domain.Incident{ID: 7, AnalysisStatus: domain.AnalysisComplete}, domain.AnalysisComplete)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMySQLIncidentRepository_CreateBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	incidents := make([]*domain.Incident, importBatchSize+1)
+	for i := range incidents {
+		incidents[i] = &domain.Incident{Title: "Imported", Description: "From CSV", AffectedService: "api", AISeverity: "Low", AICategory: "Software"}
+	}
+
+	// Every row must bind exactly one value per column
+	row := []driver.Value{"Imported", "From CSV", "api", "Low", "Software", sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil, nil, "", nil, false, nil, nil, nil}
+	var firstBatch []driver.Value
+	for i := 0; i < importBatchSize; i++ {
+		firstBatch = append(firstBatch, row...)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO incidents \\(title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, ai_reasoning, ai_input_truncated, affected_users, reference, ai_confidence\\)\\s+VALUES \\(\\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?\\), \\(").
+		WithArgs(firstBatch...).
+		WillReturnResult(sqlmock.NewResult(10, int64(importBatchSize)))
+	mock.ExpectExec("INSERT INTO incidents .+ VALUES \\(\\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?\\)$").
+		WithArgs(row...).
+		WillReturnResult(sqlmock.NewResult(110, 1))
+	mock.ExpectCommit()
+
+	err = repo.CreateBatch(context.Background(), incidents)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, incidents[0].ID)
+	assert.Equal(t, 109, incidents[importBatchSize-1].ID)
+	assert.Equal(t, 110, incidents[importBatchSize].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_CreateBatch_RollsBackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO incidents").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+	mock.ExpectRollback()
+
+	err = repo.CreateBatch(context.Background(), []*domain.Incident{{Title: "Imported"}, {Title: "Imported"}})
+	assert.ErrorIs(t, err, domain.ErrDuplicate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMySQLIncidentRepository_Delete(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -193,15 +466,38 @@ func TestMySQLIncidentRepository_Delete(t *testing.T) {
 
 	repo := NewMySQLIncidentRepository(db)
 
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO incident_archive \\(incident_id, title, ai_severity, created_at, deleted_at, purged_by\\)\\s+SELECT id, title, ai_severity, created_at, NULL, \\? FROM incidents WHERE id = \\?").
+		WithArgs("api", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectExec("DELETE FROM incidents WHERE id = ?").
 		WithArgs(1).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
-	err = repo.Delete(1)
+	err = repo.Delete(context.Background(), 1, "api")
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestMySQLIncidentRepository_Delete_ArchiveFailureKeepsIncident(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO incident_archive").
+		WithArgs("api", 1).
+		WillReturnError(errors.New("table is read only"))
+	mock.ExpectRollback()
+
+	err = repo.Delete(context.Background(), 1, "api")
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMySQLIncidentRepository_Delete_NotFound(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -209,12 +505,857 @@ func TestMySQLIncidentRepository_Delete_NotFound(t *testing.T) {
 
 	repo := NewMySQLIncidentRepository(db)
 
-	mock.ExpectExec("DELETE FROM incidents WHERE id = ?").
-		WithArgs(999).
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO incident_archive").
+		WithArgs("api", 999).
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
 
-	err = repo.Delete(999)
+	err = repo.Delete(context.Background(), 999, "api")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "incident not found with id 999")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_ReadsUseReader(t *testing.T) {
+	writer, writerMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer writer.Close()
+
+	reader, readerMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	repo := NewMySQLIncidentRepositoryWithReader(writer, reader)
+
+	readerMock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence FROM incidents WHERE id = ?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+			AddRow(1, "Test Incident", "Test Description", "Test Service", "Medium", "Software", time.Now(), time.Now(), nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil))
+	readerMock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence FROM incidents WHERE false_positive_reason IS NULL ORDER BY created_at DESC").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}))
+	writerMock.ExpectQuery("SELECT id, title, .* FROM incidents WHERE id = ?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+			AddRow(1, "Test Incident", "Test Description", "Test Service", "Medium", "Software", time.Now(), time.Now(), nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil))
+	writerMock.ExpectBegin()
+	writerMock.ExpectExec("INSERT INTO incident_archive").
+		WithArgs("api", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	writerMock.ExpectExec("DELETE FROM incidents WHERE id = ?").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	writerMock.ExpectCommit()
+
+	_, err = repo.GetByID(context.Background(), 1)
+	assert.NoError(t, err)
+	_, err = repo.GetAll(context.Background())
+	assert.NoError(t, err)
+	_, err = repo.GetByIDForWrite(context.Background(), 1)
+	assert.NoError(t, err)
+	err = repo.Delete(context.Background(), 1, "api")
+	assert.NoError(t, err)
+
+	assert.NoError(t, readerMock.ExpectationsWereMet())
+	assert.NoError(t, writerMock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_StreamAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+		AddRow(1, "Test Incident 1", "Test Description 1", "Test Service 1", "Medium", "Software", time.Now(), time.Now(), nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil).
+		AddRow(2, "Test Incident 2", "Test Description 2", "Test Service 2", "High", "Network", time.Now(), time.Now(), nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil)
+
+	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence FROM incidents WHERE ai_severity IN \\(\\?, \\?\\) AND false_positive_reason IS NULL ORDER BY id ASC").
+		WithArgs("Medium", "High").
+		WillReturnRows(rows)
+
+	var ids []int
+	err = repo.StreamAll(context.Background(), &domain.IncidentFilter{Severities: []string{"Medium", "High"}}, func(incident *domain.Incident) error {
+		ids = append(ids, incident.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_GetPageAfterID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+		AddRow(11, "Test Incident 11", "Test Description 11", "Test Service 11", "Medium", "Software", time.Now(), time.Now(), nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil)
+
+	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence FROM incidents WHERE false_positive_reason IS NULL AND id > \\? ORDER BY id ASC LIMIT \\?").
+		WithArgs(10, 500).
+		WillReturnRows(rows)
+
+	incidents, err := repo.GetPageAfterID(context.Background(), nil, 10, 500)
+	assert.NoError(t, err)
+	assert.Len(t, incidents, 1)
+	assert.Equal(t, 11, incidents[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_GetUnfinishedAnalyses(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	pendingBefore := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+		AddRow(4, "Test Incident 4", "Test Description 4", "Test Service 4", "Medium", "Software", time.Now(), time.Now(), nil, nil, nil, "failed", nil, nil, false, nil, nil, nil, nil)
+
+	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence FROM incidents WHERE analysis_status = \\? OR \\(analysis_status = \\? AND updated_at < \\?\\) ORDER BY id ASC LIMIT \\?").
+		WithArgs("failed", "pending", pendingBefore, 100).
+		WillReturnRows(rows)
+
+	incidents, err := repo.GetUnfinishedAnalyses(context.Background(), pendingBefore, 100)
+	assert.NoError(t, err)
+	assert.Len(t, incidents, 1)
+	assert.Equal(t, "failed", incidents[0].AnalysisStatus)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_SaveAnalysis(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	incident := &domain.Incident{
+		ID:             7,
+		AISeverity:     "High",
+		AICategory:     "Hardware",
+		AIReasoning:    "Writes fail on a full volume",
+		AIConfidence:   floatPtr(0.9),
+		Assignee:       "alice",
+		AnalysisStatus: "complete",
+		UpdatedAt:      updatedAt,
+	}
+
+	tests := []struct {
+		name     string
+		affected int64
+		saved    bool
+	}{
+		{name: "still pending", affected: 1, saved: true},
+		{name: "analyzed meanwhile", affected: 0, saved: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewMySQLIncidentRepository(db)
+
+			mock.ExpectExec("UPDATE incidents\\s+SET ai_severity = \\?, ai_category = \\?, ai_suggested_action = \\?, ai_reasoning = \\?, ai_input_truncated = \\?, ai_confidence = \\?, assignee = COALESCE\\(assignee, \\?\\), analysis_status = \\?, updated_at = \\?\\s+WHERE id = \\? AND analysis_status = \\?").
+				WithArgs("High", "Hardware", nil, "Writes fail on a full volume", false, 0.9, "alice", "complete", updatedAt, 7, "pending").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			saved, err := repo.SaveAnalysis(context.Background(), incident, "pending")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.saved, saved)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMySQLIncidentRepository_GetIDsBySeverity(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	mock.ExpectQuery("SELECT id FROM incidents WHERE ai_severity = \\? AND id > \\? ORDER BY id ASC LIMIT \\?").
+		WithArgs("Medium", 10, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11).AddRow(15))
+
+	ids, err := repo.GetIDsBySeverity(context.Background(), "Medium", 10, 500)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{11, 15}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_UpdateSeverity(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("UPDATE incidents SET ai_severity = \\?, updated_at = \\? WHERE id IN \\(\\?, \\?\\)").
+		WithArgs("High", updatedAt, 11, 15).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.UpdateSeverity(context.Background(), []int{11, 15}, "High", updatedAt)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBuildFilterClause(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        *domain.IncidentFilter
+		expectedWhere string
+		expectedArgs  []interface{}
+	}{
+		{name: "nil filter", filter: nil, expectedWhere: " WHERE false_positive_reason IS NULL", expectedArgs: nil},
+		{name: "empty filter", filter: &domain.IncidentFilter{}, expectedWhere: " WHERE false_positive_reason IS NULL", expectedArgs: nil},
+		{name: "including false positives", filter: &domain.IncidentFilter{IncludeFalsePositive: true}, expectedWhere: "", expectedArgs: nil},
+		{
+			name:          "single severity",
+			filter:        &domain.IncidentFilter{Severities: []string{"High"}},
+			expectedWhere: " WHERE ai_severity IN (?) AND false_positive_reason IS NULL",
+			expectedArgs:  []interface{}{"High"},
+		},
+		{
+			name:          "multiple severities and categories",
+			filter:        &domain.IncidentFilter{Severities: []string{"High", "Critical"}, Categories: []string{"Database"}, IncludeFalsePositive: true},
+			expectedWhere: " WHERE ai_severity IN (?, ?) AND ai_category IN (?)",
+			expectedArgs:  []interface{}{"High", "Critical", "Database"},
+		},
+		{
+			name:          "custom fields in key order",
+			filter:        &domain.IncidentFilter{Severities: []string{"High"}, CustomFields: map[string]string{"region": "eu", "customer_impact": "true"}},
+			expectedWhere: " WHERE ai_severity IN (?) AND custom_fields->>'$.customer_impact' = ? AND custom_fields->>'$.region' = ? AND false_positive_reason IS NULL",
+			expectedArgs:  []interface{}{"High", "true", "eu"},
+		},
+		{
+			name:          "minimum affected users",
+			filter:        &domain.IncidentFilter{Severities: []string{"High"}, MinAffectedUsers: intPtr(500)},
+			expectedWhere: " WHERE ai_severity IN (?) AND affected_users >= ? AND false_positive_reason IS NULL",
+			expectedArgs:  []interface{}{"High", 500},
+		},
+		{
+			name:          "effective priority",
+			filter:        &domain.IncidentFilter{Priorities: []string{"P1", "P2"}},
+			expectedWhere: " WHERE COALESCE(priority_override, CONCAT('P', GREATEST(1, LEAST(4, 5 - FIELD(ai_severity, ?, ?, ?, ?)) - IF(affected_users >= ?, 1, 0)))) IN (?, ?) AND false_positive_reason IS NULL",
+			expectedArgs:  []interface{}{"Low", "Medium", "High", "Critical", domain.HighImpactUsers, "P1", "P2"},
+		},
+		{
+			name:          "unsafe custom field key matches nothing",
+			filter:        &domain.IncidentFilter{CustomFields: map[string]string{"region' OR '1": "eu"}},
+			expectedWhere: " WHERE 1 = 0 AND false_positive_reason IS NULL",
+			expectedArgs:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := buildFilterClause(tt.filter)
+			assert.Equal(t, tt.expectedWhere, where)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestMySQLIncidentRepository_GetAllFiltered(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+		AddRow(1, "Test Incident 1", "Test Description 1", "Test Service 1", "Critical", "Database", time.Now(), time.Now(), nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil)
+
+	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence FROM incidents WHERE ai_severity IN \\(\\?, \\?\\) AND ai_category IN \\(\\?\\) AND false_positive_reason IS NULL ORDER BY created_at DESC").
+		WithArgs("High", "Critical", "Database").
+		WillReturnRows(rows)
+
+	incidents, err := repo.GetAllFiltered(context.Background(), &domain.IncidentFilter{Severities: []string{"High", "Critical"}, Categories: []string{"Database"}})
+	assert.NoError(t, err)
+	assert.Len(t, incidents, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_GetAllPaginated(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+		AddRow(2, "Test Incident 2", "Test Description 2", "Test Service 2", "High", "Network", time.Now(), time.Now(), nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil)
+
+	mock.ExpectQuery("SELECT id, title, description, affected_service, ai_severity, ai_category, created_at, updated_at, custom_fields, ai_suggested_action, assignee, analysis_status, false_positive_reason, ai_reasoning, ai_input_truncated, affected_users, priority_override, reference, ai_confidence FROM incidents WHERE false_positive_reason IS NULL ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?").
+		WithArgs(50, 50).
+		WillReturnRows(rows)
+
+	incidents, err := repo.GetAllPaginated(context.Background(), &domain.IncidentFilter{}, 50, 50)
+	assert.NoError(t, err)
+	if assert.Len(t, incidents, 1) {
+		assert.Equal(t, 2, incidents[0].ID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_Search(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+	pattern := `%100\%\_done%`
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM incidents WHERE \\(title LIKE \\? OR description LIKE \\?\\) AND false_positive_reason IS NULL").
+		WithArgs(pattern, pattern).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+		AddRow(5, "Batch 100%_done stuck", "Job never finished", "Batch", "Low", "Software", time.Now(), time.Now(), nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil)
+	mock.ExpectQuery("SELECT id, title, .* FROM incidents WHERE \\(title LIKE \\? OR description LIKE \\?\\) AND false_positive_reason IS NULL ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?").
+		WithArgs(pattern, pattern, 1, 2).
+		WillReturnRows(rows)
+
+	page, err := repo.Search(context.Background(), "100%_done", 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, page.Total)
+	assert.Len(t, page.Incidents, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_Search_NoMatches(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM incidents WHERE").
+		WithArgs("%timeout%", "%timeout%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	page, err := repo.Search(context.Background(), "timeout", 50, 0)
+	assert.NoError(t, err)
+	assert.Zero(t, page.Total)
+	assert.Empty(t, page.Incidents)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_GetAllSummary(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+	createdAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "title", "ai_severity", "ai_category", "created_at"}).
+		AddRow(1, "Test Incident 1", "Critical", "Database", createdAt)
+
+	mock.ExpectQuery("SELECT id, title, ai_severity, ai_category, created_at FROM incidents WHERE ai_severity IN \\(\\?\\) AND false_positive_reason IS NULL ORDER BY created_at DESC, id DESC LIMIT \\? OFFSET \\?").
+		WithArgs("Critical", 50, 100).
+		WillReturnRows(rows)
+
+	summaries, err := repo.GetAllSummary(context.Background(), &domain.IncidentFilter{Severities: []string{"Critical"}}, 50, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.IncidentSummary{{ID: 1, Title: "Test Incident 1", AISeverity: "Critical", AICategory: "Database", CreatedAt: createdAt}}, summaries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_MaxUpdatedAt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\), MAX\\(updated_at\\), COALESCE\\(BIT_XOR\\(CRC32\\(CONCAT_WS\\(':', id, updated_at\\)\\)\\), 0\\) FROM incidents WHERE ai_severity IN \\(\\?\\)").
+		WithArgs("High").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "max", "checksum"}).AddRow(3, updatedAt, uint64(48879)))
+
+	version, err := repo.MaxUpdatedAt(context.Background(), &domain.IncidentFilter{Severities: []string{"High"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.ListVersion{Count: 3, MaxUpdatedAt: updatedAt, Checksum: 48879}, version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_MaxUpdatedAt_Empty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\), MAX\\(updated_at\\), .* FROM incidents").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "max", "checksum"}).AddRow(0, nil, 0))
+
+	version, err := repo.MaxUpdatedAt(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, version.Count)
+	assert.True(t, version.MaxUpdatedAt.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_MaxUpdatedAtFromWriter(t *testing.T) {
+	writer, writerMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer writer.Close()
+	reader, readerMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	repo := NewMySQLIncidentRepositoryWithReader(writer, reader)
+
+	writerMock.ExpectQuery("SELECT COUNT\\(\\*\\), MAX\\(updated_at\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "max", "checksum"}).AddRow(1, time.Now(), 7))
+
+	version, err := repo.MaxUpdatedAtFromWriter(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, version.Count)
+	assert.NoError(t, writerMock.ExpectationsWereMet())
+	assert.NoError(t, readerMock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_CountDistribution(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	mock.ExpectQuery("FROM incidents WHERE ai_category IN \\(\\?\\) AND false_positive_reason IS NULL GROUP BY ai_severity\\s+UNION ALL\\s+SELECT 'ai_category'").
+		WithArgs("Database", "Database", "Database").
+		WillReturnRows(sqlmock.NewRows([]string{"field", "value", "count"}).
+			AddRow("ai_severity", "High", 2).
+			AddRow("ai_category", "Database", 2).
+			AddRow("affected_users", "total", 1500))
+
+	counts, err := repo.CountDistribution(context.Background(), &domain.IncidentFilter{Categories: []string{"Database"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.DistributionCount{
+		{Field: "ai_severity", Value: "High", Count: 2},
+		{Field: "ai_category", Value: "Database", Count: 2},
+		{Field: "affected_users", Value: "total", Count: 1500},
+	}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_GetQueue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+	now := time.Now()
+
+	mock.ExpectQuery("FROM incidents\\s+WHERE false_positive_reason IS NULL\\s+ORDER BY COALESCE\\(priority_override, .+\\) ASC, FIELD\\(ai_severity, \\?, \\?, \\?, \\?\\) DESC, created_at ASC, id ASC\\s+LIMIT \\? OFFSET \\?").
+		WithArgs("Low", "Medium", "High", "Critical", domain.HighImpactUsers, "Low", "Medium", "High", "Critical", 10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+			AddRow(3, "Outage", "Down", "api", "Critical", "Network", now, now, nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil).
+			AddRow(1, "Slow", "Latency", "api", "High", "Network", now, now, nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil))
+
+	incidents, err := repo.GetQueue(context.Background(), 10, 20)
+	assert.NoError(t, err)
+	assert.Len(t, incidents, 2)
+	assert.Equal(t, 3, incidents[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_Reassign(t *testing.T) {
+	now := time.Now()
+
+	t.Run("moves the matching incidents in one transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM incidents WHERE assignee = \\? AND false_positive_reason IS NULL AND ai_severity = \\? AND affected_service = \\? ORDER BY id ASC FOR UPDATE").
+			WithArgs("alice", "High", "api").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(8))
+		mock.ExpectExec("UPDATE incidents SET assignee = \\?, updated_at = \\? WHERE id IN \\(\\?, \\?\\)").
+			WithArgs("bob", now, 3, 8).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		ids, err := NewMySQLIncidentRepository(db).Reassign(context.Background(), "alice", "bob", domain.ReassignScope{Severity: "High", AffectedService: "api"}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{3, 8}, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no matching incidents", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM incidents WHERE assignee = \\? AND false_positive_reason IS NULL ORDER BY id ASC FOR UPDATE").
+			WithArgs("alice").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		ids, err := NewMySQLIncidentRepository(db).Reassign(context.Background(), "alice", "bob", domain.ReassignScope{}, now)
+		assert.NoError(t, err)
+		assert.Empty(t, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update failure rolls back", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM incidents").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectExec("UPDATE incidents SET assignee").
+			WillReturnError(errors.New("lock wait timeout"))
+		mock.ExpectRollback()
+
+		_, err = NewMySQLIncidentRepository(db).Reassign(context.Background(), "alice", "bob", domain.ReassignScope{}, now)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMySQLIncidentRepository_MarkFalsePositive(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("marks the incident", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE incidents SET false_positive_reason = \\?, updated_at = \\?\\s+WHERE id = \\? AND false_positive_reason IS NULL").
+			WithArgs("Synthetic probe", updatedAt, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, NewMySQLIncidentRepository(db).MarkFalsePositive(context.Background(), 7, "Synthetic probe", updatedAt))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already marked", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE incidents SET false_positive_reason").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT false_positive_reason IS NOT NULL FROM incidents WHERE id = \\?").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"marked"}).AddRow(true))
+
+		err = NewMySQLIncidentRepository(db).MarkFalsePositive(context.Background(), 7, "Synthetic probe", updatedAt)
+		assert.ErrorIs(t, err, domain.ErrFalsePositive)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing incident", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE incidents SET false_positive_reason").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT false_positive_reason IS NOT NULL FROM incidents WHERE id = \\?").
+			WithArgs(7).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM incident_archive WHERE incident_id = \\?\\)").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		err = NewMySQLIncidentRepository(db).MarkFalsePositive(context.Background(), 7, "Synthetic probe", updatedAt)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMySQLIncidentRepository_GetIDByReference(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	mock.ExpectQuery("SELECT id FROM incidents WHERE reference = \\?").
+		WithArgs("INC-2024-000123").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	mock.ExpectQuery("SELECT id FROM incidents WHERE reference = \\?").
+		WithArgs("INC-2024-999999").
+		WillReturnError(sql.ErrNoRows)
+
+	id, err := repo.GetIDByReference(context.Background(), "INC-2024-000123")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	_, err = repo.GetIDByReference(context.Background(), "INC-2024-999999")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_SetStormCount(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("sets only the count", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE incidents SET custom_fields = JSON_SET\\(COALESCE\\(custom_fields, JSON_OBJECT\\(\\)\\), '\\$.storm_count', \\?\\), updated_at = \\?\\s+WHERE id = \\? AND false_positive_reason IS NULL").
+			WithArgs(4, updatedAt, 99).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, NewMySQLIncidentRepository(db).SetStormCount(context.Background(), 99, 4, updatedAt))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("deleted storm incident", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE incidents SET custom_fields").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT false_positive_reason IS NOT NULL FROM incidents WHERE id = \\?").
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM incident_archive WHERE incident_id = \\?\\)").
+			WithArgs(99).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		err = NewMySQLIncidentRepository(db).SetStormCount(context.Background(), 99, 4, updatedAt)
+		assert.ErrorIs(t, err, domain.ErrDeleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("storm incident marked as a false positive", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE incidents SET custom_fields").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT false_positive_reason IS NOT NULL FROM incidents WHERE id = \\?").
+			WithArgs(99).
+			WillReturnRows(sqlmock.NewRows([]string{"marked"}).AddRow(true))
+
+		err = NewMySQLIncidentRepository(db).SetStormCount(context.Background(), 99, 4, updatedAt)
+		assert.ErrorIs(t, err, domain.ErrFalsePositive)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMySQLIncidentRepository_SetPriorityOverride(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("sets the override", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE incidents SET priority_override = \\?, updated_at = \\?\\s+WHERE id = \\? AND false_positive_reason IS NULL").
+			WithArgs("P1", updatedAt, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, NewMySQLIncidentRepository(db).SetPriorityOverride(context.Background(), 7, "P1", updatedAt))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("clearing stores NULL", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE incidents SET priority_override").
+			WithArgs(nil, updatedAt, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, NewMySQLIncidentRepository(db).SetPriorityOverride(context.Background(), 7, "", updatedAt))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("false positive", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec("UPDATE incidents SET priority_override").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT false_positive_reason IS NOT NULL FROM incidents WHERE id = \\?").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"marked"}).AddRow(true))
+
+		err = NewMySQLIncidentRepository(db).SetPriorityOverride(context.Background(), 7, "P1", updatedAt)
+		assert.ErrorIs(t, err, domain.ErrFalsePositive)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMySQLIncidentRepository_ColumnLimits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	mock.ExpectQuery("FROM information_schema.COLUMNS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "CHARACTER_MAXIMUM_LENGTH"}).
+			AddRow("title", 255).
+			AddRow("description", 16777215).
+			AddRow("affected_service", 100))
+
+	limits, err := repo.ColumnLimits()
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FieldLimits{Title: 255, Description: 16777215, AffectedService: 100}, limits)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_CustomFieldsRoundTrip(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+	now := time.Now()
+	incident := &domain.Incident{
+		Title:           "Checkout errors",
+		Description:     "5xx from checkout",
+		AffectedService: "checkout",
+		AISeverity:      "High",
+		AICategory:      "Application",
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		CustomFields:    map[string]interface{}{"region": "eu", "customer_impact": true},
+	}
+
+	mock.ExpectExec("INSERT INTO incidents").
+		WithArgs(incident.Title, incident.Description, incident.AffectedService, incident.AISeverity, incident.AICategory, now, now, `{"customer_impact":true,"region":"eu"}`, nil, nil, incident.AnalysisStatus, nil, false, nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectQuery("FROM incidents WHERE id = ?").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+			AddRow(7, incident.Title, incident.Description, incident.AffectedService, incident.AISeverity, incident.AICategory, now, now, []byte(`{"customer_impact":true,"region":"eu"}`), nil, nil, "complete", nil, nil, false, nil, nil, nil, nil))
+
+	assert.NoError(t, repo.Create(context.Background(), incident))
+	stored, err := repo.GetByID(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, incident.CustomFields, stored.CustomFields)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_FindDuplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+	now := time.Now()
+
+	mock.ExpectQuery("WHERE title = \\? AND affected_service = \\?\\s+ORDER BY created_at DESC LIMIT 1").
+		WithArgs("Disk full", "storage").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+			AddRow(42, "Disk full", "Root volume at 100%", "storage", "High", "Hardware", now, now, nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil))
+	mock.ExpectQuery("WHERE title = \\? AND affected_service = \\?").
+		WithArgs("Disk full", "billing").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	existing, err := repo.FindDuplicate(context.Background(), "Disk full", "storage", time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, existing.ID)
+
+	existing, err = repo.FindDuplicate(context.Background(), "Disk full", "billing", time.Time{})
+	assert.NoError(t, err)
+	assert.Nil(t, existing)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_GetRecent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+	now := time.Now()
+	since := now.Add(-time.Hour)
+
+	mock.ExpectQuery("WHERE created_at >= \\? AND id <> \\? AND false_positive_reason IS NULL\\s+ORDER BY created_at DESC, id DESC LIMIT \\?").
+		WithArgs(since, 13, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "affected_service", "ai_severity", "ai_category", "created_at", "updated_at", "custom_fields", "ai_suggested_action", "assignee", "analysis_status", "false_positive_reason", "ai_reasoning", "ai_input_truncated", "affected_users", "priority_override", "reference", "ai_confidence"}).
+			AddRow(12, "Payment timeouts", "Card payments time out", "checkout", "High", "Application", now, now, nil, nil, nil, "complete", nil, nil, false, nil, nil, nil, nil))
+
+	incidents, err := repo.GetRecent(context.Background(), since, 13, 50)
+
+	assert.NoError(t, err)
+	assert.Len(t, incidents, 1)
+	assert.Equal(t, 12, incidents[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestMySQLIncidentRepository_CountQualityIssues(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+
+	count := func(n int) *sqlmock.Rows { return sqlmock.NewRows([]string{"count"}).AddRow(n) }
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM incidents WHERE TRIM\\(description\\) = ''").
+		WillReturnRows(count(3))
+	mock.ExpectQuery("WHERE LOWER\\(TRIM\\(description\\)\\) IN \\(").
+		WithArgs("-", ".", "n/a", "na", "none", "tbd", "todo", "test", "placeholder", "see title", "lorem ipsum").
+		WillReturnRows(count(1))
+	mock.ExpectQuery("WHERE ai_severity NOT IN \\(\\?, \\?, \\?, \\?\\)").
+		WithArgs("Low", "Medium", "High", "Critical").
+		WillReturnRows(count(0))
+	mock.ExpectQuery("WHERE ai_category NOT IN \\(").
+		WithArgs("Network", "Software", "Hardware", "Security", "Database", "Application", "Infrastructure").
+		WillReturnRows(count(2))
+	mock.ExpectQuery("WHERE CHAR_LENGTH\\(TRIM\\(title\\)\\) < \\?").
+		WithArgs(domain.MinTitleLength).
+		WillReturnRows(count(5))
+	mock.ExpectQuery("WHERE title <> TRIM\\(title\\) OR description <> TRIM\\(description\\) OR affected_service <> TRIM\\(affected_service\\)").
+		WillReturnRows(count(4))
+
+	counts, err := repo.CountQualityIssues(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.QualityCount{
+		{Issue: domain.QualityMissingDescription, Count: 3},
+		{Issue: domain.QualityPlaceholderDescription, Count: 1},
+		{Issue: domain.QualityInvalidSeverity, Count: 0},
+		{Issue: domain.QualityInvalidCategory, Count: 2},
+		{Issue: domain.QualityShortTitle, Count: 5},
+		{Issue: domain.QualityUntrimmed, Count: 4},
+	}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLIncidentRepository_TrimWhitespace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLIncidentRepository(db)
+	now := time.Now()
+
+	mock.ExpectExec("SET title = TRIM\\(title\\), description = TRIM\\(description\\), affected_service = TRIM\\(affected_service\\), updated_at = \\?\\s+WHERE title <> TRIM\\(title\\)").
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	trimmed, err := repo.TrimWhitespace(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, trimmed)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// queryColumns maps the query expression fields compared directly with a non-null column
+var queryColumns = map[string]string{
+	domain.QueryFieldSeverity: "ai_severity",
+	domain.QueryFieldCategory: "ai_category",
+	domain.QueryFieldService:  "affected_service",
+}
+
+// queryOperators maps query operators to SQL
+var queryOperators = map[string]string{
+	domain.QueryOpMatch:        "=",
+	domain.QueryOpGreater:      ">",
+	domain.QueryOpGreaterEqual: ">=",
+	domain.QueryOpLess:         "<",
+	domain.QueryOpLessEqual:    "<=",
+}
+
+// compileQuery compiles a parsed query expression into a parameterized SQL condition. Only
+// whitelisted columns and operators are written into the SQL; every value is an argument.
+// Terms on nullable columns are false rather than NULL for missing values, so NOT matches
+// unassigned incidents and incidents without an affected user count.
+func compileQuery(node domain.QueryNode) (string, []interface{}) {
+	switch n := node.(type) {
+	case *domain.QueryAnd:
+		return compileBinary(n.Left, "AND", n.Right)
+	case *domain.QueryOr:
+		return compileBinary(n.Left, "OR", n.Right)
+	case *domain.QueryNot:
+		operand, args := compileQuery(n.Operand)
+		return "NOT " + operand, args
+	case *domain.QueryTerm:
+		return compileTerm(n)
+	default:
+		// The parser only builds the nodes above; match nothing rather than everything
+		return "1 = 0", nil
+	}
+}
+
+// compileBinary compiles two operands joined by an AND or OR
+func compileBinary(left domain.QueryNode, op string, right domain.QueryNode) (string, []interface{}) {
+	leftSQL, args := compileQuery(left)
+	rightSQL, rightArgs := compileQuery(right)
+	return "(" + leftSQL + " " + op + " " + rightSQL + ")", append(args, rightArgs...)
+}
+
+// compileTerm compiles a single field comparison
+func compileTerm(term *domain.QueryTerm) (string, []interface{}) {
+	op, ok := queryOperators[term.Op]
+	if !ok {
+		return "1 = 0", nil
+	}
+
+	switch term.Field {
+	case domain.QueryFieldTitle:
+		return "title LIKE ?", []interface{}{"%" + escapeLike(term.Value) + "%"}
+	case domain.QueryFieldPriority:
+		priority, args := effectivePriority()
+		return "(" + priority + " " + op + " ?)", append(args, term.Value)
+	case domain.QueryFieldCreatedAt:
+		return "(created_at " + op + " ?)", []interface{}{term.Time}
+	case domain.QueryFieldAffectedUsers:
+		return "COALESCE(affected_users " + op + " ?, FALSE)", []interface{}{term.Number}
+	case domain.QueryFieldAssignee:
+		return "COALESCE(assignee " + op + " ?, FALSE)", []interface{}{term.Value}
+	}
+
+	column, ok := queryColumns[term.Field]
+	if !ok {
+		return "1 = 0", nil
+	}
+	return fmt.Sprintf("(%s %s ?)", column, op), []interface{}{term.Value}
+}
+
+// escapeLike escapes the LIKE wildcards of a value, with the default backslash escape character
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
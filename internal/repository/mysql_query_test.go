@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{
+			name:         "conjunction and disjunction",
+			query:        "severity:Critical AND (category:Database OR service:auth)",
+			expectedSQL:  "((ai_severity = ?) AND ((ai_category = ?) OR (affected_service = ?)))",
+			expectedArgs: []interface{}{"Critical", "Database", "auth"},
+		},
+		{
+			name:         "created after a date",
+			query:        "created_at>2024-01-01",
+			expectedSQL:  "(created_at > ?)",
+			expectedArgs: []interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:         "negated nullable column",
+			query:        "NOT assignee:alice",
+			expectedSQL:  "NOT COALESCE(assignee = ?, FALSE)",
+			expectedArgs: []interface{}{"alice"},
+		},
+		{
+			name:         "affected users",
+			query:        "affected_users>=500",
+			expectedSQL:  "COALESCE(affected_users >= ?, FALSE)",
+			expectedArgs: []interface{}{500},
+		},
+		{
+			name:         "title wildcards are escaped",
+			query:        `title:"50%_off\\"`,
+			expectedSQL:  "title LIKE ?",
+			expectedArgs: []interface{}{`%50\%\_off\\%`},
+		},
+		{
+			name:         "values never reach the SQL",
+			query:        `service:"x' OR '1'='1"`,
+			expectedSQL:  "(affected_service = ?)",
+			expectedArgs: []interface{}{"x' OR '1'='1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := domain.ParseQuery(tt.query)
+			assert.NoError(t, err)
+
+			sql, args := compileQuery(node)
+
+			assert.Equal(t, tt.expectedSQL, sql)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestCompileQuery_Priority(t *testing.T) {
+	node, err := domain.ParseQuery("priority:P1")
+	assert.NoError(t, err)
+
+	sql, args := compileQuery(node)
+
+	priority, priorityArgs := effectivePriority()
+	assert.Equal(t, "("+priority+" = ?)", sql)
+	assert.Equal(t, append(priorityArgs, "P1"), args)
+}
+
+func TestBuildFilterClause_Query(t *testing.T) {
+	node, err := domain.ParseQuery("service:auth OR service:billing")
+	assert.NoError(t, err)
+
+	where, args := buildFilterClause(&domain.IncidentFilter{Severities: []string{"High"}, Query: node})
+
+	assert.Equal(t, " WHERE ai_severity IN (?) AND ((affected_service = ?) OR (affected_service = ?)) AND false_positive_reason IS NULL", where)
+	assert.Equal(t, []interface{}{"High", "auth", "billing"}, args)
+}
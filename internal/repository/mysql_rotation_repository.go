@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// MySQLRotationRepository implements the RotationRepository interface using MySQL
+type MySQLRotationRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLRotationRepository creates a new MySQL rotation repository
+func NewMySQLRotationRepository(db *sql.DB) *MySQLRotationRepository {
+	return &MySQLRotationRepository{db: db}
+}
+
+// Next atomically advances the team's rotation. LAST_INSERT_ID(expr) makes the new position
+// available to this connection without a second query, so concurrent creates never share one.
+func (r *MySQLRotationRepository) Next(ctx context.Context, team string) (int, error) {
+	query := `
+		INSERT INTO team_rotation (team, position) VALUES (?, LAST_INSERT_ID(0))
+		ON DUPLICATE KEY UPDATE position = LAST_INSERT_ID(position + 1)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, team)
+	if err != nil {
+		return 0, fmt.Errorf("failed to advance rotation: %w", err)
+	}
+
+	position, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read rotation position: %w", err)
+	}
+
+	return int(position), nil
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLRotationRepository_Next(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLRotationRepository(db)
+
+	mock.ExpectExec("INSERT INTO team_rotation \\(team, position\\) VALUES \\(\\?, LAST_INSERT_ID\\(0\\)\\) ON DUPLICATE KEY UPDATE position = LAST_INSERT_ID\\(position \\+ 1\\)").
+		WithArgs("database").
+		WillReturnResult(sqlmock.NewResult(4, 2))
+
+	position, err := repo.Next(context.Background(), "database")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, position)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"incident-triage-assistant/internal/domain"
+	"time"
+)
+
+// MySQLRunbookRepository implements the RunbookRepository interface using MySQL
+type MySQLRunbookRepository struct {
+	db     *sql.DB
+	reader *sql.DB
+}
+
+// NewMySQLRunbookRepository creates a new MySQL runbook repository
+func NewMySQLRunbookRepository(db *sql.DB) *MySQLRunbookRepository {
+	return &MySQLRunbookRepository{db: db, reader: db}
+}
+
+// NewMySQLRunbookRepositoryWithReader creates a new MySQL runbook repository that sends reads to a replica
+func NewMySQLRunbookRepositoryWithReader(writer, reader *sql.DB) *MySQLRunbookRepository {
+	return &MySQLRunbookRepository{db: writer, reader: reader}
+}
+
+// AddStep inserts a step that is not done yet and sets its ID
+func (r *MySQLRunbookRepository) AddStep(ctx context.Context, step *domain.RunbookStep) error {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO runbook_steps (incident_id, step, done, created_at)
+		VALUES (?, ?, FALSE, ?)
+	`, step.IncidentID, step.Step, step.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add runbook step: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	step.ID = int(id)
+	return nil
+}
+
+// GetSteps retrieves the steps of an incident in the order they were added
+func (r *MySQLRunbookRepository) GetSteps(ctx context.Context, incidentID int) ([]*domain.RunbookStep, error) {
+	rows, err := r.reader.QueryContext(ctx, `
+		SELECT id, incident_id, step, done, done_by, done_at, created_at
+		FROM runbook_steps WHERE incident_id = ?
+		ORDER BY id ASC
+	`, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query runbook steps: %w", err)
+	}
+	defer rows.Close()
+
+	steps := []*domain.RunbookStep{}
+	for rows.Next() {
+		step := &domain.RunbookStep{}
+		var doneBy sql.NullString
+		var doneAt sql.NullTime
+		err := rows.Scan(
+			&step.ID,
+			&step.IncidentID,
+			&step.Step,
+			&step.Done,
+			&doneBy,
+			&doneAt,
+			&step.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan runbook step: %w", err)
+		}
+		step.DoneBy = doneBy.String
+		if doneAt.Valid {
+			step.DoneAt = &doneAt.Time
+		}
+		steps = append(steps, step)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating runbook steps: %w", err)
+	}
+
+	return steps, nil
+}
+
+// CompleteStep marks a step done. Only a pending step is updated, so two responders completing
+// the same step cannot both succeed; the loser gets domain.ErrStepDone.
+func (r *MySQLRunbookRepository) CompleteStep(ctx context.Context, incidentID, stepID int, doneBy string, doneAt time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE runbook_steps SET done = TRUE, done_by = ?, done_at = ?
+		WHERE id = ? AND incident_id = ? AND done = FALSE
+	`, doneBy, doneAt, stepID, incidentID)
+	if err != nil {
+		return fmt.Errorf("failed to complete runbook step: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	var done bool
+	err = r.db.QueryRowContext(ctx, `SELECT done FROM runbook_steps WHERE id = ? AND incident_id = ?`, stepID, incidentID).Scan(&done)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("step %d of incident %d: %w", stepID, incidentID, domain.ErrStepNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check runbook step: %w", err)
+	}
+	return fmt.Errorf("step %d of incident %d: %w", stepID, incidentID, domain.ErrStepDone)
+}
+
+// CountSteps counts the steps of an incident and how many of them are done
+func (r *MySQLRunbookRepository) CountSteps(ctx context.Context, incidentID int) (int, int, error) {
+	var total, done int
+	err := r.reader.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(done), 0)
+		FROM runbook_steps WHERE incident_id = ?
+	`, incidentID).Scan(&total, &done)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count runbook steps: %w", err)
+	}
+
+	return total, done, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLRunbookRepository_AddStep(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLRunbookRepository(db)
+	now := time.Now()
+
+	mock.ExpectExec("INSERT INTO runbook_steps \\(incident_id, step, done, created_at\\) VALUES \\(\\?, \\?, FALSE, \\?\\)").
+		WithArgs(7, "Fail over the primary", now).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+
+	step := &domain.RunbookStep{IncidentID: 7, Step: "Fail over the primary", CreatedAt: now}
+	err = repo.AddStep(context.Background(), step)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, step.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLRunbookRepository_GetSteps(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLRunbookRepository(db)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "incident_id", "step", "done", "done_by", "done_at", "created_at"}).
+		AddRow(1, 7, "Fail over the primary", true, "alice", now, now).
+		AddRow(2, 7, "Flush the CDN", false, nil, nil, now)
+
+	mock.ExpectQuery("SELECT id, incident_id, step, done, done_by, done_at, created_at FROM runbook_steps WHERE incident_id = \\? ORDER BY id ASC").
+		WithArgs(7).
+		WillReturnRows(rows)
+
+	steps, err := repo.GetSteps(context.Background(), 7)
+	assert.NoError(t, err)
+	if assert.Len(t, steps, 2) {
+		assert.Equal(t, "alice", steps[0].DoneBy)
+		assert.Equal(t, now, *steps[0].DoneAt)
+		assert.False(t, steps[1].Done)
+		assert.Nil(t, steps[1].DoneAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLRunbookRepository_CompleteStep(t *testing.T) {
+	now := time.Now()
+	update := "UPDATE runbook_steps SET done = TRUE, done_by = \\?, done_at = \\? WHERE id = \\? AND incident_id = \\? AND done = FALSE"
+	check := "SELECT done FROM runbook_steps WHERE id = \\? AND incident_id = \\?"
+
+	t.Run("pending step", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(update).WithArgs("alice", now, 2, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = NewMySQLRunbookRepository(db).CompleteStep(context.Background(), 7, 2, "alice", now)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already done", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(update).WithArgs("bob", now, 2, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(check).WithArgs(2, 7).WillReturnRows(sqlmock.NewRows([]string{"done"}).AddRow(true))
+
+		err = NewMySQLRunbookRepository(db).CompleteStep(context.Background(), 7, 2, "bob", now)
+		assert.ErrorIs(t, err, domain.ErrStepDone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown step", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectExec(update).WithArgs("alice", now, 9, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(check).WithArgs(9, 7).WillReturnRows(sqlmock.NewRows([]string{"done"}))
+
+		err = NewMySQLRunbookRepository(db).CompleteStep(context.Background(), 7, 9, "alice", now)
+		assert.ErrorIs(t, err, domain.ErrStepNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMySQLRunbookRepository_CountSteps(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLRunbookRepository(db)
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\), COALESCE\\(SUM\\(done\\), 0\\) FROM runbook_steps WHERE incident_id = \\?").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "done"}).AddRow(4, 1))
+
+	total, done, err := repo.CountSteps(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, 1, done)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
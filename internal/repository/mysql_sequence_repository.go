@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// MySQLSequenceRepository implements the SequenceRepository interface using MySQL
+type MySQLSequenceRepository struct {
+	db *sql.DB
+}
+
+// NewMySQLSequenceRepository creates a new MySQL sequence repository
+func NewMySQLSequenceRepository(db *sql.DB) *MySQLSequenceRepository {
+	return &MySQLSequenceRepository{db: db}
+}
+
+// Next atomically advances the year's sequence. As with team rotation, LAST_INSERT_ID(expr)
+// returns the new value on this connection, so concurrent creates never share a number.
+func (r *MySQLSequenceRepository) Next(ctx context.Context, year int) (int, error) {
+	query := `
+		INSERT INTO incident_sequence (year, value) VALUES (?, LAST_INSERT_ID(1))
+		ON DUPLICATE KEY UPDATE value = LAST_INSERT_ID(value + 1)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, year)
+	if err != nil {
+		return 0, fmt.Errorf("failed to advance incident sequence: %w", err)
+	}
+
+	value, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read incident sequence: %w", err)
+	}
+
+	return int(value), nil
+}
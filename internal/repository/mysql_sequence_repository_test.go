@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLSequenceRepository_Next(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLSequenceRepository(db)
+
+	mock.ExpectExec("INSERT INTO incident_sequence \\(year, value\\) VALUES \\(\\?, LAST_INSERT_ID\\(1\\)\\) ON DUPLICATE KEY UPDATE value = LAST_INSERT_ID\\(value \\+ 1\\)").
+		WithArgs(2024).
+		WillReturnResult(sqlmock.NewResult(123, 2))
+
+	seq, err := repo.Next(context.Background(), 2024)
+	assert.NoError(t, err)
+	assert.Equal(t, 123, seq)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
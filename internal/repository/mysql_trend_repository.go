@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"incident-triage-assistant/internal/domain"
+	"time"
+)
+
+// MySQLTrendRepository implements the SevereIncidentCounter interface using MySQL
+type MySQLTrendRepository struct {
+	reader *sql.DB
+}
+
+// NewMySQLTrendRepository creates a new MySQL severity trend repository. A trend is read over a
+// window of minutes or more, so a replica lagging a moment behind is fine to count on.
+func NewMySQLTrendRepository(reader *sql.DB) *MySQLTrendRepository {
+	return &MySQLTrendRepository{reader: reader}
+}
+
+// CountSevereIncidents counts the incidents with one of domain.SevereSeverities created at or
+// after since and before until, leaving out false positives
+func (r *MySQLTrendRepository) CountSevereIncidents(ctx context.Context, since, until time.Time) (int, error) {
+	args := make([]interface{}, 0, len(domain.SevereSeverities)+2)
+	for _, severity := range domain.SevereSeverities {
+		args = append(args, severity)
+	}
+	args = append(args, since, until)
+
+	var count int
+	err := r.reader.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM incidents
+		WHERE ai_severity IN (`+placeholders(len(domain.SevereSeverities))+`) AND created_at >= ? AND created_at < ?
+			AND `+notFalsePositive, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count severe incidents: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLTrendRepository_CountSevereIncidents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLTrendRepository(db)
+	until := time.Now()
+	since := until.Add(-time.Hour)
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM incidents WHERE ai_severity IN \\(\\?, \\?\\) AND created_at >= \\? AND created_at < \\? "+
+		"AND false_positive_reason IS NULL").
+		WithArgs("High", "Critical", since, until).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	count, err := repo.CountSevereIncidents(context.Background(), since, until)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLTrendRepository_CountSevereIncidents_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMySQLTrendRepository(db)
+	mock.ExpectQuery("SELECT COUNT").WillReturnError(errors.New("connection lost"))
+
+	_, err = repo.CountSevereIncidents(context.Background(), time.Now().Add(-time.Hour), time.Now())
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// slowQueries counts repository operations that exceeded the slow query threshold
+var slowQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "incident_repository_slow_queries_total",
+	Help: "Repository operations slower than SLOW_QUERY_MS, by operation.",
+}, []string{"operation"})
+
+// SlowQueryIncidentRepository decorates an IncidentRepository, logging and counting
+// every operation that takes longer than the threshold
+type SlowQueryIncidentRepository struct {
+	next      domain.IncidentRepository
+	threshold time.Duration
+	clock     clock.Clock
+}
+
+// NewSlowQueryIncidentRepository wraps next with slow query logging
+func NewSlowQueryIncidentRepository(next domain.IncidentRepository, threshold time.Duration, clk clock.Clock) *SlowQueryIncidentRepository {
+	return &SlowQueryIncidentRepository{next: next, threshold: threshold, clock: clk}
+}
+
+// observe logs and counts the operation if it started more than the threshold ago. The log
+// line carries the request ID of ctx.
+func (r *SlowQueryIncidentRepository) observe(ctx context.Context, operation string, start time.Time) {
+	elapsed := r.clock.Now().Sub(start)
+	if elapsed <= r.threshold {
+		return
+	}
+
+	logging.FromContext(ctx).Warn("Slow query", "operation", operation, "elapsed", elapsed.String(), "threshold", r.threshold.String())
+	slowQueries.WithLabelValues(operation).Inc()
+}
+
+// Create times IncidentRepository.Create
+func (r *SlowQueryIncidentRepository) Create(ctx context.Context, incident *domain.Incident) error {
+	defer r.observe(ctx, "Create", r.clock.Now())
+	return r.next.Create(ctx, incident)
+}
+
+// CreateUnique times IncidentRepository.CreateUnique
+func (r *SlowQueryIncidentRepository) CreateUnique(ctx context.Context, incident *domain.Incident, since time.Time) error {
+	defer r.observe(ctx, "CreateUnique", r.clock.Now())
+	return r.next.CreateUnique(ctx, incident, since)
+}
+
+// CreateBatch times IncidentRepository.CreateBatch
+func (r *SlowQueryIncidentRepository) CreateBatch(ctx context.Context, incidents []*domain.Incident) error {
+	defer r.observe(ctx, "CreateBatch", r.clock.Now())
+	return r.next.CreateBatch(ctx, incidents)
+}
+
+// GetByID times IncidentRepository.GetByID
+func (r *SlowQueryIncidentRepository) GetByID(ctx context.Context, id int) (*domain.Incident, error) {
+	defer r.observe(ctx, "GetByID", r.clock.Now())
+	return r.next.GetByID(ctx, id)
+}
+
+// GetByIDForWrite times IncidentRepository.GetByIDForWrite
+func (r *SlowQueryIncidentRepository) GetByIDForWrite(ctx context.Context, id int) (*domain.Incident, error) {
+	defer r.observe(ctx, "GetByIDForWrite", r.clock.Now())
+	return r.next.GetByIDForWrite(ctx, id)
+}
+
+// GetIDByReference times IncidentRepository.GetIDByReference
+func (r *SlowQueryIncidentRepository) GetIDByReference(ctx context.Context, reference string) (int, error) {
+	defer r.observe(ctx, "GetIDByReference", r.clock.Now())
+	return r.next.GetIDByReference(ctx, reference)
+}
+
+// FindDuplicate times IncidentRepository.FindDuplicate
+func (r *SlowQueryIncidentRepository) FindDuplicate(ctx context.Context, title, affectedService string, since time.Time) (*domain.Incident, error) {
+	defer r.observe(ctx, "FindDuplicate", r.clock.Now())
+	return r.next.FindDuplicate(ctx, title, affectedService, since)
+}
+
+// GetAll times IncidentRepository.GetAll
+func (r *SlowQueryIncidentRepository) GetAll(ctx context.Context) ([]*domain.Incident, error) {
+	defer r.observe(ctx, "GetAll", r.clock.Now())
+	return r.next.GetAll(ctx)
+}
+
+// GetAllSummary times IncidentRepository.GetAllSummary
+func (r *SlowQueryIncidentRepository) GetAllSummary(ctx context.Context, filter *domain.IncidentFilter, limit, offset int) ([]*domain.IncidentSummary, error) {
+	defer r.observe(ctx, "GetAllSummary", r.clock.Now())
+	return r.next.GetAllSummary(ctx, filter, limit, offset)
+}
+
+// GetAllFiltered times IncidentRepository.GetAllFiltered
+func (r *SlowQueryIncidentRepository) GetAllFiltered(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.Incident, error) {
+	defer r.observe(ctx, "GetAllFiltered", r.clock.Now())
+	return r.next.GetAllFiltered(ctx, filter)
+}
+
+// Search times IncidentRepository.Search
+func (r *SlowQueryIncidentRepository) Search(ctx context.Context, term string, limit, offset int) (*domain.SearchPage, error) {
+	defer r.observe(ctx, "Search", r.clock.Now())
+	return r.next.Search(ctx, term, limit, offset)
+}
+
+// GetAllPaginated times IncidentRepository.GetAllPaginated
+func (r *SlowQueryIncidentRepository) GetAllPaginated(ctx context.Context, filter *domain.IncidentFilter, limit, offset int) ([]*domain.Incident, error) {
+	defer r.observe(ctx, "GetAllPaginated", r.clock.Now())
+	return r.next.GetAllPaginated(ctx, filter, limit, offset)
+}
+
+// MaxUpdatedAt times IncidentRepository.MaxUpdatedAt
+func (r *SlowQueryIncidentRepository) MaxUpdatedAt(ctx context.Context, filter *domain.IncidentFilter) (*domain.ListVersion, error) {
+	defer r.observe(ctx, "MaxUpdatedAt", r.clock.Now())
+	return r.next.MaxUpdatedAt(ctx, filter)
+}
+
+// GetQueue times IncidentRepository.GetQueue
+func (r *SlowQueryIncidentRepository) GetQueue(ctx context.Context, limit, offset int) ([]*domain.Incident, error) {
+	defer r.observe(ctx, "GetQueue", r.clock.Now())
+	return r.next.GetQueue(ctx, limit, offset)
+}
+
+// StreamAll times IncidentRepository.StreamAll, including the time spent in fn
+func (r *SlowQueryIncidentRepository) StreamAll(ctx context.Context, filter *domain.IncidentFilter, fn func(*domain.Incident) error) error {
+	defer r.observe(ctx, "StreamAll", r.clock.Now())
+	return r.next.StreamAll(ctx, filter, fn)
+}
+
+// GetPageAfterID times IncidentRepository.GetPageAfterID
+func (r *SlowQueryIncidentRepository) GetPageAfterID(ctx context.Context, filter *domain.IncidentFilter, afterID, limit int) ([]*domain.Incident, error) {
+	defer r.observe(ctx, "GetPageAfterID", r.clock.Now())
+	return r.next.GetPageAfterID(ctx, filter, afterID, limit)
+}
+
+// GetUnfinishedAnalyses times IncidentRepository.GetUnfinishedAnalyses
+func (r *SlowQueryIncidentRepository) GetUnfinishedAnalyses(ctx context.Context, pendingBefore time.Time, limit int) ([]*domain.Incident, error) {
+	defer r.observe(ctx, "GetUnfinishedAnalyses", r.clock.Now())
+	return r.next.GetUnfinishedAnalyses(ctx, pendingBefore, limit)
+}
+
+// GetRecent times IncidentRepository.GetRecent
+func (r *SlowQueryIncidentRepository) GetRecent(ctx context.Context, since time.Time, excludeID, limit int) ([]*domain.Incident, error) {
+	defer r.observe(ctx, "GetRecent", r.clock.Now())
+	return r.next.GetRecent(ctx, since, excludeID, limit)
+}
+
+// GetIDsBySeverity times IncidentRepository.GetIDsBySeverity
+func (r *SlowQueryIncidentRepository) GetIDsBySeverity(ctx context.Context, severity string, afterID, limit int) ([]int, error) {
+	defer r.observe(ctx, "GetIDsBySeverity", r.clock.Now())
+	return r.next.GetIDsBySeverity(ctx, severity, afterID, limit)
+}
+
+// UpdateSeverity times IncidentRepository.UpdateSeverity
+func (r *SlowQueryIncidentRepository) UpdateSeverity(ctx context.Context, ids []int, severity string, updatedAt time.Time) error {
+	defer r.observe(ctx, "UpdateSeverity", r.clock.Now())
+	return r.next.UpdateSeverity(ctx, ids, severity, updatedAt)
+}
+
+// MaxUpdatedAtFromWriter times IncidentRepository.MaxUpdatedAtFromWriter
+func (r *SlowQueryIncidentRepository) MaxUpdatedAtFromWriter(ctx context.Context, filter *domain.IncidentFilter) (*domain.ListVersion, error) {
+	defer r.observe(ctx, "MaxUpdatedAtFromWriter", r.clock.Now())
+	return r.next.MaxUpdatedAtFromWriter(ctx, filter)
+}
+
+// CountDistribution times IncidentRepository.CountDistribution
+func (r *SlowQueryIncidentRepository) CountDistribution(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.DistributionCount, error) {
+	defer r.observe(ctx, "CountDistribution", r.clock.Now())
+	return r.next.CountDistribution(ctx, filter)
+}
+
+// MarkFalsePositive times IncidentRepository.MarkFalsePositive
+func (r *SlowQueryIncidentRepository) MarkFalsePositive(ctx context.Context, id int, reason string, updatedAt time.Time) error {
+	defer r.observe(ctx, "MarkFalsePositive", r.clock.Now())
+	return r.next.MarkFalsePositive(ctx, id, reason, updatedAt)
+}
+
+// SetStormCount times IncidentRepository.SetStormCount
+func (r *SlowQueryIncidentRepository) SetStormCount(ctx context.Context, id, count int, updatedAt time.Time) error {
+	defer r.observe(ctx, "SetStormCount", r.clock.Now())
+	return r.next.SetStormCount(ctx, id, count, updatedAt)
+}
+
+// SetPriorityOverride times IncidentRepository.SetPriorityOverride
+func (r *SlowQueryIncidentRepository) SetPriorityOverride(ctx context.Context, id int, priority string, updatedAt time.Time) error {
+	defer r.observe(ctx, "SetPriorityOverride", r.clock.Now())
+	return r.next.SetPriorityOverride(ctx, id, priority, updatedAt)
+}
+
+// Reassign times IncidentRepository.Reassign
+func (r *SlowQueryIncidentRepository) Reassign(ctx context.Context, from, to string, scope domain.ReassignScope, updatedAt time.Time) ([]int, error) {
+	defer r.observe(ctx, "Reassign", r.clock.Now())
+	return r.next.Reassign(ctx, from, to, scope, updatedAt)
+}
+
+// CountQualityIssues times IncidentRepository.CountQualityIssues
+func (r *SlowQueryIncidentRepository) CountQualityIssues(ctx context.Context) ([]*domain.QualityCount, error) {
+	defer r.observe(ctx, "CountQualityIssues", r.clock.Now())
+	return r.next.CountQualityIssues(ctx)
+}
+
+// TrimWhitespace times IncidentRepository.TrimWhitespace
+func (r *SlowQueryIncidentRepository) TrimWhitespace(ctx context.Context, updatedAt time.Time) (int, error) {
+	defer r.observe(ctx, "TrimWhitespace", r.clock.Now())
+	return r.next.TrimWhitespace(ctx, updatedAt)
+}
+
+// Update times IncidentRepository.Update
+func (r *SlowQueryIncidentRepository) Update(ctx context.Context, incident *domain.Incident, analysisStatus string) error {
+	defer r.observe(ctx, "Update", r.clock.Now())
+	return r.next.Update(ctx, incident, analysisStatus)
+}
+
+// SaveAnalysis times IncidentRepository.SaveAnalysis
+func (r *SlowQueryIncidentRepository) SaveAnalysis(ctx context.Context, incident *domain.Incident, status string) (bool, error) {
+	defer r.observe(ctx, "SaveAnalysis", r.clock.Now())
+	return r.next.SaveAnalysis(ctx, incident, status)
+}
+
+// Delete times IncidentRepository.Delete
+func (r *SlowQueryIncidentRepository) Delete(ctx context.Context, id int, purgedBy string) error {
+	defer r.observe(ctx, "Delete", r.clock.Now())
+	return r.next.Delete(ctx, id, purgedBy)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowIncidentRepository advances a mock clock inside GetByID to simulate query latency
+type slowIncidentRepository struct {
+	domain.IncidentRepository
+	clock   *clock.Mock
+	latency time.Duration
+}
+
+func (r *slowIncidentRepository) GetByID(ctx context.Context, id int) (*domain.Incident, error) {
+	r.clock.Advance(r.latency)
+	return &domain.Incident{ID: id}, nil
+}
+
+func TestSlowQueryIncidentRepository(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&logs))
+	defer slog.SetDefault(previous)
+	ctx := logging.WithRequestID(context.Background(), "abc123")
+
+	clk := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	inner := &slowIncidentRepository{clock: clk}
+	repo := NewSlowQueryIncidentRepository(inner, 100*time.Millisecond, clk)
+	before := testutil.ToFloat64(slowQueries.WithLabelValues("GetByID"))
+
+	inner.latency = 50 * time.Millisecond
+	incident, err := repo.GetByID(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, incident.ID)
+	assert.Empty(t, logs.String())
+
+	inner.latency = 250 * time.Millisecond
+	_, err = repo.GetByID(ctx, 1)
+	assert.NoError(t, err)
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &line))
+	assert.Equal(t, "WARN", line["level"])
+	assert.Equal(t, "Slow query", line["msg"])
+	assert.Equal(t, "GetByID", line["operation"])
+	assert.Equal(t, "250ms", line["elapsed"])
+	assert.Equal(t, "100ms", line["threshold"])
+	assert.Equal(t, "abc123", line[logging.RequestIDKey])
+	assert.Equal(t, before+1, testutil.ToFloat64(slowQueries.WithLabelValues("GetByID")))
+}
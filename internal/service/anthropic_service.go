@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// errAnthropicNotImplemented is returned by every analysis until the Anthropic provider exists
+var errAnthropicNotImplemented = errors.New("the anthropic AI provider does not analyze incidents yet")
+
+// AnthropicService is the placeholder for analyzing incidents with Anthropic models. It holds
+// the credentials so AI_PROVIDER=anthropic can be configured, but does not call the API yet:
+// every analysis fails and the health check reports the provider as unusable.
+type AnthropicService struct {
+	apiKey string
+}
+
+// NewAnthropicService creates a new Anthropic service instance. ANTHROPIC_API_KEY must be set.
+func NewAnthropicService() (*AnthropicService, error) {
+	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	if apiKey == "" {
+		return nil, errors.New("ANTHROPIC_API_KEY environment variable is required")
+	}
+	return &AnthropicService{apiKey: apiKey}, nil
+}
+
+// AnalyzeIncident fails, as the Anthropic provider is not implemented yet
+func (s *AnthropicService) AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*domain.IncidentAnalysis, error) {
+	return nil, errAnthropicNotImplemented
+}
+
+// CheckHealth reports the provider as unusable until it is implemented
+func (s *AnthropicService) CheckHealth() error {
+	return errAnthropicNotImplemented
+}
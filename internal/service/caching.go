@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+)
+
+// Normalization rules applied to the text of an incident before it is used as an AI cache key
+const (
+	// NormalizeLowercase ignores letter case
+	NormalizeLowercase = "lowercase"
+	// NormalizeWhitespace trims and collapses runs of whitespace into one space
+	NormalizeWhitespace = "whitespace"
+	// NormalizePunctuation drops punctuation and symbols
+	NormalizePunctuation = "punctuation"
+)
+
+// NormalizationRules lists every normalization rule, all of which are applied by default
+var NormalizationRules = []string{NormalizeLowercase, NormalizeWhitespace, NormalizePunctuation}
+
+// Normalizer reduces trivially different texts to the same cache key
+type Normalizer struct {
+	lowercase   bool
+	whitespace  bool
+	punctuation bool
+}
+
+// NewNormalizer builds a normalizer applying rules, which must be NormalizationRules entries.
+// No rules keeps texts as they are.
+func NewNormalizer(rules []string) (*Normalizer, error) {
+	n := &Normalizer{}
+	for _, rule := range rules {
+		switch rule {
+		case NormalizeLowercase:
+			n.lowercase = true
+		case NormalizeWhitespace:
+			n.whitespace = true
+		case NormalizePunctuation:
+			n.punctuation = true
+		default:
+			return nil, fmt.Errorf("unknown normalization rule %q (known: %s)", rule, strings.Join(NormalizationRules, ", "))
+		}
+	}
+	return n, nil
+}
+
+// Normalize applies the configured rules to text. Punctuation becomes a space rather than
+// disappearing so "db-01" and "db 01" match without gluing words together.
+func (n *Normalizer) Normalize(text string) string {
+	if n.lowercase {
+		text = strings.ToLower(text)
+	}
+	if n.punctuation {
+		text = strings.Map(func(r rune) rune {
+			if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+				return ' '
+			}
+			return r
+		}, text)
+	}
+	if n.whitespace {
+		text = strings.Join(strings.Fields(text), " ")
+	}
+	return text
+}
+
+// cachedAnalysis is a stored analysis and when it stops being served
+type cachedAnalysis struct {
+	analysis  *domain.IncidentAnalysis
+	expiresAt time.Time
+}
+
+// CachingAIService decorates an AIService with a cache of successful analyses keyed by the
+// normalized title, description and affected service, so equivalent incidents reported with
+// different formatting cost one analysis. Failures are not cached.
+type CachingAIService struct {
+	next       domain.AIService
+	ttl        time.Duration
+	maxEntries int
+	normalizer *Normalizer
+	clock      clock.Clock
+
+	mu      sync.Mutex
+	entries map[string]*cachedAnalysis
+}
+
+// NewCachingAIService wraps next with a cache holding up to maxEntries analyses for ttl each
+func NewCachingAIService(next domain.AIService, ttl time.Duration, maxEntries int, normalizer *Normalizer, c clock.Clock) *CachingAIService {
+	return &CachingAIService{
+		next:       next,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		normalizer: normalizer,
+		clock:      c,
+		entries:    make(map[string]*cachedAnalysis),
+	}
+}
+
+// AnalyzeIncident returns the cached analysis of an equivalent incident, or analyzes it and
+// caches the result. Every caller receives its own copy.
+func (s *CachingAIService) AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*domain.IncidentAnalysis, error) {
+	key := s.key(title, description, affectedService)
+
+	s.mu.Lock()
+	entry, ok := s.entries[key]
+	if ok && s.clock.Now().Before(entry.expiresAt) {
+		analysis := *entry.analysis
+		s.mu.Unlock()
+		return &analysis, nil
+	}
+	s.mu.Unlock()
+
+	analysis, err := s.next.AnalyzeIncident(ctx, title, description, affectedService)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := *analysis
+	s.mu.Lock()
+	s.store(key, &cachedAnalysis{analysis: &stored, expiresAt: s.clock.Now().Add(s.ttl)})
+	s.mu.Unlock()
+	return analysis, nil
+}
+
+// key builds the cache key of an incident from its normalized fields
+func (s *CachingAIService) key(title, description, affectedService string) string {
+	return strings.Join([]string{
+		s.normalizer.Normalize(title),
+		s.normalizer.Normalize(description),
+		s.normalizer.Normalize(affectedService),
+	}, "\x00")
+}
+
+// store adds an entry, first dropping expired entries and then the entry closest to expiry
+// when the cache is full. The caller holds s.mu.
+func (s *CachingAIService) store(key string, entry *cachedAnalysis) {
+	if _, ok := s.entries[key]; !ok && len(s.entries) >= s.maxEntries {
+		now := s.clock.Now()
+		oldestKey := ""
+		for k, e := range s.entries {
+			if !now.Before(e.expiresAt) {
+				delete(s.entries, k)
+				continue
+			}
+			if oldestKey == "" || e.expiresAt.Before(s.entries[oldestKey].expiresAt) {
+				oldestKey = k
+			}
+		}
+		if len(s.entries) >= s.maxEntries {
+			delete(s.entries, oldestKey)
+		}
+	}
+	s.entries[key] = entry
+}
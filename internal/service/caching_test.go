@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingAIService counts calls and returns a fixed analysis or error
+type countingAIService struct {
+	calls int32
+	err   error
+}
+
+func (s *countingAIService) AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*domain.IncidentAnalysis, error) {
+	atomic.AddInt32(&s.calls, 1)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.IncidentAnalysis{Severity: "High", Category: "Database"}, nil
+}
+
+func newTestCache(t *testing.T, upstream domain.AIService, rules []string, c clock.Clock) *CachingAIService {
+	normalizer, err := NewNormalizer(rules)
+	assert.NoError(t, err)
+	return NewCachingAIService(upstream, time.Minute, 2, normalizer, c)
+}
+
+func TestCachingAIService_EquivalentIncidentsShareEntry(t *testing.T) {
+	upstream := &countingAIService{}
+	service := newTestCache(t, upstream, NormalizationRules, clock.NewMock(time.Now()))
+
+	first, err := service.AnalyzeIncident(context.Background(), "DB connection timeout!", "Users  cannot\nlog in.", "Auth-Service")
+	assert.NoError(t, err)
+	second, err := service.AnalyzeIncident(context.Background(), "db connection timeout", " users cannot log in ", "auth service")
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&upstream.calls))
+	assert.Equal(t, first, second)
+
+	// Callers get copies, so changing one result leaves the cached entry alone
+	second.Severity = "Low"
+	third, _ := service.AnalyzeIncident(context.Background(), "DB connection timeout", "Users cannot log in", "auth-service")
+	assert.Equal(t, "High", third.Severity)
+}
+
+func TestCachingAIService_RulesAreConfigurable(t *testing.T) {
+	upstream := &countingAIService{}
+	service := newTestCache(t, upstream, []string{NormalizeWhitespace}, clock.NewMock(time.Now()))
+
+	_, _ = service.AnalyzeIncident(context.Background(), "Disk  full", "Root volume", "storage")
+	_, _ = service.AnalyzeIncident(context.Background(), "Disk full", " Root volume ", "storage")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&upstream.calls))
+
+	// Case is significant without the lowercase rule
+	_, _ = service.AnalyzeIncident(context.Background(), "DISK FULL", "Root volume", "storage")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&upstream.calls))
+}
+
+func TestCachingAIService_ExpiryEvictionAndFailures(t *testing.T) {
+	upstream := &countingAIService{}
+	mockClock := clock.NewMock(time.Now())
+	service := newTestCache(t, upstream, NormalizationRules, mockClock)
+
+	_, _ = service.AnalyzeIncident(context.Background(), "a", "one", "svc")
+	mockClock.Advance(time.Minute)
+	_, _ = service.AnalyzeIncident(context.Background(), "a", "one", "svc")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&upstream.calls), "expired entries are analyzed again")
+
+	mockClock.Advance(time.Second)
+	_, _ = service.AnalyzeIncident(context.Background(), "b", "two", "svc")
+	mockClock.Advance(time.Second)
+	_, _ = service.AnalyzeIncident(context.Background(), "c", "three", "svc")
+	assert.Len(t, service.entries, 2, "the entry closest to expiry is evicted")
+	_, _ = service.AnalyzeIncident(context.Background(), "c", "three", "svc")
+	assert.Equal(t, int32(4), atomic.LoadInt32(&upstream.calls))
+
+	upstream.err = errors.New("rate limited")
+	_, err := service.AnalyzeIncident(context.Background(), "d", "four", "svc")
+	assert.Error(t, err)
+	assert.Len(t, service.entries, 2, "failures are not cached")
+}
+
+func TestNewNormalizer_UnknownRule(t *testing.T) {
+	_, err := NewNormalizer([]string{"stemming"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// analysisCall is an in-flight analysis shared by every caller with the same fingerprint
+type analysisCall struct {
+	done     chan struct{}
+	analysis *domain.IncidentAnalysis
+	err      error
+
+	// waiters counts the callers still waiting on the call; when the last one leaves, cancel
+	// abandons the upstream request
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// CoalescingAIService decorates an AIService so that concurrent analyses of identical
+// incidents share a single upstream request. Nothing is cached once the request returns.
+type CoalescingAIService struct {
+	next domain.AIService
+
+	mu       sync.Mutex
+	inFlight map[string]*analysisCall
+}
+
+// NewCoalescingAIService wraps next with request coalescing
+func NewCoalescingAIService(next domain.AIService) *CoalescingAIService {
+	return &CoalescingAIService{next: next, inFlight: make(map[string]*analysisCall)}
+}
+
+// AnalyzeIncident joins the in-flight analysis of an identical incident, or starts one.
+// Every caller receives its own copy of the result. Each caller stops waiting when its own ctx
+// is done; the shared analysis is only cancelled once every caller has stopped waiting, so the
+// caller that started it cannot cancel it for the others.
+func (s *CoalescingAIService) AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*domain.IncidentAnalysis, error) {
+	key := strings.Join([]string{title, description, affectedService}, "\x00")
+
+	s.mu.Lock()
+	call, ok := s.inFlight[key]
+	if ok {
+		call.waiters++
+	} else {
+		shared, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &analysisCall{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		s.inFlight[key] = call
+
+		go func() {
+			call.analysis, call.err = s.next.AnalyzeIncident(shared, title, description, affectedService)
+			cancel()
+
+			s.mu.Lock()
+			if s.inFlight[key] == call {
+				delete(s.inFlight, key)
+			}
+			s.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		s.leave(key, call)
+		return nil, ctx.Err()
+	}
+
+	if call.err != nil {
+		return nil, call.err
+	}
+	analysis := *call.analysis
+	return &analysis, nil
+}
+
+// leave removes a caller that stopped waiting from call. When it was the last one the upstream
+// request is cancelled, and the call is forgotten so later callers start a fresh analysis.
+func (s *CoalescingAIService) leave(key string, call *analysisCall) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	call.cancel()
+	if s.inFlight[key] == call {
+		delete(s.inFlight, key)
+	}
+}
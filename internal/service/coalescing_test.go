@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingAIService counts calls and blocks each one until released
+type blockingAIService struct {
+	calls   int32
+	release chan struct{}
+	err     error
+}
+
+func (s *blockingAIService) AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*domain.IncidentAnalysis, error) {
+	atomic.AddInt32(&s.calls, 1)
+	<-s.release
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.IncidentAnalysis{Severity: "High", Category: "Database"}, nil
+}
+
+// waitForWaiters blocks until n callers are waiting on the in-flight analysis of key
+func waitForWaiters(t *testing.T, s *CoalescingAIService, key string, n int) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		call, ok := s.inFlight[key]
+		joined := ok && call.waiters == n
+		s.mu.Unlock()
+		if joined {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d callers to wait", n)
+}
+
+func TestCoalescingAIService_SharesConcurrentIdenticalCalls(t *testing.T) {
+	const callers = 10
+	upstream := &blockingAIService{release: make(chan struct{})}
+	service := NewCoalescingAIService(upstream)
+
+	results := make([]*domain.IncidentAnalysis, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			analysis, err := service.AnalyzeIncident(context.Background(), "DB down", "Primary unreachable", "orders")
+			assert.NoError(t, err)
+			results[i] = analysis
+		}(i)
+	}
+
+	waitForWaiters(t, service, "DB down\x00Primary unreachable\x00orders", callers)
+	close(upstream.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&upstream.calls))
+	for _, analysis := range results {
+		assert.Equal(t, &domain.IncidentAnalysis{Severity: "High", Category: "Database"}, analysis)
+	}
+	assert.NotSame(t, results[0], results[1])
+	assert.Empty(t, service.inFlight)
+}
+
+func TestCoalescingAIService_SharesErrors(t *testing.T) {
+	upstream := &blockingAIService{release: make(chan struct{}), err: errors.New("rate limited")}
+	service := NewCoalescingAIService(upstream)
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := service.AnalyzeIncident(context.Background(), "DB down", "Primary unreachable", "orders")
+			errs <- err
+		}()
+	}
+
+	waitForWaiters(t, service, "DB down\x00Primary unreachable\x00orders", 2)
+	close(upstream.release)
+
+	assert.EqualError(t, <-errs, "rate limited")
+	assert.EqualError(t, <-errs, "rate limited")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&upstream.calls))
+}
+
+func TestCoalescingAIService_CancelledCallerStopsWaiting(t *testing.T) {
+	upstream := &blockingAIService{release: make(chan struct{})}
+	service := NewCoalescingAIService(upstream)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := service.AnalyzeIncident(ctx, "DB down", "Primary unreachable", "orders")
+		errs <- err
+	}()
+	for atomic.LoadInt32(&upstream.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := service.AnalyzeIncident(context.Background(), "DB down", "Primary unreachable", "orders")
+		done <- err
+	}()
+	waitForWaiters(t, service, "DB down\x00Primary unreachable\x00orders", 2)
+
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+
+	close(upstream.release)
+	assert.NoError(t, <-done, "the shared analysis outlives the caller that started it")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&upstream.calls))
+}
+
+// cancellableAIService blocks each call until its ctx is done and reports the ctx error
+type cancellableAIService struct {
+	started  chan struct{}
+	finished chan error
+}
+
+func (s *cancellableAIService) AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*domain.IncidentAnalysis, error) {
+	s.started <- struct{}{}
+	<-ctx.Done()
+	s.finished <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+func TestCoalescingAIService_CancelsUpstreamWhenEveryCallerLeaves(t *testing.T) {
+	upstream := &cancellableAIService{started: make(chan struct{}, 1), finished: make(chan error, 1)}
+	service := NewCoalescingAIService(upstream)
+	key := "DB down\x00Primary unreachable\x00orders"
+
+	var cancels []context.CancelFunc
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancels = append(cancels, cancel)
+		go func() {
+			_, err := service.AnalyzeIncident(ctx, "DB down", "Primary unreachable", "orders")
+			errs <- err
+		}()
+	}
+	<-upstream.started
+	waitForWaiters(t, service, key, 2)
+
+	cancels[0]()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-upstream.finished:
+		t.Fatal("the upstream analysis was cancelled while a caller was still waiting")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancels[1]()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case err := <-upstream.finished:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("the upstream analysis was not cancelled after every caller left")
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	assert.NotContains(t, service.inFlight, key)
+}
+
+func TestCoalescingAIService_DistinctIncidentsCallSeparately(t *testing.T) {
+	upstream := &blockingAIService{release: make(chan struct{})}
+	close(upstream.release)
+	service := NewCoalescingAIService(upstream)
+
+	_, err := service.AnalyzeIncident(context.Background(), "DB down", "Primary unreachable", "orders")
+	assert.NoError(t, err)
+	_, err = service.AnalyzeIncident(context.Background(), "DB down", "Primary unreachable", "payments")
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&upstream.calls))
+}
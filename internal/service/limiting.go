@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// aiInFlight is the number of analyses holding a concurrency slot
+	aiInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ai_analyses_in_flight",
+		Help: "AI analyses currently running, bounded by AI_MAX_CONCURRENCY.",
+	})
+
+	// aiWaiting is the number of analyses queued for a concurrency slot
+	aiWaiting = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ai_analyses_waiting",
+		Help: "AI analyses waiting for a free AI_MAX_CONCURRENCY slot.",
+	})
+
+	// aiQueueWait is how long analyses waited for a concurrency slot
+	aiQueueWait = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "ai_analysis_queue_wait_seconds",
+		Help:    "Time AI analyses waited for a free AI_MAX_CONCURRENCY slot.",
+		Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+	})
+)
+
+// LimitingAIService decorates an AIService so that at most a fixed number of analyses run
+// at once, keeping bursts under the provider's concurrency limit. Unlike a rate limit it does
+// not care how many calls are made per minute, only how many are open simultaneously.
+type LimitingAIService struct {
+	ctx   context.Context
+	next  domain.AIService
+	slots chan struct{}
+	clock clock.Clock
+}
+
+// NewLimitingAIService wraps next so that at most maxConcurrent analyses run at once.
+// Analyses queued when ctx is done, typically at shutdown, give up waiting.
+func NewLimitingAIService(ctx context.Context, next domain.AIService, maxConcurrent int, c clock.Clock) *LimitingAIService {
+	return &LimitingAIService{ctx: ctx, next: next, slots: make(chan struct{}, maxConcurrent), clock: c}
+}
+
+// AnalyzeIncident waits for a free slot until ctx or the service's context is done, then
+// analyzes the incident
+func (s *LimitingAIService) AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*domain.IncidentAnalysis, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	return s.next.AnalyzeIncident(ctx, title, description, affectedService)
+}
+
+// acquire takes a slot, recording how long that took
+func (s *LimitingAIService) acquire(ctx context.Context) error {
+	start := s.clock.Now()
+	defer func() { aiQueueWait.Observe(s.clock.Now().Sub(start).Seconds()) }()
+
+	select {
+	case s.slots <- struct{}{}:
+		aiInFlight.Inc()
+		return nil
+	default:
+	}
+
+	aiWaiting.Inc()
+	defer aiWaiting.Dec()
+	select {
+	case s.slots <- struct{}{}:
+		aiInFlight.Inc()
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for an AI analysis slot: %w", ctx.Err())
+	case <-s.ctx.Done():
+		return fmt.Errorf("waiting for an AI analysis slot: %w", s.ctx.Err())
+	}
+}
+
+// release frees a slot taken by acquire
+func (s *LimitingAIService) release() {
+	aiInFlight.Dec()
+	<-s.slots
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// concurrencyAIService records the most analyses it saw running at once
+type concurrencyAIService struct {
+	running int32
+	peak    int32
+}
+
+func (s *concurrencyAIService) AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*domain.IncidentAnalysis, error) {
+	running := atomic.AddInt32(&s.running, 1)
+	defer atomic.AddInt32(&s.running, -1)
+	for {
+		peak := atomic.LoadInt32(&s.peak)
+		if running <= peak || atomic.CompareAndSwapInt32(&s.peak, peak, running) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	return &domain.IncidentAnalysis{Severity: "High", Category: "Database"}, nil
+}
+
+func TestLimitingAIService_BoundsConcurrentAnalyses(t *testing.T) {
+	const limit, burst = 3, 30
+	upstream := &concurrencyAIService{}
+	service := NewLimitingAIService(context.Background(), upstream, limit, clock.Real{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < burst; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			analysis, err := service.AnalyzeIncident(context.Background(), "DB down", "Primary unreachable", "orders")
+			assert.NoError(t, err)
+			assert.Equal(t, "High", analysis.Severity)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&upstream.peak), int32(limit))
+	assert.Equal(t, int32(limit), atomic.LoadInt32(&upstream.peak), "a burst should saturate every slot")
+	assert.Empty(t, service.slots)
+}
+
+func TestLimitingAIService_WaitRespectsContext(t *testing.T) {
+	upstream := &blockingAIService{release: make(chan struct{})}
+	service := NewLimitingAIService(context.Background(), upstream, 1, clock.Real{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := service.AnalyzeIncident(context.Background(), "DB down", "Primary unreachable", "orders")
+		assert.NoError(t, err)
+	}()
+	for atomic.LoadInt32(&upstream.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := service.AnalyzeIncident(ctx, "Disk full", "No space left", "storage")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&upstream.calls))
+
+	close(upstream.release)
+	<-done
+}
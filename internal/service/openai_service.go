@@ -3,10 +3,17 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+	"math/rand"
+	"net"
+	"net/http"
 	"os"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/sashabaranov/go-openai"
 )
@@ -14,30 +21,64 @@ import (
 // OpenAIClient interface for mocking
 type OpenAIClient interface {
 	CreateChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error)
+	CreateEmbeddings(ctx context.Context, conv openai.EmbeddingRequestConverter) (openai.EmbeddingResponse, error)
+	ListModels(ctx context.Context) (openai.ModelsList, error)
 }
 
-// OpenAIService implements the AIService interface using OpenAI API
-type OpenAIService struct {
-	client OpenAIClient
-}
+// healthCheckTimeout bounds the provider liveness check
+const healthCheckTimeout = 3 * time.Second
 
-// NewOpenAIService creates a new OpenAI service instance
-func NewOpenAIService() *OpenAIService {
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	if apiKey == "" {
-		panic("OPENAI_API_KEY environment variable is required")
-	}
+// DefaultModel is the model of the first analysis pass unless another is configured
+const DefaultModel = openai.GPT3Dot5Turbo
 
-	client := openai.NewClient(apiKey)
-	return &OpenAIService{client: client}
-}
+// DefaultPromptTokenBudget bounds the estimated size of an analysis prompt, leaving room in
+// the default model's context window for the response
+const DefaultPromptTokenBudget = 12000
 
-// AnalyzeIncident analyzes an incident using OpenAI to determine severity and category
-func (s *OpenAIService) AnalyzeIncident(title, description, affectedService string) (*domain.IncidentAnalysis, error) {
-	prompt := fmt.Sprintf(`
+// maxRetryDelay caps the backoff between attempts of an analysis
+const maxRetryDelay = 10 * time.Second
+
+// charsPerToken is the rough number of characters per token used to estimate prompt sizes
+// without a tokenizer
+const charsPerToken = 4
+
+// elisionMarker replaces the middle of a description cut to fit the prompt budget
+const elisionMarker = "\n[... %d characters omitted ...]\n"
+
+// systemPrompt frames every analysis request
+const systemPrompt = "You are an IT incident triage assistant. Analyze incidents and provide severity and category classifications. Respond only with valid JSON."
+
+// analysisPrompt asks for the full analysis of an incident's title, description and affected service
+const analysisPrompt = `
 Analyze the following IT incident and provide:
 1. Severity level (Low, Medium, High, Critical)
 2. Category (Network, Software, Hardware, Security, Database, Application, Infrastructure)
+3. A suggested first remediation step, in one or two sentences
+4. A short explanation of why you chose that severity and category, in one sentence
+5. How confident you are in that severity and category, from 0 (guessing) to 1 (certain)
+
+Incident Details:
+- Title: %s
+- Description: %s
+- Affected Service: %s
+
+Please respond with only a JSON object in this exact format:
+{
+  "severity": "Low|Medium|High|Critical",
+  "category": "Network|Software|Hardware|Security|Database|Application|Infrastructure",
+  "suggested_action": "First step an on-call engineer should take",
+  "reasoning": "Why this severity and category",
+  "confidence": 0.0
+}
+`
+
+// refinePrompt asks a stronger model to reassess an incident of a known category
+const refinePrompt = `
+The following IT incident was classified in the %s category. Reassess it and provide:
+1. Severity level (Low, Medium, High, Critical)
+2. A suggested first remediation step, in one or two sentences
+3. A short explanation of why you chose that severity, in one sentence
+4. How confident you are in that severity, from 0 (guessing) to 1 (certain)
 
 Incident Details:
 - Title: %s
@@ -47,28 +88,186 @@ Incident Details:
 Please respond with only a JSON object in this exact format:
 {
   "severity": "Low|Medium|High|Critical",
-  "category": "Network|Software|Hardware|Security|Database|Application|Infrastructure"
-}
-`, title, description, affectedService)
-
-	resp, err := s.client.CreateChatCompletion(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Model: openai.GPT3Dot5Turbo,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleSystem,
-					Content: "You are an IT incident triage assistant. Analyze incidents and provide severity and category classifications. Respond only with valid JSON.",
-				},
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: prompt,
-				},
+  "suggested_action": "First step an on-call engineer should take",
+  "reasoning": "Why this severity",
+  "confidence": 0.0
+}
+`
+
+// OpenAIService implements the AIService interface using OpenAI API
+type OpenAIService struct {
+	client OpenAIClient
+
+	// jsonMode constrains the model to emit a JSON object. Disable it with
+	// OPENAI_JSON_MODE=false for models that do not support response formats.
+	jsonMode bool
+
+	// model runs the first analysis pass; empty means DefaultModel
+	model string
+
+	// refineModels maps categories that warrant a second, stronger pass to its model
+	refineModels map[string]string
+
+	// promptTokens bounds the estimated tokens of a prompt; zero means DefaultPromptTokenBudget
+	promptTokens int
+
+	// maxAttempts counts the calls of an analysis, retries included; zero or one never retries
+	maxAttempts int
+	// baseDelay is the backoff before the first retry, doubled before each further one
+	baseDelay time.Duration
+	// jitter randomizes a backoff; nil uses equalJitter
+	jitter func(time.Duration) time.Duration
+	// sleep waits between attempts, returning early with an error when ctx is done; nil uses sleepContext
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// Option configures optional OpenAIService settings
+type Option func(*OpenAIService)
+
+// WithModel sets the model of the first analysis pass
+func WithModel(model string) Option {
+	return func(s *OpenAIService) {
+		s.model = model
+	}
+}
+
+// WithRefineModels enables a second analysis pass for the categories in models, using the
+// model each maps to. An empty map disables the second pass.
+func WithRefineModels(models map[string]string) Option {
+	return func(s *OpenAIService) {
+		s.refineModels = models
+	}
+}
+
+// WithPromptTokenBudget bounds the estimated tokens of a prompt. Longer descriptions are
+// shortened to fit rather than sent whole.
+func WithPromptTokenBudget(tokens int) Option {
+	return func(s *OpenAIService) {
+		s.promptTokens = tokens
+	}
+}
+
+// WithRetry retries analysis requests failing with a rate limit, a server error or a timeout,
+// making up to maxAttempts calls in all. The backoff starts around baseDelay and doubles
+// after each attempt, randomized so that callers failing together do not retry together.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(s *OpenAIService) {
+		s.maxAttempts = maxAttempts
+		s.baseDelay = baseDelay
+	}
+}
+
+// NewOpenAIService creates a new OpenAI service instance. OPENAI_API_KEY must be set.
+func NewOpenAIService(opts ...Option) (*OpenAIService, error) {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return nil, errors.New("OPENAI_API_KEY environment variable is required")
+	}
+
+	client := openai.NewClient(apiKey)
+	s := &OpenAIService{
+		client:   client,
+		jsonMode: os.Getenv("OPENAI_JSON_MODE") != "false",
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// AnalyzeIncident analyzes an incident using OpenAI to determine severity and category. When
+// the category has a refine model, a second pass with that model reassesses the severity,
+// suggested action and reasoning; if it fails the first analysis is kept. A description too
+// long for the prompt budget is shortened and the analysis marked InputTruncated. The OpenAI
+// calls are abandoned, and retries stop, once ctx is done.
+func (s *OpenAIService) AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*domain.IncidentAnalysis, error) {
+	description, truncated := s.fitDescription(title, description, affectedService)
+	if truncated {
+		logging.FromContext(ctx).Info("Truncated the incident description to fit the prompt budget", "title", title, "token_budget", s.tokenBudget())
+	}
+
+	analysis, err := s.requestAnalysis(ctx, s.classifierModel(), fmt.Sprintf(analysisPrompt, title, description, affectedService))
+	if err != nil {
+		return nil, err
+	}
+	analysis.InputTruncated = truncated
+
+	model, ok := s.refineModels[analysis.Category]
+	if !ok {
+		return analysis, nil
+	}
+
+	refined, err := s.requestAnalysis(ctx, model, fmt.Sprintf(refinePrompt, analysis.Category, title, description, affectedService))
+	if err != nil {
+		logging.FromContext(ctx).Warn("Refining the analysis failed, keeping the first pass", "category", analysis.Category, "model", model, "error", err)
+		return analysis, nil
+	}
+
+	refined.Category = analysis.Category
+	refined.InputTruncated = truncated
+	return refined, nil
+}
+
+// classifierModel returns the model of the first analysis pass
+func (s *OpenAIService) classifierModel() string {
+	if s.model == "" {
+		return DefaultModel
+	}
+	return s.model
+}
+
+// tokenBudget returns the prompt token budget
+func (s *OpenAIService) tokenBudget() int {
+	if s.promptTokens <= 0 {
+		return DefaultPromptTokenBudget
+	}
+	return s.promptTokens
+}
+
+// fitDescription shortens description so the larger of the two prompts stays within the
+// token budget, keeping its head and tail around a marker of how much was left out. It
+// reports whether anything was cut.
+func (s *OpenAIService) fitDescription(title, description, affectedService string) (string, bool) {
+	overhead := max(
+		utf8.RuneCountInString(fmt.Sprintf(analysisPrompt, title, "", affectedService)),
+		utf8.RuneCountInString(fmt.Sprintf(refinePrompt, domain.DefaultCategory, title, "", affectedService)),
+	) + utf8.RuneCountInString(systemPrompt)
+	available := s.tokenBudget()*charsPerToken - overhead
+
+	runes := []rune(description)
+	if len(runes) <= available {
+		return description, false
+	}
+
+	keep := max(available-len(fmt.Sprintf(elisionMarker, len(runes))), 0)
+	head, tail := keep-keep/2, keep/2
+	marker := fmt.Sprintf(elisionMarker, len(runes)-head-tail)
+	return string(runes[:head]) + marker + string(runes[len(runes)-tail:]), true
+}
+
+// requestAnalysis sends an analysis prompt to model and parses the JSON analysis it returns,
+// falling back to the default severity and category for values outside the taxonomy and to
+// the default confidence when none is given
+func (s *OpenAIService) requestAnalysis(ctx context.Context, model, prompt string) (*domain.IncidentAnalysis, error) {
+	req := openai.ChatCompletionRequest{
+		Model: model,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: systemPrompt,
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: prompt,
 			},
-			Temperature: 0.1, // Low temperature for consistent classification
 		},
-	)
+		Temperature: 0.1, // Low temperature for consistent classification
+	}
+	if s.jsonMode {
+		req.ResponseFormat = &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	}
 
+	resp, err := s.createChatCompletion(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get AI analysis: %w", err)
 	}
@@ -78,6 +277,9 @@ Please respond with only a JSON object in this exact format:
 	}
 
 	content := strings.TrimSpace(resp.Choices[0].Message.Content)
+	if !s.jsonMode {
+		content = stripCodeFence(content)
+	}
 
 	// Parse JSON response
 	var analysis domain.IncidentAnalysis
@@ -87,20 +289,177 @@ Please respond with only a JSON object in this exact format:
 	}
 
 	// Validate severity
-	validSeverities := []string{"Low", "Medium", "High", "Critical"}
-	if !contains(validSeverities, analysis.Severity) {
-		analysis.Severity = "Medium" // Default fallback
+	if !contains(domain.Severities, analysis.Severity) {
+		analysis.Severity = domain.DefaultSeverity
 	}
 
 	// Validate category
-	validCategories := []string{"Network", "Software", "Hardware", "Security", "Database", "Application", "Infrastructure"}
-	if !contains(validCategories, analysis.Category) {
-		analysis.Category = "Software" // Default fallback
+	if !contains(domain.Categories, analysis.Category) {
+		analysis.Category = domain.DefaultCategory
+	}
+
+	// The suggestion and reasoning are optional free text, so normalize them rather than
+	// reject the analysis
+	analysis.SuggestedAction = normalizeFreeText(analysis.SuggestedAction, domain.MaxSuggestedActionLength)
+	analysis.Reasoning = normalizeFreeText(analysis.Reasoning, domain.MaxReasoningLength)
+
+	// The confidence is optional too: a missing one counts as undecided and others are
+	// clamped to [0, 1]
+	confidence := domain.DefaultConfidence
+	if analysis.Confidence != nil {
+		confidence = min(max(*analysis.Confidence, 0), 1)
 	}
+	analysis.Confidence = &confidence
 
 	return &analysis, nil
 }
 
+// createChatCompletion sends req, retrying retryable failures with exponential backoff
+func (s *OpenAIService) createChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	attempts := max(s.maxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		resp, err := s.client.CreateChatCompletion(ctx, req)
+		if err == nil || attempt == attempts || !retryable(err) {
+			return resp, err
+		}
+
+		delay := s.backoff(attempt)
+		logging.FromContext(ctx).Warn("OpenAI request failed, retrying", "model", req.Model, "attempt", attempt, "attempts", attempts, "retry_in", delay.String(), "error", err)
+
+		sleep := s.sleep
+		if sleep == nil {
+			sleep = sleepContext
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return openai.ChatCompletionResponse{}, err
+		}
+	}
+}
+
+// backoff returns the randomized wait after a failed attempt: baseDelay doubled for each
+// earlier attempt, capped at maxRetryDelay
+func (s *OpenAIService) backoff(attempt int) time.Duration {
+	delay := s.baseDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxRetryDelay)
+
+	jitter := s.jitter
+	if jitter == nil {
+		jitter = equalJitter
+	}
+	return jitter(delay)
+}
+
+// equalJitter picks a wait between half of delay and delay
+func equalJitter(delay time.Duration) time.Duration {
+	if delay <= 1 {
+		return delay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryable reports whether a failed OpenAI call may succeed if repeated: rate limits other
+// than an exhausted quota, server errors and network timeouts. Cancellation is final.
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Code == "insufficient_quota" {
+			return false
+		}
+		return retryableStatus(apiErr.HTTPStatusCode)
+	}
+
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return retryableStatus(reqErr.HTTPStatusCode)
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryableStatus reports whether an HTTP status is a rate limit or a server error
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// EmbedText computes an embedding vector for the given text using the OpenAI embeddings API
+func (s *OpenAIService) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	resp, err := s.client.CreateEmbeddings(
+		ctx,
+		openai.EmbeddingRequestStrings{
+			Input: []string{text},
+			Model: openai.SmallEmbedding3,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embedding: %w", err)
+	}
+
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("no embedding returned from AI service")
+	}
+
+	return resp.Data[0].Embedding, nil
+}
+
+// CheckHealth verifies the OpenAI API is reachable and the key is accepted by listing models,
+// which costs no tokens
+func (s *OpenAIService) CheckHealth() error {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	if _, err := s.client.ListModels(ctx); err != nil {
+		return fmt.Errorf("AI provider unreachable: %w", err)
+	}
+	return nil
+}
+
+// normalizeFreeText collapses whitespace in free text from the model and truncates it to
+// maxLength characters
+func normalizeFreeText(text string, maxLength int) string {
+	text = strings.Join(strings.Fields(text), " ")
+
+	runes := []rune(text)
+	if len(runes) > maxLength {
+		text = strings.TrimSpace(string(runes[:maxLength]))
+	}
+	return text
+}
+
+// stripCodeFence removes a markdown code fence that models sometimes wrap around JSON
+// when they are not constrained to a response format
+func stripCodeFence(content string) string {
+	if !strings.HasPrefix(content, "```") {
+		return content
+	}
+
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimPrefix(content, "json")
+	content = strings.TrimSuffix(content, "```")
+	return strings.TrimSpace(content)
+}
+
 // contains checks if a slice contains a specific string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
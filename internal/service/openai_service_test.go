@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"incident-triage-assistant/internal/domain"
 
@@ -13,6 +16,11 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// confidence returns a pointer to c for expected analyses
+func confidence(c float64) *float64 {
+	return &c
+}
+
 // MockOpenAIClient is a mock implementation of the OpenAI client
 type MockOpenAIClient struct {
 	mock.Mock
@@ -23,6 +31,16 @@ func (m *MockOpenAIClient) CreateChatCompletion(ctx context.Context, req openai.
 	return args.Get(0).(openai.ChatCompletionResponse), args.Error(1)
 }
 
+func (m *MockOpenAIClient) CreateEmbeddings(ctx context.Context, conv openai.EmbeddingRequestConverter) (openai.EmbeddingResponse, error) {
+	args := m.Called(ctx, conv)
+	return args.Get(0).(openai.EmbeddingResponse), args.Error(1)
+}
+
+func (m *MockOpenAIClient) ListModels(ctx context.Context) (openai.ModelsList, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(openai.ModelsList), args.Error(1)
+}
+
 func TestOpenAIService_AnalyzeIncident(t *testing.T) {
 	// Set a dummy API key for testing
 	os.Setenv("OPENAI_API_KEY", "test-key")
@@ -46,8 +64,9 @@ func TestOpenAIService_AnalyzeIncident(t *testing.T) {
 			aiResponse:      `{"severity": "High", "category": "Database"}`,
 			aiError:         nil,
 			expectedResult: &domain.IncidentAnalysis{
-				Severity: "High",
-				Category: "Database",
+				Severity:   "High",
+				Category:   "Database",
+				Confidence: confidence(domain.DefaultConfidence),
 			},
 			expectedError: false,
 		},
@@ -59,8 +78,9 @@ func TestOpenAIService_AnalyzeIncident(t *testing.T) {
 			aiResponse:      `{"severity": "Invalid", "category": "Software"}`,
 			aiError:         nil,
 			expectedResult: &domain.IncidentAnalysis{
-				Severity: "Medium", // Should fallback to Medium
-				Category: "Software",
+				Severity:   "Medium", // Should fallback to Medium
+				Category:   "Software",
+				Confidence: confidence(domain.DefaultConfidence),
 			},
 			expectedError: false,
 		},
@@ -72,11 +92,93 @@ func TestOpenAIService_AnalyzeIncident(t *testing.T) {
 			aiResponse:      `{"severity": "High", "category": "Invalid"}`,
 			aiError:         nil,
 			expectedResult: &domain.IncidentAnalysis{
-				Severity: "High",
-				Category: "Software", // Should fallback to Software
+				Severity:   "High",
+				Category:   "Software", // Should fallback to Software
+				Confidence: confidence(domain.DefaultConfidence),
+			},
+			expectedError: false,
+		},
+		{
+			name:            "suggested action is trimmed",
+			title:           "Disk full",
+			description:     "Root volume at 100%",
+			affectedService: "Storage",
+			aiResponse:      `{"severity": "High", "category": "Hardware", "suggested_action": "  Free space on\n the root volume  "}`,
+			aiError:         nil,
+			expectedResult: &domain.IncidentAnalysis{
+				Severity:        "High",
+				Category:        "Hardware",
+				SuggestedAction: "Free space on the root volume",
+				Confidence:      confidence(domain.DefaultConfidence),
+			},
+			expectedError: false,
+		},
+		{
+			name:            "reasoning is trimmed and capped",
+			title:           "Login failures",
+			description:     "Every sign-in returns 500",
+			affectedService: "Auth Service",
+			aiResponse:      `{"severity": "Critical", "category": "Application", "reasoning": "  All users\n are locked out  "}`,
+			aiError:         nil,
+			expectedResult: &domain.IncidentAnalysis{
+				Severity:   "Critical",
+				Category:   "Application",
+				Reasoning:  "All users are locked out",
+				Confidence: confidence(domain.DefaultConfidence),
 			},
 			expectedError: false,
 		},
+		{
+			name:            "overlong reasoning is truncated",
+			title:           "Login failures",
+			description:     "Every sign-in returns 500",
+			affectedService: "Auth Service",
+			aiResponse:      `{"severity": "Critical", "category": "Application", "reasoning": "` + strings.Repeat("a", domain.MaxReasoningLength+20) + `"}`,
+			aiError:         nil,
+			expectedResult: &domain.IncidentAnalysis{
+				Severity:   "Critical",
+				Category:   "Application",
+				Reasoning:  strings.Repeat("a", domain.MaxReasoningLength),
+				Confidence: confidence(domain.DefaultConfidence),
+			},
+			expectedError: false,
+		},
+		{
+			name:            "confidence is kept",
+			title:           "Database timeout",
+			description:     "Users unable to login",
+			affectedService: "Auth Service",
+			aiResponse:      `{"severity": "High", "category": "Database", "confidence": 0.85}`,
+			expectedResult: &domain.IncidentAnalysis{
+				Severity:   "High",
+				Category:   "Database",
+				Confidence: confidence(0.85),
+			},
+		},
+		{
+			name:            "confidence above 1 is clamped",
+			title:           "Database timeout",
+			description:     "Users unable to login",
+			affectedService: "Auth Service",
+			aiResponse:      `{"severity": "High", "category": "Database", "confidence": 7}`,
+			expectedResult: &domain.IncidentAnalysis{
+				Severity:   "High",
+				Category:   "Database",
+				Confidence: confidence(1),
+			},
+		},
+		{
+			name:            "negative confidence is clamped",
+			title:           "Database timeout",
+			description:     "Users unable to login",
+			affectedService: "Auth Service",
+			aiResponse:      `{"severity": "High", "category": "Database", "confidence": -0.3}`,
+			expectedResult: &domain.IncidentAnalysis{
+				Severity:   "High",
+				Category:   "Database",
+				Confidence: confidence(0),
+			},
+		},
 		{
 			name:            "AI service error",
 			title:           "Test incident",
@@ -114,7 +216,7 @@ func TestOpenAIService_AnalyzeIncident(t *testing.T) {
 					Return(openai.ChatCompletionResponse{}, tt.aiError)
 			}
 
-			result, err := service.AnalyzeIncident(tt.title, tt.description, tt.affectedService)
+			result, err := service.AnalyzeIncident(context.Background(), tt.title, tt.description, tt.affectedService)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -124,6 +226,9 @@ func TestOpenAIService_AnalyzeIncident(t *testing.T) {
 				assert.NotNil(t, result)
 				assert.Equal(t, tt.expectedResult.Severity, result.Severity)
 				assert.Equal(t, tt.expectedResult.Category, result.Category)
+				assert.Equal(t, tt.expectedResult.SuggestedAction, result.SuggestedAction)
+				assert.Equal(t, tt.expectedResult.Reasoning, result.Reasoning)
+				assert.Equal(t, tt.expectedResult.Confidence, result.Confidence)
 			}
 
 			mockClient.AssertExpectations(t)
@@ -131,21 +236,246 @@ func TestOpenAIService_AnalyzeIncident(t *testing.T) {
 	}
 }
 
+func TestOpenAIService_AnalyzeIncident_ResponseFormat(t *testing.T) {
+	response := func(content string) openai.ChatCompletionResponse {
+		return openai.ChatCompletionResponse{
+			Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Content: content}}},
+		}
+	}
+
+	t.Run("JSON mode constrains the response format", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient, jsonMode: true}
+
+		mockClient.On("CreateChatCompletion", mock.Anything, mock.MatchedBy(func(req openai.ChatCompletionRequest) bool {
+			return req.ResponseFormat != nil && req.ResponseFormat.Type == openai.ChatCompletionResponseFormatTypeJSONObject
+		})).Return(response(`{"severity": "High", "category": "Database"}`), nil)
+
+		result, err := service.AnalyzeIncident(context.Background(), "Database timeout", "Users unable to login", "Auth Service")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.IncidentAnalysis{Severity: "High", Category: "Database", Confidence: confidence(domain.DefaultConfidence)}, result)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("prompt fallback strips code fences", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient}
+
+		mockClient.On("CreateChatCompletion", mock.Anything, mock.MatchedBy(func(req openai.ChatCompletionRequest) bool {
+			return req.ResponseFormat == nil
+		})).Return(response("```json\n{\"severity\": \"Low\", \"category\": \"Network\"}\n```"), nil)
+
+		result, err := service.AnalyzeIncident(context.Background(), "Packet loss", "Intermittent drops", "Edge Router")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.IncidentAnalysis{Severity: "Low", Category: "Network", Confidence: confidence(domain.DefaultConfidence)}, result)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("a cancelled caller context aborts the request", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mockClient.On("CreateChatCompletion", mock.MatchedBy(func(c context.Context) bool {
+			return c.Err() != nil
+		}), mock.Anything).Return(openai.ChatCompletionResponse{}, context.Canceled)
+
+		_, err := service.AnalyzeIncident(ctx, "Database timeout", "Users unable to login", "Auth Service")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestOpenAIService_AnalyzeIncident_Retry(t *testing.T) {
+	success := openai.ChatCompletionResponse{
+		Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Content: `{"severity": "High", "category": "Database"}`}}},
+	}
+	rateLimited := &openai.APIError{HTTPStatusCode: 429, Message: "Rate limit reached"}
+	unavailable := &openai.RequestError{HTTPStatusCode: 503, Err: errors.New("service unavailable")}
+
+	// newService retries without jitter and records the waits instead of sleeping
+	newService := func(client OpenAIClient, waits *[]time.Duration) *OpenAIService {
+		s := &OpenAIService{client: client}
+		WithRetry(3, 500*time.Millisecond)(s)
+		s.jitter = func(d time.Duration) time.Duration { return d }
+		s.sleep = func(ctx context.Context, d time.Duration) error {
+			*waits = append(*waits, d)
+			return nil
+		}
+		return s
+	}
+
+	t.Run("succeeds after two transient failures", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		var waits []time.Duration
+		service := newService(mockClient, &waits)
+
+		mockClient.On("CreateChatCompletion", mock.Anything, mock.Anything).Return(openai.ChatCompletionResponse{}, rateLimited).Once()
+		mockClient.On("CreateChatCompletion", mock.Anything, mock.Anything).Return(openai.ChatCompletionResponse{}, unavailable).Once()
+		mockClient.On("CreateChatCompletion", mock.Anything, mock.Anything).Return(success, nil).Once()
+
+		result, err := service.AnalyzeIncident(context.Background(), "Database timeout", "Users unable to login", "Auth Service")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.IncidentAnalysis{Severity: "High", Category: "Database", Confidence: confidence(domain.DefaultConfidence)}, result)
+		assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second}, waits)
+		mockClient.AssertNumberOfCalls(t, "CreateChatCompletion", 3)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		var waits []time.Duration
+		service := newService(mockClient, &waits)
+
+		mockClient.On("CreateChatCompletion", mock.Anything, mock.Anything).Return(openai.ChatCompletionResponse{}, rateLimited)
+
+		_, err := service.AnalyzeIncident(context.Background(), "Database timeout", "Users unable to login", "Auth Service")
+
+		assert.ErrorIs(t, err, rateLimited)
+		assert.Len(t, waits, 2)
+		mockClient.AssertNumberOfCalls(t, "CreateChatCompletion", 3)
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		for _, clientErr := range []error{
+			&openai.APIError{HTTPStatusCode: 400, Message: "Invalid request"},
+			&openai.APIError{HTTPStatusCode: 429, Code: "insufficient_quota", Message: "Quota exceeded"},
+			context.Canceled,
+		} {
+			mockClient := new(MockOpenAIClient)
+			var waits []time.Duration
+			service := newService(mockClient, &waits)
+
+			mockClient.On("CreateChatCompletion", mock.Anything, mock.Anything).Return(openai.ChatCompletionResponse{}, clientErr)
+
+			_, err := service.AnalyzeIncident(context.Background(), "Database timeout", "Users unable to login", "Auth Service")
+
+			assert.ErrorIs(t, err, clientErr)
+			assert.Empty(t, waits)
+			mockClient.AssertNumberOfCalls(t, "CreateChatCompletion", 1)
+		}
+	})
+
+	t.Run("backoff is capped", func(t *testing.T) {
+		service := &OpenAIService{baseDelay: 4 * time.Second, jitter: func(d time.Duration) time.Duration { return d }}
+
+		assert.Equal(t, 4*time.Second, service.backoff(1))
+		assert.Equal(t, 8*time.Second, service.backoff(2))
+		assert.Equal(t, maxRetryDelay, service.backoff(3))
+		assert.Equal(t, maxRetryDelay, service.backoff(30))
+	})
+}
+
+func TestEqualJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		wait := equalJitter(time.Second)
+		assert.GreaterOrEqual(t, wait, 500*time.Millisecond)
+		assert.LessOrEqual(t, wait, time.Second)
+	}
+	assert.Zero(t, equalJitter(0))
+}
+
+func TestOpenAIService_EmbedText(t *testing.T) {
+	t.Run("successful embedding", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient}
+
+		response := openai.EmbeddingResponse{
+			Data: []openai.Embedding{{Embedding: []float32{0.1, 0.2, 0.3}}},
+		}
+		mockClient.On("CreateEmbeddings", mock.Anything, openai.EmbeddingRequestStrings{
+			Input: []string{"Database timeout"},
+			Model: openai.SmallEmbedding3,
+		}).Return(response, nil)
+
+		embedding, err := service.EmbedText(context.Background(), "Database timeout")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []float32{0.1, 0.2, 0.3}, embedding)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("AI service error", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient}
+
+		mockClient.On("CreateEmbeddings", mock.Anything, mock.Anything).
+			Return(openai.EmbeddingResponse{}, errors.New("API error"))
+
+		embedding, err := service.EmbedText(context.Background(), "Database timeout")
+
+		assert.Error(t, err)
+		assert.Nil(t, embedding)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient}
+
+		mockClient.On("CreateEmbeddings", mock.Anything, mock.Anything).
+			Return(openai.EmbeddingResponse{}, nil)
+
+		embedding, err := service.EmbedText(context.Background(), "Database timeout")
+
+		assert.Error(t, err)
+		assert.Nil(t, embedding)
+	})
+}
+
+func TestOpenAIService_CheckHealth(t *testing.T) {
+	t.Run("reachable", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient}
+		mockClient.On("ListModels", mock.Anything).Return(openai.ModelsList{}, nil)
+
+		assert.NoError(t, service.CheckHealth())
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient}
+		mockClient.On("ListModels", mock.Anything).Return(openai.ModelsList{}, errors.New("connection refused"))
+
+		assert.EqualError(t, service.CheckHealth(), "AI provider unreachable: connection refused")
+	})
+}
+
 func TestOpenAIService_NewOpenAIService(t *testing.T) {
 	// Test with missing API key
 	os.Unsetenv("OPENAI_API_KEY")
 
-	assert.Panics(t, func() {
-		NewOpenAIService()
-	})
+	_, err := NewOpenAIService()
+	assert.EqualError(t, err, "OPENAI_API_KEY environment variable is required")
 
 	// Test with valid API key
 	os.Setenv("OPENAI_API_KEY", "test-key")
 	defer os.Unsetenv("OPENAI_API_KEY")
 
-	service := NewOpenAIService()
-	assert.NotNil(t, service)
+	service, err := NewOpenAIService()
+	assert.NoError(t, err)
 	assert.NotNil(t, service.client)
+	assert.True(t, service.jsonMode)
+
+	// JSON mode can be turned off for models without response format support
+	os.Setenv("OPENAI_JSON_MODE", "false")
+	defer os.Unsetenv("OPENAI_JSON_MODE")
+
+	service, err = NewOpenAIService()
+	assert.NoError(t, err)
+	assert.False(t, service.jsonMode)
+}
+
+func TestNormalizeFreeText(t *testing.T) {
+	long := strings.Repeat("é", domain.MaxSuggestedActionLength+10)
+
+	assert.Equal(t, "", normalizeFreeText("   ", domain.MaxSuggestedActionLength))
+	assert.Equal(t, "Restart the pod", normalizeFreeText(" Restart\tthe  pod ", domain.MaxSuggestedActionLength))
+	assert.Equal(t, domain.MaxSuggestedActionLength, utf8.RuneCountInString(normalizeFreeText(long, domain.MaxSuggestedActionLength)))
 }
 
 func TestContains(t *testing.T) {
@@ -157,3 +487,120 @@ func TestContains(t *testing.T) {
 	assert.False(t, contains(slice, "d"))
 	assert.False(t, contains(slice, ""))
 }
+
+func TestOpenAIService_AnalyzeIncident_TwoPass(t *testing.T) {
+	response := func(content string) openai.ChatCompletionResponse {
+		return openai.ChatCompletionResponse{
+			Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Content: content}}},
+		}
+	}
+	model := func(name string) interface{} {
+		return mock.MatchedBy(func(req openai.ChatCompletionRequest) bool { return req.Model == name })
+	}
+	refineModels := map[string]string{"Security": "gpt-4o"}
+
+	t.Run("high-value category is refined by the stronger model", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient, model: "gpt-4o-mini", refineModels: refineModels}
+
+		mockClient.On("CreateChatCompletion", mock.Anything, model("gpt-4o-mini")).
+			Return(response(`{"severity": "Medium", "category": "Security", "reasoning": "Suspicious logins"}`), nil).Once()
+		mockClient.On("CreateChatCompletion", mock.Anything, model("gpt-4o")).
+			Return(response(`{"severity": "Critical", "suggested_action": "Rotate the leaked keys", "reasoning": "Credentials are exposed", "confidence": 0.9}`), nil).Once()
+
+		result, err := service.AnalyzeIncident(context.Background(), "Leaked keys", "API keys pushed to a public repo", "Auth Service")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.IncidentAnalysis{
+			Severity:        "Critical",
+			Category:        "Security",
+			SuggestedAction: "Rotate the leaked keys",
+			Reasoning:       "Credentials are exposed",
+			Confidence:      confidence(0.9),
+		}, result)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("routine category only uses the first model", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient, refineModels: refineModels}
+
+		mockClient.On("CreateChatCompletion", mock.Anything, model(DefaultModel)).
+			Return(response(`{"severity": "Low", "category": "Software"}`), nil).Once()
+
+		result, err := service.AnalyzeIncident(context.Background(), "Typo", "Typo on the settings page", "UI Service")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Low", result.Severity)
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNumberOfCalls(t, "CreateChatCompletion", 1)
+	})
+
+	t.Run("failed refinement keeps the first pass", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient, refineModels: refineModels}
+
+		mockClient.On("CreateChatCompletion", mock.Anything, model(DefaultModel)).
+			Return(response(`{"severity": "High", "category": "Security"}`), nil).Once()
+		mockClient.On("CreateChatCompletion", mock.Anything, model("gpt-4o")).
+			Return(openai.ChatCompletionResponse{}, errors.New("rate limited")).Once()
+
+		result, err := service.AnalyzeIncident(context.Background(), "Leaked keys", "API keys pushed to a public repo", "Auth Service")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "High", result.Severity)
+		assert.Equal(t, "Security", result.Category)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestOpenAIService_AnalyzeIncident_OversizedDescription(t *testing.T) {
+	response := openai.ChatCompletionResponse{
+		Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Content: `{"severity": "High", "category": "Application"}`}}},
+	}
+	promptRunes := func(req openai.ChatCompletionRequest) int {
+		total := 0
+		for _, message := range req.Messages {
+			total += utf8.RuneCountInString(message.Content)
+		}
+		return total
+	}
+
+	t.Run("huge description is truncated rather than rejected", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient, promptTokens: 1000}
+		description := "HEAD " + strings.Repeat("stack frame ", 50000) + " TAIL"
+
+		var sent openai.ChatCompletionRequest
+		mockClient.On("CreateChatCompletion", mock.Anything, mock.AnythingOfType("openai.ChatCompletionRequest")).
+			Run(func(args mock.Arguments) { sent = args.Get(1).(openai.ChatCompletionRequest) }).
+			Return(response, nil)
+
+		result, err := service.AnalyzeIncident(context.Background(), "Crash loop", description, "API")
+
+		assert.NoError(t, err)
+		assert.True(t, result.InputTruncated)
+		assert.LessOrEqual(t, promptRunes(sent), 1000*charsPerToken)
+
+		prompt := sent.Messages[1].Content
+		assert.Contains(t, prompt, "HEAD stack frame")
+		assert.Contains(t, prompt, "stack frame  TAIL")
+		assert.Contains(t, prompt, "characters omitted ...]")
+	})
+
+	t.Run("description within the budget is sent whole", func(t *testing.T) {
+		mockClient := new(MockOpenAIClient)
+		service := &OpenAIService{client: mockClient}
+		description := strings.Repeat("stack frame ", 100)
+
+		mockClient.On("CreateChatCompletion", mock.Anything, mock.MatchedBy(func(req openai.ChatCompletionRequest) bool {
+			return strings.Contains(req.Messages[1].Content, description)
+		})).Return(response, nil)
+
+		result, err := service.AnalyzeIncident(context.Background(), "Crash loop", description, "API")
+
+		assert.NoError(t, err)
+		assert.False(t, result.InputTruncated)
+		mockClient.AssertExpectations(t)
+	})
+}
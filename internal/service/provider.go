@@ -0,0 +1,46 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// mockAnalysis is the analysis of every incident under AI_PROVIDER=mock
+var mockAnalysis = domain.IncidentAnalysis{
+	Severity:  domain.DefaultSeverity,
+	Category:  domain.DefaultCategory,
+	Reasoning: "Fixed analysis from the mock AI provider",
+}
+
+// AIProvider is an AI backend that analyzes incidents and can report whether it is usable.
+// Providers that also compute embeddings implement domain.EmbeddingService.
+type AIProvider interface {
+	domain.AIService
+	CheckHealth() error
+}
+
+// NewAIProvider creates the provider named name, one of domain.AIProviders. opts configure
+// the OpenAI provider and are ignored by the others. A provider whose credentials are not
+// set is an error.
+func NewAIProvider(name string, opts ...Option) (AIProvider, error) {
+	switch name {
+	case domain.AIProviderOpenAI:
+		s, err := NewOpenAIService(opts...)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	case domain.AIProviderAnthropic:
+		s, err := NewAnthropicService()
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	case domain.AIProviderMock:
+		return NewStaticService(mockAnalysis), nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider %q (known: %s)", name, strings.Join(domain.AIProviders, ", "))
+	}
+}
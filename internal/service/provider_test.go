@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAIProvider(t *testing.T) {
+	t.Run("openai requires its API key", func(t *testing.T) {
+		t.Setenv("OPENAI_API_KEY", "")
+
+		_, err := NewAIProvider(domain.AIProviderOpenAI)
+		assert.EqualError(t, err, "OPENAI_API_KEY environment variable is required")
+	})
+
+	t.Run("openai computes embeddings", func(t *testing.T) {
+		t.Setenv("OPENAI_API_KEY", "test-key")
+
+		provider, err := NewAIProvider(domain.AIProviderOpenAI, WithModel("gpt-4o"))
+		assert.NoError(t, err)
+		assert.Equal(t, "gpt-4o", provider.(*OpenAIService).model)
+		assert.Implements(t, (*domain.EmbeddingService)(nil), provider)
+	})
+
+	t.Run("anthropic requires its API key", func(t *testing.T) {
+		t.Setenv("ANTHROPIC_API_KEY", "")
+
+		_, err := NewAIProvider(domain.AIProviderAnthropic)
+		assert.EqualError(t, err, "ANTHROPIC_API_KEY environment variable is required")
+	})
+
+	t.Run("anthropic is a stub", func(t *testing.T) {
+		t.Setenv("ANTHROPIC_API_KEY", "test-key")
+
+		provider, err := NewAIProvider(domain.AIProviderAnthropic)
+		assert.NoError(t, err)
+		_, err = provider.AnalyzeIncident(context.Background(), "Disk full", "No space left", "storage")
+		assert.ErrorIs(t, err, errAnthropicNotImplemented)
+		assert.ErrorIs(t, provider.CheckHealth(), errAnthropicNotImplemented)
+	})
+
+	t.Run("mock needs no credentials", func(t *testing.T) {
+		t.Setenv("OPENAI_API_KEY", "")
+
+		provider, err := NewAIProvider(domain.AIProviderMock)
+		assert.NoError(t, err)
+		assert.NoError(t, provider.CheckHealth())
+
+		analysis, err := provider.AnalyzeIncident(context.Background(), "Disk full", "No space left", "storage")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DefaultSeverity, analysis.Severity)
+		assert.Equal(t, domain.DefaultCategory, analysis.Category)
+
+		// Callers get their own copy
+		analysis.Severity = "Critical"
+		again, _ := provider.AnalyzeIncident(context.Background(), "Disk full", "No space left", "storage")
+		assert.Equal(t, domain.DefaultSeverity, again.Severity)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := NewAIProvider("llama")
+		assert.EqualError(t, err, `unknown AI provider "llama" (known: openai, anthropic, mock)`)
+	})
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// StaticService returns the same analysis for every incident without calling any API. It
+// lets the server run locally without an API key.
+type StaticService struct {
+	analysis domain.IncidentAnalysis
+}
+
+// NewStaticService creates a service answering every analysis with a copy of analysis
+func NewStaticService(analysis domain.IncidentAnalysis) *StaticService {
+	return &StaticService{analysis: analysis}
+}
+
+// AnalyzeIncident returns the fixed analysis, or ctx's error once ctx is done
+func (s *StaticService) AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*domain.IncidentAnalysis, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	analysis := s.analysis
+	return &analysis, nil
+}
+
+// CheckHealth always succeeds, as nothing external is involved
+func (s *StaticService) CheckHealth() error {
+	return nil
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+)
+
+// ackReminderBatch caps how many reminders one SendAckReminders call sends
+const ackReminderBatch = 100
+
+// WithAckReminders reminds notifier about incidents nobody has been assigned to, as policy decides
+func WithAckReminders(reminders domain.AckReminderRepository, notifier domain.AckReminderNotifier, policy domain.AckReminderPolicy) Option {
+	return func(uc *IncidentUseCase) {
+		uc.ackReminders = reminders
+		uc.ackNotifier = notifier
+		uc.ackPolicy = policy
+	}
+}
+
+// NotifyUnacknowledged logs a reminder about an unassigned incident, at a level that rises with
+// its urgency
+func (LogNotifier) NotifyUnacknowledged(incident *domain.Incident, reminder *domain.AckReminder, urgency string) {
+	level := slog.LevelInfo
+	switch urgency {
+	case domain.AckUrgencyHigh:
+		level = slog.LevelWarn
+	case domain.AckUrgencyCritical:
+		level = slog.LevelError
+	}
+	slog.Log(context.Background(), level, "Incident still unassigned", "incident_id", incident.ID, "urgency", urgency,
+		"reminder", reminder.Reminders+1, "severity", incident.AISeverity, "title", incident.Title, "age", incident.AgeHuman)
+}
+
+// SendAckReminders reminds about every incident still unassigned after the policy's threshold
+// and not reminded about within its repeat interval, and records each reminder to throttle the
+// next. The urgency grows with the incident's age. It returns the number of reminders sent.
+func (uc *IncidentUseCase) SendAckReminders(ctx context.Context) (int, error) {
+	if uc.ackReminders == nil || !uc.ackPolicy.Enabled() {
+		return 0, nil
+	}
+
+	now := uc.clock.Now()
+	due, err := uc.ackReminders.DueAckReminders(ctx, now.Add(-uc.ackPolicy.After), now.Add(-uc.ackPolicy.Repeat), ackReminderBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, reminder := range due {
+		incident, err := uc.incidentRepo.GetByIDForWrite(ctx, reminder.IncidentID)
+		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrDeleted) {
+			continue
+		}
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to load incident for its acknowledgment reminder", "incident_id", reminder.IncidentID, "error", err)
+			continue
+		}
+		// Picked up or closed since the due reminders were read
+		if incident.Assignee != "" || incident.FalsePositiveReason != "" {
+			continue
+		}
+
+		if err := uc.ackReminders.RecordAckReminder(ctx, incident.ID, now); err != nil {
+			logging.FromContext(ctx).Error("Failed to record acknowledgment reminder", "incident_id", incident.ID, "error", err)
+			continue
+		}
+
+		uc.decorate(ctx, incident)
+		if uc.ackNotifier != nil {
+			uc.ackNotifier.NotifyUnacknowledged(incident, reminder, uc.ackPolicy.Urgency(now.Sub(incident.CreatedAt)))
+		}
+		sent++
+	}
+
+	return sent, nil
+}
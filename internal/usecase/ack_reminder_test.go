@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryAckReminders is an in-process AckReminderRepository over a fixed set of incidents
+type memoryAckReminders struct {
+	incidents map[int]*domain.Incident
+	reminders map[int]*domain.AckReminder
+}
+
+func (r *memoryAckReminders) DueAckReminders(ctx context.Context, createdBefore, remindedBefore time.Time, limit int) ([]*domain.AckReminder, error) {
+	due := []*domain.AckReminder{}
+	for id, incident := range r.incidents {
+		if incident.Assignee != "" || incident.FalsePositiveReason != "" || !incident.CreatedAt.Before(createdBefore) {
+			continue
+		}
+		reminder, ok := r.reminders[id]
+		if !ok {
+			reminder = &domain.AckReminder{IncidentID: id}
+		}
+		if reminder.LastRemindedAt != nil && !reminder.LastRemindedAt.Before(remindedBefore) {
+			continue
+		}
+		copied := *reminder
+		due = append(due, &copied)
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].IncidentID < due[j].IncidentID })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (r *memoryAckReminders) RecordAckReminder(ctx context.Context, incidentID int, remindedAt time.Time) error {
+	reminder, ok := r.reminders[incidentID]
+	if !ok {
+		reminder = &domain.AckReminder{IncidentID: incidentID}
+		r.reminders[incidentID] = reminder
+	}
+	reminder.Reminders++
+	reminder.LastRemindedAt = &remindedAt
+	return nil
+}
+
+// recordingAckNotifier remembers the urgency of every reminder it was asked to deliver
+type recordingAckNotifier struct {
+	urgencies []string
+}
+
+func (n *recordingAckNotifier) NotifyUnacknowledged(incident *domain.Incident, reminder *domain.AckReminder, urgency string) {
+	n.urgencies = append(n.urgencies, urgency)
+}
+
+func TestSendAckReminders(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	fixedClock := clock.NewMock(start)
+	incident := &domain.Incident{ID: 7, Title: "Checkout errors", CreatedAt: start}
+	reminders := &memoryAckReminders{
+		incidents: map[int]*domain.Incident{7: incident},
+		reminders: map[int]*domain.AckReminder{},
+	}
+	notifier := &recordingAckNotifier{}
+	mockRepo := new(MockIncidentRepository)
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithClock(fixedClock),
+		WithAckReminders(reminders, notifier, domain.AckReminderPolicy{After: 30 * time.Minute, Repeat: time.Hour}))
+	mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(incident, nil)
+
+	sendAt := func(offset time.Duration) int {
+		fixedClock.Set(start.Add(offset))
+		sent, err := useCase.SendAckReminders(context.Background())
+		assert.NoError(t, err)
+		return sent
+	}
+
+	assert.Equal(t, 0, sendAt(30*time.Minute))
+	assert.Equal(t, 1, sendAt(31*time.Minute))
+	// Throttled until an hour after the last reminder
+	assert.Equal(t, 0, sendAt(91*time.Minute))
+	assert.Equal(t, 1, sendAt(95*time.Minute))
+	assert.Equal(t, 1, sendAt(160*time.Minute))
+	assert.Equal(t, []string{domain.AckUrgencyNormal, domain.AckUrgencyHigh, domain.AckUrgencyCritical}, notifier.urgencies)
+	assert.Equal(t, 3, reminders.reminders[7].Reminders)
+
+	// Picking the incident up stops the reminders
+	incident.Assignee = "alice"
+	assert.Equal(t, 0, sendAt(5*time.Hour))
+	assert.Len(t, notifier.urgencies, 3)
+}
+
+func TestSendAckReminders_Disabled(t *testing.T) {
+	useCase := NewIncidentUseCase(new(MockIncidentRepository), new(MockAIService),
+		WithAckReminders(&memoryAckReminders{}, &recordingAckNotifier{}, domain.AckReminderPolicy{}))
+
+	sent, err := useCase.SendAckReminders(context.Background())
+
+	assert.NoError(t, err)
+	assert.Zero(t, sent)
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// RoundRobinAssigner assigns incidents to the available members of a team in turn
+type RoundRobinAssigner struct {
+	rosters  map[string][]domain.TeamMember
+	rotation domain.RotationRepository
+}
+
+// NewRoundRobinAssigner creates an assigner over team rosters keyed by team name
+func NewRoundRobinAssigner(rosters map[string][]domain.TeamMember, rotation domain.RotationRepository) *RoundRobinAssigner {
+	return &RoundRobinAssigner{rosters: rosters, rotation: rotation}
+}
+
+// Assign returns the next available member of the team, skipping unavailable members, or an
+// empty name when the team has no roster or nobody is available
+func (a *RoundRobinAssigner) Assign(ctx context.Context, team string) (string, error) {
+	var available []string
+	for _, member := range a.rosters[team] {
+		if member.Available {
+			available = append(available, member.Name)
+		}
+	}
+	if len(available) == 0 {
+		return "", nil
+	}
+
+	position, err := a.rotation.Next(ctx, team)
+	if err != nil {
+		return "", fmt.Errorf("failed to advance %s rotation: %w", team, err)
+	}
+	return available[position%len(available)], nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryRotation is an in-process RotationRepository
+type memoryRotation struct {
+	positions map[string]int
+}
+
+func (r *memoryRotation) Next(ctx context.Context, team string) (int, error) {
+	position, ok := r.positions[team]
+	if ok {
+		position++
+	}
+	r.positions[team] = position
+	return position, nil
+}
+
+func TestRoundRobinAssigner_SkipsUnavailableMembers(t *testing.T) {
+	assigner := NewRoundRobinAssigner(map[string][]domain.TeamMember{
+		"database": {{Name: "alice", Available: true}, {Name: "bob", Available: false}, {Name: "carol", Available: true}},
+		"security": {{Name: "dave", Available: false}},
+	}, &memoryRotation{positions: map[string]int{}})
+
+	var assigned []string
+	for i := 0; i < 3; i++ {
+		name, err := assigner.Assign(context.Background(), "database")
+		assert.NoError(t, err)
+		assigned = append(assigned, name)
+	}
+	assert.Equal(t, []string{"alice", "carol", "alice"}, assigned)
+
+	name, err := assigner.Assign(context.Background(), "security")
+	assert.NoError(t, err)
+	assert.Empty(t, name)
+}
+
+func TestCreateIncident_RoundRobinAssignment(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	router := NewCategoryRouter(map[string]domain.TeamRoute{"Database": {Team: "database", Channel: "#db-oncall"}}, nil)
+	assigner := NewRoundRobinAssigner(map[string][]domain.TeamMember{
+		"database": {{Name: "alice", Available: true}, {Name: "bob", Available: true}, {Name: "carol", Available: true}},
+	}, &memoryRotation{positions: map[string]int{}})
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithTeamRouter(router), WithAssigner(assigner))
+
+	req := &domain.CreateIncidentRequest{Title: "Replica lag", Description: "Replica 40s behind", AffectedService: "orders-db"}
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Database"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(nil)
+
+	var assigned []string
+	for i := 0; i < 3; i++ {
+		incident, err := useCase.CreateIncident(context.Background(), req)
+		assert.NoError(t, err)
+		assigned = append(assigned, incident.Assignee)
+	}
+	assert.Equal(t, []string{"alice", "bob", "carol"}, assigned)
+
+	explicit := *req
+	explicit.Assignee = "erin"
+	incident, err := useCase.CreateIncident(context.Background(), &explicit)
+	assert.NoError(t, err)
+	assert.Equal(t, "erin", incident.Assignee)
+}
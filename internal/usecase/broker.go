@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"sync"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// subscriberBuffer is the number of events a subscriber may fall behind before events to it
+// are dropped
+const subscriberBuffer = 64
+
+// Broker is an in-process pub/sub of incident change events. Publishing never blocks: a
+// subscriber whose buffer is full misses the event.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[chan *domain.IncidentEvent]struct{}
+	closed      bool
+}
+
+// NewBroker creates a broker without subscribers
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[chan *domain.IncidentEvent]struct{})}
+}
+
+// Publish delivers an event to every subscriber with room in its buffer
+func (b *Broker) Publish(event *domain.IncidentEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a feed of the events published from now on and a function that ends
+// the subscription and closes the feed. The feed of a closed broker is already closed.
+func (b *Broker) Subscribe() (<-chan *domain.IncidentEvent, func()) {
+	ch := make(chan *domain.IncidentEvent, subscriberBuffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	b.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Close ends every subscription, closing their feeds so that long-lived streams return, and
+// makes later subscriptions end immediately. It is called when the server shuts down.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Subscribers reports the number of open subscriptions
+func (b *Broker) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker_PublishesToSubscribers(t *testing.T) {
+	broker := NewBroker()
+	first, unsubscribeFirst := broker.Subscribe()
+	second, unsubscribeSecond := broker.Subscribe()
+	defer unsubscribeSecond()
+
+	event := &domain.IncidentEvent{Type: domain.EventDeleted, ID: 7}
+	broker.Publish(event)
+
+	assert.Same(t, event, <-first)
+	assert.Same(t, event, <-second)
+
+	unsubscribeFirst()
+	unsubscribeFirst()
+	_, open := <-first
+	assert.False(t, open)
+	assert.Equal(t, 1, broker.Subscribers())
+}
+
+func TestBroker_DropsEventsForSlowSubscribers(t *testing.T) {
+	broker := NewBroker()
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer+10; i++ {
+		broker.Publish(&domain.IncidentEvent{Type: domain.EventDeleted, ID: i})
+	}
+
+	assert.Len(t, events, subscriberBuffer)
+	assert.Equal(t, 0, (<-events).ID)
+}
+
+func TestBroker_CloseEndsSubscriptions(t *testing.T) {
+	broker := NewBroker()
+	events, unsubscribe := broker.Subscribe()
+
+	broker.Close()
+	_, open := <-events
+	assert.False(t, open)
+	assert.Equal(t, 0, broker.Subscribers())
+	unsubscribe()
+
+	late, unsubscribeLate := broker.Subscribe()
+	defer unsubscribeLate()
+	_, open = <-late
+	assert.False(t, open)
+	assert.Equal(t, 0, broker.Subscribers())
+
+	broker.Publish(&domain.IncidentEvent{Type: domain.EventDeleted, ID: 7})
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+)
+
+// comparedField reads one field of an incident for a comparison
+type comparedField struct {
+	name  string
+	value func(*domain.Incident) interface{}
+}
+
+// comparedFields are the fields diffed by CompareIncidents, in response order. IDs and
+// timestamps are left out since they always differ.
+var comparedFields = []comparedField{
+	{"title", func(i *domain.Incident) interface{} { return i.Title }},
+	{"description", func(i *domain.Incident) interface{} { return i.Description }},
+	{"affected_service", func(i *domain.Incident) interface{} { return i.AffectedService }},
+	{"ai_severity", func(i *domain.Incident) interface{} { return i.AISeverity }},
+	{"ai_category", func(i *domain.Incident) interface{} { return i.AICategory }},
+	{"priority", func(i *domain.Incident) interface{} { return i.Priority }},
+	{"assignee", func(i *domain.Incident) interface{} { return i.Assignee }},
+	{"affected_users", func(i *domain.Incident) interface{} {
+		if i.AffectedUsers == nil {
+			return nil
+		}
+		return *i.AffectedUsers
+	}},
+	{"analysis_status", func(i *domain.Incident) interface{} { return i.AnalysisStatus }},
+	{"false_positive_reason", func(i *domain.Incident) interface{} { return i.FalsePositiveReason }},
+}
+
+// CompareIncidents returns two incidents with the fields that differ between them and how
+// similar their descriptions are
+func (uc *IncidentUseCase) CompareIncidents(ctx context.Context, aID, bID int) (*domain.IncidentComparison, error) {
+	a, err := uc.incidentRepo.GetByID(ctx, aID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := uc.incidentRepo.GetByID(ctx, bID)
+	if err != nil {
+		return nil, err
+	}
+	uc.decorate(ctx, a, b)
+
+	comparison := &domain.IncidentComparison{
+		A:           a,
+		B:           b,
+		Differences: []*domain.FieldDifference{},
+		Same:        []string{},
+	}
+	for _, field := range comparedFields {
+		valueA, valueB := field.value(a), field.value(b)
+		if valueA == valueB {
+			comparison.Same = append(comparison.Same, field.name)
+			continue
+		}
+		comparison.Differences = append(comparison.Differences, &domain.FieldDifference{Field: field.name, A: valueA, B: valueB})
+	}
+
+	comparison.Similarity, comparison.SimilarityMethod = uc.descriptionSimilarity(ctx, a, b)
+	return comparison, nil
+}
+
+// descriptionSimilarity scores two incidents by their stored embeddings when both have one,
+// falling back to the overlap of their description keywords. Embeddings are not computed
+// here, so a comparison never costs an AI call.
+func (uc *IncidentUseCase) descriptionSimilarity(ctx context.Context, a, b *domain.Incident) (float64, string) {
+	if uc.embeddingRepo != nil {
+		var embeddingB []float32
+		embeddingA, err := uc.embeddingRepo.GetEmbedding(ctx, a.ID)
+		if err == nil {
+			embeddingB, err = uc.embeddingRepo.GetEmbedding(ctx, b.ID)
+		}
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to read embeddings to compare incidents", "incident_id", a.ID, "other_incident_id", b.ID, "error", err)
+		} else if embeddingA != nil && embeddingB != nil {
+			return domain.CosineSimilarity(embeddingA, embeddingB), domain.SimilarityEmbedding
+		}
+	}
+
+	_, overlap := keywordOverlap(titleKeywords(a.Description), titleKeywords(b.Description))
+	return overlap, domain.SimilarityKeywords
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCompareIncidents(t *testing.T) {
+	users := 1200
+	a := func() *domain.Incident {
+		return &domain.Incident{ID: 1, Title: "Checkout timeouts", Description: "Payment gateway requests time out at checkout",
+			AffectedService: "checkout", AISeverity: "High", AICategory: "Application", Assignee: "alice"}
+	}
+	b := func() *domain.Incident {
+		return &domain.Incident{ID: 2, Title: "Checkout timeouts", Description: "Checkout requests time out after deploy",
+			AffectedService: "checkout", AISeverity: "Critical", AICategory: "Application", AffectedUsers: &users}
+	}
+
+	t.Run("diff and keyword similarity", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+		mockRepo.On("GetByID", mock.Anything, 1).Return(a(), nil)
+		mockRepo.On("GetByID", mock.Anything, 2).Return(b(), nil)
+
+		comparison, err := useCase.CompareIncidents(context.Background(), 1, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, comparison.A.ID)
+		assert.Equal(t, 2, comparison.B.ID)
+		assert.Equal(t, []*domain.FieldDifference{
+			{Field: "description", A: "Payment gateway requests time out at checkout", B: "Checkout requests time out after deploy"},
+			{Field: "ai_severity", A: "High", B: "Critical"},
+			{Field: "priority", A: "P2", B: "P1"},
+			{Field: "assignee", A: "alice", B: ""},
+			{Field: "affected_users", A: nil, B: 1200},
+		}, comparison.Differences)
+		assert.Equal(t, []string{"title", "affected_service", "ai_category", "analysis_status", "false_positive_reason"}, comparison.Same)
+		// Shared: checkout, requests, time, out; union adds payment, gateway, deploy
+		assert.InDelta(t, 4.0/7.0, comparison.Similarity, 1e-9)
+		assert.Equal(t, domain.SimilarityKeywords, comparison.SimilarityMethod)
+	})
+
+	t.Run("embedding similarity when both are stored", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockEmbeddings := new(MockEmbeddingRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithEmbeddings(new(MockEmbeddingService), mockEmbeddings, mockEmbeddings))
+		mockRepo.On("GetByID", mock.Anything, 1).Return(a(), nil)
+		mockRepo.On("GetByID", mock.Anything, 2).Return(b(), nil)
+		mockEmbeddings.On("GetEmbedding", mock.Anything, 1).Return([]float32{1, 0}, nil)
+		mockEmbeddings.On("GetEmbedding", mock.Anything, 2).Return([]float32{1, 1}, nil)
+
+		comparison, err := useCase.CompareIncidents(context.Background(), 1, 2)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.7071, comparison.Similarity, 1e-4)
+		assert.Equal(t, domain.SimilarityEmbedding, comparison.SimilarityMethod)
+	})
+
+	t.Run("keywords when an embedding is missing", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockEmbeddings := new(MockEmbeddingRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithEmbeddings(new(MockEmbeddingService), mockEmbeddings, mockEmbeddings))
+		mockRepo.On("GetByID", mock.Anything, 1).Return(a(), nil)
+		mockRepo.On("GetByID", mock.Anything, 2).Return(b(), nil)
+		mockEmbeddings.On("GetEmbedding", mock.Anything, 1).Return([]float32{1, 0}, nil)
+		mockEmbeddings.On("GetEmbedding", mock.Anything, 2).Return(nil, nil)
+
+		comparison, err := useCase.CompareIncidents(context.Background(), 1, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.SimilarityKeywords, comparison.SimilarityMethod)
+	})
+
+	t.Run("missing incident", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+		mockRepo.On("GetByID", mock.Anything, 1).Return(a(), nil)
+		mockRepo.On("GetByID", mock.Anything, 9).Return(nil, domain.ErrNotFound)
+
+		_, err := useCase.CompareIncidents(context.Background(), 1, 9)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
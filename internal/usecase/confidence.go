@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"log/slog"
+
+	"incident-triage-assistant/internal/domain"
+)
+
+// WithConfidenceFloor stops trusting AI classifications below the floor: their severity is
+// replaced by the floor's safe default, they are flagged needs_review and notifier, when set,
+// is told about them
+func WithConfidenceFloor(floor domain.ConfidenceFloor, notifier domain.ReviewNotifier) Option {
+	return func(uc *IncidentUseCase) {
+		uc.confidenceFloor = floor
+		uc.reviewNotifier = notifier
+	}
+}
+
+// NotifyNeedsReview logs an incident whose classification needs review
+func (LogNotifier) NotifyNeedsReview(incident *domain.Incident) {
+	slog.Warn("Incident needs review", "incident_id", incident.ID, "severity", incident.AISeverity,
+		"category", incident.AICategory, "confidence", *incident.AIConfidence, "title", incident.Title)
+}
+
+// applyConfidenceFloor replaces the severity of a classification below the confidence floor
+// with the floor's safe default, so an unsure AI neither escalates nor downplays the incident
+func (uc *IncidentUseCase) applyConfidenceFloor(incident *domain.Incident) {
+	if uc.confidenceFloor.Below(incident.AIConfidence) {
+		incident.AISeverity = uc.confidenceFloor.Severity
+	}
+}
+
+// notifyReview tells the review notifier about a decorated incident that needs review
+func (uc *IncidentUseCase) notifyReview(incident *domain.Incident) {
+	if uc.reviewNotifier == nil || !incident.NeedsReview {
+		return
+	}
+
+	snapshot := *incident
+	snapshot.Timings = nil
+	uc.reviewNotifier.NotifyNeedsReview(&snapshot)
+}
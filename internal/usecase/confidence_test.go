@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// recordingReviewNotifier records every incident it is told needs review
+type recordingReviewNotifier struct {
+	mu        sync.Mutex
+	incidents []*domain.Incident
+}
+
+func (n *recordingReviewNotifier) NotifyNeedsReview(incident *domain.Incident) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.incidents = append(n.incidents, incident)
+}
+
+func (n *recordingReviewNotifier) reviewed() []*domain.Incident {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.incidents
+}
+
+func TestCreateIncident_ConfidenceFloor(t *testing.T) {
+	req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+	floor := domain.ConfidenceFloor{Floor: 0.5, Severity: "High"}
+	confidence := func(c float64) *float64 { return &c }
+
+	tests := []struct {
+		name     string
+		floor    domain.ConfidenceFloor
+		analysis *domain.IncidentAnalysis
+		severity string
+		review   bool
+	}{
+		{
+			name:     "below the floor gets the safe severity and needs review",
+			floor:    floor,
+			analysis: &domain.IncidentAnalysis{Severity: "Low", Category: "Hardware", Confidence: confidence(0.3)},
+			severity: "High",
+			review:   true,
+		},
+		{
+			name:     "above the floor keeps the classification",
+			floor:    floor,
+			analysis: &domain.IncidentAnalysis{Severity: "Low", Category: "Hardware", Confidence: confidence(0.8)},
+			severity: "Low",
+		},
+		{
+			name:     "without a floor every classification is trusted",
+			analysis: &domain.IncidentAnalysis{Severity: "Critical", Category: "Hardware", Confidence: confidence(0.1)},
+			severity: "Critical",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIncidentRepository)
+			mockAI := new(MockAIService)
+			reviews := &recordingReviewNotifier{}
+			useCase := NewIncidentUseCase(mockRepo, mockAI, WithConfidenceFloor(tt.floor, reviews))
+
+			mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).Return(tt.analysis, nil)
+			mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool {
+				return incident.AISeverity == tt.severity
+			})).Return(nil)
+
+			incident, err := useCase.CreateIncident(context.Background(), req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.severity, incident.AISeverity)
+			assert.Equal(t, tt.review, incident.NeedsReview)
+			if tt.review {
+				assert.Len(t, reviews.reviewed(), 1)
+			} else {
+				assert.Empty(t, reviews.reviewed())
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateIncident_ConfidenceFloorAfterPendingAnalysis(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	reviews := &recordingReviewNotifier{}
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithAIBudget(20*time.Millisecond),
+		WithConfidenceFloor(domain.ConfidenceFloor{Floor: 0.5, Severity: "High"}, reviews))
+
+	req := &domain.CreateIncidentRequest{Title: "Checkout slow", Description: "p99 at 3s", AffectedService: "checkout"}
+	lowConfidence := 0.2
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		After(100*time.Millisecond).
+		Return(&domain.IncidentAnalysis{Severity: "Low", Category: "Application", Confidence: &lowConfidence}, nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) { args.Get(1).(*domain.Incident).ID = 9 }).Return(nil)
+	saved := make(chan *domain.Incident, 1)
+	mockRepo.On("SaveAnalysis", mock.Anything, mock.Anything, domain.AnalysisPending).
+		Run(func(args mock.Arguments) { saved <- args.Get(1).(*domain.Incident) }).Return(true, nil)
+	mockRepo.On("GetByIDForWrite", mock.Anything, 9).
+		Return(&domain.Incident{ID: 9, AISeverity: "High", AIConfidence: &lowConfidence, AnalysisStatus: domain.AnalysisComplete}, nil)
+
+	incident, err := useCase.CreateIncident(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AnalysisPending, incident.AnalysisStatus)
+	assert.Empty(t, reviews.reviewed())
+
+	select {
+	case analyzed := <-saved:
+		assert.Equal(t, "High", analyzed.AISeverity)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the pending analysis was never saved")
+	}
+	assert.Eventually(t, func() bool { return len(reviews.reviewed()) == 1 }, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import "incident-triage-assistant/internal/domain"
+
+// StaticFlags is a fixed set of feature flags, typically loaded from configuration at startup
+type StaticFlags struct {
+	flags map[string]bool
+}
+
+// NewStaticFlags creates flags from the defaults with the given overrides applied
+func NewStaticFlags(overrides map[string]bool) *StaticFlags {
+	flags := make(map[string]bool, len(domain.DefaultFeatureFlags))
+	for flag, enabled := range domain.DefaultFeatureFlags {
+		flags[flag] = enabled
+	}
+	for flag, enabled := range overrides {
+		flags[flag] = enabled
+	}
+	return &StaticFlags{flags: flags}
+}
+
+// Enabled reports whether a flag is on. Unknown flags are off.
+func (f *StaticFlags) Enabled(flag string) bool {
+	return f.flags[flag]
+}
+
+// All returns a copy of every flag and its state
+func (f *StaticFlags) All() map[string]bool {
+	all := make(map[string]bool, len(f.flags))
+	for flag, enabled := range f.flags {
+		all[flag] = enabled
+	}
+	return all
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticFlags(t *testing.T) {
+	flags := NewStaticFlags(map[string]bool{domain.FlagIngest: false})
+
+	assert.True(t, flags.Enabled(domain.FlagDryRun))
+	assert.False(t, flags.Enabled(domain.FlagIngest))
+	assert.False(t, flags.Enabled("unknown"))
+
+	all := flags.All()
+	assert.Len(t, all, len(domain.DefaultFeatureFlags))
+	all[domain.FlagDryRun] = false
+	assert.True(t, flags.Enabled(domain.FlagDryRun), "All must return a copy")
+}
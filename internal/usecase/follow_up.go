@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+)
+
+// followUpBatch caps how many due follow-ups one SendDueFollowUps call sends
+const followUpBatch = 100
+
+// WithFollowUps enables recurring follow-up reminders stored in followUps and delivered by notifier
+func WithFollowUps(followUps domain.FollowUpRepository, notifier domain.FollowUpNotifier) Option {
+	return func(uc *IncidentUseCase) {
+		uc.followUps = followUps
+		uc.followUpNotifier = notifier
+	}
+}
+
+// NotifyFollowUp logs a reminder to the assignee of a long-running incident
+func (LogNotifier) NotifyFollowUp(incident *domain.Incident, followUp *domain.FollowUp) {
+	assignee := incident.Assignee
+	if assignee == "" {
+		assignee = "unassigned"
+	}
+	slog.Info("Follow-up: still investigating?", "incident_id", incident.ID, "nudge", followUp.Nudges+1,
+		"title", incident.Title, "assignee", assignee, "age", incident.AgeHuman)
+}
+
+// SetFollowUp schedules a reminder every interval from now on, or stops the reminders when
+// interval is zero, in which case nil is returned. False positives cannot be followed up.
+func (uc *IncidentUseCase) SetFollowUp(ctx context.Context, incidentID int, interval time.Duration) (*domain.FollowUp, error) {
+	if uc.followUps == nil {
+		return nil, domain.ErrFollowUpsUnavailable
+	}
+
+	incident, err := uc.incidentRepo.GetByIDForWrite(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if interval == 0 {
+		return nil, uc.followUps.ClearFollowUp(ctx, incidentID)
+	}
+	if incident.FalsePositiveReason != "" {
+		return nil, fmt.Errorf("incident %d: %w", incidentID, domain.ErrFalsePositive)
+	}
+
+	if err := uc.scheduleFollowUp(ctx, incidentID, interval); err != nil {
+		return nil, err
+	}
+	return uc.followUps.GetFollowUp(ctx, incidentID)
+}
+
+// GetFollowUp returns the follow-up schedule of an incident, or nil when it has none
+func (uc *IncidentUseCase) GetFollowUp(ctx context.Context, incidentID int) (*domain.FollowUp, error) {
+	if uc.followUps == nil {
+		return nil, domain.ErrFollowUpsUnavailable
+	}
+	if _, err := uc.incidentRepo.GetByID(ctx, incidentID); err != nil {
+		return nil, err
+	}
+	return uc.followUps.GetFollowUp(ctx, incidentID)
+}
+
+// scheduleFollowUp stores a schedule whose first reminder is one interval from now
+func (uc *IncidentUseCase) scheduleFollowUp(ctx context.Context, incidentID int, interval time.Duration) error {
+	return uc.followUps.SetFollowUp(ctx, &domain.FollowUp{
+		IncidentID: incidentID,
+		Interval:   interval,
+		NextAt:     uc.clock.Now().Add(interval),
+	})
+}
+
+// followUpOnCreate schedules the follow-ups requested with a new incident. Like assignment it
+// is best-effort: a failure is logged rather than failing the create.
+func (uc *IncidentUseCase) followUpOnCreate(ctx context.Context, incident *domain.Incident, req *domain.CreateIncidentRequest) {
+	if uc.followUps == nil || req.FollowUpInterval == "" {
+		return
+	}
+
+	interval, err := time.ParseDuration(req.FollowUpInterval)
+	if err == nil {
+		err = uc.scheduleFollowUp(ctx, incident.ID, interval)
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to schedule follow-ups", "incident_id", incident.ID, "error", err)
+	}
+}
+
+// SendDueFollowUps sends every due reminder, records it in the incident history and schedules
+// the next one. Reminders missed while the server was down are not replayed: the next one is
+// the first interval boundary after now. Follow-ups of deleted incidents and false positives
+// are cleared instead. It returns the number of reminders sent.
+func (uc *IncidentUseCase) SendDueFollowUps(ctx context.Context) (int, error) {
+	if uc.followUps == nil {
+		return 0, nil
+	}
+
+	now := uc.clock.Now()
+	due, err := uc.followUps.DueFollowUps(ctx, now, followUpBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, followUp := range due {
+		incident, err := uc.incidentRepo.GetByIDForWrite(ctx, followUp.IncidentID)
+		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrDeleted) || (err == nil && incident.FalsePositiveReason != "") {
+			if err := uc.followUps.ClearFollowUp(ctx, followUp.IncidentID); err != nil {
+				logging.FromContext(ctx).Error("Failed to clear follow-ups", "incident_id", followUp.IncidentID, "error", err)
+			}
+			continue
+		}
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to load incident for its follow-up", "incident_id", followUp.IncidentID, "error", err)
+			continue
+		}
+
+		next := followUp.NextAt
+		for !next.After(now) {
+			next = next.Add(followUp.Interval)
+		}
+		if err := uc.followUps.RecordNudge(ctx, followUp.IncidentID, now, next); err != nil {
+			logging.FromContext(ctx).Error("Failed to record follow-up", "incident_id", followUp.IncidentID, "error", err)
+			continue
+		}
+
+		uc.decorate(ctx, incident)
+		if uc.followUpNotifier != nil {
+			uc.followUpNotifier.NotifyFollowUp(incident, followUp)
+		}
+		sent++
+
+		if uc.historyRepo != nil {
+			entry := &domain.HistoryEntry{
+				IncidentID: incident.ID,
+				Field:      domain.FieldFollowUp,
+				NewValue:   fmt.Sprintf("reminder %d sent", followUp.Nudges+1),
+				Actor:      domain.ActorSystem,
+				CreatedAt:  now,
+			}
+			if err := uc.historyRepo.AddEntries(ctx, []*domain.HistoryEntry{entry}); err != nil {
+				logging.FromContext(ctx).Error("Failed to record history", "incident_id", incident.ID, "error", err)
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+// FollowUpSender sends the follow-up reminders that are due
+type FollowUpSender interface {
+	SendDueFollowUps(ctx context.Context) (int, error)
+}
+
+// FollowUpScheduler checks for due follow-ups on a fixed interval. Start runs the schedule and
+// Stop ends it.
+type FollowUpScheduler struct {
+	sender FollowUpSender
+	every  time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewFollowUpScheduler sends the due follow-ups of sender every interval
+func NewFollowUpScheduler(sender FollowUpSender, every time.Duration) *FollowUpScheduler {
+	return &FollowUpScheduler{
+		sender: sender,
+		every:  every,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start sends due follow-ups every interval until Stop
+func (s *FollowUpScheduler) Start() {
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.sender.SendDueFollowUps(context.Background()); err != nil {
+					slog.Error("Failed to send follow-ups", "error", err)
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the schedule started by Start, waiting for a check in progress to finish
+func (s *FollowUpScheduler) Stop() {
+	close(s.stop)
+	<-s.done
+}
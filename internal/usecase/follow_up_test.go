@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryFollowUps is an in-process FollowUpRepository
+type memoryFollowUps struct {
+	followUps map[int]*domain.FollowUp
+}
+
+func (r *memoryFollowUps) SetFollowUp(ctx context.Context, followUp *domain.FollowUp) error {
+	stored := *followUp
+	if existing, ok := r.followUps[followUp.IncidentID]; ok {
+		stored.Nudges, stored.LastNudgedAt = existing.Nudges, existing.LastNudgedAt
+	}
+	r.followUps[followUp.IncidentID] = &stored
+	return nil
+}
+
+func (r *memoryFollowUps) ClearFollowUp(ctx context.Context, incidentID int) error {
+	delete(r.followUps, incidentID)
+	return nil
+}
+
+func (r *memoryFollowUps) GetFollowUp(ctx context.Context, incidentID int) (*domain.FollowUp, error) {
+	followUp, ok := r.followUps[incidentID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *followUp
+	return &copied, nil
+}
+
+func (r *memoryFollowUps) DueFollowUps(ctx context.Context, now time.Time, limit int) ([]*domain.FollowUp, error) {
+	due := []*domain.FollowUp{}
+	for _, followUp := range r.followUps {
+		if !followUp.NextAt.After(now) {
+			copied := *followUp
+			due = append(due, &copied)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAt.Before(due[j].NextAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (r *memoryFollowUps) RecordNudge(ctx context.Context, incidentID int, nudgedAt, nextAt time.Time) error {
+	followUp := r.followUps[incidentID]
+	followUp.Nudges++
+	followUp.LastNudgedAt = &nudgedAt
+	followUp.NextAt = nextAt
+	return nil
+}
+
+// recordingFollowUpNotifier remembers the reminders it was asked to deliver
+type recordingFollowUpNotifier struct {
+	reminders []int
+}
+
+func (n *recordingFollowUpNotifier) NotifyFollowUp(incident *domain.Incident, followUp *domain.FollowUp) {
+	n.reminders = append(n.reminders, incident.ID)
+}
+
+func TestSendDueFollowUps(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	fixedClock := clock.NewMock(start)
+	mockRepo := new(MockIncidentRepository)
+	mockHistory := new(MockHistoryRepository)
+	followUps := &memoryFollowUps{followUps: map[int]*domain.FollowUp{}}
+	notifier := &recordingFollowUpNotifier{}
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithFollowUps(followUps, notifier),
+		WithHistory(mockHistory), WithClock(fixedClock))
+
+	incident := &domain.Incident{ID: 7, Title: "Checkout errors", AISeverity: "High", Assignee: "alice", CreatedAt: start}
+	mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(incident, nil)
+	mockHistory.On("AddEntries", mock.Anything, mock.Anything).Return(nil)
+
+	followUp, err := useCase.SetFollowUp(context.Background(), 7, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, start.Add(time.Hour), followUp.NextAt)
+
+	// sendAt moves the clock to offset from start and sends the due reminders
+	sendAt := func(offset time.Duration) int {
+		fixedClock.Set(start.Add(offset))
+		sent, err := useCase.SendDueFollowUps(context.Background())
+		assert.NoError(t, err)
+		return sent
+	}
+
+	assert.Equal(t, 0, sendAt(30*time.Minute))
+	assert.Equal(t, 1, sendAt(time.Hour))
+	assert.Equal(t, 0, sendAt(90*time.Minute))
+	assert.Equal(t, 1, sendAt(2*time.Hour))
+	assert.Equal(t, []int{7, 7}, notifier.reminders)
+	assert.Equal(t, 2, followUps.followUps[7].Nudges)
+
+	// Reminders missed while the server was down are not replayed
+	assert.Equal(t, 1, sendAt(5*time.Hour+10*time.Minute))
+	assert.Equal(t, start.Add(6*time.Hour), followUps.followUps[7].NextAt)
+
+	mockHistory.AssertCalled(t, "AddEntries", mock.Anything, []*domain.HistoryEntry{{
+		IncidentID: 7,
+		Field:      domain.FieldFollowUp,
+		NewValue:   "reminder 3 sent",
+		Actor:      domain.ActorSystem,
+		CreatedAt:  start.Add(5*time.Hour + 10*time.Minute),
+	}})
+
+	// Closing the incident as a false positive stops the reminders
+	incident.FalsePositiveReason = "Synthetic probe"
+	assert.Equal(t, 0, sendAt(6*time.Hour))
+	assert.Empty(t, followUps.followUps)
+	assert.Len(t, notifier.reminders, 3)
+}
+
+func TestSendDueFollowUps_DeletedIncident(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	mockRepo := new(MockIncidentRepository)
+	followUps := &memoryFollowUps{followUps: map[int]*domain.FollowUp{
+		7: {IncidentID: 7, Interval: time.Hour, NextAt: start},
+	}}
+	notifier := &recordingFollowUpNotifier{}
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithFollowUps(followUps, notifier), WithClock(clock.NewMock(start)))
+	mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(nil, domain.ErrDeleted)
+
+	sent, err := useCase.SendDueFollowUps(context.Background())
+
+	assert.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Empty(t, followUps.followUps)
+	assert.Empty(t, notifier.reminders)
+}
+
+func TestSetFollowUp(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("zero interval stops the reminders", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		followUps := &memoryFollowUps{followUps: map[int]*domain.FollowUp{7: {IncidentID: 7, Interval: time.Hour}}}
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithFollowUps(followUps, nil))
+		mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(&domain.Incident{ID: 7}, nil)
+
+		followUp, err := useCase.SetFollowUp(context.Background(), 7, 0)
+
+		assert.NoError(t, err)
+		assert.Nil(t, followUp)
+		assert.Empty(t, followUps.followUps)
+	})
+
+	t.Run("false positive", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithFollowUps(&memoryFollowUps{followUps: map[int]*domain.FollowUp{}}, nil))
+		mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(&domain.Incident{ID: 7, FalsePositiveReason: "Probe"}, nil)
+
+		_, err := useCase.SetFollowUp(context.Background(), 7, time.Hour)
+
+		assert.ErrorIs(t, err, domain.ErrFalsePositive)
+	})
+
+	t.Run("scheduled on create", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		followUps := &memoryFollowUps{followUps: map[int]*domain.FollowUp{}}
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithFollowUps(followUps, nil), WithClock(clock.NewMock(now)))
+
+		req := &domain.CreateIncidentRequest{Title: "Checkout errors", Description: "5xx", AffectedService: "checkout", FollowUpInterval: "4h"}
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+			Return(&domain.IncidentAnalysis{Severity: "High", Category: "Application"}, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Incident).ID = 12
+		}).Return(nil)
+
+		_, err := useCase.CreateIncident(context.Background(), req)
+
+		assert.NoError(t, err)
+		if assert.Contains(t, followUps.followUps, 12) {
+			assert.Equal(t, 4*time.Hour, followUps.followUps[12].Interval)
+			assert.Equal(t, now.Add(4*time.Hour), followUps.followUps[12].NextAt)
+		}
+	})
+
+	t.Run("scheduled on batch create", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		followUps := &memoryFollowUps{followUps: map[int]*domain.FollowUp{}}
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithFollowUps(followUps, nil), WithClock(clock.NewMock(now)))
+
+		req := &domain.CreateIncidentRequest{Title: "Checkout errors", Description: "5xx", AffectedService: "checkout", FollowUpInterval: "2h"}
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+			Return(&domain.IncidentAnalysis{Severity: "High", Category: "Application"}, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Incident).ID = 13
+		}).Return(nil)
+
+		results := useCase.CreateIncidentsBatch(context.Background(), []*domain.CreateIncidentRequest{req})
+
+		assert.Equal(t, domain.BatchCreated, results[0].Outcome)
+		if assert.Contains(t, followUps.followUps, 13) {
+			assert.Equal(t, 2*time.Hour, followUps.followUps[13].Interval)
+			assert.Equal(t, now.Add(2*time.Hour), followUps.followUps[13].NextAt)
+		}
+	})
+}
@@ -1,94 +1,1403 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"incident-triage-assistant/internal/clock"
 	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+	"sync"
 	"time"
 )
 
 // IncidentUseCase implements the business logic for incident management
 type IncidentUseCase struct {
-	incidentRepo domain.IncidentRepository
-	aiService    domain.AIService
+	incidentRepo     domain.IncidentRepository
+	aiService        domain.AIService
+	embeddingService domain.EmbeddingService
+	embeddingRepo    domain.EmbeddingRepository
+	similarity       domain.SimilaritySearcher
+	sanitizer        *Sanitizer
+	fieldLimits      domain.FieldLimits
+	customFields     domain.CustomFieldSchema
+	historyRepo      domain.HistoryRepository
+	clock            clock.Clock
+	router           domain.TeamRouter
+	flags            domain.FeatureFlags
+	assigner         domain.Assigner
+	strictUnique     bool
+	storms           *stormLimiter
+	events           domain.EventPublisher
+	notifier         domain.IncidentNotifier
+	references       domain.ReferenceFormat
+	sequences        domain.SequenceRepository
+	runbooks         domain.RunbookRepository
+	followUps        domain.FollowUpRepository
+	followUpNotifier domain.FollowUpNotifier
+	catalog          *domain.ServiceCatalog
+	dedup            domain.DedupWindows
+	reanalysis       domain.ReanalysisPolicy
+	directory        domain.ServiceDirectory
+	aiBudget         time.Duration
+	triage           domain.TriagePolicy
+	confidenceFloor  domain.ConfidenceFloor
+	reviewNotifier   domain.ReviewNotifier
+	ackReminders     domain.AckReminderRepository
+	ackNotifier      domain.AckReminderNotifier
+	ackPolicy        domain.AckReminderPolicy
+	trend            *trendAlerter
+}
+
+// Option configures optional IncidentUseCase dependencies
+type Option func(*IncidentUseCase)
+
+// WithEmbeddings enables embedding storage and similarity search
+func WithEmbeddings(embeddingService domain.EmbeddingService, embeddingRepo domain.EmbeddingRepository, similarity domain.SimilaritySearcher) Option {
+	return func(uc *IncidentUseCase) {
+		uc.embeddingService = embeddingService
+		uc.embeddingRepo = embeddingRepo
+		uc.similarity = similarity
+	}
+}
+
+// WithFieldLimits sets the maximum lengths sanitized incident text fields are validated against
+func WithFieldLimits(limits domain.FieldLimits) Option {
+	return func(uc *IncidentUseCase) {
+		uc.fieldLimits = limits
+	}
+}
+
+// WithCustomFieldSchema sets the schema sanitized custom fields are validated against
+func WithCustomFieldSchema(schema domain.CustomFieldSchema) Option {
+	return func(uc *IncidentUseCase) {
+		uc.customFields = schema
+	}
+}
+
+// WithSanitizer replaces the default sanitizer used to clean incident text
+func WithSanitizer(sanitizer *Sanitizer) Option {
+	return func(uc *IncidentUseCase) {
+		uc.sanitizer = sanitizer
+	}
+}
+
+// WithHistory enables recording of incident changes
+func WithHistory(historyRepo domain.HistoryRepository) Option {
+	return func(uc *IncidentUseCase) {
+		uc.historyRepo = historyRepo
+	}
+}
+
+// WithClock replaces the system clock, typically with a mock in tests
+func WithClock(c clock.Clock) Option {
+	return func(uc *IncidentUseCase) {
+		uc.clock = c
+	}
+}
+
+// WithTeamRouter resolves the responsible team of each returned incident
+func WithTeamRouter(router domain.TeamRouter) Option {
+	return func(uc *IncidentUseCase) {
+		uc.router = router
+	}
+}
+
+// WithFeatureFlags replaces the default feature flags
+func WithFeatureFlags(flags domain.FeatureFlags) Option {
+	return func(uc *IncidentUseCase) {
+		uc.flags = flags
+	}
+}
+
+// WithAssigner assigns new incidents without an assignee to a member of their routed team.
+// It has no effect without a team router.
+func WithAssigner(assigner domain.Assigner) Option {
+	return func(uc *IncidentUseCase) {
+		uc.assigner = assigner
+	}
+}
+
+// WithStrictUnique rejects creating an incident whose title and affected service match an
+// existing one within its dedup window with domain.ErrDuplicate, unless the request sets AllowDuplicate
+func WithStrictUnique(enabled bool) Option {
+	return func(uc *IncidentUseCase) {
+		uc.strictUnique = enabled
+	}
+}
+
+// WithEventPublisher publishes an event after every create, update and delete
+func WithEventPublisher(events domain.EventPublisher) Option {
+	return func(uc *IncidentUseCase) {
+		uc.events = events
+	}
+}
+
+// WithDedupWindows limits duplicate checks to incidents created within the window of their
+// affected service
+func WithDedupWindows(windows domain.DedupWindows) Option {
+	return func(uc *IncidentUseCase) {
+		uc.dedup = windows
+	}
+}
+
+// WithReanalysisPolicy sets when updates re-run the AI analysis; by default they always do
+func WithReanalysisPolicy(policy domain.ReanalysisPolicy) Option {
+	return func(uc *IncidentUseCase) {
+		uc.reanalysis = policy
+	}
+}
+
+// WithServiceCatalog flags incidents whose affected service is missing from catalog
+func WithServiceCatalog(catalog *domain.ServiceCatalog) Option {
+	return func(uc *IncidentUseCase) {
+		uc.catalog = catalog
+	}
+}
+
+// WithServiceDirectory attaches the owner, on-call and runbook of each returned incident's
+// affected service from directory
+func WithServiceDirectory(directory domain.ServiceDirectory) Option {
+	return func(uc *IncidentUseCase) {
+		uc.directory = directory
+	}
+}
+
+// WithAIBudget caps how long create waits for the AI analysis. When the budget runs out the
+// incident is saved as pending with default severity and category, and the analysis finishes
+// in the background. Zero waits for the analysis.
+func WithAIBudget(budget time.Duration) Option {
+	return func(uc *IncidentUseCase) {
+		uc.aiBudget = budget
+	}
+}
+
+// WithTriagePolicy sets how incidents are classified; by default the AI analyzes them. With
+// triage off the AI is never called and the severity and category come from the request.
+func WithTriagePolicy(policy domain.TriagePolicy) Option {
+	return func(uc *IncidentUseCase) {
+		uc.triage = policy
+	}
 }
 
 // NewIncidentUseCase creates a new instance of IncidentUseCase
-func NewIncidentUseCase(incidentRepo domain.IncidentRepository, aiService domain.AIService) *IncidentUseCase {
-	return &IncidentUseCase{
+func NewIncidentUseCase(incidentRepo domain.IncidentRepository, aiService domain.AIService, opts ...Option) *IncidentUseCase {
+	// The default patterns are constant and known to compile
+	sanitizer, _ := NewSanitizer(DefaultRedactPatterns)
+
+	uc := &IncidentUseCase{
 		incidentRepo: incidentRepo,
 		aiService:    aiService,
+		sanitizer:    sanitizer,
+		fieldLimits:  domain.DefaultFieldLimits,
+		clock:        clock.Real{},
+		flags:        NewStaticFlags(nil),
 	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
 }
 
 // CreateIncident creates a new incident with AI analysis
-func (uc *IncidentUseCase) CreateIncident(req *domain.CreateIncidentRequest) (*domain.Incident, error) {
-	// Analyze incident using AI
-	analysis, err := uc.aiService.AnalyzeIncident(req.Title, req.Description, req.AffectedService)
+func (uc *IncidentUseCase) CreateIncident(ctx context.Context, req *domain.CreateIncidentRequest) (*domain.Incident, error) {
+	start := uc.clock.Now()
+	if uc.storms != nil {
+		if storm, err := uc.suppressStorm(ctx, req); storm != nil || err != nil {
+			return storm, err
+		}
+	}
+
+	incident, pending, err := uc.analyzeWithinBudget(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	analyzed := uc.clock.Now()
+
+	// A pending incident is assigned once its category is known
+	if pending == nil {
+		uc.assign(ctx, incident)
+	}
+	uc.reference(ctx, incident)
+
+	// Save to repository
+	if err := uc.insert(ctx, incident, req); err != nil {
+		return nil, err
+	}
+	saved := uc.clock.Now()
+
+	if pending != nil {
+		backfill := *incident
+		go uc.finishAnalysis(context.WithoutCancel(ctx), &backfill, pending)
+	}
+
+	// Store the embedding for similarity search; failures must not block creation
+	var embedding []float32
+	if uc.embeddingService != nil && uc.flags.Enabled(domain.FlagEmbeddings) {
+		if embedding, err = uc.storeEmbedding(ctx, incident); err != nil {
+			logging.FromContext(ctx).Error("Failed to store embedding", "incident_id", incident.ID, "error", err)
+		}
+	}
+
+	if uc.flags.Enabled(domain.FlagSuggestLinks) {
+		incident.SuggestedLinks = uc.suggestLinks(ctx, incident, embedding)
+	}
+
+	incident.Timings = &domain.CreateTimings{
+		AIMs:    analyzed.Sub(start).Milliseconds(),
+		DBMs:    saved.Sub(analyzed).Milliseconds(),
+		TotalMs: uc.clock.Now().Sub(start).Milliseconds(),
+	}
+
+	uc.followUpOnCreate(ctx, incident, req)
+
+	uc.decorate(ctx, incident)
+	uc.publish(domain.EventCreated, incident)
+	uc.notify(incident)
+	return incident, nil
+}
+
+// insert saves a new incident. In strict mode it is rejected with domain.ErrDuplicate when it
+// repeats an incident within its dedup window, unless the request allows duplicates.
+func (uc *IncidentUseCase) insert(ctx context.Context, incident *domain.Incident, req *domain.CreateIncidentRequest) error {
+	if uc.strictUnique && !req.AllowDuplicate {
+		return uc.incidentRepo.CreateUnique(ctx, incident, uc.dedup.Since(incident.AffectedService, uc.clock.Now()))
+	}
+	return uc.incidentRepo.Create(ctx, incident)
+}
+
+// CreateIncidentsBatch creates each request independently. An item whose AI analysis fails is
+// still saved, with default severity and category and analysis_status=failed so it can be
+// reprocessed later; an item the repository rejects, e.g. a duplicate in strict mode, is
+// reported and the rest continue.
+// Result indexes are positions in reqs.
+func (uc *IncidentUseCase) CreateIncidentsBatch(ctx context.Context, reqs []*domain.CreateIncidentRequest) []*domain.BatchItemResult {
+	results := make([]*domain.BatchItemResult, len(reqs))
+	for i, req := range reqs {
+		result := &domain.BatchItemResult{Index: i, Outcome: domain.BatchCreated}
+
+		sanitized, err := uc.sanitizeRequest(req)
+		if err != nil {
+			results[i] = &domain.BatchItemResult{Index: i, Outcome: domain.BatchRejected, Error: err.Error()}
+			continue
+		}
+
+		incident, err := uc.analyzeRequest(ctx, req)
+		if err != nil {
+			logging.FromContext(ctx).Warn("AI analysis failed for batch item, saving with defaults", "index", i, "error", err)
+			result.Outcome = domain.BatchAnalysisFailed
+			result.Error = err.Error()
+			incident = uc.newIncident(sanitized, &domain.IncidentAnalysis{
+				Severity: domain.DefaultSeverity,
+				Category: domain.DefaultCategory,
+			}, domain.AnalysisFailed)
+		}
+
+		uc.assign(ctx, incident)
+		uc.reference(ctx, incident)
+
+		if err := uc.insert(ctx, incident, req); err != nil {
+			results[i] = &domain.BatchItemResult{Index: i, Outcome: domain.BatchRejected, Error: err.Error()}
+			continue
+		}
+
+		if result.Outcome == domain.BatchCreated && uc.embeddingService != nil && uc.flags.Enabled(domain.FlagEmbeddings) {
+			if _, err := uc.storeEmbedding(ctx, incident); err != nil {
+				logging.FromContext(ctx).Error("Failed to store embedding", "incident_id", incident.ID, "error", err)
+			}
+		}
+
+		uc.followUpOnCreate(ctx, incident, req)
+
+		uc.decorate(ctx, incident)
+		uc.publish(domain.EventCreated, incident)
+		uc.notify(incident)
+		result.Incident = incident
+		results[i] = result
+	}
+	return results
+}
+
+// PreviewIncident runs the create flow without persisting anything, reporting whether the
+// request would create a new incident or duplicate an existing one
+func (uc *IncidentUseCase) PreviewIncident(ctx context.Context, req *domain.CreateIncidentRequest) (*domain.IncidentPreview, error) {
+	incident, err := uc.analyzeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.decorate(ctx, incident)
+	preview := &domain.IncidentPreview{Incident: incident, Outcome: domain.PreviewCreated}
+	if !uc.flags.Enabled(domain.FlagDedup) {
+		return preview, nil
+	}
+
+	since := uc.dedup.Since(incident.AffectedService, uc.clock.Now())
+	existing, err := uc.incidentRepo.FindDuplicate(ctx, incident.Title, incident.AffectedService, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for duplicates: %w", err)
+	}
+	if existing != nil {
+		preview.Outcome = domain.PreviewDuplicate
+		preview.DuplicateOf = &existing.ID
+	}
+
+	return preview, nil
+}
+
+// analyzeRequest sanitizes a create request and builds the unsaved incident with its AI analysis
+func (uc *IncidentUseCase) analyzeRequest(ctx context.Context, req *domain.CreateIncidentRequest) (*domain.Incident, error) {
+	req, err := uc.sanitizeRequest(req)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create incident with AI insights
+	analysis, err := uc.analyze(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.newIncident(req, analysis, domain.AnalysisComplete), nil
+}
+
+// analyze classifies a sanitized request with the AI, or from the request itself when triage is off
+func (uc *IncidentUseCase) analyze(ctx context.Context, req *domain.CreateIncidentRequest) (*domain.IncidentAnalysis, error) {
+	if uc.triage.Off() {
+		return uc.triage.Analysis(req), nil
+	}
+	return uc.aiService.AnalyzeIncident(ctx, req.Title, req.Description, req.AffectedService)
+}
+
+// analysisResult is the outcome of an AI analysis running in the background
+type analysisResult struct {
+	analysis *domain.IncidentAnalysis
+	err      error
+}
+
+// analyzeWithinBudget is analyzeRequest bounded by the AI budget. When the budget runs out it
+// returns a pending incident with default severity and category, and the channel the analysis
+// will be delivered on. The background analysis is detached from ctx's cancellation because
+// it outlives the request that started it.
+func (uc *IncidentUseCase) analyzeWithinBudget(ctx context.Context, req *domain.CreateIncidentRequest) (*domain.Incident, <-chan analysisResult, error) {
+	if uc.aiBudget <= 0 || uc.triage.Off() {
+		incident, err := uc.analyzeRequest(ctx, req)
+		return incident, nil, err
+	}
+
+	req, err := uc.sanitizeRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	results := make(chan analysisResult, 1)
+	background := context.WithoutCancel(ctx)
+	go func() {
+		analysis, err := uc.aiService.AnalyzeIncident(background, req.Title, req.Description, req.AffectedService)
+		results <- analysisResult{analysis: analysis, err: err}
+	}()
+
+	timer := time.NewTimer(uc.aiBudget)
+	defer timer.Stop()
+
+	select {
+	case result := <-results:
+		if result.err != nil {
+			return nil, nil, result.err
+		}
+		return uc.newIncident(req, result.analysis, domain.AnalysisComplete), nil, nil
+	case <-timer.C:
+		incident := uc.newIncident(req, &domain.IncidentAnalysis{
+			Severity: domain.DefaultSeverity,
+			Category: domain.DefaultCategory,
+		}, domain.AnalysisPending)
+		return incident, results, nil
+	}
+}
+
+// finishAnalysis waits for the background analysis of a saved pending incident and stores it.
+// A failed analysis leaves the defaults with analysis_status=failed so it can be reprocessed.
+// Only the analysis is written, and only while the incident is still pending: edits made
+// meanwhile are kept, and an update that reanalyzed the incident wins. ctx carries the
+// originating request's ID for the log lines.
+func (uc *IncidentUseCase) finishAnalysis(ctx context.Context, incident *domain.Incident, pending <-chan analysisResult) {
+	result := <-pending
+
+	previous := *incident
+	if result.err != nil {
+		logging.FromContext(ctx).Error("Background analysis failed", "incident_id", incident.ID, "error", result.err)
+		incident.AnalysisStatus = domain.AnalysisFailed
+	} else {
+		incident.AISeverity = result.analysis.Severity
+		incident.AICategory = result.analysis.Category
+		incident.AISuggestedAction = result.analysis.SuggestedAction
+		incident.AIReasoning = result.analysis.Reasoning
+		incident.AIInputTruncated = result.analysis.InputTruncated
+		incident.AIConfidence = result.analysis.Confidence
+		incident.AnalysisStatus = domain.AnalysisComplete
+		uc.applyConfidenceFloor(incident)
+		uc.assign(ctx, incident)
+	}
+	incident.UpdatedAt = uc.clock.Now()
+
+	saved, err := uc.incidentRepo.SaveAnalysis(ctx, incident, domain.AnalysisPending)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to save background analysis", "incident_id", incident.ID, "error", err)
+		return
+	}
+	if !saved {
+		logging.FromContext(ctx).Info("Discarded background analysis of an incident no longer pending", "incident_id", incident.ID)
+		return
+	}
+
+	uc.recordChanges(ctx, &previous, incident)
+	uc.publishCurrent(ctx, incident.ID, true)
+}
+
+// publishCurrent publishes the stored incident after its analysis was saved, since the copy the
+// analysis was saved from may miss edits made meanwhile. An incident created pending is
+// notified now that its analysis decides its severity.
+func (uc *IncidentUseCase) publishCurrent(ctx context.Context, id int, wasPending bool) {
+	incident, err := uc.incidentRepo.GetByIDForWrite(ctx, id)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to load incident to publish its analysis", "incident_id", id, "error", err)
+		return
+	}
+	uc.decorate(ctx, incident)
+	uc.publish(domain.EventUpdated, incident)
+	if wasPending {
+		uc.notify(incident)
+	} else {
+		// Already notified when it was created, with the defaults of its failed analysis
+		uc.notifyReview(incident)
+	}
+}
+
+// newIncident builds an unsaved incident from a sanitized request and its analysis
+func (uc *IncidentUseCase) newIncident(req *domain.CreateIncidentRequest, analysis *domain.IncidentAnalysis, status string) *domain.Incident {
+	now := uc.clock.Now()
 	incident := &domain.Incident{
 		Title:           req.Title,
 		Description:     req.Description,
 		AffectedService: req.AffectedService,
 		AISeverity:      analysis.Severity,
 		AICategory:      analysis.Category,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		CustomFields:    req.CustomFields,
+
+		AISuggestedAction: analysis.SuggestedAction,
+		AIReasoning:       analysis.Reasoning,
+		AIInputTruncated:  analysis.InputTruncated,
+		AIConfidence:      analysis.Confidence,
+		Assignee:          req.Assignee,
+		AffectedUsers:     req.AffectedUsers,
+		AnalysisStatus:    status,
 	}
+	uc.applyConfidenceFloor(incident)
+	return incident
+}
 
-	// Save to repository
-	err = uc.incidentRepo.Create(incident)
+// ImportIncidents creates the incidents of validated CSV rows in one transaction. With
+// analyze set each row is analyzed by the AI and rows whose analysis fails are rejected;
+// otherwise the severity and category from the CSV are kept. A storage error fails the
+// whole import.
+func (uc *IncidentUseCase) ImportIncidents(ctx context.Context, rows []*domain.ImportRow, analyze bool) ([]*domain.ImportRowResult, error) {
+	results := make([]*domain.ImportRowResult, len(rows))
+	var incidents []*domain.Incident
+	var created []*domain.ImportRowResult
+	for i, row := range rows {
+		results[i] = &domain.ImportRowResult{Line: row.Line}
+		req, err := uc.sanitizeRequest(row.Request)
+		if err != nil {
+			results[i].Outcome = domain.ImportRejected
+			results[i].Error = err.Error()
+			continue
+		}
+
+		analysis := &domain.IncidentAnalysis{Severity: row.Severity, Category: row.Category}
+		if analyze {
+			analysis, err = uc.analyze(ctx, req)
+			if err != nil {
+				results[i].Outcome = domain.ImportRejected
+				results[i].Error = "AI analysis failed: " + err.Error()
+				continue
+			}
+		}
+
+		incident := uc.newIncident(req, analysis, domain.AnalysisComplete)
+		uc.reference(ctx, incident)
+		incidents = append(incidents, incident)
+		created = append(created, results[i])
+	}
+
+	if len(incidents) > 0 {
+		if err := uc.incidentRepo.CreateBatch(ctx, incidents); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.decorate(ctx, incidents...)
+	for i, result := range created {
+		result.Outcome = domain.ImportCreated
+		result.IncidentID = incidents[i].ID
+		uc.publish(domain.EventCreated, incidents[i])
+	}
+	return results, nil
+}
+
+// GetIncident retrieves an incident by ID
+func (uc *IncidentUseCase) GetIncident(ctx context.Context, id int) (*domain.Incident, error) {
+	incident, err := uc.incidentRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	uc.decorate(ctx, incident)
+	uc.runbookCompletion(ctx, incident)
 	return incident, nil
 }
 
-// GetIncident retrieves an incident by ID
-func (uc *IncidentUseCase) GetIncident(id int) (*domain.Incident, error) {
-	return uc.incidentRepo.GetByID(id)
+// ResolveReference returns the ID of the incident with a human-friendly reference
+func (uc *IncidentUseCase) ResolveReference(ctx context.Context, reference string) (int, error) {
+	return uc.incidentRepo.GetIDByReference(ctx, reference)
 }
 
-// GetAllIncidents retrieves all incidents
-func (uc *IncidentUseCase) GetAllIncidents() ([]*domain.Incident, error) {
-	return uc.incidentRepo.GetAll()
+// GetAllIncidents retrieves all incidents matching the filter
+func (uc *IncidentUseCase) GetAllIncidents(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.Incident, error) {
+	var incidents []*domain.Incident
+	var err error
+	if filter.IsEmpty() {
+		incidents, err = uc.incidentRepo.GetAll(ctx)
+	} else {
+		incidents, err = uc.incidentRepo.GetAllFiltered(ctx, filter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uc.decorate(ctx, incidents...)
+	return incidents, nil
+}
+
+// GetAllIncidentsPaginated retrieves a page of the incidents matching the filter, newest first
+func (uc *IncidentUseCase) GetAllIncidentsPaginated(ctx context.Context, filter *domain.IncidentFilter, limit, offset int) ([]*domain.Incident, error) {
+	incidents, err := uc.incidentRepo.GetAllPaginated(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.decorate(ctx, incidents...)
+	return incidents, nil
+}
+
+// SearchIncidents retrieves a page of the incidents whose title or description mention term
+func (uc *IncidentUseCase) SearchIncidents(ctx context.Context, term string, limit, offset int) (*domain.SearchPage, error) {
+	page, err := uc.incidentRepo.Search(ctx, term, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search incidents: %w", err)
+	}
+
+	uc.decorate(ctx, page.Incidents...)
+	return page, nil
+}
+
+// GetIncidentSummaries retrieves the compact projection of a page of the incidents matching the filter
+func (uc *IncidentUseCase) GetIncidentSummaries(ctx context.Context, filter *domain.IncidentFilter, limit, offset int) ([]*domain.IncidentSummary, error) {
+	return uc.incidentRepo.GetAllSummary(ctx, filter, limit, offset)
+}
+
+// listVersionPeriod is how long a listing version stays valid while nothing changes, since the
+// age fields of the listed incidents keep growing
+const listVersionPeriod = time.Minute
+
+// GetListVersion returns the change summary of the incidents matching the filter, as of the
+// replica the listing is read from
+func (uc *IncidentUseCase) GetListVersion(ctx context.Context, filter *domain.IncidentFilter) (*domain.ListVersion, error) {
+	version, err := uc.incidentRepo.MaxUpdatedAt(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	version.AsOf = uc.clock.Now().Truncate(listVersionPeriod)
+	return version, nil
+}
+
+// GetCurrentListVersion returns the change summary of the incidents matching the filter
+// including changes the replica has not caught up with yet
+func (uc *IncidentUseCase) GetCurrentListVersion(ctx context.Context, filter *domain.IncidentFilter) (*domain.ListVersion, error) {
+	version, err := uc.incidentRepo.MaxUpdatedAtFromWriter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	version.AsOf = uc.clock.Now().Truncate(listVersionPeriod)
+	return version, nil
+}
+
+// ExportIncidents streams every incident matching the filter to fn for exports
+func (uc *IncidentUseCase) ExportIncidents(ctx context.Context, filter *domain.IncidentFilter, fn func(*domain.Incident) error) error {
+	return uc.incidentRepo.StreamAll(ctx, filter, func(incident *domain.Incident) error {
+		uc.decorate(ctx, incident)
+		return fn(incident)
+	})
+}
+
+// ExportIncidentArchive streams every incident matching the filter to fn with its change
+// history, for archival exports
+func (uc *IncidentUseCase) ExportIncidentArchive(ctx context.Context, filter *domain.IncidentFilter, fn func(*domain.ExportedIncident) error) error {
+	return uc.ExportIncidents(ctx, filter, func(incident *domain.Incident) error {
+		exported := &domain.ExportedIncident{Incident: incident, History: []*domain.HistoryEntry{}}
+		if uc.historyRepo != nil {
+			history, err := uc.historyRepo.GetByIncident(ctx, incident.ID, "")
+			if err != nil {
+				return fmt.Errorf("failed to get history of incident %d: %w", incident.ID, err)
+			}
+			exported.History = history
+		}
+		return fn(exported)
+	})
+}
+
+// ExportIncidentsPage returns up to limit incidents matching the filter after the keyset
+// position afterID, in export order, and the position of the next page if there is one
+func (uc *IncidentUseCase) ExportIncidentsPage(ctx context.Context, filter *domain.IncidentFilter, afterID, limit int) (*domain.ExportPage, error) {
+	// Fetch one extra row to learn whether another page follows without a count query
+	incidents, err := uc.incidentRepo.GetPageAfterID(ctx, filter, afterID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get export page: %w", err)
+	}
+
+	page := &domain.ExportPage{Incidents: incidents}
+	if len(incidents) > limit {
+		page.Incidents = incidents[:limit]
+		page.NextAfterID = page.Incidents[limit-1].ID
+	}
+	uc.decorate(ctx, page.Incidents...)
+	return page, nil
 }
 
-// UpdateIncident updates an existing incident
-func (uc *IncidentUseCase) UpdateIncident(id int, req *domain.CreateIncidentRequest) (*domain.Incident, error) {
-	// Get existing incident
-	incident, err := uc.incidentRepo.GetByID(id)
+// GetTriageQueue returns a page of incidents ordered for triage, most severe and oldest first
+func (uc *IncidentUseCase) GetTriageQueue(ctx context.Context, limit, offset int) ([]*domain.Incident, error) {
+	incidents, err := uc.incidentRepo.GetQueue(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get triage queue: %w", err)
+	}
+
+	uc.decorate(ctx, incidents...)
+	return incidents, nil
+}
+
+// GetDistribution returns the number of incidents matching the filter per severity and per category
+func (uc *IncidentUseCase) GetDistribution(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.DistributionCount, error) {
+	return uc.incidentRepo.CountDistribution(ctx, filter)
+}
+
+// CheckDataQuality counts the incidents with each data quality issue. With fix set to
+// domain.QualityFixTrim, surrounding whitespace is trimmed first and the report reflects the
+// result; any other non-empty fix is rejected.
+func (uc *IncidentUseCase) CheckDataQuality(ctx context.Context, fix string) (*domain.QualityReport, error) {
+	report := &domain.QualityReport{Fix: fix}
+	switch fix {
+	case "":
+	case domain.QualityFixTrim:
+		trimmed, err := uc.incidentRepo.TrimWhitespace(ctx, uc.clock.Now())
+		if err != nil {
+			return nil, err
+		}
+		report.Fixed = trimmed
+	default:
+		return nil, fmt.Errorf("unknown quality fix %q", fix)
+	}
+
+	issues, err := uc.incidentRepo.CountQualityIssues(ctx)
 	if err != nil {
 		return nil, err
 	}
+	report.Issues = issues
+	return report, nil
+}
+
+// updateAttempts bounds how often an update is reapplied after an analysis was saved under it
+const updateAttempts = 3
 
-	// Re-analyze with AI if content changed
-	analysis, err := uc.aiService.AnalyzeIncident(req.Title, req.Description, req.AffectedService)
+// UpdateIncident updates an existing incident. When a background analysis or reprocess saves
+// its result between the read and the write, the update is reapplied on top of it rather than
+// overwriting it.
+func (uc *IncidentUseCase) UpdateIncident(ctx context.Context, id int, req *domain.CreateIncidentRequest) (*domain.Incident, error) {
+	req, err := uc.sanitizeRequest(req)
 	if err != nil {
 		return nil, err
 	}
 
+	for attempt := 1; ; attempt++ {
+		previous, incident, err := uc.applyUpdate(ctx, id, req)
+		if errors.Is(err, domain.ErrAnalysisChanged) && attempt < updateAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		uc.recordChanges(ctx, previous, incident)
+		uc.refreshEmbedding(ctx, previous, incident)
+
+		uc.decorate(ctx, incident)
+		uc.publish(domain.EventUpdated, incident)
+		return incident, nil
+	}
+}
+
+// applyUpdate reads an incident, applies a sanitized update request to it and saves it,
+// returning the incident as read and as saved
+func (uc *IncidentUseCase) applyUpdate(ctx context.Context, id int, req *domain.CreateIncidentRequest) (*domain.Incident, *domain.Incident, error) {
+	incident, err := uc.incidentRepo.GetByIDForWrite(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if incident.FalsePositiveReason != "" {
+		return nil, nil, fmt.Errorf("incident %d: %w", id, domain.ErrFalsePositive)
+	}
+
+	previous := *incident
+
+	// With triage off only severities and categories the client sends replace the stored ones,
+	// and the AI confidence no longer applies. Otherwise re-analyze with AI when the reanalysis
+	// policy calls for it, or keep the analysis.
+	if uc.triage.Off() {
+		if severity, ok := domain.CanonicalValue(domain.Severities, req.Severity); ok {
+			incident.AISeverity = severity
+			incident.AIConfidence = nil
+		}
+		if category, ok := domain.CanonicalValue(domain.Categories, req.Category); ok {
+			incident.AICategory = category
+			incident.AIConfidence = nil
+		}
+	} else if uc.reanalysis.Reanalyze(incident, req) {
+		analysis, err := uc.aiService.AnalyzeIncident(ctx, req.Title, req.Description, req.AffectedService)
+		if err != nil {
+			return nil, nil, err
+		}
+		incident.AISeverity = analysis.Severity
+		incident.AICategory = analysis.Category
+		incident.AISuggestedAction = analysis.SuggestedAction
+		incident.AIReasoning = analysis.Reasoning
+		incident.AIInputTruncated = analysis.InputTruncated
+		incident.AIConfidence = analysis.Confidence
+		incident.AnalysisStatus = domain.AnalysisComplete
+		uc.applyConfidenceFloor(incident)
+	}
+
 	// Update fields
 	incident.Title = req.Title
 	incident.Description = req.Description
 	incident.AffectedService = req.AffectedService
-	incident.AISeverity = analysis.Severity
-	incident.AICategory = analysis.Category
-	incident.UpdatedAt = time.Now()
+	incident.UpdatedAt = uc.clock.Now()
+	if req.CustomFields != nil {
+		incident.CustomFields = req.CustomFields
+	}
+	if req.Assignee != "" {
+		incident.Assignee = req.Assignee
+	}
+	if req.AffectedUsers != nil {
+		incident.AffectedUsers = req.AffectedUsers
+	}
 
-	// Save to repository
-	err = uc.incidentRepo.Update(incident)
+	// Save to repository, unless an analysis was saved since the read
+	if err := uc.incidentRepo.Update(ctx, incident, previous.AnalysisStatus); err != nil {
+		return nil, nil, err
+	}
+	return &previous, incident, nil
+}
+
+// MarkFalsePositive marks an incident as a false positive with the given reason. The mark is
+// final: it hides the incident from the default list and the triage queue, and later updates
+// are rejected with domain.ErrFalsePositive.
+func (uc *IncidentUseCase) MarkFalsePositive(ctx context.Context, id int, reason string) (*domain.Incident, error) {
+	incident, err := uc.incidentRepo.GetByIDForWrite(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if incident.FalsePositiveReason != "" {
+		return nil, fmt.Errorf("incident %d: %w", id, domain.ErrFalsePositive)
+	}
+
+	now := uc.clock.Now()
+	if err := uc.incidentRepo.MarkFalsePositive(ctx, id, reason, now); err != nil {
+		return nil, err
+	}
+	incident.FalsePositiveReason = reason
+	incident.UpdatedAt = now
+
+	if uc.historyRepo != nil {
+		entry := &domain.HistoryEntry{
+			IncidentID: id,
+			Field:      domain.FieldFalsePositive,
+			NewValue:   reason,
+			Actor:      domain.ActorAPI,
+			CreatedAt:  now,
+		}
+		if err := uc.historyRepo.AddEntries(ctx, []*domain.HistoryEntry{entry}); err != nil {
+			logging.FromContext(ctx).Error("Failed to record history", "incident_id", id, "error", err)
+		}
+	}
 
+	uc.decorate(ctx, incident)
+	uc.publish(domain.EventUpdated, incident)
 	return incident, nil
 }
 
+// SetPriorityOverride sets the priority of an incident regardless of its severity and affected
+// users, or clears the override when priority is empty. False positives cannot be reprioritized.
+func (uc *IncidentUseCase) SetPriorityOverride(ctx context.Context, id int, priority string) (*domain.Incident, error) {
+	incident, err := uc.incidentRepo.GetByIDForWrite(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.FalsePositiveReason != "" {
+		return nil, fmt.Errorf("incident %d: %w", id, domain.ErrFalsePositive)
+	}
+
+	previous := incident.PriorityOverride
+	now := uc.clock.Now()
+	if err := uc.incidentRepo.SetPriorityOverride(ctx, id, priority, now); err != nil {
+		return nil, err
+	}
+	incident.PriorityOverride = priority
+	incident.UpdatedAt = now
+
+	if uc.historyRepo != nil && previous != priority {
+		entry := &domain.HistoryEntry{
+			IncidentID: id,
+			Field:      domain.FieldPriority,
+			OldValue:   previous,
+			NewValue:   priority,
+			Actor:      domain.ActorAPI,
+			CreatedAt:  now,
+		}
+		if err := uc.historyRepo.AddEntries(ctx, []*domain.HistoryEntry{entry}); err != nil {
+			logging.FromContext(ctx).Error("Failed to record history", "incident_id", id, "error", err)
+		}
+	}
+
+	uc.decorate(ctx, incident)
+	uc.publish(domain.EventUpdated, incident)
+	return incident, nil
+}
+
+// reprocessWorkers bounds the number of concurrent AI calls while reprocessing failed analyses
+const reprocessWorkers = 4
+
+// stalePendingAge is how long an analysis may stay pending before reprocessing treats it as lost,
+// e.g. to a restart. It is well beyond any AI call with its retries.
+const stalePendingAge = 10 * time.Minute
+
+// ReprocessFailedAnalyses retries the AI analysis of up to limit incidents whose analysis
+// failed or was lost while pending, oldest first, and saves the ones that now succeed. It
+// returns domain.ErrTriageOff when triage is off.
+func (uc *IncidentUseCase) ReprocessFailedAnalyses(ctx context.Context, limit int) (*domain.ReprocessResult, error) {
+	if uc.triage.Off() {
+		return nil, domain.ErrTriageOff
+	}
+
+	incidents, err := uc.incidentRepo.GetUnfinishedAnalyses(ctx, uc.clock.Now().Add(-stalePendingAge), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incidents to reprocess: %w", err)
+	}
+
+	work := make(chan *domain.Incident)
+	fixed := make(chan bool, len(incidents))
+	var wg sync.WaitGroup
+	for i := 0; i < reprocessWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for incident := range work {
+				fixed <- uc.reprocess(ctx, incident)
+			}
+		}()
+	}
+	for _, incident := range incidents {
+		work <- incident
+	}
+	close(work)
+	wg.Wait()
+	close(fixed)
+
+	result := &domain.ReprocessResult{Attempted: len(incidents)}
+	for ok := range fixed {
+		if ok {
+			result.Fixed++
+		} else {
+			result.StillFailing++
+		}
+	}
+	return result, nil
+}
+
+// reprocess re-runs the analysis of one incident and saves it unless it was analyzed meanwhile,
+// reporting whether the incident is now analyzed
+func (uc *IncidentUseCase) reprocess(ctx context.Context, incident *domain.Incident) bool {
+	analysis, err := uc.aiService.AnalyzeIncident(ctx, incident.Title, incident.Description, incident.AffectedService)
+	if err != nil {
+		logging.FromContext(ctx).Error("Reprocessing analysis failed", "incident_id", incident.ID, "error", err)
+		return false
+	}
+
+	previous := *incident
+	incident.AISeverity = analysis.Severity
+	incident.AICategory = analysis.Category
+	incident.AISuggestedAction = analysis.SuggestedAction
+	incident.AIReasoning = analysis.Reasoning
+	incident.AIInputTruncated = analysis.InputTruncated
+	incident.AIConfidence = analysis.Confidence
+	incident.AnalysisStatus = domain.AnalysisComplete
+	uc.applyConfidenceFloor(incident)
+	incident.UpdatedAt = uc.clock.Now()
+
+	saved, err := uc.incidentRepo.SaveAnalysis(ctx, incident, previous.AnalysisStatus)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to save reprocessed incident", "incident_id", incident.ID, "error", err)
+		return false
+	}
+	if !saved {
+		// Analyzed meanwhile, by an update or a late background analysis
+		logging.FromContext(ctx).Info("Discarded reprocessed analysis of an incident analyzed meanwhile", "incident_id", incident.ID)
+		return true
+	}
+
+	uc.recordChanges(ctx, &previous, incident)
+	uc.publishCurrent(ctx, incident.ID, previous.AnalysisStatus == domain.AnalysisPending)
+	return true
+}
+
+// remapBatchSize bounds the number of incidents read or updated per query by a severity remap
+const remapBatchSize = 500
+
+// RemapSeverity moves every incident with an old severity of the mapping to its new severity,
+// in batches, recording each change in history. The matching IDs are collected before
+// anything is updated so chained mappings such as Low→Medium, Medium→High move each incident
+// once. With dryRun set only the counts are returned.
+func (uc *IncidentUseCase) RemapSeverity(ctx context.Context, mapping map[string]string, dryRun bool) (*domain.RemapResult, error) {
+	matched := make(map[string][]int, len(mapping))
+	result := &domain.RemapResult{DryRun: dryRun, Remapped: make(map[string]int, len(mapping))}
+	for old, severity := range mapping {
+		if old == severity {
+			continue
+		}
+		afterID := 0
+		for {
+			ids, err := uc.incidentRepo.GetIDsBySeverity(ctx, old, afterID, remapBatchSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find %s incidents: %w", old, err)
+			}
+			matched[old] = append(matched[old], ids...)
+			if len(ids) < remapBatchSize {
+				break
+			}
+			afterID = ids[len(ids)-1]
+		}
+		result.Remapped[old] = len(matched[old])
+		result.Total += len(matched[old])
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	now := uc.clock.Now()
+	for old, ids := range matched {
+		severity := mapping[old]
+		for start := 0; start < len(ids); start += remapBatchSize {
+			end := start + remapBatchSize
+			if end > len(ids) {
+				end = len(ids)
+			}
+			batch := ids[start:end]
+
+			if err := uc.incidentRepo.UpdateSeverity(ctx, batch, severity, now); err != nil {
+				return nil, fmt.Errorf("failed to remap %s incidents: %w", old, err)
+			}
+			uc.recordRemap(ctx, batch, old, severity, now)
+		}
+	}
+	return result, nil
+}
+
+// recordRemap records a batch of admin severity changes in history
+func (uc *IncidentUseCase) recordRemap(ctx context.Context, ids []int, old, severity string, at time.Time) {
+	if uc.historyRepo == nil {
+		return
+	}
+
+	entries := make([]*domain.HistoryEntry, len(ids))
+	for i, id := range ids {
+		entries[i] = &domain.HistoryEntry{
+			IncidentID: id,
+			Field:      domain.FieldAISeverity,
+			OldValue:   old,
+			NewValue:   severity,
+			Actor:      domain.ActorAdmin,
+			CreatedAt:  at,
+		}
+	}
+
+	if err := uc.historyRepo.AddEntries(ctx, entries); err != nil {
+		logging.FromContext(ctx).Error("Failed to record severity remap history", "error", err)
+	}
+}
+
+// ReassignIncidents moves every incident assigned to from, except false positives, to the
+// assignee to, recording each change in history
+func (uc *IncidentUseCase) ReassignIncidents(ctx context.Context, from, to string, scope domain.ReassignScope) (*domain.ReassignResult, error) {
+	now := uc.clock.Now()
+	ids, err := uc.incidentRepo.Reassign(ctx, from, to, scope, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign incidents: %w", err)
+	}
+
+	uc.recordReassignment(ctx, ids, from, to, now)
+	return &domain.ReassignResult{Reassigned: len(ids), IncidentIDs: ids}, nil
+}
+
+// recordReassignment records a bulk admin reassignment in history
+func (uc *IncidentUseCase) recordReassignment(ctx context.Context, ids []int, from, to string, at time.Time) {
+	if uc.historyRepo == nil || len(ids) == 0 {
+		return
+	}
+
+	entries := make([]*domain.HistoryEntry, len(ids))
+	for i, id := range ids {
+		entries[i] = &domain.HistoryEntry{
+			IncidentID: id,
+			Field:      domain.FieldAssignee,
+			OldValue:   from,
+			NewValue:   to,
+			Actor:      domain.ActorAdmin,
+			CreatedAt:  at,
+		}
+	}
+
+	if err := uc.historyRepo.AddEntries(ctx, entries); err != nil {
+		logging.FromContext(ctx).Error("Failed to record reassignment history", "error", err)
+	}
+}
+
 // DeleteIncident deletes an incident by ID
-func (uc *IncidentUseCase) DeleteIncident(id int) error {
-	return uc.incidentRepo.Delete(id)
+func (uc *IncidentUseCase) DeleteIncident(ctx context.Context, id int) error {
+	if err := uc.incidentRepo.Delete(ctx, id, domain.ActorAPI); err != nil {
+		return err
+	}
+
+	if uc.events != nil {
+		uc.events.Publish(&domain.IncidentEvent{Type: domain.EventDeleted, ID: id})
+	}
+	return nil
+}
+
+// FindSimilarIncidents returns the incidents most similar to the given one, most similar first
+func (uc *IncidentUseCase) FindSimilarIncidents(ctx context.Context, id int, limit int) ([]*domain.SimilarIncident, error) {
+	if uc.embeddingService == nil {
+		return nil, domain.ErrEmbeddingsUnavailable
+	}
+
+	incident, err := uc.incidentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	embedding, err := uc.embeddingRepo.GetEmbedding(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Backfill incidents created before embeddings were enabled
+	if embedding == nil {
+		embedding, err = uc.storeEmbedding(ctx, incident)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	matches, err := uc.similarity.FindNearest(ctx, embedding, id, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	similar := make([]*domain.SimilarIncident, 0, len(matches))
+	for _, match := range matches {
+		candidate, err := uc.incidentRepo.GetByID(ctx, match.IncidentID)
+		if err != nil {
+			// The incident may have been deleted since the embedding was read
+			continue
+		}
+		similar = append(similar, &domain.SimilarIncident{
+			Incident: candidate,
+			Score:    match.Score,
+		})
+	}
+
+	return similar, nil
+}
+
+// GetSeverityHistory returns the severity changes of an incident, oldest first
+func (uc *IncidentUseCase) GetSeverityHistory(ctx context.Context, id int) ([]*domain.HistoryEntry, error) {
+	if _, err := uc.incidentRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if uc.historyRepo == nil {
+		return []*domain.HistoryEntry{}, nil
+	}
+
+	return uc.historyRepo.GetByIncident(ctx, id, domain.FieldAISeverity)
+}
+
+// GetHistory returns up to limit history entries across all incidents, newest first, starting
+// after the keyset position after, and the position of the next page if there is one
+func (uc *IncidentUseCase) GetHistory(ctx context.Context, filter domain.HistoryFilter, after *domain.Cursor, limit int) (*domain.HistoryPage, error) {
+	if uc.historyRepo == nil {
+		return &domain.HistoryPage{Entries: []*domain.HistoryEntry{}}, nil
+	}
+
+	// Fetch one extra entry to learn whether another page follows without a count query
+	entries, err := uc.historyRepo.List(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history: %w", err)
+	}
+
+	page := &domain.HistoryPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		last := page.Entries[limit-1]
+		page.NextCursor = &domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return page, nil
+}
+
+// recordChanges writes a history entry for every tracked field that differs between two versions of an incident.
+// History is best-effort and never fails the triggering operation.
+func (uc *IncidentUseCase) recordChanges(ctx context.Context, before, after *domain.Incident) {
+	if uc.historyRepo == nil {
+		return
+	}
+
+	var entries []*domain.HistoryEntry
+	addChange := func(field, oldValue, newValue, actor string) {
+		if oldValue == newValue {
+			return
+		}
+		entries = append(entries, &domain.HistoryEntry{
+			IncidentID: after.ID,
+			Field:      field,
+			OldValue:   oldValue,
+			NewValue:   newValue,
+			Actor:      actor,
+			CreatedAt:  after.UpdatedAt,
+		})
+	}
+
+	addChange(domain.FieldTitle, before.Title, after.Title, domain.ActorAPI)
+	addChange(domain.FieldAffectedService, before.AffectedService, after.AffectedService, domain.ActorAPI)
+	addChange(domain.FieldAISeverity, before.AISeverity, after.AISeverity, domain.ActorAI)
+	addChange(domain.FieldAICategory, before.AICategory, after.AICategory, domain.ActorAI)
+
+	if len(entries) == 0 {
+		return
+	}
+
+	if err := uc.historyRepo.AddEntries(ctx, entries); err != nil {
+		logging.FromContext(ctx).Error("Failed to record history", "incident_id", after.ID, "error", err)
+	}
+}
+
+// publish sends a change event with a snapshot of the incident, when events are enabled
+func (uc *IncidentUseCase) publish(eventType string, incident *domain.Incident) {
+	if uc.events == nil {
+		return
+	}
+
+	snapshot := *incident
+	snapshot.Timings = nil
+	uc.events.Publish(&domain.IncidentEvent{Type: eventType, ID: incident.ID, Incident: &snapshot})
+}
+
+// notify tells the notifiers about a new incident. An incident still pending analysis is held
+// back until its analysis is stored, since its default severity says nothing about urgency.
+func (uc *IncidentUseCase) notify(incident *domain.Incident) {
+	if incident.AnalysisStatus == domain.AnalysisPending {
+		return
+	}
+
+	if uc.notifier != nil {
+		snapshot := *incident
+		snapshot.Timings = nil
+		uc.notifier.NotifyIncident(&snapshot)
+	}
+	uc.notifyReview(incident)
+}
+
+// decorate fills in the read-time fields of incidents returned to callers
+func (uc *IncidentUseCase) decorate(ctx context.Context, incidents ...*domain.Incident) {
+	now := uc.clock.Now()
+	for _, incident := range incidents {
+		if uc.router != nil {
+			incident.Team = uc.router.Route(incident.AICategory)
+		}
+		incident.Priority = incident.PriorityOverride
+		if incident.Priority == "" {
+			incident.Priority = domain.ComputePriority(incident.AISeverity, incident.AffectedUsers)
+		}
+		incident.UnknownService = !uc.catalog.Knows(incident.AffectedService)
+		incident.LowConfidence = incident.AIConfidence != nil && *incident.AIConfidence < domain.LowConfidenceThreshold
+		incident.NeedsReview = uc.confidenceFloor.Below(incident.AIConfidence)
+		if uc.directory != nil {
+			metadata, err := uc.directory.Lookup(incident.AffectedService)
+			if err != nil {
+				logging.FromContext(ctx).Error("Failed to look up service metadata", "service", incident.AffectedService, "error", err)
+			}
+			incident.ServiceMetadata = metadata
+		}
+
+		age := now.Sub(incident.CreatedAt)
+		if age < 0 {
+			age = 0
+		}
+		incident.AgeSeconds = int64(age / time.Second)
+		incident.AgeHuman = formatAge(age)
+	}
+}
+
+// formatAge renders a duration in its two most significant units, e.g. "3h12m" or "2d5h"
+func formatAge(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	minutes := int(d/time.Minute) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm", minutes)
+	default:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	}
+}
+
+// assign picks an assignee from the routed team's rotation for an unassigned incident.
+// Assignment is best-effort: a failure leaves the incident unassigned rather than failing creation.
+func (uc *IncidentUseCase) assign(ctx context.Context, incident *domain.Incident) {
+	if incident.Assignee != "" || uc.assigner == nil || uc.router == nil {
+		return
+	}
+
+	route := uc.router.Route(incident.AICategory)
+	if route == nil {
+		return
+	}
+
+	assignee, err := uc.assigner.Assign(ctx, route.Team)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to assign incident", "incident_id", incident.ID, "team", route.Team, "error", err)
+		return
+	}
+	incident.Assignee = assignee
+}
+
+// WithReferences gives new incidents a human-friendly reference in format, numbered by the
+// per-year sequences
+func WithReferences(format domain.ReferenceFormat, sequences domain.SequenceRepository) Option {
+	return func(uc *IncidentUseCase) {
+		uc.references = format
+		uc.sequences = sequences
+	}
+}
+
+// reference numbers a new incident within the year it was created. Like assignment it is
+// best-effort: a failure leaves the incident without a reference rather than failing creation.
+func (uc *IncidentUseCase) reference(ctx context.Context, incident *domain.Incident) {
+	if uc.sequences == nil {
+		return
+	}
+
+	year := incident.CreatedAt.Year()
+	seq, err := uc.sequences.Next(ctx, year)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to number incident reference", "year", year, "error", err)
+		return
+	}
+	incident.Reference = uc.references.Format(year, seq)
+}
+
+// sanitizeRequest returns a copy of the request with cleaned and redacted text fields. The copy
+// is validated again, since redaction can lengthen a field past its limit and cleaning can
+// empty it, and a *domain.ValidationError is returned when it fails.
+func (uc *IncidentUseCase) sanitizeRequest(req *domain.CreateIncidentRequest) (*domain.CreateIncidentRequest, error) {
+	sanitized := &domain.CreateIncidentRequest{
+		Title:           uc.sanitizer.SanitizeLine(req.Title),
+		Description:     uc.sanitizer.SanitizeText(req.Description),
+		AffectedService: uc.sanitizer.SanitizeLine(req.AffectedService),
+		CustomFields:    uc.sanitizeCustomFields(req.CustomFields),
+		Assignee:        uc.sanitizer.SanitizeLine(req.Assignee),
+		AffectedUsers:   req.AffectedUsers,
+		Severity:        req.Severity,
+		Category:        req.Category,
+	}
+	if err := sanitized.ValidateWith(uc.fieldLimits, uc.customFields); err != nil {
+		return nil, err
+	}
+	return sanitized, nil
+}
+
+// sanitizeCustomFields redacts string custom field values, preserving a nil map
+func (uc *IncidentUseCase) sanitizeCustomFields(fields map[string]interface{}) map[string]interface{} {
+	if fields == nil {
+		return nil
+	}
+
+	sanitized := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if s, ok := value.(string); ok {
+			value = uc.sanitizer.SanitizeLine(s)
+		}
+		sanitized[key] = value
+	}
+	return sanitized
+}
+
+// storeEmbedding computes and persists the embedding of an incident
+func (uc *IncidentUseCase) storeEmbedding(ctx context.Context, incident *domain.Incident) ([]float32, error) {
+	embedding, err := uc.embeddingService.EmbedText(ctx, embeddingText(incident))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.embeddingRepo.SaveEmbedding(ctx, incident.ID, embedding); err != nil {
+		return nil, err
+	}
+
+	return embedding, nil
+}
+
+// refreshEmbedding re-embeds an incident whose text changed so similarity search does not
+// match it by its old text. When that fails the stale embedding is deleted instead, and
+// similarity search backfills it on the next lookup; failures must not fail the update.
+func (uc *IncidentUseCase) refreshEmbedding(ctx context.Context, previous, incident *domain.Incident) {
+	if uc.embeddingService == nil || embeddingText(previous) == embeddingText(incident) {
+		return
+	}
+
+	if uc.flags.Enabled(domain.FlagEmbeddings) {
+		_, err := uc.storeEmbedding(ctx, incident)
+		if err == nil {
+			return
+		}
+		logging.FromContext(ctx).Error("Failed to refresh embedding", "incident_id", incident.ID, "error", err)
+	}
+
+	if err := uc.embeddingRepo.DeleteEmbedding(ctx, incident.ID); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete stale embedding", "incident_id", incident.ID, "error", err)
+	}
+}
+
+// embeddingText builds the text that represents an incident for embedding purposes
+func embeddingText(incident *domain.Incident) string {
+	return fmt.Sprintf("%s\n%s\nAffected service: %s", incident.Title, incident.Description, incident.AffectedService)
 }
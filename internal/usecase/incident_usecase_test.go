@@ -1,14 +1,22 @@
 package usecase
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
+	"incident-triage-assistant/internal/clock"
 	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockIncidentRepository is a mock implementation of IncidentRepository
@@ -16,34 +24,212 @@ type MockIncidentRepository struct {
 	mock.Mock
 }
 
-func (m *MockIncidentRepository) Create(incident *domain.Incident) error {
-	args := m.Called(incident)
+func (m *MockIncidentRepository) Create(ctx context.Context, incident *domain.Incident) error {
+	args := m.Called(ctx, incident)
 	return args.Error(0)
 }
 
-func (m *MockIncidentRepository) GetByID(id int) (*domain.Incident, error) {
-	args := m.Called(id)
+func (m *MockIncidentRepository) GetUnfinishedAnalyses(ctx context.Context, pendingBefore time.Time, limit int) ([]*domain.Incident, error) {
+	args := m.Called(ctx, pendingBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentRepository) GetRecent(ctx context.Context, since time.Time, excludeID, limit int) ([]*domain.Incident, error) {
+	args := m.Called(ctx, since, excludeID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentRepository) GetIDsBySeverity(ctx context.Context, severity string, afterID, limit int) ([]int, error) {
+	args := m.Called(ctx, severity, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockIncidentRepository) UpdateSeverity(ctx context.Context, ids []int, severity string, updatedAt time.Time) error {
+	args := m.Called(ctx, ids, severity, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockIncidentRepository) MarkFalsePositive(ctx context.Context, id int, reason string, updatedAt time.Time) error {
+	args := m.Called(ctx, id, reason, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockIncidentRepository) SetStormCount(ctx context.Context, id, count int, updatedAt time.Time) error {
+	args := m.Called(ctx, id, count, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockIncidentRepository) SetPriorityOverride(ctx context.Context, id int, priority string, updatedAt time.Time) error {
+	args := m.Called(ctx, id, priority, updatedAt)
+	return args.Error(0)
+}
+
+func (m *MockIncidentRepository) GetIDByReference(ctx context.Context, reference string) (int, error) {
+	args := m.Called(ctx, reference)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockIncidentRepository) Reassign(ctx context.Context, from, to string, scope domain.ReassignScope, updatedAt time.Time) ([]int, error) {
+	args := m.Called(ctx, from, to, scope, updatedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockIncidentRepository) CreateUnique(ctx context.Context, incident *domain.Incident, since time.Time) error {
+	args := m.Called(ctx, incident, since)
+	return args.Error(0)
+}
+
+func (m *MockIncidentRepository) CreateBatch(ctx context.Context, incidents []*domain.Incident) error {
+	args := m.Called(ctx, incidents)
+	return args.Error(0)
+}
+
+func (m *MockIncidentRepository) GetByID(ctx context.Context, id int) (*domain.Incident, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentRepository) GetByIDForWrite(ctx context.Context, id int) (*domain.Incident, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Incident), args.Error(1)
 }
 
-func (m *MockIncidentRepository) GetAll() ([]*domain.Incident, error) {
-	args := m.Called()
+func (m *MockIncidentRepository) GetAll(ctx context.Context) ([]*domain.Incident, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentRepository) GetAllFiltered(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.Incident, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentRepository) GetAllPaginated(ctx context.Context, filter *domain.IncidentFilter, limit, offset int) ([]*domain.Incident, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentRepository) Search(ctx context.Context, term string, limit, offset int) (*domain.SearchPage, error) {
+	args := m.Called(ctx, term, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SearchPage), args.Error(1)
+}
+
+func (m *MockIncidentRepository) GetAllSummary(ctx context.Context, filter *domain.IncidentFilter, limit, offset int) ([]*domain.IncidentSummary, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.IncidentSummary), args.Error(1)
+}
+
+func (m *MockIncidentRepository) MaxUpdatedAtFromWriter(ctx context.Context, filter *domain.IncidentFilter) (*domain.ListVersion, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ListVersion), args.Error(1)
+}
+
+func (m *MockIncidentRepository) MaxUpdatedAt(ctx context.Context, filter *domain.IncidentFilter) (*domain.ListVersion, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ListVersion), args.Error(1)
+}
+
+func (m *MockIncidentRepository) CountQualityIssues(ctx context.Context) ([]*domain.QualityCount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.QualityCount), args.Error(1)
+}
+
+func (m *MockIncidentRepository) TrimWhitespace(ctx context.Context, updatedAt time.Time) (int, error) {
+	args := m.Called(ctx, updatedAt)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockIncidentRepository) CountDistribution(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.DistributionCount, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DistributionCount), args.Error(1)
+}
+
+func (m *MockIncidentRepository) GetQueue(ctx context.Context, limit, offset int) ([]*domain.Incident, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentRepository) GetPageAfterID(ctx context.Context, filter *domain.IncidentFilter, afterID, limit int) ([]*domain.Incident, error) {
+	args := m.Called(ctx, filter, afterID, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*domain.Incident), args.Error(1)
 }
 
-func (m *MockIncidentRepository) Update(incident *domain.Incident) error {
-	args := m.Called(incident)
+func (m *MockIncidentRepository) FindDuplicate(ctx context.Context, title, affectedService string, since time.Time) (*domain.Incident, error) {
+	args := m.Called(ctx, title, affectedService, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Incident), args.Error(1)
+}
+
+func (m *MockIncidentRepository) StreamAll(ctx context.Context, filter *domain.IncidentFilter, fn func(*domain.Incident) error) error {
+	args := m.Called(ctx, filter, fn)
+	return args.Error(0)
+}
+
+func (m *MockIncidentRepository) Update(ctx context.Context, incident *domain.Incident, analysisStatus string) error {
+	args := m.Called(ctx, incident, analysisStatus)
 	return args.Error(0)
 }
 
-func (m *MockIncidentRepository) Delete(id int) error {
-	args := m.Called(id)
+func (m *MockIncidentRepository) SaveAnalysis(ctx context.Context, incident *domain.Incident, status string) (bool, error) {
+	args := m.Called(ctx, incident, status)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockIncidentRepository) Delete(ctx context.Context, id int, purgedBy string) error {
+	args := m.Called(ctx, id, purgedBy)
 	return args.Error(0)
 }
 
@@ -52,14 +238,84 @@ type MockAIService struct {
 	mock.Mock
 }
 
-func (m *MockAIService) AnalyzeIncident(title, description, affectedService string) (*domain.IncidentAnalysis, error) {
-	args := m.Called(title, description, affectedService)
+func (m *MockAIService) AnalyzeIncident(ctx context.Context, title, description, affectedService string) (*domain.IncidentAnalysis, error) {
+	args := m.Called(ctx, title, description, affectedService)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.IncidentAnalysis), args.Error(1)
 }
 
+// MockEmbeddingService is a mock implementation of EmbeddingService
+type MockEmbeddingService struct {
+	mock.Mock
+}
+
+func (m *MockEmbeddingService) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	args := m.Called(ctx, text)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]float32), args.Error(1)
+}
+
+// MockEmbeddingRepository is a mock implementation of EmbeddingRepository and SimilaritySearcher
+type MockEmbeddingRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmbeddingRepository) SaveEmbedding(ctx context.Context, incidentID int, embedding []float32) error {
+	args := m.Called(ctx, incidentID, embedding)
+	return args.Error(0)
+}
+
+func (m *MockEmbeddingRepository) DeleteEmbedding(ctx context.Context, incidentID int) error {
+	args := m.Called(ctx, incidentID)
+	return args.Error(0)
+}
+
+func (m *MockEmbeddingRepository) GetEmbedding(ctx context.Context, incidentID int) ([]float32, error) {
+	args := m.Called(ctx, incidentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]float32), args.Error(1)
+}
+
+func (m *MockEmbeddingRepository) FindNearest(ctx context.Context, embedding []float32, excludeID int, limit int) ([]*domain.SimilarityMatch, error) {
+	args := m.Called(ctx, embedding, excludeID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SimilarityMatch), args.Error(1)
+}
+
+// MockHistoryRepository is a mock implementation of HistoryRepository
+type MockHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockHistoryRepository) AddEntries(ctx context.Context, entries []*domain.HistoryEntry) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+
+func (m *MockHistoryRepository) GetByIncident(ctx context.Context, incidentID int, field string) ([]*domain.HistoryEntry, error) {
+	args := m.Called(ctx, incidentID, field)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.HistoryEntry), args.Error(1)
+}
+
+func (m *MockHistoryRepository) List(ctx context.Context, filter domain.HistoryFilter, after *domain.Cursor, limit int) ([]*domain.HistoryEntry, error) {
+	args := m.Called(ctx, filter, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.HistoryEntry), args.Error(1)
+}
+
 func TestCreateIncident(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -125,22 +381,23 @@ func TestCreateIncident(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockIncidentRepository)
 			mockAI := new(MockAIService)
+			fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
 
-			useCase := NewIncidentUseCase(mockRepo, mockAI)
+			useCase := NewIncidentUseCase(mockRepo, mockAI, WithClock(fixedClock))
 
 			if tt.aiAnalysis != nil {
-				mockAI.On("AnalyzeIncident", tt.request.Title, tt.request.Description, tt.request.AffectedService).
+				mockAI.On("AnalyzeIncident", mock.Anything, tt.request.Title, tt.request.Description, tt.request.AffectedService).
 					Return(tt.aiAnalysis, tt.aiError)
 			} else {
-				mockAI.On("AnalyzeIncident", tt.request.Title, tt.request.Description, tt.request.AffectedService).
+				mockAI.On("AnalyzeIncident", mock.Anything, tt.request.Title, tt.request.Description, tt.request.AffectedService).
 					Return(nil, tt.aiError)
 			}
 
 			if tt.aiError == nil {
-				mockRepo.On("Create", mock.AnythingOfType("*domain.Incident")).Return(tt.repoError)
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(tt.repoError)
 			}
 
-			result, err := useCase.CreateIncident(tt.request)
+			result, err := useCase.CreateIncident(context.Background(), tt.request)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -153,6 +410,8 @@ func TestCreateIncident(t *testing.T) {
 				assert.Equal(t, tt.expectedResult.AffectedService, result.AffectedService)
 				assert.Equal(t, tt.expectedResult.AISeverity, result.AISeverity)
 				assert.Equal(t, tt.expectedResult.AICategory, result.AICategory)
+				assert.Equal(t, fixedClock.Now(), result.CreatedAt)
+				assert.Equal(t, fixedClock.Now(), result.UpdatedAt)
 			}
 
 			mockRepo.AssertExpectations(t)
@@ -177,9 +436,9 @@ func TestGetIncident(t *testing.T) {
 		UpdatedAt:       time.Now(),
 	}
 
-	mockRepo.On("GetByID", 1).Return(expectedIncident, nil)
+	mockRepo.On("GetByID", mock.Anything, 1).Return(expectedIncident, nil)
 
-	result, err := useCase.GetIncident(1)
+	result, err := useCase.GetIncident(context.Background(), 1)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedIncident, result)
@@ -214,11 +473,1898 @@ func TestGetAllIncidents(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("GetAll").Return(expectedIncidents, nil)
+	mockRepo.On("GetAll", mock.Anything, mock.Anything).Return(expectedIncidents, nil)
+
+	result, err := useCase.GetAllIncidents(context.Background(), &domain.IncidentFilter{})
 
-	result, err := useCase.GetAllIncidents()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedIncidents, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAllIncidents_Filtered(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+	filter := &domain.IncidentFilter{Severities: []string{"High", "Critical"}}
+	expectedIncidents := []*domain.Incident{{ID: 1, AISeverity: "High"}}
+
+	mockRepo.On("GetAllFiltered", mock.Anything, filter).Return(expectedIncidents, nil)
+
+	result, err := useCase.GetAllIncidents(context.Background(), filter)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedIncidents, result)
+	mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAllIncidentsPaginated(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	router := NewCategoryRouter(map[string]domain.TeamRoute{
+		"Database": {Team: "database", Channel: "#db-oncall"},
+	}, nil)
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithTeamRouter(router))
+
+	filter := &domain.IncidentFilter{}
+	mockRepo.On("GetAllPaginated", mock.Anything, filter, 20, 40).Return([]*domain.Incident{{ID: 3, AICategory: "Database"}}, nil)
+
+	result, err := useCase.GetAllIncidentsPaginated(context.Background(), filter, 20, 40)
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, &domain.TeamRoute{Team: "database", Channel: "#db-oncall"}, result[0].Team)
+	}
 	mockRepo.AssertExpectations(t)
 }
+
+func TestSearchIncidents(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+	page := &domain.SearchPage{Incidents: []*domain.Incident{{ID: 4, Title: "Login timeout"}}, Total: 1}
+	mockRepo.On("Search", mock.Anything, "timeout", 50, 0).Return(page, nil)
+	mockRepo.On("Search", mock.Anything, "disk", 50, 0).Return(nil, errors.New("connection refused"))
+
+	result, err := useCase.SearchIncidents(context.Background(), "timeout", 50, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, page, result)
+
+	_, err = useCase.SearchIncidents(context.Background(), "disk", 50, 0)
+	assert.ErrorContains(t, err, "failed to search incidents")
+}
+
+func TestCreateIncident_StoresEmbedding(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	mockEmbedder := new(MockEmbeddingService)
+	mockEmbeddings := new(MockEmbeddingRepository)
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithEmbeddings(mockEmbedder, mockEmbeddings, mockEmbeddings))
+
+	req := &domain.CreateIncidentRequest{
+		Title:           "Test Incident",
+		Description:     "Test Description",
+		AffectedService: "Test Service",
+	}
+	embedding := []float32{0.1, 0.2}
+
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Network"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Incident).ID = 7
+	}).Return(nil)
+	mockEmbedder.On("EmbedText", mock.Anything, "Test Incident\nTest Description\nAffected service: Test Service").Return(embedding, nil)
+	mockEmbeddings.On("SaveEmbedding", mock.Anything, 7, embedding).Return(nil)
+
+	result, err := useCase.CreateIncident(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, result.ID)
+	mockEmbedder.AssertExpectations(t)
+	mockEmbeddings.AssertExpectations(t)
+}
+
+func TestCreateIncident_AIContext(t *testing.T) {
+	req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+	analysis := &domain.IncidentAnalysis{Severity: "High", Category: "Hardware"}
+
+	t.Run("the request context reaches the AI", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mockAI.On("AnalyzeIncident", ctx, req.Title, req.Description, req.AffectedService).Return(analysis, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := useCase.CreateIncident(ctx, req)
+
+		assert.NoError(t, err)
+		mockAI.AssertExpectations(t)
+	})
+
+	t.Run("a background analysis is not cancelled with the request", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithAIBudget(20*time.Millisecond))
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var aiErr error
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+			After(100*time.Millisecond).
+			Run(func(args mock.Arguments) { aiErr = args.Get(0).(context.Context).Err() }).
+			Return(analysis, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		backfilled := make(chan *domain.Incident, 1)
+		mockRepo.On("SaveAnalysis", mock.Anything, mock.Anything, domain.AnalysisPending).Run(func(args mock.Arguments) {
+			backfilled <- args.Get(1).(*domain.Incident)
+		}).Return(true, nil)
+		mockRepo.On("GetByIDForWrite", mock.Anything, mock.Anything).Return(&domain.Incident{}, nil)
+
+		_, err := useCase.CreateIncident(ctx, req)
+		cancel()
+		assert.NoError(t, err)
+
+		select {
+		case updated := <-backfilled:
+			assert.Equal(t, domain.AnalysisComplete, updated.AnalysisStatus)
+			assert.NoError(t, aiErr)
+		case <-time.After(2 * time.Second):
+			t.Fatal("the pending analysis was never saved")
+		}
+	})
+}
+
+func TestCreateIncident_AIConfidence(t *testing.T) {
+	req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+	confidence := func(c float64) *float64 { return &c }
+
+	tests := []struct {
+		name       string
+		confidence *float64
+		low        bool
+	}{
+		{name: "confident analysis", confidence: confidence(0.8)},
+		{name: "threshold is not low", confidence: confidence(domain.LowConfidenceThreshold)},
+		{name: "low confidence is flagged", confidence: confidence(0.3), low: true},
+		{name: "no confidence is not flagged", confidence: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIncidentRepository)
+			mockAI := new(MockAIService)
+			useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+			mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+				Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware", Confidence: tt.confidence}, nil)
+			mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool {
+				return assert.ObjectsAreEqual(tt.confidence, incident.AIConfidence)
+			})).Return(nil)
+
+			incident, err := useCase.CreateIncident(context.Background(), req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.low, incident.LowConfidence)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("triage off has no confidence", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithTriagePolicy(domain.TriagePolicy{Mode: domain.TriageOff}))
+
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		incident, err := useCase.CreateIncident(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.Nil(t, incident.AIConfidence)
+		assert.False(t, incident.LowConfidence)
+	})
+}
+
+func TestCreateIncident_EmbeddingFailureDoesNotFail(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	mockEmbedder := new(MockEmbeddingService)
+	mockEmbeddings := new(MockEmbeddingRepository)
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithEmbeddings(mockEmbedder, mockEmbeddings, mockEmbeddings))
+
+	req := &domain.CreateIncidentRequest{
+		Title:           "Test Incident",
+		Description:     "Test Description",
+		AffectedService: "Test Service",
+	}
+
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Network"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(nil)
+	mockEmbedder.On("EmbedText", mock.Anything, mock.Anything).Return(nil, errors.New("embedding unavailable"))
+
+	result, err := useCase.CreateIncident(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockEmbeddings.AssertNotCalled(t, "SaveEmbedding", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFindSimilarIncidents(t *testing.T) {
+	t.Run("returns matches in score order", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		mockEmbedder := new(MockEmbeddingService)
+		mockEmbeddings := new(MockEmbeddingRepository)
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithEmbeddings(mockEmbedder, mockEmbeddings, mockEmbeddings))
+
+		embedding := []float32{0.1, 0.2}
+		source := &domain.Incident{ID: 1, Title: "Source"}
+		first := &domain.Incident{ID: 2, Title: "First"}
+		second := &domain.Incident{ID: 3, Title: "Second"}
+
+		mockRepo.On("GetByID", mock.Anything, 1).Return(source, nil)
+		mockRepo.On("GetByID", mock.Anything, 2).Return(first, nil)
+		mockRepo.On("GetByID", mock.Anything, 3).Return(second, nil)
+		mockEmbeddings.On("GetEmbedding", mock.Anything, 1).Return(embedding, nil)
+		mockEmbeddings.On("FindNearest", mock.Anything, embedding, 1, 5).Return([]*domain.SimilarityMatch{
+			{IncidentID: 2, Score: 0.9},
+			{IncidentID: 3, Score: 0.7},
+		}, nil)
+
+		result, err := useCase.FindSimilarIncidents(context.Background(), 1, 5)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, first, result[0].Incident)
+		assert.Equal(t, 0.9, result[0].Score)
+		assert.Equal(t, second, result[1].Incident)
+		mockEmbedder.AssertNotCalled(t, "EmbedText", mock.Anything, mock.Anything)
+	})
+
+	t.Run("backfills missing embedding", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		mockEmbedder := new(MockEmbeddingService)
+		mockEmbeddings := new(MockEmbeddingRepository)
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithEmbeddings(mockEmbedder, mockEmbeddings, mockEmbeddings))
+
+		embedding := []float32{0.3, 0.4}
+		source := &domain.Incident{ID: 1, Title: "Source", Description: "Desc", AffectedService: "Svc"}
+
+		mockRepo.On("GetByID", mock.Anything, 1).Return(source, nil)
+		mockEmbeddings.On("GetEmbedding", mock.Anything, 1).Return(nil, nil)
+		mockEmbedder.On("EmbedText", mock.Anything, "Source\nDesc\nAffected service: Svc").Return(embedding, nil)
+		mockEmbeddings.On("SaveEmbedding", mock.Anything, 1, embedding).Return(nil)
+		mockEmbeddings.On("FindNearest", mock.Anything, embedding, 1, 5).Return([]*domain.SimilarityMatch{}, nil)
+
+		result, err := useCase.FindSimilarIncidents(context.Background(), 1, 5)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+		mockEmbedder.AssertExpectations(t)
+		mockEmbeddings.AssertExpectations(t)
+	})
+
+	t.Run("embeddings not configured", func(t *testing.T) {
+		useCase := NewIncidentUseCase(new(MockIncidentRepository), new(MockAIService))
+
+		result, err := useCase.FindSimilarIncidents(context.Background(), 1, 5)
+
+		assert.ErrorIs(t, err, domain.ErrEmbeddingsUnavailable)
+		assert.Nil(t, result)
+	})
+}
+
+func TestCreateIncident_RedactsSecretsBeforeAnalysis(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+	req := &domain.CreateIncidentRequest{
+		Title:           "  Login   failures ",
+		Description:     "Auth fails with key sk-live0123456789abcdefXYZ\x00 in logs",
+		AffectedService: "Auth Service",
+	}
+
+	mockAI.On("AnalyzeIncident", mock.Anything, "Login failures", "Auth fails with key [REDACTED] in logs", "Auth Service").
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Security"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool {
+		return incident.Description == "Auth fails with key [REDACTED] in logs"
+	})).Return(nil)
+
+	result, err := useCase.CreateIncident(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Login failures", result.Title)
+	assert.NotContains(t, result.Description, "sk-live")
+	mockAI.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSanitizedRequestsAreValidated(t *testing.T) {
+	// Redaction lengthens a field that was within its limit
+	lengthened := &domain.CreateIncidentRequest{Title: "Login failures", Description: "Auth fails", AffectedService: strings.Repeat("a", 92) + " token:a"}
+	// Cleaning empties a field made only of control characters
+	emptied := &domain.CreateIncidentRequest{Title: "\x00\x07\x1b", Description: "Auth fails", AffectedService: "auth"}
+
+	assertRejected := func(t *testing.T, err error, field, rule string) {
+		var validationErr *domain.ValidationError
+		if assert.ErrorAs(t, err, &validationErr) {
+			assert.Equal(t, []domain.FieldError{{Field: field, Rule: rule, Message: validationErr.Fields[0].Message}}, validationErr.Fields)
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+		_, err := useCase.CreateIncident(context.Background(), lengthened)
+		assertRejected(t, err, "affected_service", domain.RuleMax)
+
+		_, err = useCase.CreateIncident(context.Background(), emptied)
+		assertRejected(t, err, "title", domain.RuleRequired)
+
+		mockAI.AssertNotCalled(t, "AnalyzeIncident", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+		_, err := useCase.UpdateIncident(context.Background(), 1, emptied)
+		assertRejected(t, err, "title", domain.RuleRequired)
+		mockRepo.AssertNotCalled(t, "GetByIDForWrite", mock.Anything, mock.Anything)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+		results := useCase.CreateIncidentsBatch(context.Background(), []*domain.CreateIncidentRequest{lengthened})
+		assert.Equal(t, domain.BatchRejected, results[0].Outcome)
+		assert.Contains(t, results[0].Error, "affected_service must be at most 100 characters")
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("configured limits", func(t *testing.T) {
+		useCase := NewIncidentUseCase(new(MockIncidentRepository), new(MockAIService),
+			WithFieldLimits(domain.FieldLimits{Title: 10, Description: 100, AffectedService: 100}))
+
+		_, err := useCase.CreateIncident(context.Background(), &domain.CreateIncidentRequest{Title: "a token:b", Description: "Auth fails", AffectedService: "auth"})
+		assertRejected(t, err, "title", domain.RuleMax)
+	})
+}
+
+func TestUpdateIncident_RecordsChangedFields(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	mockHistory := new(MockHistoryRepository)
+	fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithHistory(mockHistory), WithClock(fixedClock))
+
+	existing := &domain.Incident{
+		ID:              1,
+		Title:           "Test Incident",
+		Description:     "Test Description",
+		AffectedService: "Test Service",
+		AISeverity:      "Low",
+		AICategory:      "Software",
+	}
+	req := &domain.CreateIncidentRequest{
+		Title:           "Test Incident",
+		Description:     "Now affecting every user",
+		AffectedService: "Test Service",
+	}
+
+	mockRepo.On("GetByIDForWrite", mock.Anything, 1).Return(existing, nil)
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "Critical", Category: "Software"}, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Incident"), mock.Anything).Return(nil)
+	mockHistory.On("AddEntries", mock.Anything, mock.MatchedBy(func(entries []*domain.HistoryEntry) bool {
+		return len(entries) == 1 &&
+			entries[0].Field == domain.FieldAISeverity &&
+			entries[0].OldValue == "Low" &&
+			entries[0].NewValue == "Critical" &&
+			entries[0].Actor == domain.ActorAI &&
+			entries[0].CreatedAt.Equal(fixedClock.Now())
+	})).Return(nil)
+
+	result, err := useCase.UpdateIncident(context.Background(), 1, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Critical", result.AISeverity)
+	assert.Equal(t, fixedClock.Now(), result.UpdatedAt)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestUpdateIncident_KeepsAnalysisSavedDuringUpdate(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService),
+		WithReanalysisPolicy(domain.ReanalysisPolicy{Mode: domain.ReanalyzeNever}))
+
+	req := &domain.CreateIncidentRequest{Title: "Checkout errors", Description: "5xx from checkout", AffectedService: "checkout", Assignee: "dana"}
+	pending := &domain.Incident{ID: 3, Title: req.Title, Description: req.Description, AffectedService: req.AffectedService,
+		AISeverity: domain.DefaultSeverity, AICategory: domain.DefaultCategory, AnalysisStatus: domain.AnalysisPending}
+	analyzed := &domain.Incident{ID: 3, Title: req.Title, Description: req.Description, AffectedService: req.AffectedService,
+		AISeverity: "Critical", AICategory: "Application", AnalysisStatus: domain.AnalysisComplete}
+
+	// The background analysis is saved between the first read and its write
+	mockRepo.On("GetByIDForWrite", mock.Anything, 3).Return(pending, nil).Once()
+	mockRepo.On("Update", mock.Anything, mock.Anything, domain.AnalysisPending).Return(domain.ErrAnalysisChanged).Once()
+	mockRepo.On("GetByIDForWrite", mock.Anything, 3).Return(analyzed, nil).Once()
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool {
+		return incident.AISeverity == "Critical" && incident.AnalysisStatus == domain.AnalysisComplete && incident.Assignee == "dana"
+	}), domain.AnalysisComplete).Return(nil).Once()
+
+	result, err := useCase.UpdateIncident(context.Background(), 3, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Critical", result.AISeverity)
+	assert.Equal(t, domain.AnalysisComplete, result.AnalysisStatus)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateIncident_RefreshesEmbedding(t *testing.T) {
+	existing := func() *domain.Incident {
+		return &domain.Incident{ID: 5, Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage", AISeverity: "High", AICategory: "Hardware"}
+	}
+	req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Data volume at 100%", AffectedService: "storage"}
+	setup := func() (*IncidentUseCase, *MockEmbeddingService, *MockEmbeddingRepository) {
+		mockRepo := new(MockIncidentRepository)
+		mockEmbedder := new(MockEmbeddingService)
+		mockEmbeddings := new(MockEmbeddingRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService),
+			WithEmbeddings(mockEmbedder, mockEmbeddings, mockEmbeddings),
+			WithReanalysisPolicy(domain.ReanalysisPolicy{Mode: domain.ReanalyzeNever}))
+		mockRepo.On("GetByIDForWrite", mock.Anything, 5).Return(existing(), nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		return useCase, mockEmbedder, mockEmbeddings
+	}
+
+	t.Run("changed text is re-embedded", func(t *testing.T) {
+		useCase, mockEmbedder, mockEmbeddings := setup()
+		embedding := []float32{0.3, 0.4}
+		mockEmbedder.On("EmbedText", mock.Anything, "Disk full\nData volume at 100%\nAffected service: storage").Return(embedding, nil)
+		mockEmbeddings.On("SaveEmbedding", mock.Anything, 5, embedding).Return(nil)
+
+		_, err := useCase.UpdateIncident(context.Background(), 5, req)
+
+		assert.NoError(t, err)
+		mockEmbeddings.AssertExpectations(t)
+		mockEmbeddings.AssertNotCalled(t, "DeleteEmbedding", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a failed re-embed deletes the stale embedding", func(t *testing.T) {
+		useCase, mockEmbedder, mockEmbeddings := setup()
+		mockEmbedder.On("EmbedText", mock.Anything, mock.Anything).Return(nil, errors.New("embedding unavailable"))
+		mockEmbeddings.On("DeleteEmbedding", mock.Anything, 5).Return(nil)
+
+		_, err := useCase.UpdateIncident(context.Background(), 5, req)
+
+		assert.NoError(t, err)
+		mockEmbeddings.AssertExpectations(t)
+	})
+
+	t.Run("unchanged text keeps the embedding", func(t *testing.T) {
+		useCase, mockEmbedder, mockEmbeddings := setup()
+		unchanged := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage", Assignee: "dana"}
+
+		_, err := useCase.UpdateIncident(context.Background(), 5, unchanged)
+
+		assert.NoError(t, err)
+		mockEmbedder.AssertNotCalled(t, "EmbedText", mock.Anything, mock.Anything)
+		mockEmbeddings.AssertNotCalled(t, "DeleteEmbedding", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateIncident_ReanalysisPolicy(t *testing.T) {
+	cosmetic := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume on db-1 is at 100%.", AffectedService: "db-primary"}
+	rewritten := &domain.CreateIncidentRequest{Title: "Replication lag", Description: "db-2 is ten minutes behind", AffectedService: "database"}
+
+	tests := []struct {
+		name          string
+		policy        domain.ReanalysisPolicy
+		req           *domain.CreateIncidentRequest
+		expectedCalls int
+	}{
+		{name: "always", policy: domain.ReanalysisPolicy{Mode: domain.ReanalyzeAlways}, req: cosmetic, expectedCalls: 1},
+		{name: "never", policy: domain.ReanalysisPolicy{Mode: domain.ReanalyzeNever}, req: rewritten, expectedCalls: 0},
+		{name: "significant skips a cosmetic edit", policy: domain.ReanalysisPolicy{Mode: domain.ReanalyzeSignificant, Threshold: 0.2}, req: cosmetic, expectedCalls: 0},
+		{name: "significant reanalyzes a rewrite", policy: domain.ReanalysisPolicy{Mode: domain.ReanalyzeSignificant, Threshold: 0.2}, req: rewritten, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIncidentRepository)
+			mockAI := new(MockAIService)
+			useCase := NewIncidentUseCase(mockRepo, mockAI, WithReanalysisPolicy(tt.policy))
+
+			mockRepo.On("GetByIDForWrite", mock.Anything, 1).Return(&domain.Incident{
+				ID:                1,
+				Title:             "Disk full",
+				Description:       "Root volume on db-1 is at 100%",
+				AffectedService:   "database",
+				AISeverity:        "High",
+				AICategory:        "Hardware",
+				AISuggestedAction: "Expand the volume",
+				AnalysisStatus:    domain.AnalysisComplete,
+			}, nil)
+			mockAI.On("AnalyzeIncident", mock.Anything, tt.req.Title, tt.req.Description, tt.req.AffectedService).
+				Return(&domain.IncidentAnalysis{Severity: "Critical", Category: "Database"}, nil)
+			mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Incident"), mock.Anything).Return(nil)
+
+			result, err := useCase.UpdateIncident(context.Background(), 1, tt.req)
+
+			assert.NoError(t, err)
+			mockAI.AssertNumberOfCalls(t, "AnalyzeIncident", tt.expectedCalls)
+			assert.Equal(t, tt.req.AffectedService, result.AffectedService)
+			if tt.expectedCalls == 0 {
+				assert.Equal(t, "High", result.AISeverity)
+				assert.Equal(t, "Hardware", result.AICategory)
+				assert.Equal(t, "Expand the volume", result.AISuggestedAction)
+			} else {
+				assert.Equal(t, "Critical", result.AISeverity)
+				assert.Equal(t, "Database", result.AICategory)
+			}
+		})
+	}
+}
+
+func TestGetSeverityHistory(t *testing.T) {
+	t.Run("returns severity changes", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockHistory := new(MockHistoryRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithHistory(mockHistory))
+
+		changes := []*domain.HistoryEntry{{IncidentID: 1, Field: domain.FieldAISeverity, OldValue: "Low", NewValue: "High"}}
+		mockRepo.On("GetByID", mock.Anything, 1).Return(&domain.Incident{ID: 1}, nil)
+		mockHistory.On("GetByIncident", mock.Anything, 1, domain.FieldAISeverity).Return(changes, nil)
+
+		result, err := useCase.GetSeverityHistory(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, changes, result)
+	})
+
+	t.Run("incident not found", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockHistory := new(MockHistoryRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithHistory(mockHistory))
+
+		mockRepo.On("GetByID", mock.Anything, 999).Return(nil, errors.New("incident not found with id 999"))
+
+		result, err := useCase.GetSeverityHistory(context.Background(), 999)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		mockHistory.AssertNotCalled(t, "GetByIncident", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("history not configured", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+		mockRepo.On("GetByID", mock.Anything, 1).Return(&domain.Incident{ID: 1}, nil)
+
+		result, err := useCase.GetSeverityHistory(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	filter := domain.HistoryFilter{Actor: domain.ActorAdmin, Field: domain.FieldAISeverity}
+
+	t.Run("more entries than the limit", func(t *testing.T) {
+		mockHistory := new(MockHistoryRepository)
+		useCase := NewIncidentUseCase(new(MockIncidentRepository), new(MockAIService), WithHistory(mockHistory))
+
+		entries := []*domain.HistoryEntry{{ID: 9, CreatedAt: now}, {ID: 8, CreatedAt: now}, {ID: 7, CreatedAt: now}}
+		mockHistory.On("List", mock.Anything, filter, (*domain.Cursor)(nil), 3).Return(entries, nil)
+
+		page, err := useCase.GetHistory(context.Background(), filter, nil, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Entries, 2)
+		assert.Equal(t, &domain.Cursor{ID: 8, CreatedAt: now}, page.NextCursor)
+		mockHistory.AssertExpectations(t)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		mockHistory := new(MockHistoryRepository)
+		useCase := NewIncidentUseCase(new(MockIncidentRepository), new(MockAIService), WithHistory(mockHistory))
+
+		after := &domain.Cursor{ID: 8, CreatedAt: now}
+		mockHistory.On("List", mock.Anything, filter, after, 3).Return([]*domain.HistoryEntry{{ID: 7, CreatedAt: now}}, nil)
+
+		page, err := useCase.GetHistory(context.Background(), filter, after, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Entries, 1)
+		assert.Nil(t, page.NextCursor)
+	})
+
+	t.Run("history not configured", func(t *testing.T) {
+		useCase := NewIncidentUseCase(new(MockIncidentRepository), new(MockAIService))
+
+		page, err := useCase.GetHistory(context.Background(), filter, nil, 2)
+
+		assert.NoError(t, err)
+		assert.Empty(t, page.Entries)
+		assert.Nil(t, page.NextCursor)
+	})
+}
+
+func TestCreateIncident_RoutesDatabaseIncidentToDatabaseTeam(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	router := NewCategoryRouter(map[string]domain.TeamRoute{
+		"Database": {Team: "database", Channel: "#db-oncall"},
+	}, &domain.TeamRoute{Team: "operations", Channel: "#incidents"})
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithTeamRouter(router))
+
+	req := &domain.CreateIncidentRequest{
+		Title:           "Replica lag",
+		Description:     "Primary replica lagging by 10 minutes",
+		AffectedService: "Orders DB",
+	}
+
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Database"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(nil)
+
+	result, err := useCase.CreateIncident(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.TeamRoute{Team: "database", Channel: "#db-oncall"}, result.Team)
+}
+
+func TestGetAllIncidents_ResolvesTeams(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	router := NewCategoryRouter(map[string]domain.TeamRoute{
+		"Database": {Team: "database", Channel: "#db-oncall"},
+	}, &domain.TeamRoute{Team: "operations", Channel: "#incidents"})
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithTeamRouter(router))
+
+	mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]*domain.Incident{
+		{ID: 1, AICategory: "Database"},
+		{ID: 2, AICategory: "Network"},
+	}, nil)
+
+	result, err := useCase.GetAllIncidents(context.Background(), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "database", result[0].Team.Team)
+	assert.Equal(t, "operations", result[1].Team.Team)
+}
+
+func TestGetIncident_ComputesAge(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	createdAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	fixedClock := clock.NewMock(createdAt.Add(3*time.Hour + 12*time.Minute + 30*time.Second))
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithClock(fixedClock))
+
+	mockRepo.On("GetByID", mock.Anything, 1).Return(&domain.Incident{ID: 1, CreatedAt: createdAt}, nil)
+
+	result, err := useCase.GetIncident(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11550), result.AgeSeconds)
+	assert.Equal(t, "3h12m", result.AgeHuman)
+}
+
+func TestGetAllIncidents_FlagsUnknownServices(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	catalog := &domain.ServiceCatalog{Services: []string{"auth-service"}}
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithServiceCatalog(catalog))
+
+	mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]*domain.Incident{
+		{ID: 1, AffectedService: "Auth-Service"},
+		{ID: 2, AffectedService: "auth-servce"},
+	}, nil)
+
+	result, err := useCase.GetAllIncidents(context.Background(), nil)
+
+	assert.NoError(t, err)
+	assert.False(t, result[0].UnknownService)
+	assert.True(t, result[1].UnknownService)
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		age      time.Duration
+		expected string
+	}{
+		{0, "0s"},
+		{45 * time.Second, "45s"},
+		{5*time.Minute + 59*time.Second, "5m"},
+		{3*time.Hour + 12*time.Minute, "3h12m"},
+		{2*24*time.Hour + 5*time.Hour + 30*time.Minute, "2d5h"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatAge(tt.age))
+	}
+}
+
+func TestGetTriageQueue(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+	queue := []*domain.Incident{{ID: 3, AISeverity: "Critical"}, {ID: 1, AISeverity: "High"}}
+	mockRepo.On("GetQueue", mock.Anything, 50, 0).Return(queue, nil)
+
+	result, err := useCase.GetTriageQueue(context.Background(), 50, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, queue, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateIncident_CustomFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{name: "omitted keeps stored values", fields: nil, expected: map[string]interface{}{"region": "eu"}},
+		{name: "provided replaces stored values", fields: map[string]interface{}{"region": "us"}, expected: map[string]interface{}{"region": "us"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIncidentRepository)
+			mockAI := new(MockAIService)
+			useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+			mockRepo.On("GetByIDForWrite", mock.Anything, 1).Return(&domain.Incident{ID: 1, CustomFields: map[string]interface{}{"region": "eu"}}, nil)
+			mockAI.On("AnalyzeIncident", mock.Anything, "Title", "Description", "Service").Return(&domain.IncidentAnalysis{Severity: "Low", Category: "Network"}, nil)
+			mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool {
+				return assert.ObjectsAreEqual(tt.expected, incident.CustomFields)
+			}), mock.Anything).Return(nil)
+
+			_, err := useCase.UpdateIncident(context.Background(), 1, &domain.CreateIncidentRequest{
+				Title: "Title", Description: "Description", AffectedService: "Service", CustomFields: tt.fields,
+			})
+
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExportIncidentsPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     []*domain.Incident
+		wantIDs  []int
+		wantNext int
+	}{
+		{name: "more pages", rows: []*domain.Incident{{ID: 4}, {ID: 5}, {ID: 6}}, wantIDs: []int{4, 5}, wantNext: 5},
+		{name: "last page", rows: []*domain.Incident{{ID: 4}}, wantIDs: []int{4}, wantNext: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIncidentRepository)
+			useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+			mockRepo.On("GetPageAfterID", mock.Anything, mock.Anything, 3, 3).Return(tt.rows, nil)
+
+			page, err := useCase.ExportIncidentsPage(context.Background(), nil, 3, 2)
+
+			assert.NoError(t, err)
+			var ids []int
+			for _, incident := range page.Incidents {
+				ids = append(ids, incident.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantNext, page.NextAfterID)
+		})
+	}
+}
+
+func TestGetListVersion_AgesWithTheClock(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 42, 0, time.UTC))
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithClock(fixedClock))
+
+	mockRepo.On("MaxUpdatedAt", mock.Anything, mock.Anything).Return(&domain.ListVersion{Count: 1}, nil)
+	mockRepo.On("MaxUpdatedAtFromWriter", mock.Anything, mock.Anything).Return(&domain.ListVersion{Count: 2}, nil)
+
+	version, err := useCase.GetListVersion(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, version.Count)
+	assert.Equal(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), version.AsOf)
+
+	current, err := useCase.GetCurrentListVersion(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, current.Count)
+	assert.Equal(t, version.AsOf, current.AsOf)
+}
+
+func TestExportIncidentArchive(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockHistory := new(MockHistoryRepository)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithHistory(mockHistory), WithClock(clock.NewMock(now)))
+
+	filter := &domain.IncidentFilter{Severities: []string{"Critical"}}
+	change := &domain.HistoryEntry{ID: 3, IncidentID: 8, Field: domain.FieldAISeverity, OldValue: "High", NewValue: "Critical"}
+	mockRepo.On("StreamAll", mock.Anything, filter, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*domain.Incident) error)
+		assert.NoError(t, fn(&domain.Incident{ID: 8, AISeverity: "Critical", CreatedAt: now.Add(-2 * time.Hour)}))
+	}).Return(nil)
+	mockHistory.On("GetByIncident", mock.Anything, 8, "").Return([]*domain.HistoryEntry{change}, nil)
+
+	var exported []*domain.ExportedIncident
+	err := useCase.ExportIncidentArchive(context.Background(), filter, func(incident *domain.ExportedIncident) error {
+		exported = append(exported, incident)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, exported, 1) {
+		assert.Equal(t, domain.ComputePriority("Critical", nil), exported[0].Priority)
+		assert.Equal(t, int64(7200), exported[0].AgeSeconds)
+		assert.Equal(t, []*domain.HistoryEntry{change}, exported[0].History)
+	}
+}
+
+func TestPreviewIncident(t *testing.T) {
+	req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+
+	tests := []struct {
+		name        string
+		existing    *domain.Incident
+		outcome     string
+		duplicateOf *int
+	}{
+		{name: "new incident", existing: nil, outcome: domain.PreviewCreated},
+		{name: "duplicate", existing: &domain.Incident{ID: 42}, outcome: domain.PreviewDuplicate, duplicateOf: intPtr(42)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIncidentRepository)
+			mockAI := new(MockAIService)
+			useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+			mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+				Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware"}, nil)
+			mockRepo.On("FindDuplicate", mock.Anything, req.Title, req.AffectedService, time.Time{}).Return(tt.existing, nil)
+
+			preview, err := useCase.PreviewIncident(context.Background(), req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.outcome, preview.Outcome)
+			assert.Equal(t, tt.duplicateOf, preview.DuplicateOf)
+			assert.Equal(t, "High", preview.Incident.AISeverity)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestFeatureFlags_DisableEmbeddingsAndDedup(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	mockEmbedder := new(MockEmbeddingService)
+	mockEmbeddings := new(MockEmbeddingRepository)
+	flags := NewStaticFlags(map[string]bool{domain.FlagEmbeddings: false, domain.FlagDedup: false})
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithEmbeddings(mockEmbedder, mockEmbeddings, mockEmbeddings), WithFeatureFlags(flags))
+
+	req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(nil)
+
+	_, err := useCase.CreateIncident(context.Background(), req)
+	assert.NoError(t, err)
+
+	preview, err := useCase.PreviewIncident(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PreviewCreated, preview.Outcome)
+
+	mockEmbedder.AssertNotCalled(t, "EmbedText", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "FindDuplicate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateIncidentsBatch_MixedOutcomes(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+	analyzed := &domain.CreateIncidentRequest{Title: "Outage", Description: "API down", AffectedService: "api"}
+	aiFailure := &domain.CreateIncidentRequest{Title: "Latency", Description: "p99 at 4s", AffectedService: "api"}
+	rejected := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+
+	mockAI.On("AnalyzeIncident", mock.Anything, analyzed.Title, analyzed.Description, analyzed.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "Critical", Category: "Network"}, nil)
+	mockAI.On("AnalyzeIncident", mock.Anything, aiFailure.Title, aiFailure.Description, aiFailure.AffectedService).
+		Return(nil, errors.New("AI service unavailable"))
+	mockAI.On("AnalyzeIncident", mock.Anything, rejected.Title, rejected.Description, rejected.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool { return incident.Title == "Disk full" })).
+		Return(domain.ErrDuplicate)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(nil)
+
+	results := useCase.CreateIncidentsBatch(context.Background(), []*domain.CreateIncidentRequest{analyzed, aiFailure, rejected})
+
+	assert.Len(t, results, 3)
+
+	assert.Equal(t, domain.BatchCreated, results[0].Outcome)
+	assert.Equal(t, "Critical", results[0].Incident.AISeverity)
+	assert.Equal(t, domain.AnalysisComplete, results[0].Incident.AnalysisStatus)
+
+	assert.Equal(t, domain.BatchAnalysisFailed, results[1].Outcome)
+	assert.Equal(t, domain.DefaultSeverity, results[1].Incident.AISeverity)
+	assert.Equal(t, domain.DefaultCategory, results[1].Incident.AICategory)
+	assert.Equal(t, domain.AnalysisFailed, results[1].Incident.AnalysisStatus)
+	assert.Contains(t, results[1].Error, "AI service unavailable")
+
+	assert.Equal(t, 2, results[2].Index)
+	assert.Equal(t, domain.BatchRejected, results[2].Outcome)
+	assert.Nil(t, results[2].Incident)
+	assert.Contains(t, results[2].Error, "already exists")
+}
+
+func TestCreateIncidentsBatch_LogsRequestID(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&logs))
+	defer slog.SetDefault(previous)
+
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+	req := &domain.CreateIncidentRequest{Title: "Latency", Description: "p99 at 4s", AffectedService: "api"}
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(nil, errors.New("AI service unavailable"))
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(nil)
+
+	ctx := logging.WithRequestID(context.Background(), "trace-7f3a")
+	useCase.CreateIncidentsBatch(ctx, []*domain.CreateIncidentRequest{req})
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &line))
+	assert.Equal(t, "trace-7f3a", line[logging.RequestIDKey])
+	assert.Equal(t, "AI service unavailable", line["error"])
+}
+
+func TestCreateIncident_StrictUnique(t *testing.T) {
+	tests := []struct {
+		name           string
+		strict         bool
+		allowDuplicate bool
+		method         string
+	}{
+		{name: "strict mode checks uniqueness", strict: true, method: "CreateUnique"},
+		{name: "allow_duplicate overrides strict mode", strict: true, allowDuplicate: true, method: "Create"},
+		{name: "default mode", strict: false, method: "Create"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIncidentRepository)
+			mockAI := new(MockAIService)
+			useCase := NewIncidentUseCase(mockRepo, mockAI, WithStrictUnique(tt.strict))
+
+			req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage", AllowDuplicate: tt.allowDuplicate}
+			mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+				Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware"}, nil)
+			if tt.method == "CreateUnique" {
+				mockRepo.On("CreateUnique", mock.Anything, mock.AnythingOfType("*domain.Incident"), time.Time{}).Return(nil)
+			} else {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(nil)
+			}
+
+			_, err := useCase.CreateIncident(context.Background(), req)
+
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("conflict", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithStrictUnique(true))
+
+		req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+			Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware"}, nil)
+		mockRepo.On("CreateUnique", mock.Anything, mock.AnythingOfType("*domain.Incident"), time.Time{}).Return(domain.ErrDuplicate)
+
+		_, err := useCase.CreateIncident(context.Background(), req)
+
+		assert.ErrorIs(t, err, domain.ErrDuplicate)
+	})
+}
+
+func TestCreateIncidentsBatch_StrictUnique(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithStrictUnique(true))
+
+	repeated := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+	allowed := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage", AllowDuplicate: true}
+	mockAI.On("AnalyzeIncident", mock.Anything, repeated.Title, repeated.Description, repeated.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware"}, nil)
+	mockRepo.On("CreateUnique", mock.Anything, mock.AnythingOfType("*domain.Incident"), time.Time{}).Return(domain.ErrDuplicate)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(nil)
+
+	results := useCase.CreateIncidentsBatch(context.Background(), []*domain.CreateIncidentRequest{repeated, allowed})
+
+	assert.Equal(t, domain.BatchRejected, results[0].Outcome)
+	assert.Contains(t, results[0].Error, "already exists")
+	assert.Equal(t, domain.BatchCreated, results[1].Outcome)
+	mockRepo.AssertNumberOfCalls(t, "CreateUnique", 1)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestCreateIncident_PerServiceDedupWindows(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	existingCreatedAt := now.Add(-10 * time.Minute)
+	windows := domain.DedupWindows{
+		Default:  time.Hour,
+		Services: map[string]time.Duration{"flapping-batch": 6 * time.Hour, "payment-gateway": 2 * time.Minute},
+	}
+
+	tests := []struct {
+		service   string
+		since     time.Time
+		duplicate bool
+	}{
+		{service: "flapping-batch", since: now.Add(-6 * time.Hour), duplicate: true},
+		{service: "payment-gateway", since: now.Add(-2 * time.Minute), duplicate: false},
+		{service: "storage", since: now.Add(-time.Hour), duplicate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			mockRepo := new(MockIncidentRepository)
+			mockAI := new(MockAIService)
+			useCase := NewIncidentUseCase(mockRepo, mockAI, WithStrictUnique(true), WithDedupWindows(windows), WithClock(clock.NewMock(now)))
+
+			req := &domain.CreateIncidentRequest{Title: "Job failed", Description: "Nightly run failed", AffectedService: tt.service}
+			mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+				Return(&domain.IncidentAnalysis{Severity: "Low", Category: "Software"}, nil)
+
+			// The repository reports a duplicate when the earlier incident falls inside the window
+			var result error
+			if !existingCreatedAt.Before(tt.since) {
+				result = domain.ErrDuplicate
+			}
+			mockRepo.On("CreateUnique", mock.Anything, mock.AnythingOfType("*domain.Incident"), tt.since).Return(result)
+
+			_, err := useCase.CreateIncident(context.Background(), req)
+
+			if tt.duplicate {
+				assert.ErrorIs(t, err, domain.ErrDuplicate)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateIncident_StoresSuggestedAction(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+	req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware", SuggestedAction: "Free space on the root volume"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool {
+		return incident.AISuggestedAction == "Free space on the root volume"
+	})).Return(nil)
+
+	incident, err := useCase.CreateIncident(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Free space on the root volume", incident.AISuggestedAction)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateIncident_StoresReasoning(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+	req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware", Reasoning: "A full root volume stops writes on the host"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool {
+		return incident.AIReasoning == "A full root volume stops writes on the host"
+	})).Return(nil)
+
+	incident, err := useCase.CreateIncident(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "A full root volume stops writes on the host", incident.AIReasoning)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateIncident_AIBudget(t *testing.T) {
+	req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+	analysis := &domain.IncidentAnalysis{Severity: "High", Category: "Hardware", Reasoning: "Writes fail on a full volume"}
+
+	t.Run("slow analysis is saved as pending and backfilled", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithAIBudget(20*time.Millisecond))
+
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+			After(300*time.Millisecond).
+			Return(analysis, nil)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool {
+			return incident.AnalysisStatus == domain.AnalysisPending && incident.AISeverity == domain.DefaultSeverity
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Incident).ID = 42
+		}).Return(nil)
+
+		backfilled := make(chan *domain.Incident, 1)
+		mockRepo.On("SaveAnalysis", mock.Anything, mock.Anything, domain.AnalysisPending).Run(func(args mock.Arguments) {
+			backfilled <- args.Get(1).(*domain.Incident)
+		}).Return(true, nil)
+		mockRepo.On("GetByIDForWrite", mock.Anything, 42).Return(&domain.Incident{ID: 42}, nil)
+
+		start := time.Now()
+		incident, err := useCase.CreateIncident(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(start), 200*time.Millisecond)
+		assert.Equal(t, domain.AnalysisPending, incident.AnalysisStatus)
+		assert.Equal(t, domain.DefaultCategory, incident.AICategory)
+
+		select {
+		case updated := <-backfilled:
+			assert.Equal(t, 42, updated.ID)
+			assert.Equal(t, domain.AnalysisComplete, updated.AnalysisStatus)
+			assert.Equal(t, "High", updated.AISeverity)
+			assert.Equal(t, "Hardware", updated.AICategory)
+			assert.Equal(t, "Writes fail on a full volume", updated.AIReasoning)
+		case <-time.After(2 * time.Second):
+			t.Fatal("the pending analysis was never saved")
+		}
+
+		// The incident returned to the caller is not touched by the backfill
+		assert.Equal(t, domain.AnalysisPending, incident.AnalysisStatus)
+	})
+
+	t.Run("failed background analysis is left for reprocessing", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithAIBudget(20*time.Millisecond))
+
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+			After(100*time.Millisecond).
+			Return(nil, errors.New("AI service unavailable"))
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		backfilled := make(chan *domain.Incident, 1)
+		mockRepo.On("SaveAnalysis", mock.Anything, mock.Anything, domain.AnalysisPending).Run(func(args mock.Arguments) {
+			backfilled <- args.Get(1).(*domain.Incident)
+		}).Return(true, nil)
+		mockRepo.On("GetByIDForWrite", mock.Anything, mock.Anything).Return(&domain.Incident{}, nil)
+
+		_, err := useCase.CreateIncident(context.Background(), req)
+		assert.NoError(t, err)
+
+		select {
+		case updated := <-backfilled:
+			assert.Equal(t, domain.AnalysisFailed, updated.AnalysisStatus)
+			assert.Equal(t, domain.DefaultSeverity, updated.AISeverity)
+		case <-time.After(2 * time.Second):
+			t.Fatal("the failed analysis was never saved")
+		}
+	})
+
+	t.Run("background analysis is discarded once the incident is no longer pending", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithAIBudget(20*time.Millisecond))
+
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+			After(100*time.Millisecond).
+			Return(analysis, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		attempted := make(chan struct{}, 1)
+		mockRepo.On("SaveAnalysis", mock.Anything, mock.Anything, domain.AnalysisPending).Run(func(args mock.Arguments) {
+			attempted <- struct{}{}
+		}).Return(false, nil)
+
+		_, err := useCase.CreateIncident(context.Background(), req)
+		assert.NoError(t, err)
+
+		select {
+		case <-attempted:
+		case <-time.After(2 * time.Second):
+			t.Fatal("the pending analysis was never saved")
+		}
+		time.Sleep(20 * time.Millisecond)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "GetByIDForWrite", mock.Anything, mock.Anything)
+	})
+
+	t.Run("analysis within the budget completes normally", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithAIBudget(time.Second))
+
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).Return(analysis, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		incident, err := useCase.CreateIncident(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AnalysisComplete, incident.AnalysisStatus)
+		assert.Equal(t, "High", incident.AISeverity)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("analysis error within the budget fails the create", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithAIBudget(time.Second))
+
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).Return(nil, errors.New("AI service unavailable"))
+
+		_, err := useCase.CreateIncident(context.Background(), req)
+
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCreateIncident_RecordsTimings(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithClock(fixedClock))
+
+	req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Run(func(mock.Arguments) { fixedClock.Advance(1200 * time.Millisecond) }).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).
+		Run(func(mock.Arguments) { fixedClock.Advance(30 * time.Millisecond) }).
+		Return(nil)
+
+	incident, err := useCase.CreateIncident(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.CreateTimings{AIMs: 1200, DBMs: 30, TotalMs: 1230}, incident.Timings)
+}
+
+func TestImportIncidents_KeepsCSVSeveritiesWithoutAnalysis(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+	rows := []*domain.ImportRow{
+		{Line: 2, Request: &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}, Severity: "High", Category: "Hardware"},
+		{Line: 4, Request: &domain.CreateIncidentRequest{Title: "Slow queries", Description: "p99 at 4s", AffectedService: "db"}, Severity: "Low", Category: "Database"},
+	}
+	mockRepo.On("CreateBatch", mock.Anything, mock.MatchedBy(func(incidents []*domain.Incident) bool {
+		return len(incidents) == 2 && incidents[0].AISeverity == "High" && incidents[1].AICategory == "Database"
+	})).Run(func(args mock.Arguments) {
+		for i, incident := range args.Get(1).([]*domain.Incident) {
+			incident.ID = 20 + i
+		}
+	}).Return(nil)
+
+	results, err := useCase.ImportIncidents(context.Background(), rows, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.ImportRowResult{
+		{Line: 2, Outcome: domain.ImportCreated, IncidentID: 20},
+		{Line: 4, Outcome: domain.ImportCreated, IncidentID: 21},
+	}, results)
+	mockAI.AssertNotCalled(t, "AnalyzeIncident", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportIncidents_RejectsRowsWhoseAnalysisFails(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+	rows := []*domain.ImportRow{
+		{Line: 2, Request: &domain.CreateIncidentRequest{Title: "Outage", Description: "API down", AffectedService: "api"}},
+		{Line: 3, Request: &domain.CreateIncidentRequest{Title: "Latency", Description: "p99 at 4s", AffectedService: "api"}},
+	}
+	mockAI.On("AnalyzeIncident", mock.Anything, "Outage", "API down", "api").Return(&domain.IncidentAnalysis{Severity: "Critical", Category: "Network"}, nil)
+	mockAI.On("AnalyzeIncident", mock.Anything, "Latency", "p99 at 4s", "api").Return(nil, errors.New("AI service unavailable"))
+	mockRepo.On("CreateBatch", mock.Anything, mock.MatchedBy(func(incidents []*domain.Incident) bool {
+		return len(incidents) == 1 && incidents[0].AISeverity == "Critical"
+	})).Return(nil)
+
+	results, err := useCase.ImportIncidents(context.Background(), rows, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImportCreated, results[0].Outcome)
+	assert.Equal(t, 3, results[1].Line)
+	assert.Equal(t, domain.ImportRejected, results[1].Outcome)
+	assert.Contains(t, results[1].Error, "AI service unavailable")
+}
+
+func TestImportIncidents_StorageErrorFailsImport(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+	rows := []*domain.ImportRow{{Line: 2, Request: &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}, Severity: "High", Category: "Hardware"}}
+	mockRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+	results, err := useCase.ImportIncidents(context.Background(), rows, false)
+
+	assert.Error(t, err)
+	assert.Nil(t, results)
+}
+
+func TestReprocessFailedAnalyses_MixedOutcomes(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	clk := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithClock(clk))
+
+	recovered := &domain.Incident{ID: 1, Title: "Outage", Description: "API down", AffectedService: "api", AISeverity: domain.DefaultSeverity, AICategory: domain.DefaultCategory, AnalysisStatus: domain.AnalysisFailed}
+	stillFailing := &domain.Incident{ID: 2, Title: "Latency", Description: "p99 at 4s", AffectedService: "api", AISeverity: domain.DefaultSeverity, AICategory: domain.DefaultCategory, AnalysisStatus: domain.AnalysisFailed}
+	saveFails := &domain.Incident{ID: 3, Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage", AISeverity: domain.DefaultSeverity, AICategory: domain.DefaultCategory, AnalysisStatus: domain.AnalysisFailed}
+
+	lostPending := &domain.Incident{ID: 4, Title: "Cert expiry", Description: "TLS cert expired", AffectedService: "edge", AISeverity: domain.DefaultSeverity, AICategory: domain.DefaultCategory, AnalysisStatus: domain.AnalysisPending}
+	analyzedMeanwhile := &domain.Incident{ID: 5, Title: "Queue backlog", Description: "Consumers stalled", AffectedService: "jobs", AISeverity: domain.DefaultSeverity, AICategory: domain.DefaultCategory, AnalysisStatus: domain.AnalysisFailed}
+
+	mockRepo.On("GetUnfinishedAnalyses", mock.Anything, clk.Now().Add(-stalePendingAge), 100).
+		Return([]*domain.Incident{recovered, stillFailing, saveFails, lostPending, analyzedMeanwhile}, nil)
+	mockAI.On("AnalyzeIncident", mock.Anything, recovered.Title, recovered.Description, recovered.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "Critical", Category: "Network"}, nil)
+	mockAI.On("AnalyzeIncident", mock.Anything, stillFailing.Title, stillFailing.Description, stillFailing.AffectedService).
+		Return(nil, errors.New("AI service unavailable"))
+	mockAI.On("AnalyzeIncident", mock.Anything, saveFails.Title, saveFails.Description, saveFails.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware"}, nil)
+	mockAI.On("AnalyzeIncident", mock.Anything, lostPending.Title, lostPending.Description, lostPending.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Security"}, nil)
+	mockAI.On("AnalyzeIncident", mock.Anything, analyzedMeanwhile.Title, analyzedMeanwhile.Description, analyzedMeanwhile.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "Medium", Category: "Software"}, nil)
+	mockRepo.On("SaveAnalysis", mock.Anything, saveFails, domain.AnalysisFailed).Return(false, errors.New("connection reset"))
+	mockRepo.On("SaveAnalysis", mock.Anything, recovered, domain.AnalysisFailed).Return(true, nil)
+	mockRepo.On("SaveAnalysis", mock.Anything, lostPending, domain.AnalysisPending).Return(true, nil)
+	mockRepo.On("SaveAnalysis", mock.Anything, analyzedMeanwhile, domain.AnalysisFailed).Return(false, nil)
+	mockRepo.On("GetByIDForWrite", mock.Anything, recovered.ID).Return(&domain.Incident{ID: recovered.ID}, nil)
+	mockRepo.On("GetByIDForWrite", mock.Anything, lostPending.ID).Return(&domain.Incident{ID: lostPending.ID}, nil)
+
+	result, err := useCase.ReprocessFailedAnalyses(context.Background(), 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.ReprocessResult{Attempted: 5, Fixed: 3, StillFailing: 2}, result)
+	assert.Equal(t, "Critical", recovered.AISeverity)
+	assert.Equal(t, domain.AnalysisComplete, recovered.AnalysisStatus)
+	assert.Equal(t, "Security", lostPending.AICategory)
+	assert.Equal(t, domain.AnalysisFailed, stillFailing.AnalysisStatus)
+	mockRepo.AssertNotCalled(t, "SaveAnalysis", mock.Anything, stillFailing, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReprocessFailedAnalyses_RepositoryError(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+	mockRepo.On("GetUnfinishedAnalyses", mock.Anything, mock.Anything, 10).Return(nil, errors.New("database error"))
+
+	result, err := useCase.ReprocessFailedAnalyses(context.Background(), 10)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestRemapSeverity_AppliesMapping(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockHistory := new(MockHistoryRepository)
+	fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithHistory(mockHistory), WithClock(fixedClock))
+
+	mockRepo.On("GetIDsBySeverity", mock.Anything, "Low", 0, remapBatchSize).Return([]int{1, 4}, nil)
+	mockRepo.On("GetIDsBySeverity", mock.Anything, "Medium", 0, remapBatchSize).Return([]int{2}, nil)
+	mockRepo.On("UpdateSeverity", mock.Anything, []int{1, 4}, "Medium", fixedClock.Now()).Return(nil)
+	mockRepo.On("UpdateSeverity", mock.Anything, []int{2}, "High", fixedClock.Now()).Return(nil)
+	mockHistory.On("AddEntries", mock.Anything, mock.MatchedBy(func(entries []*domain.HistoryEntry) bool {
+		return len(entries) == 2 && entries[0].IncidentID == 1 && entries[1].IncidentID == 4 &&
+			entries[0].OldValue == "Low" && entries[0].NewValue == "Medium" && entries[0].Actor == domain.ActorAdmin
+	})).Return(nil).Once()
+	mockHistory.On("AddEntries", mock.Anything, mock.MatchedBy(func(entries []*domain.HistoryEntry) bool {
+		return len(entries) == 1 && entries[0].IncidentID == 2 && entries[0].NewValue == "High"
+	})).Return(nil).Once()
+
+	// Chained mappings move each incident once because IDs are collected before updating
+	result, err := useCase.RemapSeverity(context.Background(), map[string]string{"Low": "Medium", "Medium": "High"}, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.RemapResult{Remapped: map[string]int{"Low": 2, "Medium": 1}, Total: 3}, result)
+	mockRepo.AssertExpectations(t)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestRemapSeverity_DryRunChangesNothing(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+	page := make([]int, remapBatchSize)
+	for i := range page {
+		page[i] = i + 1
+	}
+	mockRepo.On("GetIDsBySeverity", mock.Anything, "Medium", 0, remapBatchSize).Return(page, nil)
+	mockRepo.On("GetIDsBySeverity", mock.Anything, "Medium", remapBatchSize, remapBatchSize).Return([]int{remapBatchSize + 1}, nil)
+
+	result, err := useCase.RemapSeverity(context.Background(), map[string]string{"Medium": "High"}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.RemapResult{DryRun: true, Remapped: map[string]int{"Medium": remapBatchSize + 1}, Total: remapBatchSize + 1}, result)
+	mockRepo.AssertNotCalled(t, "UpdateSeverity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReassignIncidents(t *testing.T) {
+	t.Run("reassigns every incident and records history", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockHistory := new(MockHistoryRepository)
+		fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithHistory(mockHistory), WithClock(fixedClock))
+
+		scope := domain.ReassignScope{Severity: "High"}
+		mockRepo.On("Reassign", mock.Anything, "alice", "bob", scope, fixedClock.Now()).Return([]int{3, 8, 13}, nil)
+		mockHistory.On("AddEntries", mock.Anything, mock.MatchedBy(func(entries []*domain.HistoryEntry) bool {
+			if len(entries) != 3 {
+				return false
+			}
+			for i, id := range []int{3, 8, 13} {
+				entry := entries[i]
+				if entry.IncidentID != id || entry.Field != domain.FieldAssignee || entry.OldValue != "alice" ||
+					entry.NewValue != "bob" || entry.Actor != domain.ActorAdmin || !entry.CreatedAt.Equal(fixedClock.Now()) {
+					return false
+				}
+			}
+			return true
+		})).Return(nil)
+
+		result, err := useCase.ReassignIncidents(context.Background(), "alice", "bob", scope)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.ReassignResult{Reassigned: 3, IncidentIDs: []int{3, 8, 13}}, result)
+		mockRepo.AssertExpectations(t)
+		mockHistory.AssertExpectations(t)
+	})
+
+	t.Run("nothing to reassign", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockHistory := new(MockHistoryRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithHistory(mockHistory))
+
+		mockRepo.On("Reassign", mock.Anything, "alice", "bob", domain.ReassignScope{}, mock.Anything).Return([]int{}, nil)
+
+		result, err := useCase.ReassignIncidents(context.Background(), "alice", "bob", domain.ReassignScope{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Reassigned)
+		mockHistory.AssertNotCalled(t, "AddEntries", mock.Anything, mock.Anything)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+		mockRepo.On("Reassign", mock.Anything, "alice", "bob", domain.ReassignScope{}, mock.Anything).Return(nil, errors.New("lock wait timeout"))
+
+		result, err := useCase.ReassignIncidents(context.Background(), "alice", "bob", domain.ReassignScope{})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestMarkFalsePositive(t *testing.T) {
+	t.Run("marks and records history", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockHistory := new(MockHistoryRepository)
+		fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithHistory(mockHistory), WithClock(fixedClock))
+
+		mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(&domain.Incident{ID: 7, Title: "Probe failed"}, nil)
+		mockRepo.On("MarkFalsePositive", mock.Anything, 7, "Synthetic probe", fixedClock.Now()).Return(nil)
+		mockHistory.On("AddEntries", mock.Anything, []*domain.HistoryEntry{{
+			IncidentID: 7,
+			Field:      domain.FieldFalsePositive,
+			NewValue:   "Synthetic probe",
+			Actor:      domain.ActorAPI,
+			CreatedAt:  fixedClock.Now(),
+		}}).Return(nil)
+
+		incident, err := useCase.MarkFalsePositive(context.Background(), 7, "Synthetic probe")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Synthetic probe", incident.FalsePositiveReason)
+		assert.Equal(t, fixedClock.Now(), incident.UpdatedAt)
+		mockRepo.AssertExpectations(t)
+		mockHistory.AssertExpectations(t)
+	})
+
+	t.Run("already marked", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+		mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(&domain.Incident{ID: 7, FalsePositiveReason: "Synthetic probe"}, nil)
+
+		_, err := useCase.MarkFalsePositive(context.Background(), 7, "Again")
+
+		assert.ErrorIs(t, err, domain.ErrFalsePositive)
+		mockRepo.AssertNotCalled(t, "MarkFalsePositive", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("passes the request context on and logs a history failure with its ID", func(t *testing.T) {
+		var logs bytes.Buffer
+		previous := slog.Default()
+		slog.SetDefault(logging.New(&logs))
+		defer slog.SetDefault(previous)
+
+		mockRepo := new(MockIncidentRepository)
+		mockHistory := new(MockHistoryRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithHistory(mockHistory))
+
+		inRequest := mock.MatchedBy(func(ctx context.Context) bool { return logging.RequestID(ctx) == "trace-7f3a" })
+		mockRepo.On("GetByIDForWrite", inRequest, 7).Return(&domain.Incident{ID: 7}, nil)
+		mockRepo.On("MarkFalsePositive", inRequest, 7, "Synthetic probe", mock.Anything).Return(nil)
+		mockHistory.On("AddEntries", mock.Anything, mock.Anything).Return(errors.New("history table locked"))
+
+		ctx := logging.WithRequestID(context.Background(), "trace-7f3a")
+		_, err := useCase.MarkFalsePositive(ctx, 7, "Synthetic probe")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(logs.Bytes(), &line))
+		assert.Equal(t, "trace-7f3a", line[logging.RequestIDKey])
+		assert.Equal(t, float64(7), line["incident_id"])
+		assert.Equal(t, "history table locked", line["error"])
+	})
+}
+
+// memorySequences is an in-process SequenceRepository
+type memorySequences struct {
+	values map[int]int
+	err    error
+}
+
+func (r *memorySequences) Next(ctx context.Context, year int) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	r.values[year]++
+	return r.values[year], nil
+}
+
+func TestCreateIncident_Reference(t *testing.T) {
+	req := &domain.CreateIncidentRequest{Title: "Checkout errors", Description: "5xx from checkout", AffectedService: "checkout"}
+	analysis := &domain.IncidentAnalysis{Severity: "High", Category: "Application"}
+
+	t.Run("numbered within the year of creation", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		fixedClock := clock.NewMock(time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC))
+		sequences := &memorySequences{values: map[int]int{2024: 122}}
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithClock(fixedClock), WithReferences(domain.DefaultReferenceFormat, sequences))
+
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).Return(analysis, nil)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool {
+			return incident.Reference == "INC-2024-000123"
+		})).Return(nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool {
+			return incident.Reference == "INC-2025-000001"
+		})).Return(nil).Once()
+
+		first, err := useCase.CreateIncident(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, "INC-2024-000123", first.Reference)
+
+		fixedClock.Advance(2 * time.Hour)
+		second, err := useCase.CreateIncident(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, "INC-2025-000001", second.Reference)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("sequence failure leaves no reference", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		sequences := &memorySequences{err: errors.New("lock wait timeout")}
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithReferences(domain.DefaultReferenceFormat, sequences))
+
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).Return(analysis, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		incident, err := useCase.CreateIncident(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Empty(t, incident.Reference)
+	})
+}
+
+func TestSetPriorityOverride(t *testing.T) {
+	t.Run("override takes precedence over the computed priority", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockHistory := new(MockHistoryRepository)
+		fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithHistory(mockHistory), WithClock(fixedClock))
+
+		mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(&domain.Incident{ID: 7, AISeverity: "Low"}, nil)
+		mockRepo.On("SetPriorityOverride", mock.Anything, 7, "P1", fixedClock.Now()).Return(nil)
+		mockHistory.On("AddEntries", mock.Anything, []*domain.HistoryEntry{{
+			IncidentID: 7,
+			Field:      domain.FieldPriority,
+			NewValue:   "P1",
+			Actor:      domain.ActorAPI,
+			CreatedAt:  fixedClock.Now(),
+		}}).Return(nil)
+
+		incident, err := useCase.SetPriorityOverride(context.Background(), 7, "P1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "P1", incident.PriorityOverride)
+		assert.Equal(t, "P1", incident.Priority)
+		assert.Equal(t, fixedClock.Now(), incident.UpdatedAt)
+		mockRepo.AssertExpectations(t)
+		mockHistory.AssertExpectations(t)
+	})
+
+	t.Run("clearing falls back to the computed priority", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+		mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(&domain.Incident{ID: 7, AISeverity: "High", PriorityOverride: "P4"}, nil)
+		mockRepo.On("SetPriorityOverride", mock.Anything, 7, "", mock.Anything).Return(nil)
+
+		incident, err := useCase.SetPriorityOverride(context.Background(), 7, "")
+
+		assert.NoError(t, err)
+		assert.Empty(t, incident.PriorityOverride)
+		assert.Equal(t, "P2", incident.Priority)
+	})
+
+	t.Run("false positive", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+		mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(&domain.Incident{ID: 7, FalsePositiveReason: "Synthetic probe"}, nil)
+
+		_, err := useCase.SetPriorityOverride(context.Background(), 7, "P1")
+
+		assert.ErrorIs(t, err, domain.ErrFalsePositive)
+		mockRepo.AssertNotCalled(t, "SetPriorityOverride", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetIncident_PriorityOverride(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+	users := domain.HighImpactUsers
+	mockRepo.On("GetByID", mock.Anything, 3).Return(&domain.Incident{ID: 3, AISeverity: "Critical", AffectedUsers: &users, PriorityOverride: "P3"}, nil)
+
+	incident, err := useCase.GetIncident(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "P3", incident.Priority)
+}
+
+func TestUpdateIncident_RejectsFalsePositive(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+	mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(&domain.Incident{ID: 7, FalsePositiveReason: "Synthetic probe"}, nil)
+
+	_, err := useCase.UpdateIncident(context.Background(), 7, &domain.CreateIncidentRequest{Title: "Probe failed", Description: "Again", AffectedService: "probe"})
+
+	assert.ErrorIs(t, err, domain.ErrFalsePositive)
+	mockAI.AssertNotCalled(t, "AnalyzeIncident", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetAllIncidents_IncludeFalsePositiveUsesFilter(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+	filter := &domain.IncidentFilter{IncludeFalsePositive: true}
+	mockRepo.On("GetAllFiltered", mock.Anything, filter).Return([]*domain.Incident{{ID: 7, FalsePositiveReason: "Synthetic probe"}}, nil)
+
+	incidents, err := useCase.GetAllIncidents(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Len(t, incidents, 1)
+	mockRepo.AssertNotCalled(t, "GetAll", mock.Anything, mock.Anything)
+}
+
+func TestIncidentUseCase_PublishesEvents(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	broker := NewBroker()
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithEventPublisher(broker))
+
+	events, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.Incident).ID = 5 }).
+		Return(nil)
+	mockRepo.On("Delete", mock.Anything, 5, domain.ActorAPI).Return(nil)
+
+	incident, err := useCase.CreateIncident(context.Background(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, useCase.DeleteIncident(context.Background(), 5))
+
+	created := <-events
+	assert.Equal(t, domain.EventCreated, created.Type)
+	assert.Equal(t, 5, created.ID)
+	assert.Equal(t, "High", created.Incident.AISeverity)
+	assert.Nil(t, created.Incident.Timings)
+	assert.NotSame(t, incident, created.Incident)
+
+	assert.Equal(t, &domain.IncidentEvent{Type: domain.EventDeleted, ID: 5}, <-events)
+}
+
+func TestCreateIncident_TriageMode(t *testing.T) {
+	off := domain.TriagePolicy{Mode: domain.TriageOff, DefaultSeverity: "Low", DefaultCategory: "Application"}
+
+	tests := []struct {
+		name             string
+		policy           domain.TriagePolicy
+		severity         string
+		category         string
+		expectedSeverity string
+		expectedCategory string
+	}{
+		{name: "off uses the request classification", policy: off, severity: "critical", category: "Database", expectedSeverity: "Critical", expectedCategory: "Database"},
+		{name: "off falls back to the defaults", policy: off, expectedSeverity: "Low", expectedCategory: "Application"},
+		{name: "on ignores the request classification", policy: domain.TriagePolicy{Mode: domain.TriageOn}, severity: "Low", category: "Database", expectedSeverity: "High", expectedCategory: "Hardware"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIncidentRepository)
+			mockAI := new(MockAIService)
+			useCase := NewIncidentUseCase(mockRepo, mockAI, WithTriagePolicy(tt.policy))
+
+			req := &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage", Severity: tt.severity, Category: tt.category}
+			if !tt.policy.Off() {
+				mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+					Return(&domain.IncidentAnalysis{Severity: "High", Category: "Hardware"}, nil)
+			}
+			mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			incident, err := useCase.CreateIncident(context.Background(), req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSeverity, incident.AISeverity)
+			assert.Equal(t, tt.expectedCategory, incident.AICategory)
+			assert.Equal(t, domain.AnalysisComplete, incident.AnalysisStatus)
+			if tt.policy.Off() {
+				mockAI.AssertNotCalled(t, "AnalyzeIncident", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockAI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTriageOff_UpdateAndReprocess(t *testing.T) {
+	off := domain.TriagePolicy{Mode: domain.TriageOff, DefaultSeverity: "Medium", DefaultCategory: "Software"}
+
+	t.Run("update keeps the classification unless the request sets it", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithTriagePolicy(off))
+
+		existing := &domain.Incident{ID: 1, Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage", AISeverity: "High", AICategory: "Hardware", AnalysisStatus: domain.AnalysisComplete}
+		mockRepo.On("GetByIDForWrite", mock.Anything, 1).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		req := &domain.CreateIncidentRequest{Title: "Disk still full", Description: "Root volume at 100%", AffectedService: "storage", Category: "Infrastructure"}
+		incident, err := useCase.UpdateIncident(context.Background(), 1, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "High", incident.AISeverity)
+		assert.Equal(t, "Infrastructure", incident.AICategory)
+		mockAI.AssertNotCalled(t, "AnalyzeIncident", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reprocessing is refused", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithTriagePolicy(off))
+
+		_, err := useCase.ReprocessFailedAnalyses(context.Background(), 10)
+
+		assert.ErrorIs(t, err, domain.ErrTriageOff)
+		mockRepo.AssertNotCalled(t, "GetUnfinishedAnalyses", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAffectedUsers_CreateAndUpdate(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+	users := 2500
+	req := &domain.CreateIncidentRequest{Title: "Login failures", Description: "SSO returns 500", AffectedService: "auth", AffectedUsers: &users}
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Application"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool {
+		return incident.AffectedUsers != nil && *incident.AffectedUsers == 2500
+	})).Return(nil)
+
+	incident, err := useCase.CreateIncident(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "P1", incident.Priority)
+
+	existing := &domain.Incident{ID: 1, Title: req.Title, Description: req.Description, AffectedService: req.AffectedService, AISeverity: "High", AICategory: "Application", AffectedUsers: &users}
+	mockRepo.On("GetByIDForWrite", mock.Anything, 1).Return(existing, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	updated, err := useCase.UpdateIncident(context.Background(), 1, &domain.CreateIncidentRequest{Title: req.Title, Description: req.Description, AffectedService: req.AffectedService})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2500, *updated.AffectedUsers)
+	assert.Equal(t, "P1", updated.Priority)
+}
+
+func TestCheckDataQuality(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	issues := []*domain.QualityCount{{Issue: domain.QualityUntrimmed, Count: 0}}
+
+	t.Run("trim runs before counting", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithClock(clock.NewMock(now)))
+
+		trim := mockRepo.On("TrimWhitespace", mock.Anything, now).Return(3, nil)
+		mockRepo.On("CountQualityIssues", mock.Anything, mock.Anything).Return(issues, nil).NotBefore(trim)
+
+		report, err := useCase.CheckDataQuality(context.Background(), domain.QualityFixTrim)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.QualityReport{Issues: issues, Fix: domain.QualityFixTrim, Fixed: 3}, report)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("report only changes nothing", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService))
+
+		mockRepo.On("CountQualityIssues", mock.Anything, mock.Anything).Return(issues, nil)
+
+		report, err := useCase.CheckDataQuality(context.Background(), "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, issues, report.Issues)
+		mockRepo.AssertNotCalled(t, "TrimWhitespace", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown fix", func(t *testing.T) {
+		useCase := NewIncidentUseCase(new(MockIncidentRepository), new(MockAIService))
+
+		_, err := useCase.CheckDataQuality(context.Background(), "delete")
+		assert.Error(t, err)
+	})
+}
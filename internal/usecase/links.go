@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+)
+
+const (
+	// suggestLinksLimit caps how many related incidents a create suggests
+	suggestLinksLimit = 5
+	// suggestLinksCandidates is how many recent incidents are compared by service and keywords
+	suggestLinksCandidates = 50
+	// minLinkSimilarity is the lowest embedding similarity suggested as related
+	minLinkSimilarity = 0.85
+	// minSharedKeywords is how many title keywords an incident of another service must share
+	minSharedKeywords = 2
+	// suggestLinksWindow bounds how far back related incidents are looked for
+	suggestLinksWindow = 7 * 24 * time.Hour
+)
+
+// stopWords are common title words that say nothing about what an incident is about
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "not": true,
+	"are": true, "was": true, "has": true, "have": true, "after": true, "into": true,
+}
+
+// suggestLinks finds recent incidents likely related to a new one: nearest neighbours by
+// embedding when one is given, and recent incidents of the same service or sharing title
+// keywords. Lookup failures are logged and yield fewer suggestions rather than failing the create.
+func (uc *IncidentUseCase) suggestLinks(ctx context.Context, incident *domain.Incident, embedding []float32) []*domain.SuggestedLink {
+	links := map[int]*domain.SuggestedLink{}
+
+	if embedding != nil {
+		matches, err := uc.similarity.FindNearest(ctx, embedding, incident.ID, suggestLinksLimit)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to find similar incidents to suggest", "incident_id", incident.ID, "error", err)
+		}
+		for _, match := range matches {
+			if match.Score < minLinkSimilarity {
+				continue
+			}
+			candidate, err := uc.incidentRepo.GetByID(ctx, match.IncidentID)
+			if err != nil || candidate.FalsePositiveReason != "" {
+				continue
+			}
+			link := newSuggestedLink(candidate)
+			link.Reasons = append(link.Reasons, domain.LinkReasonSimilar)
+			link.Score = match.Score
+			links[candidate.ID] = link
+		}
+	}
+
+	since := uc.clock.Now().Add(-suggestLinksWindow)
+	recent, err := uc.incidentRepo.GetRecent(ctx, since, incident.ID, suggestLinksCandidates)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to find recent incidents to suggest", "incident_id", incident.ID, "error", err)
+	}
+
+	keywords := titleKeywords(incident.Title)
+	for _, candidate := range recent {
+		sameService := strings.EqualFold(strings.TrimSpace(candidate.AffectedService), strings.TrimSpace(incident.AffectedService))
+		shared, overlap := keywordOverlap(keywords, titleKeywords(candidate.Title))
+		if !sameService && shared < minSharedKeywords {
+			continue
+		}
+
+		link, ok := links[candidate.ID]
+		if !ok {
+			link = newSuggestedLink(candidate)
+			link.Score = overlap
+			links[candidate.ID] = link
+		}
+		if shared > 0 {
+			link.Reasons = append(link.Reasons, domain.LinkReasonKeywords)
+		}
+		if sameService {
+			link.Reasons = append(link.Reasons, domain.LinkReasonSameService)
+		}
+	}
+
+	return rankLinks(links)
+}
+
+// newSuggestedLink builds a suggestion without reasons for candidate
+func newSuggestedLink(candidate *domain.Incident) *domain.SuggestedLink {
+	return &domain.SuggestedLink{
+		IncidentID:      candidate.ID,
+		Title:           candidate.Title,
+		AffectedService: candidate.AffectedService,
+	}
+}
+
+// rankLinks orders suggestions by how many reasons support them, then by score and recency
+// (higher IDs first), keeping at most suggestLinksLimit
+func rankLinks(links map[int]*domain.SuggestedLink) []*domain.SuggestedLink {
+	ranked := make([]*domain.SuggestedLink, 0, len(links))
+	for _, link := range links {
+		ranked = append(ranked, link)
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if len(a.Reasons) != len(b.Reasons) {
+			return len(a.Reasons) > len(b.Reasons)
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.IncidentID > b.IncidentID
+	})
+
+	if len(ranked) > suggestLinksLimit {
+		ranked = ranked[:suggestLinksLimit]
+	}
+	return ranked
+}
+
+// titleKeywords returns the distinct lower-case words of a title, ignoring short and stop words
+func titleKeywords(title string) map[string]bool {
+	keywords := map[string]bool{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) < 3 || stopWords[word] {
+			continue
+		}
+		keywords[word] = true
+	}
+	return keywords
+}
+
+// keywordOverlap returns how many keywords a and b share and the share of their union that is
+func keywordOverlap(a, b map[string]bool) (int, float64) {
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 0, 0
+	}
+	return shared, float64(shared) / float64(union)
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateIncident_SuggestedLinks(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	req := &domain.CreateIncidentRequest{Title: "Checkout payment timeouts", Description: "Card payments time out", AffectedService: "checkout"}
+	analysis := &domain.IncidentAnalysis{Severity: "High", Category: "Application"}
+
+	saveAs := func(id int) func(mock.Arguments) {
+		return func(args mock.Arguments) { args.Get(1).(*domain.Incident).ID = id }
+	}
+
+	t.Run("matching recent incidents are suggested", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		flags := NewStaticFlags(map[string]bool{domain.FlagSuggestLinks: true})
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithFeatureFlags(flags), WithClock(clock.NewMock(now)))
+
+		recent := []*domain.Incident{
+			{ID: 12, Title: "Payment timeouts on checkout", AffectedService: "checkout"},
+			{ID: 11, Title: "Payment gateway timeouts", AffectedService: "billing"},
+			{ID: 10, Title: "Disk full", AffectedService: "storage"},
+		}
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).Return(analysis, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Run(saveAs(13)).Return(nil)
+		mockRepo.On("GetRecent", mock.Anything, now.Add(-suggestLinksWindow), 13, suggestLinksCandidates).Return(recent, nil)
+
+		incident, err := useCase.CreateIncident(context.Background(), req)
+
+		assert.NoError(t, err)
+		if assert.Len(t, incident.SuggestedLinks, 2) {
+			assert.Equal(t, 12, incident.SuggestedLinks[0].IncidentID)
+			assert.Equal(t, []string{domain.LinkReasonKeywords, domain.LinkReasonSameService}, incident.SuggestedLinks[0].Reasons)
+			assert.Equal(t, 11, incident.SuggestedLinks[1].IncidentID)
+			assert.Equal(t, []string{domain.LinkReasonKeywords}, incident.SuggestedLinks[1].Reasons)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("similar embeddings are suggested", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		mockEmbedder := new(MockEmbeddingService)
+		mockEmbeddings := new(MockEmbeddingRepository)
+		flags := NewStaticFlags(map[string]bool{domain.FlagSuggestLinks: true})
+		useCase := NewIncidentUseCase(mockRepo, mockAI, WithEmbeddings(mockEmbedder, mockEmbeddings, mockEmbeddings),
+			WithFeatureFlags(flags), WithClock(clock.NewMock(now)))
+
+		embedding := []float32{0.1, 0.2}
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).Return(analysis, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Run(saveAs(13)).Return(nil)
+		mockEmbedder.On("EmbedText", mock.Anything, mock.Anything).Return(embedding, nil)
+		mockEmbeddings.On("SaveEmbedding", mock.Anything, 13, embedding).Return(nil)
+		mockEmbeddings.On("FindNearest", mock.Anything, embedding, 13, suggestLinksLimit).Return([]*domain.SimilarityMatch{
+			{IncidentID: 4, Score: 0.93},
+			{IncidentID: 5, Score: 0.40},
+		}, nil)
+		mockRepo.On("GetByID", mock.Anything, 4).Return(&domain.Incident{ID: 4, Title: "Card declines", AffectedService: "payments"}, nil)
+		mockRepo.On("GetRecent", mock.Anything, mock.Anything, 13, suggestLinksCandidates).Return([]*domain.Incident{}, nil)
+
+		incident, err := useCase.CreateIncident(context.Background(), req)
+
+		assert.NoError(t, err)
+		if assert.Len(t, incident.SuggestedLinks, 1) {
+			assert.Equal(t, 4, incident.SuggestedLinks[0].IncidentID)
+			assert.Equal(t, []string{domain.LinkReasonSimilar}, incident.SuggestedLinks[0].Reasons)
+			assert.Equal(t, 0.93, incident.SuggestedLinks[0].Score)
+		}
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, 5)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockAI := new(MockAIService)
+		useCase := NewIncidentUseCase(mockRepo, mockAI)
+
+		mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).Return(analysis, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		incident, err := useCase.CreateIncident(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.Empty(t, incident.SuggestedLinks)
+		mockRepo.AssertNotCalled(t, "GetRecent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTitleKeywords(t *testing.T) {
+	keywords := titleKeywords("The DB is down: replica lag after failover!")
+
+	assert.Equal(t, map[string]bool{"down": true, "replica": true, "lag": true, "failover": true}, keywords)
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+)
+
+// LogNotifier reports new incidents and digests in the server log
+type LogNotifier struct{}
+
+// NotifyIncident logs a new incident
+func (LogNotifier) NotifyIncident(incident *domain.Incident) {
+	slog.Info("New incident", "incident_id", incident.ID, "severity", incident.AISeverity,
+		"affected_service", incident.AffectedService, "title", incident.Title)
+}
+
+// NotifyDigest logs a digest with one line per service
+func (LogNotifier) NotifyDigest(digest *domain.IncidentDigest) {
+	slog.Info("Incident digest", "count", digest.Count(), "since", digest.Since.Format(time.RFC3339))
+	for _, service := range digest.Services {
+		titles := make([]string, len(service.Incidents))
+		for i, incident := range service.Incidents {
+			titles[i] = incident.Title
+		}
+		slog.Info("Incident digest service", "affected_service", service.Service,
+			"count", len(service.Incidents), "titles", strings.Join(titles, "; "))
+	}
+}
+
+// WithNotifier tells notifier about every created incident
+func WithNotifier(notifier domain.IncidentNotifier) Option {
+	return func(uc *IncidentUseCase) {
+		uc.notifier = notifier
+	}
+}
+
+// DigestNotifier decorates an IncidentNotifier, passing incidents of domain.DigestSeverities
+// on as a periodic digest and all others immediately. Start runs the schedule and Stop ends it,
+// sending the incidents still buffered.
+type DigestNotifier struct {
+	next     domain.IncidentNotifier
+	interval time.Duration
+	clock    clock.Clock
+
+	mu      sync.Mutex
+	since   time.Time
+	pending []*domain.Incident
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewDigestNotifier batches low-severity notifications to next into a digest every interval
+func NewDigestNotifier(next domain.IncidentNotifier, interval time.Duration, c clock.Clock) *DigestNotifier {
+	return &DigestNotifier{
+		next:     next,
+		interval: interval,
+		clock:    c,
+		since:    c.Now(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// NotifyIncident buffers an incident of a digest severity and passes any other on
+func (n *DigestNotifier) NotifyIncident(incident *domain.Incident) {
+	if _, ok := domain.CanonicalValue(domain.DigestSeverities, incident.AISeverity); !ok {
+		n.next.NotifyIncident(incident)
+		return
+	}
+
+	n.mu.Lock()
+	n.pending = append(n.pending, incident)
+	n.mu.Unlock()
+}
+
+// NotifyDigest passes a digest built elsewhere on unchanged
+func (n *DigestNotifier) NotifyDigest(digest *domain.IncidentDigest) {
+	n.next.NotifyDigest(digest)
+}
+
+// Flush sends the buffered incidents as one digest, grouped by service in name order, and
+// starts a new digest window. Nothing is sent when no incidents are buffered.
+func (n *DigestNotifier) Flush() {
+	n.mu.Lock()
+	now := n.clock.Now()
+	pending, since := n.pending, n.since
+	n.pending, n.since = nil, now
+	n.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	byService := map[string]*domain.ServiceDigest{}
+	digest := &domain.IncidentDigest{Since: since, Until: now}
+	for _, incident := range pending {
+		service, ok := byService[incident.AffectedService]
+		if !ok {
+			service = &domain.ServiceDigest{Service: incident.AffectedService}
+			byService[incident.AffectedService] = service
+			digest.Services = append(digest.Services, service)
+		}
+		service.Incidents = append(service.Incidents, incident)
+	}
+	sort.Slice(digest.Services, func(i, j int) bool {
+		return digest.Services[i].Service < digest.Services[j].Service
+	})
+
+	n.next.NotifyDigest(digest)
+}
+
+// Start flushes a digest every interval until Stop
+func (n *DigestNotifier) Start() {
+	go func() {
+		defer close(n.done)
+		ticker := n.clock.NewTicker(n.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C():
+				n.Flush()
+			case <-n.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the schedule started by Start and flushes the incidents still buffered, so none
+// are lost on shutdown
+func (n *DigestNotifier) Stop() {
+	close(n.stop)
+	<-n.done
+	n.Flush()
+}
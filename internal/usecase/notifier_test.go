@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier records every incident and digest it is told about
+type recordingNotifier struct {
+	mu        sync.Mutex
+	incidents []*domain.Incident
+	digests   []*domain.IncidentDigest
+}
+
+func (n *recordingNotifier) NotifyIncident(incident *domain.Incident) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.incidents = append(n.incidents, incident)
+}
+
+func (n *recordingNotifier) NotifyDigest(digest *domain.IncidentDigest) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.digests = append(n.digests, digest)
+}
+
+func TestDigestNotifier(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("batches low severities by service and sends the rest immediately", func(t *testing.T) {
+		fixedClock := clock.NewMock(start)
+		recorder := &recordingNotifier{}
+		digest := NewDigestNotifier(recorder, 10*time.Minute, fixedClock)
+
+		digest.NotifyIncident(&domain.Incident{ID: 1, AISeverity: "Low", AffectedService: "search"})
+		digest.NotifyIncident(&domain.Incident{ID: 2, AISeverity: "Critical", AffectedService: "checkout"})
+		digest.NotifyIncident(&domain.Incident{ID: 3, AISeverity: "Medium", AffectedService: "billing"})
+		digest.NotifyIncident(&domain.Incident{ID: 4, AISeverity: "Low", AffectedService: "search"})
+
+		if assert.Len(t, recorder.incidents, 1) {
+			assert.Equal(t, 2, recorder.incidents[0].ID)
+		}
+		assert.Empty(t, recorder.digests)
+
+		fixedClock.Advance(10 * time.Minute)
+		digest.Flush()
+
+		if assert.Len(t, recorder.digests, 1) {
+			sent := recorder.digests[0]
+			assert.Equal(t, start, sent.Since)
+			assert.Equal(t, start.Add(10*time.Minute), sent.Until)
+			assert.Equal(t, 3, sent.Count())
+			if assert.Len(t, sent.Services, 2) {
+				assert.Equal(t, "billing", sent.Services[0].Service)
+				assert.Equal(t, "search", sent.Services[1].Service)
+				assert.Equal(t, 1, sent.Services[1].Incidents[0].ID)
+				assert.Equal(t, 4, sent.Services[1].Incidents[1].ID)
+			}
+		}
+	})
+
+	t.Run("each window starts where the last ended and empty windows send nothing", func(t *testing.T) {
+		fixedClock := clock.NewMock(start)
+		recorder := &recordingNotifier{}
+		digest := NewDigestNotifier(recorder, 10*time.Minute, fixedClock)
+
+		fixedClock.Advance(10 * time.Minute)
+		digest.Flush()
+		assert.Empty(t, recorder.digests)
+
+		digest.NotifyIncident(&domain.Incident{ID: 5, AISeverity: "Low", AffectedService: "search"})
+		fixedClock.Advance(10 * time.Minute)
+		digest.Flush()
+
+		if assert.Len(t, recorder.digests, 1) {
+			assert.Equal(t, start.Add(10*time.Minute), recorder.digests[0].Since)
+			assert.Equal(t, start.Add(20*time.Minute), recorder.digests[0].Until)
+		}
+	})
+
+	t.Run("start flushes every interval", func(t *testing.T) {
+		fixedClock := clock.NewMock(start)
+		recorder := &recordingNotifier{}
+		digest := NewDigestNotifier(recorder, time.Hour, fixedClock)
+		digest.Start()
+		defer digest.Stop()
+		require.Eventually(t, func() bool { return fixedClock.Tickers() == 1 }, time.Second, time.Millisecond)
+
+		digest.NotifyIncident(&domain.Incident{ID: 7, AISeverity: "Low", AffectedService: "search"})
+		fixedClock.Advance(59 * time.Minute)
+		fixedClock.Advance(time.Minute)
+
+		sent := func() []*domain.IncidentDigest {
+			recorder.mu.Lock()
+			defer recorder.mu.Unlock()
+			return recorder.digests
+		}
+		require.Eventually(t, func() bool { return len(sent()) == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, start, sent()[0].Since)
+		assert.Equal(t, start.Add(time.Hour), sent()[0].Until)
+	})
+
+	t.Run("stop flushes pending incidents", func(t *testing.T) {
+		recorder := &recordingNotifier{}
+		digest := NewDigestNotifier(recorder, time.Hour, clock.NewMock(start))
+		digest.Start()
+
+		digest.NotifyIncident(&domain.Incident{ID: 6, AISeverity: "Medium", AffectedService: "search"})
+		digest.Stop()
+
+		if assert.Len(t, recorder.digests, 1) {
+			assert.Equal(t, 1, recorder.digests[0].Count())
+		}
+	})
+}
+
+func TestCreateIncident_Notifies(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	recorder := &recordingNotifier{}
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithNotifier(recorder))
+
+	req := &domain.CreateIncidentRequest{Title: "Checkout errors", Description: "5xx from checkout", AffectedService: "checkout"}
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Application"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) { args.Get(1).(*domain.Incident).ID = 8 }).Return(nil)
+
+	_, err := useCase.CreateIncident(context.Background(), req)
+
+	assert.NoError(t, err)
+	if assert.Len(t, recorder.incidents, 1) {
+		assert.Equal(t, 8, recorder.incidents[0].ID)
+	}
+}
+
+func TestCreateIncident_NotifiesPendingIncidentOnceAnalyzed(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	recorder := &recordingNotifier{}
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithNotifier(recorder), WithAIBudget(20*time.Millisecond))
+
+	req := &domain.CreateIncidentRequest{Title: "Checkout down", Description: "Every request fails", AffectedService: "checkout"}
+	mockAI.On("AnalyzeIncident", mock.Anything, req.Title, req.Description, req.AffectedService).
+		After(100*time.Millisecond).
+		Return(&domain.IncidentAnalysis{Severity: "Critical", Category: "Application"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) { args.Get(1).(*domain.Incident).ID = 9 }).Return(nil)
+	saved := make(chan struct{})
+	mockRepo.On("SaveAnalysis", mock.Anything, mock.Anything, domain.AnalysisPending).Return(true, nil)
+	mockRepo.On("GetByIDForWrite", mock.Anything, 9).Run(func(args mock.Arguments) { close(saved) }).
+		Return(&domain.Incident{ID: 9, AISeverity: "Critical", AnalysisStatus: domain.AnalysisComplete}, nil)
+
+	incident, err := useCase.CreateIncident(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AnalysisPending, incident.AnalysisStatus)
+	recorder.mu.Lock()
+	assert.Empty(t, recorder.incidents)
+	recorder.mu.Unlock()
+
+	select {
+	case <-saved:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the pending analysis was never saved")
+	}
+	assert.Eventually(t, func() bool {
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+		return len(recorder.incidents) == 1 && recorder.incidents[0].AISeverity == "Critical"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestImportIncidents_DoesNotNotify(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	recorder := &recordingNotifier{}
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithNotifier(recorder))
+
+	rows := []*domain.ImportRow{
+		{Line: 2, Request: &domain.CreateIncidentRequest{Title: "Disk full", Description: "Root volume at 100%", AffectedService: "storage"}, Severity: "Critical", Category: "Hardware"},
+	}
+	mockRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := useCase.ImportIncidents(context.Background(), rows, false)
+
+	assert.NoError(t, err)
+	assert.Empty(t, recorder.incidents)
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+)
+
+// reprocessBatch caps how many incidents one scheduled reprocess retries, like the default
+// limit of the admin endpoint
+const reprocessBatch = 100
+
+// FailedAnalysisReprocessor retries the AI analyses that failed or were lost
+type FailedAnalysisReprocessor interface {
+	ReprocessFailedAnalyses(ctx context.Context, limit int) (*domain.ReprocessResult, error)
+}
+
+// ReprocessScheduler retries failed analyses on a fixed interval, so incidents saved with
+// default triage, e.g. batch items whose AI call failed, are not left for an admin to notice.
+// Start runs the schedule and Stop ends it.
+type ReprocessScheduler struct {
+	reprocessor FailedAnalysisReprocessor
+	every       time.Duration
+	clock       clock.Clock
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewReprocessScheduler reprocesses the failed analyses of reprocessor every interval
+func NewReprocessScheduler(reprocessor FailedAnalysisReprocessor, every time.Duration, c clock.Clock) *ReprocessScheduler {
+	return &ReprocessScheduler{
+		reprocessor: reprocessor,
+		every:       every,
+		clock:       c,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// Start reprocesses failed analyses every interval until Stop
+func (s *ReprocessScheduler) Start() {
+	go func() {
+		defer close(s.done)
+		ticker := s.clock.NewTicker(s.every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C():
+				s.run()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// run retries one batch of failed analyses and logs the outcome when there was anything to do
+func (s *ReprocessScheduler) run() {
+	result, err := s.reprocessor.ReprocessFailedAnalyses(context.Background(), reprocessBatch)
+	if err != nil {
+		slog.Error("Failed to reprocess failed analyses", "error", err)
+		return
+	}
+	if result.Attempted > 0 {
+		slog.Info("Reprocessed failed analyses", "attempted", result.Attempted,
+			"fixed", result.Fixed, "still_failing", result.StillFailing)
+	}
+}
+
+// Stop ends the schedule started by Start, waiting for a reprocess in progress to finish
+func (s *ReprocessScheduler) Stop() {
+	close(s.stop)
+	<-s.done
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signallingReprocessor reports the limit of every reprocess it is asked for
+type signallingReprocessor struct {
+	limits chan int
+}
+
+func (r *signallingReprocessor) ReprocessFailedAnalyses(ctx context.Context, limit int) (*domain.ReprocessResult, error) {
+	r.limits <- limit
+	return &domain.ReprocessResult{}, nil
+}
+
+func TestReprocessScheduler(t *testing.T) {
+	fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	reprocessor := &signallingReprocessor{limits: make(chan int, 1)}
+	scheduler := NewReprocessScheduler(reprocessor, 5*time.Minute, fixedClock)
+	scheduler.Start()
+	require.Eventually(t, func() bool { return fixedClock.Tickers() == 1 }, time.Second, time.Millisecond)
+
+	fixedClock.Advance(4 * time.Minute)
+	assert.Empty(t, reprocessor.limits)
+
+	fixedClock.Advance(time.Minute)
+	select {
+	case limit := <-reprocessor.limits:
+		assert.Equal(t, reprocessBatch, limit)
+	case <-time.After(time.Second):
+		t.Fatal("no reprocess after the interval")
+	}
+
+	scheduler.Stop()
+	assert.Zero(t, fixedClock.Tickers())
+}
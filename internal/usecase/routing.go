@@ -0,0 +1,22 @@
+package usecase
+
+import "incident-triage-assistant/internal/domain"
+
+// CategoryRouter routes incidents to teams by AI category, falling back to a default route
+type CategoryRouter struct {
+	routes   map[string]domain.TeamRoute
+	fallback *domain.TeamRoute
+}
+
+// NewCategoryRouter creates a router from a category-to-route map and an optional fallback route
+func NewCategoryRouter(routes map[string]domain.TeamRoute, fallback *domain.TeamRoute) *CategoryRouter {
+	return &CategoryRouter{routes: routes, fallback: fallback}
+}
+
+// Route returns the route for a category, the fallback route if the category is unmapped, or nil
+func (r *CategoryRouter) Route(category string) *domain.TeamRoute {
+	if route, ok := r.routes[category]; ok {
+		return &route
+	}
+	return r.fallback
+}
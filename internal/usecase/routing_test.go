@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"testing"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryRouter_Route(t *testing.T) {
+	router := NewCategoryRouter(map[string]domain.TeamRoute{
+		"Database": {Team: "database", Channel: "#db-oncall"},
+	}, &domain.TeamRoute{Team: "operations", Channel: "#incidents"})
+
+	assert.Equal(t, &domain.TeamRoute{Team: "database", Channel: "#db-oncall"}, router.Route("Database"))
+	assert.Equal(t, &domain.TeamRoute{Team: "operations", Channel: "#incidents"}, router.Route("Network"))
+
+	noFallback := NewCategoryRouter(map[string]domain.TeamRoute{}, nil)
+	assert.Nil(t, noFallback.Route("Network"))
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+)
+
+// WithRunbooks enables runbook checklists stored in runbooks
+func WithRunbooks(runbooks domain.RunbookRepository) Option {
+	return func(uc *IncidentUseCase) {
+		uc.runbooks = runbooks
+	}
+}
+
+// AddRunbookStep appends a pending step to the runbook of an incident
+func (uc *IncidentUseCase) AddRunbookStep(ctx context.Context, incidentID int, step string) (*domain.RunbookStep, error) {
+	if uc.runbooks == nil {
+		return nil, domain.ErrRunbooksUnavailable
+	}
+	if _, err := uc.incidentRepo.GetByIDForWrite(ctx, incidentID); err != nil {
+		return nil, err
+	}
+
+	runbookStep := &domain.RunbookStep{
+		IncidentID: incidentID,
+		Step:       step,
+		CreatedAt:  uc.clock.Now(),
+	}
+	if err := uc.runbooks.AddStep(ctx, runbookStep); err != nil {
+		return nil, err
+	}
+	return runbookStep, nil
+}
+
+// ListRunbookSteps returns the runbook of an incident in the order its steps were added
+func (uc *IncidentUseCase) ListRunbookSteps(ctx context.Context, incidentID int) ([]*domain.RunbookStep, error) {
+	if uc.runbooks == nil {
+		return nil, domain.ErrRunbooksUnavailable
+	}
+	if _, err := uc.incidentRepo.GetByID(ctx, incidentID); err != nil {
+		return nil, err
+	}
+	return uc.runbooks.GetSteps(ctx, incidentID)
+}
+
+// CompleteRunbookStep marks a step of an incident's runbook done by doneBy and records the
+// completion in the incident history
+func (uc *IncidentUseCase) CompleteRunbookStep(ctx context.Context, incidentID, stepID int, doneBy string) (*domain.RunbookStep, error) {
+	if uc.runbooks == nil {
+		return nil, domain.ErrRunbooksUnavailable
+	}
+	if _, err := uc.incidentRepo.GetByIDForWrite(ctx, incidentID); err != nil {
+		return nil, err
+	}
+
+	steps, err := uc.runbooks.GetSteps(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	var step *domain.RunbookStep
+	for _, s := range steps {
+		if s.ID == stepID {
+			step = s
+			break
+		}
+	}
+	if step == nil {
+		return nil, fmt.Errorf("step %d of incident %d: %w", stepID, incidentID, domain.ErrStepNotFound)
+	}
+	if step.Done {
+		return nil, fmt.Errorf("step %d of incident %d: %w", stepID, incidentID, domain.ErrStepDone)
+	}
+
+	now := uc.clock.Now()
+	if err := uc.runbooks.CompleteStep(ctx, incidentID, stepID, doneBy, now); err != nil {
+		return nil, err
+	}
+	step.Done = true
+	step.DoneBy = doneBy
+	step.DoneAt = &now
+
+	if uc.historyRepo != nil {
+		entry := &domain.HistoryEntry{
+			IncidentID: incidentID,
+			Field:      domain.FieldRunbookStep,
+			NewValue:   fmt.Sprintf("%s (done by %s)", step.Step, doneBy),
+			Actor:      domain.ActorAPI,
+			CreatedAt:  now,
+		}
+		if err := uc.historyRepo.AddEntries(ctx, []*domain.HistoryEntry{entry}); err != nil {
+			logging.FromContext(ctx).Error("Failed to record history", "incident_id", incidentID, "error", err)
+		}
+	}
+
+	return step, nil
+}
+
+// runbookCompletion reports how much of an incident's runbook is done. A failed count is
+// logged and leaves the completion out rather than failing the read.
+func (uc *IncidentUseCase) runbookCompletion(ctx context.Context, incident *domain.Incident) {
+	if uc.runbooks == nil {
+		return
+	}
+
+	total, done, err := uc.runbooks.CountSteps(ctx, incident.ID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count runbook steps", "incident_id", incident.ID, "error", err)
+		return
+	}
+	incident.RunbookCompletion = domain.RunbookCompletion(total, done)
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryRunbooks is an in-process RunbookRepository
+type memoryRunbooks struct {
+	steps []*domain.RunbookStep
+}
+
+func (r *memoryRunbooks) AddStep(ctx context.Context, step *domain.RunbookStep) error {
+	step.ID = len(r.steps) + 1
+	stored := *step
+	r.steps = append(r.steps, &stored)
+	return nil
+}
+
+func (r *memoryRunbooks) GetSteps(ctx context.Context, incidentID int) ([]*domain.RunbookStep, error) {
+	steps := []*domain.RunbookStep{}
+	for _, step := range r.steps {
+		if step.IncidentID == incidentID {
+			copied := *step
+			steps = append(steps, &copied)
+		}
+	}
+	return steps, nil
+}
+
+func (r *memoryRunbooks) CompleteStep(ctx context.Context, incidentID, stepID int, doneBy string, doneAt time.Time) error {
+	for _, step := range r.steps {
+		if step.ID == stepID && step.IncidentID == incidentID {
+			if step.Done {
+				return fmt.Errorf("step %d: %w", stepID, domain.ErrStepDone)
+			}
+			step.Done, step.DoneBy, step.DoneAt = true, doneBy, &doneAt
+			return nil
+		}
+	}
+	return fmt.Errorf("step %d: %w", stepID, domain.ErrStepNotFound)
+}
+
+func (r *memoryRunbooks) CountSteps(ctx context.Context, incidentID int) (int, int, error) {
+	total, done := 0, 0
+	for _, step := range r.steps {
+		if step.IncidentID == incidentID {
+			total++
+			if step.Done {
+				done++
+			}
+		}
+	}
+	return total, done, nil
+}
+
+func TestRunbookSteps(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	incident := func() *domain.Incident {
+		return &domain.Incident{ID: 7, Title: "Checkout errors", AISeverity: "High", CreatedAt: now}
+	}
+
+	t.Run("steps are added pending and listed in order", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		runbooks := &memoryRunbooks{}
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithRunbooks(runbooks), WithClock(clock.NewMock(now)))
+		mockRepo.On("GetByID", mock.Anything, 7).Return(incident(), nil)
+		mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(incident(), nil)
+
+		first, err := useCase.AddRunbookStep(context.Background(), 7, "Fail over the primary")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, first.ID)
+		assert.False(t, first.Done)
+		assert.Equal(t, now, first.CreatedAt)
+		_, err = useCase.AddRunbookStep(context.Background(), 7, "Flush the CDN")
+		assert.NoError(t, err)
+
+		steps, err := useCase.ListRunbookSteps(context.Background(), 7)
+		assert.NoError(t, err)
+		if assert.Len(t, steps, 2) {
+			assert.Equal(t, "Fail over the primary", steps[0].Step)
+			assert.Equal(t, "Flush the CDN", steps[1].Step)
+		}
+	})
+
+	t.Run("completing a step records who did it and history", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		mockHistory := new(MockHistoryRepository)
+		runbooks := &memoryRunbooks{}
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithRunbooks(runbooks), WithHistory(mockHistory), WithClock(clock.NewMock(now)))
+		mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(incident(), nil)
+		mockHistory.On("AddEntries", mock.Anything, []*domain.HistoryEntry{{
+			IncidentID: 7,
+			Field:      domain.FieldRunbookStep,
+			NewValue:   "Fail over the primary (done by alice)",
+			Actor:      domain.ActorAPI,
+			CreatedAt:  now,
+		}}).Return(nil)
+
+		added, err := useCase.AddRunbookStep(context.Background(), 7, "Fail over the primary")
+		assert.NoError(t, err)
+
+		step, err := useCase.CompleteRunbookStep(context.Background(), 7, added.ID, "alice")
+		assert.NoError(t, err)
+		assert.True(t, step.Done)
+		assert.Equal(t, "alice", step.DoneBy)
+		assert.Equal(t, now, *step.DoneAt)
+		mockHistory.AssertExpectations(t)
+
+		_, err = useCase.CompleteRunbookStep(context.Background(), 7, added.ID, "bob")
+		assert.ErrorIs(t, err, domain.ErrStepDone)
+	})
+
+	t.Run("unknown step", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithRunbooks(&memoryRunbooks{}))
+		mockRepo.On("GetByIDForWrite", mock.Anything, 7).Return(incident(), nil)
+
+		_, err := useCase.CompleteRunbookStep(context.Background(), 7, 3, "alice")
+
+		assert.ErrorIs(t, err, domain.ErrStepNotFound)
+	})
+
+	t.Run("missing incident", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		runbooks := &memoryRunbooks{}
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithRunbooks(runbooks))
+		mockRepo.On("GetByIDForWrite", mock.Anything, 9).Return(nil, domain.ErrNotFound)
+
+		_, err := useCase.AddRunbookStep(context.Background(), 9, "Fail over the primary")
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Empty(t, runbooks.steps)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		useCase := NewIncidentUseCase(new(MockIncidentRepository), new(MockAIService))
+
+		_, err := useCase.ListRunbookSteps(context.Background(), 7)
+
+		assert.ErrorIs(t, err, domain.ErrRunbooksUnavailable)
+	})
+}
+
+func TestGetIncident_RunbookCompletion(t *testing.T) {
+	tests := []struct {
+		name     string
+		steps    []*domain.RunbookStep
+		expected *int
+	}{
+		{name: "no steps", steps: nil, expected: nil},
+		{
+			name: "one of three done",
+			steps: []*domain.RunbookStep{
+				{ID: 1, IncidentID: 7, Done: true},
+				{ID: 2, IncidentID: 7},
+				{ID: 3, IncidentID: 7},
+				{ID: 4, IncidentID: 8, Done: true},
+			},
+			expected: intPtr(33),
+		},
+		{
+			name:     "all done",
+			steps:    []*domain.RunbookStep{{ID: 1, IncidentID: 7, Done: true}},
+			expected: intPtr(100),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockIncidentRepository)
+			useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithRunbooks(&memoryRunbooks{steps: tt.steps}))
+			mockRepo.On("GetByID", mock.Anything, 7).Return(&domain.Incident{ID: 7, AISeverity: "High"}, nil)
+
+			incident, err := useCase.GetIncident(context.Background(), 7)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, incident.RunbookCompletion)
+			mockRepo.AssertCalled(t, "GetByID", mock.Anything, 7)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// redactedPlaceholder replaces any text matching a redaction pattern
+const redactedPlaceholder = "[REDACTED]"
+
+// DefaultRedactPatterns match common credential formats that should never be stored or sent to the AI
+var DefaultRedactPatterns = []string{
+	`sk-[A-Za-z0-9_-]{16,}`,             // OpenAI-style secret keys
+	`AKIA[0-9A-Z]{16}`,                  // AWS access key IDs
+	`gh[pousr]_[A-Za-z0-9]{30,}`,        // GitHub tokens
+	`xox[abprs]-[A-Za-z0-9-]{10,}`,      // Slack tokens
+	`(?i)bearer\s+[A-Za-z0-9._~+/-]+=*`, // Bearer tokens
+	`(?i)\b(api[_-]?key|secret|token|password|passwd)\b\s*[:=]\s*[^\s,;]+`, // key=value credentials
+}
+
+var (
+	horizontalSpace = regexp.MustCompile(`[ \t]+`)
+	excessNewlines  = regexp.MustCompile(`\n{3,}`)
+)
+
+// Sanitizer cleans user-supplied incident text before it is persisted or sent to the AI
+type Sanitizer struct {
+	redactPatterns []*regexp.Regexp
+}
+
+// NewSanitizer creates a sanitizer that redacts text matching any of the given patterns
+func NewSanitizer(patterns []string) (*Sanitizer, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return &Sanitizer{redactPatterns: compiled}, nil
+}
+
+// SanitizeLine cleans single-line text such as titles: control characters are removed,
+// all whitespace is collapsed to single spaces and credentials are redacted
+func (s *Sanitizer) SanitizeLine(text string) string {
+	text = stripControl(text, false)
+	text = strings.Join(strings.Fields(text), " ")
+	return s.redact(text)
+}
+
+// SanitizeText cleans multi-line text such as descriptions: control characters are removed,
+// runs of spaces are collapsed, blank-line runs are limited and credentials are redacted
+func (s *Sanitizer) SanitizeText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = stripControl(text, true)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(horizontalSpace.ReplaceAllString(line, " "))
+	}
+	text = excessNewlines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return s.redact(strings.TrimSpace(text))
+}
+
+// redact replaces every match of the configured patterns with a placeholder
+func (s *Sanitizer) redact(text string) string {
+	for _, re := range s.redactPatterns {
+		text = re.ReplaceAllString(text, redactedPlaceholder)
+	}
+	return text
+}
+
+// stripControl removes control characters, optionally keeping newlines and tabs
+func stripControl(text string, keepNewlines bool) string {
+	return strings.Map(func(r rune) rune {
+		if keepNewlines && (r == '\n' || r == '\t') {
+			return r
+		}
+		if r == '\t' || r == '\n' {
+			return ' '
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+)
+
+// Scheduler runs a background job on a fixed interval, logging its failures. Start runs the
+// schedule and Stop ends it.
+type Scheduler struct {
+	name  string
+	every time.Duration
+	clock clock.Clock
+	run   func(ctx context.Context) error
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewScheduler runs the job called name every interval
+func NewScheduler(name string, every time.Duration, c clock.Clock, run func(ctx context.Context) error) *Scheduler {
+	return &Scheduler{
+		name:  name,
+		every: every,
+		clock: c,
+		run:   run,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// Start runs the job every interval until Stop
+func (s *Scheduler) Start() {
+	go func() {
+		defer close(s.done)
+		ticker := s.clock.NewTicker(s.every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C():
+				if err := s.run(context.Background()); err != nil {
+					slog.Error("Scheduled job failed", "job", s.name, "error", err)
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the schedule started by Start, waiting for a run in progress to finish
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	<-s.done
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	runs := make(chan time.Time, 1)
+	scheduler := NewScheduler("test", time.Minute, fixedClock, func(ctx context.Context) error {
+		runs <- fixedClock.Now()
+		return nil
+	})
+	scheduler.Start()
+	require.Eventually(t, func() bool { return fixedClock.Tickers() == 1 }, time.Second, time.Millisecond)
+
+	fixedClock.Advance(30 * time.Second)
+	assert.Empty(t, runs)
+
+	fixedClock.Advance(30 * time.Second)
+	select {
+	case ranAt := <-runs:
+		assert.Equal(t, time.Date(2024, 6, 1, 12, 1, 0, 0, time.UTC), ranAt)
+	case <-time.After(time.Second):
+		t.Fatal("the job did not run after the interval")
+	}
+
+	scheduler.Stop()
+	assert.Zero(t, fixedClock.Tickers())
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+)
+
+// StaticServiceDirectory serves service metadata from an in-memory map, matching service
+// names case-insensitively
+type StaticServiceDirectory struct {
+	services map[string]domain.ServiceMetadata
+}
+
+// NewStaticServiceDirectory creates a directory from a service-to-metadata map
+func NewStaticServiceDirectory(services map[string]domain.ServiceMetadata) *StaticServiceDirectory {
+	normalized := make(map[string]domain.ServiceMetadata, len(services))
+	for service, metadata := range services {
+		normalized[serviceKey(service)] = metadata
+	}
+	return &StaticServiceDirectory{services: normalized}
+}
+
+// Lookup returns the metadata of service, or nil when it is unknown
+func (d *StaticServiceDirectory) Lookup(service string) (*domain.ServiceMetadata, error) {
+	metadata, ok := d.services[serviceKey(service)]
+	if !ok {
+		return nil, nil
+	}
+	return &metadata, nil
+}
+
+// cachedLookup is a remembered directory answer; a nil metadata records an unknown service
+type cachedLookup struct {
+	metadata  *domain.ServiceMetadata
+	fetchedAt time.Time
+}
+
+// CachedServiceDirectory remembers the answers of another directory for a ttl, so listings
+// that repeat a service look it up once. Unknown services are cached too; errors are not.
+type CachedServiceDirectory struct {
+	next  domain.ServiceDirectory
+	ttl   time.Duration
+	clock clock.Clock
+
+	mu      sync.Mutex
+	entries map[string]cachedLookup
+}
+
+// NewCachedServiceDirectory wraps next with a cache whose entries expire after ttl
+func NewCachedServiceDirectory(next domain.ServiceDirectory, ttl time.Duration, clk clock.Clock) *CachedServiceDirectory {
+	return &CachedServiceDirectory{next: next, ttl: ttl, clock: clk, entries: map[string]cachedLookup{}}
+}
+
+// Lookup returns the cached metadata of service, asking the wrapped directory once the entry
+// is missing or expired
+func (d *CachedServiceDirectory) Lookup(service string) (*domain.ServiceMetadata, error) {
+	key := serviceKey(service)
+	now := d.clock.Now()
+
+	d.mu.Lock()
+	entry, ok := d.entries[key]
+	d.mu.Unlock()
+	if ok && now.Sub(entry.fetchedAt) < d.ttl {
+		return entry.metadata, nil
+	}
+
+	metadata, err := d.next.Lookup(service)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	d.entries[key] = cachedLookup{metadata: metadata, fetchedAt: now}
+	d.mu.Unlock()
+	return metadata, nil
+}
+
+// serviceKey normalizes a service name for lookups
+func serviceKey(service string) string {
+	return strings.ToLower(strings.TrimSpace(service))
+}
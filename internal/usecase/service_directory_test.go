@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockServiceDirectory is a mock implementation of ServiceDirectory
+type MockServiceDirectory struct {
+	mock.Mock
+}
+
+func (m *MockServiceDirectory) Lookup(service string) (*domain.ServiceMetadata, error) {
+	args := m.Called(service)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ServiceMetadata), args.Error(1)
+}
+
+func TestStaticServiceDirectory_Lookup(t *testing.T) {
+	directory := NewStaticServiceDirectory(map[string]domain.ServiceMetadata{
+		"Payment-Gateway": {OwnerTeam: "payments", OnCall: "#payments-oncall", RunbookURL: "https://runbooks.example.com/pg"},
+	})
+
+	metadata, err := directory.Lookup(" payment-gateway ")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.ServiceMetadata{OwnerTeam: "payments", OnCall: "#payments-oncall", RunbookURL: "https://runbooks.example.com/pg"}, metadata)
+
+	metadata, err = directory.Lookup("search-api")
+	assert.NoError(t, err)
+	assert.Nil(t, metadata)
+}
+
+func TestCachedServiceDirectory_Lookup(t *testing.T) {
+	known := &domain.ServiceMetadata{OwnerTeam: "payments"}
+
+	t.Run("caches known and unknown services until the ttl passes", func(t *testing.T) {
+		next := new(MockServiceDirectory)
+		fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+		directory := NewCachedServiceDirectory(next, time.Minute, fixedClock)
+
+		next.On("Lookup", "payment-gateway").Return(known, nil).Twice()
+		next.On("Lookup", "search-api").Return(nil, nil).Once()
+
+		for i := 0; i < 3; i++ {
+			metadata, err := directory.Lookup("payment-gateway")
+			assert.NoError(t, err)
+			assert.Equal(t, known, metadata)
+
+			metadata, err = directory.Lookup("search-api")
+			assert.NoError(t, err)
+			assert.Nil(t, metadata)
+		}
+
+		fixedClock.Advance(time.Minute)
+		metadata, err := directory.Lookup("payment-gateway")
+		assert.NoError(t, err)
+		assert.Equal(t, known, metadata)
+		next.AssertExpectations(t)
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		next := new(MockServiceDirectory)
+		directory := NewCachedServiceDirectory(next, time.Minute, clock.NewMock(time.Now()))
+
+		next.On("Lookup", "payment-gateway").Return(nil, errors.New("catalog unavailable")).Once()
+		next.On("Lookup", "payment-gateway").Return(known, nil).Once()
+
+		_, err := directory.Lookup("payment-gateway")
+		assert.Error(t, err)
+
+		metadata, err := directory.Lookup("payment-gateway")
+		assert.NoError(t, err)
+		assert.Equal(t, known, metadata)
+		next.AssertExpectations(t)
+	})
+}
+
+func TestGetIncident_AttachesServiceMetadata(t *testing.T) {
+	directory := NewStaticServiceDirectory(map[string]domain.ServiceMetadata{
+		"payment-gateway": {OwnerTeam: "payments", OnCall: "#payments-oncall"},
+	})
+
+	t.Run("known service", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithServiceDirectory(directory))
+		mockRepo.On("GetByID", mock.Anything, 1).Return(&domain.Incident{ID: 1, AffectedService: "payment-gateway", CreatedAt: time.Now()}, nil)
+
+		incident, err := useCase.GetIncident(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.ServiceMetadata{OwnerTeam: "payments", OnCall: "#payments-oncall"}, incident.ServiceMetadata)
+	})
+
+	t.Run("unknown service", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithServiceDirectory(directory))
+		mockRepo.On("GetByID", mock.Anything, 2).Return(&domain.Incident{ID: 2, AffectedService: "search-api", CreatedAt: time.Now()}, nil)
+
+		incident, err := useCase.GetIncident(context.Background(), 2)
+
+		assert.NoError(t, err)
+		assert.Nil(t, incident.ServiceMetadata)
+	})
+
+	t.Run("lookup errors leave the metadata empty", func(t *testing.T) {
+		mockRepo := new(MockIncidentRepository)
+		failing := new(MockServiceDirectory)
+		useCase := NewIncidentUseCase(mockRepo, new(MockAIService), WithServiceDirectory(failing))
+		mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]*domain.Incident{{ID: 3, AffectedService: "payment-gateway", CreatedAt: time.Now()}}, nil)
+		failing.On("Lookup", "payment-gateway").Return(nil, errors.New("catalog unavailable"))
+
+		incidents, err := useCase.GetAllIncidents(context.Background(), &domain.IncidentFilter{})
+
+		assert.NoError(t, err)
+		assert.Len(t, incidents, 1)
+		assert.Nil(t, incidents[0].ServiceMetadata)
+	})
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+)
+
+// LogStormNotifier reports alert storms in the server log
+type LogStormNotifier struct{}
+
+// NotifyStorm logs the start of an alert storm
+func (LogStormNotifier) NotifyStorm(storm *domain.Incident) {
+	slog.Warn("Alert storm", "affected_service", storm.AffectedService, "incident_id", storm.ID)
+}
+
+// stormLimiter counts creates per affected service in fixed windows. Once a service exceeds
+// the limit, its further creates are suppressed into one storm incident until a whole window
+// passes at or below the limit.
+type stormLimiter struct {
+	limit    int
+	window   time.Duration
+	notifier domain.StormNotifier
+
+	mu        sync.Mutex
+	services  map[string]*serviceStorm
+	lastSweep time.Time
+}
+
+// serviceStorm is the rate and storm state of one affected service
+type serviceStorm struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	count       int
+
+	// storm is the open storm incident and suppressed the number of incidents counted in it
+	storm      *domain.Incident
+	suppressed int
+
+	// evicted is set once the state is dropped from the limiter, so a caller that looked it
+	// up just before must look again
+	evicted bool
+}
+
+// WithStormLimit suppresses creates beyond limit per affected service within window into a
+// single alert storm incident, telling notifier once per storm. A nil notifier logs storms.
+func WithStormLimit(limit int, window time.Duration, notifier domain.StormNotifier) Option {
+	return func(uc *IncidentUseCase) {
+		if notifier == nil {
+			notifier = LogStormNotifier{}
+		}
+		uc.storms = &stormLimiter{limit: limit, window: window, notifier: notifier, services: make(map[string]*serviceStorm)}
+	}
+}
+
+// service returns the state of an affected service, creating it on first use. At most once a
+// window it first drops the services that are idle, so the map does not grow with every
+// affected service ever seen.
+func (l *stormLimiter) service(name string, now time.Time) *serviceStorm {
+	key := strings.ToLower(strings.TrimSpace(name))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.lastSweep) >= l.window {
+		l.sweep(now)
+		l.lastSweep = now
+	}
+
+	s, ok := l.services[key]
+	if !ok {
+		s = &serviceStorm{}
+		l.services[key] = s
+	}
+	return s
+}
+
+// sweep drops the services whose last window was followed by a whole window without creates.
+// Such a service is not storming, so forgetting it changes nothing. l.mu must be held.
+func (l *stormLimiter) sweep(now time.Time) {
+	for key, s := range l.services {
+		s.mu.Lock()
+		if now.Sub(s.windowStart) >= 2*l.window {
+			s.evicted = true
+			delete(l.services, key)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// suppressStorm counts a create for the request's affected service. When the service is
+// storming it records the create in the storm incident and returns a copy of it marked
+// Suppressed; otherwise it returns nil and the create goes ahead.
+func (uc *IncidentUseCase) suppressStorm(ctx context.Context, req *domain.CreateIncidentRequest) (*domain.Incident, error) {
+	l := uc.storms
+	now := uc.clock.Now()
+
+	var s *serviceStorm
+	for {
+		s = l.service(req.AffectedService, now)
+		s.mu.Lock()
+		if !s.evicted {
+			break
+		}
+		s.mu.Unlock()
+	}
+	defer s.mu.Unlock()
+
+	if now.Sub(s.windowStart) >= l.window {
+		if s.count <= l.limit {
+			s.storm = nil
+			s.suppressed = 0
+		}
+		s.windowStart = now
+		s.count = 0
+	}
+	s.count++
+	if s.storm == nil && s.count <= l.limit {
+		return nil, nil
+	}
+
+	eventType := domain.EventUpdated
+	if s.storm != nil {
+		// Only the count is written, so responder edits to the storm incident are kept
+		err := uc.incidentRepo.SetStormCount(ctx, s.storm.ID, s.suppressed+1, now)
+		switch {
+		case errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrDeleted) || errors.Is(err, domain.ErrFalsePositive):
+			logging.FromContext(ctx).Info("Alert storm incident was closed, opening a new one", "incident_id", s.storm.ID, "reason", err)
+			s.storm = nil
+			s.suppressed = 0
+		case err != nil:
+			logging.FromContext(ctx).Error("Failed to update alert storm incident", "incident_id", s.storm.ID, "error", err)
+		}
+	}
+
+	s.suppressed++
+	if s.storm == nil {
+		eventType = domain.EventCreated
+		storm := &domain.Incident{
+			Title:           "Alert storm: " + req.AffectedService,
+			Description:     fmt.Sprintf("More than %d incidents for %s within %s. Further incidents are counted in %s until the rate drops.", l.limit, req.AffectedService, l.window, domain.StormCountField),
+			AffectedService: req.AffectedService,
+			AISeverity:      "High",
+			AICategory:      domain.DefaultCategory,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+			CustomFields:    map[string]interface{}{domain.StormCountField: s.suppressed},
+			AnalysisStatus:  domain.AnalysisComplete,
+		}
+		uc.reference(ctx, storm)
+		if err := uc.incidentRepo.Create(ctx, storm); err != nil {
+			s.suppressed--
+			return nil, fmt.Errorf("failed to create alert storm incident: %w", err)
+		}
+		s.storm = storm
+		l.notifier.NotifyStorm(storm)
+	}
+
+	suppressed := *s.storm
+	suppressed.CustomFields = map[string]interface{}{domain.StormCountField: s.suppressed}
+	suppressed.UpdatedAt = now
+	suppressed.Suppressed = true
+	uc.decorate(ctx, &suppressed)
+	uc.publish(eventType, &suppressed)
+	if eventType == domain.EventCreated {
+		uc.notify(&suppressed)
+	}
+	return &suppressed, nil
+}
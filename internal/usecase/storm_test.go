@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+	"incident-triage-assistant/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingStormNotifier records every storm it is told about
+type recordingStormNotifier struct {
+	storms []*domain.Incident
+}
+
+func (n *recordingStormNotifier) NotifyStorm(storm *domain.Incident) {
+	n.storms = append(n.storms, storm)
+}
+
+func TestCreateIncident_StormLimit(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	notifier := &recordingStormNotifier{}
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithClock(fixedClock), WithStormLimit(2, time.Minute, notifier))
+
+	checkout := &domain.CreateIncidentRequest{Title: "5xx", Description: "Checkout errors", AffectedService: "checkout"}
+	search := &domain.CreateIncidentRequest{Title: "Slow", Description: "Search latency", AffectedService: "search"}
+	mockAI.On("AnalyzeIncident", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Software"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool { return incident.Title == "Alert storm: checkout" })).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.Incident).ID = 99 }).
+		Return(nil).Once()
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(nil)
+	mockRepo.On("SetStormCount", mock.Anything, 99, mock.Anything, mock.Anything).Return(nil)
+
+	create := func(req *domain.CreateIncidentRequest) *domain.Incident {
+		incident, err := useCase.CreateIncident(context.Background(), req)
+		assert.NoError(t, err)
+		return incident
+	}
+
+	// Up to the limit, creates go through
+	assert.False(t, create(checkout).Suppressed)
+	assert.False(t, create(checkout).Suppressed)
+
+	// Beyond it, creates fold into one storm incident and the notifier fires once
+	storm := create(checkout)
+	assert.True(t, storm.Suppressed)
+	assert.Equal(t, 99, storm.ID)
+	assert.Equal(t, 1, storm.CustomFields[domain.StormCountField])
+
+	storm = create(checkout)
+	assert.Equal(t, 99, storm.ID)
+	assert.Equal(t, 2, storm.CustomFields[domain.StormCountField])
+	assert.Len(t, notifier.storms, 1)
+
+	// Other services are unaffected
+	assert.False(t, create(search).Suppressed)
+
+	// The storm lasts through the next window because the last one was over the limit
+	fixedClock.Advance(time.Minute)
+	storm = create(checkout)
+	assert.True(t, storm.Suppressed)
+	assert.Equal(t, 3, storm.CustomFields[domain.StormCountField])
+
+	// Once a window passes at or below the limit, creation resumes
+	fixedClock.Advance(time.Minute)
+	assert.False(t, create(checkout).Suppressed)
+
+	mockRepo.AssertCalled(t, "SetStormCount", mock.Anything, 99, 2, fixedClock.Now().Add(-2*time.Minute))
+	mockRepo.AssertCalled(t, "SetStormCount", mock.Anything, 99, 3, fixedClock.Now().Add(-time.Minute))
+	mockRepo.AssertNumberOfCalls(t, "SetStormCount", 2)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	mockAI.AssertNumberOfCalls(t, "AnalyzeIncident", 4)
+}
+
+func TestCreateIncident_StormLimitReopensClosedStorms(t *testing.T) {
+	for _, closed := range []error{domain.ErrNotFound, domain.ErrDeleted, domain.ErrFalsePositive} {
+		t.Run(closed.Error(), func(t *testing.T) {
+			mockRepo := new(MockIncidentRepository)
+			mockAI := new(MockAIService)
+			notifier := &recordingStormNotifier{}
+			useCase := NewIncidentUseCase(mockRepo, mockAI, WithStormLimit(1, time.Minute, notifier))
+
+			req := &domain.CreateIncidentRequest{Title: "5xx", Description: "Checkout errors", AffectedService: "checkout"}
+			mockAI.On("AnalyzeIncident", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(&domain.IncidentAnalysis{Severity: "High", Category: "Software"}, nil)
+			mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool { return incident.Title == "Alert storm: checkout" })).
+				Run(func(args mock.Arguments) { args.Get(1).(*domain.Incident).ID = 99 }).
+				Return(nil).Once()
+			mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(incident *domain.Incident) bool { return incident.Title == "Alert storm: checkout" })).
+				Run(func(args mock.Arguments) { args.Get(1).(*domain.Incident).ID = 100 }).
+				Return(nil).Once()
+			mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(nil)
+			mockRepo.On("SetStormCount", mock.Anything, 99, 2, mock.Anything).Return(closed)
+
+			_, err := useCase.CreateIncident(context.Background(), req)
+			assert.NoError(t, err)
+			storm, err := useCase.CreateIncident(context.Background(), req)
+			assert.NoError(t, err)
+			assert.Equal(t, 99, storm.ID)
+
+			// The responder closed the storm incident, so the next suppressed create opens a new one
+			storm, err = useCase.CreateIncident(context.Background(), req)
+			assert.NoError(t, err)
+			assert.True(t, storm.Suppressed)
+			assert.Equal(t, 100, storm.ID)
+			assert.Equal(t, 1, storm.CustomFields[domain.StormCountField])
+			assert.Len(t, notifier.storms, 2)
+		})
+	}
+}
+
+func TestCreateIncident_StormLimitForgetsIdleServices(t *testing.T) {
+	mockRepo := new(MockIncidentRepository)
+	mockAI := new(MockAIService)
+	fixedClock := clock.NewMock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	useCase := NewIncidentUseCase(mockRepo, mockAI, WithClock(fixedClock), WithStormLimit(2, time.Minute, &recordingStormNotifier{}))
+
+	mockAI.On("AnalyzeIncident", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&domain.IncidentAnalysis{Severity: "High", Category: "Software"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Incident")).Return(nil)
+
+	for _, service := range []string{"api", "search", "checkout"} {
+		_, err := useCase.CreateIncident(context.Background(), &domain.CreateIncidentRequest{Title: "5xx", Description: "Errors", AffectedService: service})
+		assert.NoError(t, err)
+	}
+	assert.Len(t, useCase.storms.services, 3)
+
+	// A window later the services are still tracked: their last window may yet be followed by
+	// one over the limit
+	fixedClock.Advance(time.Minute)
+	_, err := useCase.CreateIncident(context.Background(), &domain.CreateIncidentRequest{Title: "5xx", Description: "Errors", AffectedService: "api"})
+	assert.NoError(t, err)
+	assert.Len(t, useCase.storms.services, 3)
+
+	// Once a whole window has passed without creates, idle services are forgotten
+	fixedClock.Advance(2 * time.Minute)
+	_, err = useCase.CreateIncident(context.Background(), &domain.CreateIncidentRequest{Title: "Slow", Description: "Latency", AffectedService: "billing"})
+	assert.NoError(t, err)
+	assert.Len(t, useCase.storms.services, 1)
+	assert.Contains(t, useCase.storms.services, "billing")
+}
+
+func TestLogStormNotifier_WarnLevel(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&logs))
+	defer slog.SetDefault(previous)
+
+	LogStormNotifier{}.NotifyStorm(&domain.Incident{ID: 9, AffectedService: "checkout"})
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &line))
+	assert.Equal(t, "WARN", line["level"])
+	assert.Equal(t, "checkout", line["affected_service"])
+	assert.Equal(t, float64(9), line["incident_id"])
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// severeIncidentsInWindow is the number of severe incidents found by the last trend check
+var severeIncidentsInWindow = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "severe_incidents_in_window",
+	Help: "High and Critical incidents created within the last TREND_ALERT_WINDOW, as of the last trend check.",
+})
+
+// trendAlerter watches the rate of severe incidents. After an alert it stays quiet for a whole
+// window, until the incidents that raised the alert have left the window.
+type trendAlerter struct {
+	counter  domain.SevereIncidentCounter
+	notifier domain.TrendNotifier
+	policy   domain.TrendPolicy
+
+	mu        sync.Mutex
+	alertedAt time.Time
+}
+
+// WithSeverityTrend alerts notifier when the rate of severe incidents counted by counter
+// rises as policy decides
+func WithSeverityTrend(counter domain.SevereIncidentCounter, notifier domain.TrendNotifier, policy domain.TrendPolicy) Option {
+	return func(uc *IncidentUseCase) {
+		uc.trend = &trendAlerter{counter: counter, notifier: notifier, policy: policy}
+	}
+}
+
+// NotifyTrend logs a spike in severe incidents
+func (LogNotifier) NotifyTrend(alert *domain.TrendAlert) {
+	slog.Warn("Severe incidents spiking", "count", alert.Count, "previous_count", alert.PreviousCount,
+		"since", alert.Since, "until", alert.Until)
+}
+
+// CheckSeverityTrend counts the severe incidents of the current window and of the one before,
+// publishes the current count as a metric and alerts when the policy finds it alarming. It
+// returns the alert sent, or nil when the trend is calm or an alert was sent within the window.
+func (uc *IncidentUseCase) CheckSeverityTrend(ctx context.Context) (*domain.TrendAlert, error) {
+	if uc.trend == nil || !uc.trend.policy.Enabled() {
+		return nil, nil
+	}
+	t := uc.trend
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := uc.clock.Now()
+	since := now.Add(-t.policy.Window)
+	count, err := t.counter.CountSevereIncidents(ctx, since, now)
+	if err != nil {
+		return nil, err
+	}
+	severeIncidentsInWindow.Set(float64(count))
+
+	previous, err := t.counter.CountSevereIncidents(ctx, since.Add(-t.policy.Window), since)
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.policy.Alarming(count, previous) {
+		return nil, nil
+	}
+	if !t.alertedAt.IsZero() && now.Before(t.alertedAt.Add(t.policy.Window)) {
+		return nil, nil
+	}
+
+	alert := &domain.TrendAlert{Count: count, PreviousCount: previous, Since: since, Until: now}
+	t.alertedAt = now
+	if t.notifier != nil {
+		t.notifier.NotifyTrend(alert)
+	}
+	return alert, nil
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"incident-triage-assistant/internal/clock"
+	"incident-triage-assistant/internal/domain"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySevereIncidents is an in-process SevereIncidentCounter over the creation times of
+// severe incidents
+type memorySevereIncidents struct {
+	createdAt []time.Time
+	err       error
+}
+
+func (c *memorySevereIncidents) CountSevereIncidents(ctx context.Context, since, until time.Time) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	count := 0
+	for _, createdAt := range c.createdAt {
+		if !createdAt.Before(since) && createdAt.Before(until) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// add records n severe incidents created at the given time
+func (c *memorySevereIncidents) add(n int, createdAt time.Time) {
+	for i := 0; i < n; i++ {
+		c.createdAt = append(c.createdAt, createdAt)
+	}
+}
+
+// recordingTrendNotifier remembers every trend alert it was asked to deliver
+type recordingTrendNotifier struct {
+	alerts []*domain.TrendAlert
+}
+
+func (n *recordingTrendNotifier) NotifyTrend(alert *domain.TrendAlert) {
+	n.alerts = append(n.alerts, alert)
+}
+
+func TestCheckSeverityTrend(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newUseCase := func(policy domain.TrendPolicy) (*IncidentUseCase, *clock.Mock, *memorySevereIncidents, *recordingTrendNotifier) {
+		fixedClock := clock.NewMock(start)
+		counter := &memorySevereIncidents{}
+		notifier := &recordingTrendNotifier{}
+		useCase := NewIncidentUseCase(new(MockIncidentRepository), new(MockAIService), WithClock(fixedClock),
+			WithSeverityTrend(counter, notifier, policy))
+		return useCase, fixedClock, counter, notifier
+	}
+
+	t.Run("alerts above the threshold", func(t *testing.T) {
+		useCase, _, counter, notifier := newUseCase(domain.TrendPolicy{Window: time.Hour, Threshold: 3})
+		counter.add(3, start.Add(-10*time.Minute))
+
+		alert, err := useCase.CheckSeverityTrend(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+		assert.Equal(t, float64(3), testutil.ToFloat64(severeIncidentsInWindow))
+
+		counter.add(1, start.Add(-5*time.Minute))
+		alert, err = useCase.CheckSeverityTrend(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, alert)
+		assert.Equal(t, 4, alert.Count)
+		assert.Equal(t, start.Add(-time.Hour), alert.Since)
+		assert.Equal(t, start, alert.Until)
+		assert.Equal(t, []*domain.TrendAlert{alert}, notifier.alerts)
+		assert.Equal(t, float64(4), testutil.ToFloat64(severeIncidentsInWindow))
+	})
+
+	t.Run("alerts when the count spikes against the prior window", func(t *testing.T) {
+		useCase, _, counter, notifier := newUseCase(domain.TrendPolicy{Window: time.Hour, SpikeFactor: 3})
+		counter.add(2, start.Add(-90*time.Minute))
+		counter.add(5, start.Add(-10*time.Minute))
+
+		alert, err := useCase.CheckSeverityTrend(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, alert, "five after two is not three times as many")
+
+		counter.add(1, start.Add(-5*time.Minute))
+		alert, err = useCase.CheckSeverityTrend(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, alert)
+		assert.Equal(t, 6, alert.Count)
+		assert.Equal(t, 2, alert.PreviousCount)
+		assert.Len(t, notifier.alerts, 1)
+	})
+
+	t.Run("an empty prior window counts as one incident", func(t *testing.T) {
+		useCase, _, counter, _ := newUseCase(domain.TrendPolicy{Window: time.Hour, SpikeFactor: 3})
+		counter.add(2, start.Add(-10*time.Minute))
+
+		alert, err := useCase.CheckSeverityTrend(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+
+		counter.add(1, start.Add(-5*time.Minute))
+		alert, err = useCase.CheckSeverityTrend(context.Background())
+		require.NoError(t, err)
+		assert.NotNil(t, alert)
+	})
+
+	t.Run("stays quiet for a window after an alert", func(t *testing.T) {
+		useCase, fixedClock, counter, notifier := newUseCase(domain.TrendPolicy{Window: time.Hour, Threshold: 2})
+		counter.add(3, start.Add(-10*time.Minute))
+
+		alert, err := useCase.CheckSeverityTrend(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, alert)
+
+		// Still alarming, but the alert was sent less than a window ago
+		fixedClock.Set(start.Add(30 * time.Minute))
+		counter.add(3, start.Add(20*time.Minute))
+		alert, err = useCase.CheckSeverityTrend(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+
+		fixedClock.Set(start.Add(time.Hour))
+		alert, err = useCase.CheckSeverityTrend(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, alert)
+		assert.Equal(t, 3, alert.Count)
+		assert.Len(t, notifier.alerts, 2)
+	})
+
+	t.Run("count failure", func(t *testing.T) {
+		useCase, _, counter, notifier := newUseCase(domain.TrendPolicy{Window: time.Hour, Threshold: 1})
+		counter.err = errors.New("connection lost")
+
+		_, err := useCase.CheckSeverityTrend(context.Background())
+		assert.Error(t, err)
+		assert.Empty(t, notifier.alerts)
+	})
+
+	t.Run("off", func(t *testing.T) {
+		useCase, _, counter, notifier := newUseCase(domain.TrendPolicy{})
+		counter.add(10, start.Add(-time.Minute))
+
+		alert, err := useCase.CheckSeverityTrend(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+		assert.Empty(t, notifier.alerts)
+	})
+}
@@ -0,0 +1 @@
+ALTER TABLE incidents MODIFY description TEXT NOT NULL;
@@ -0,0 +1,3 @@
+-- TEXT holds 65535 bytes, which is fewer than 65535 utf8mb4 characters.
+-- MEDIUMTEXT fits the description length limit enforced by the API.
+ALTER TABLE incidents MODIFY description MEDIUMTEXT NOT NULL;
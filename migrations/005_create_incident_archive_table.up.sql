@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS incident_archive (
+    incident_id INT PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    ai_severity VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NULL,
+    deleted_at TIMESTAMP NULL,
+    purged_by VARCHAR(100) NOT NULL,
+    purged_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_incident_archive_purged_at (purged_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
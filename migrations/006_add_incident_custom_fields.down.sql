@@ -0,0 +1,2 @@
+ALTER TABLE incidents DROP INDEX idx_incidents_cf_region, DROP COLUMN cf_region;
+ALTER TABLE incidents DROP COLUMN custom_fields;
@@ -0,0 +1,7 @@
+ALTER TABLE incidents ADD COLUMN custom_fields JSON NULL;
+
+-- Index the commonly filtered custom fields through generated columns. MySQL uses these
+-- indexes for filters written as custom_fields->>'$.<key>' = ?.
+ALTER TABLE incidents
+    ADD COLUMN cf_region VARCHAR(255) AS (custom_fields->>'$.region') VIRTUAL,
+    ADD INDEX idx_incidents_cf_region (cf_region);
@@ -0,0 +1 @@
+ALTER TABLE incidents DROP COLUMN ai_suggested_action;
@@ -0,0 +1 @@
+ALTER TABLE incidents ADD COLUMN ai_suggested_action VARCHAR(500) NULL AFTER ai_category;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS team_rotation;
+ALTER TABLE incidents DROP COLUMN assignee;
@@ -0,0 +1,7 @@
+ALTER TABLE incidents ADD COLUMN assignee VARCHAR(100) NULL AFTER affected_service;
+
+-- One row per team holding its round-robin position, advanced atomically on every assignment
+CREATE TABLE IF NOT EXISTS team_rotation (
+    team VARCHAR(100) PRIMARY KEY,
+    position INT NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1 @@
+ALTER TABLE incidents DROP INDEX idx_incidents_analysis_status, DROP COLUMN analysis_status;
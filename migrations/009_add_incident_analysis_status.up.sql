@@ -0,0 +1,4 @@
+-- Incidents whose AI analysis failed keep default severity and category until reprocessed
+ALTER TABLE incidents
+    ADD COLUMN analysis_status VARCHAR(20) NOT NULL DEFAULT 'complete',
+    ADD INDEX idx_incidents_analysis_status (analysis_status);
@@ -0,0 +1 @@
+DROP INDEX idx_incidents_title_service ON incidents;
@@ -0,0 +1,2 @@
+-- Backs duplicate lookups and the locking read of strict uniqueness mode
+CREATE INDEX idx_incidents_title_service ON incidents (title, affected_service);
@@ -0,0 +1 @@
+ALTER TABLE incidents DROP COLUMN false_positive_reason;
@@ -0,0 +1,2 @@
+-- Incidents marked as false positives keep the reason and drop out of the default list and triage queue
+ALTER TABLE incidents ADD COLUMN false_positive_reason VARCHAR(500) NULL;
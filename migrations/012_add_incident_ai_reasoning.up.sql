@@ -0,0 +1,2 @@
+-- The AI's short explanation of the severity and category it assigned
+ALTER TABLE incidents ADD COLUMN ai_reasoning VARCHAR(500) NULL AFTER ai_suggested_action;
@@ -0,0 +1,2 @@
+-- Set when the description was shortened to fit the AI prompt budget before analysis
+ALTER TABLE incidents ADD COLUMN ai_input_truncated BOOLEAN NOT NULL DEFAULT FALSE AFTER ai_reasoning;
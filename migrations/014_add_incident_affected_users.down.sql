@@ -0,0 +1 @@
+ALTER TABLE incidents DROP INDEX idx_incidents_affected_users, DROP COLUMN affected_users;
@@ -0,0 +1,4 @@
+-- Optional number of users affected by the incident, used for filtering and priority
+ALTER TABLE incidents
+    ADD COLUMN affected_users INT UNSIGNED NULL AFTER ai_input_truncated,
+    ADD INDEX idx_incidents_affected_users (affected_users);
@@ -0,0 +1,3 @@
+-- Priority set by a responder, taking precedence over the priority computed from severity
+-- and affected users
+ALTER TABLE incidents ADD COLUMN priority_override VARCHAR(2) NULL AFTER affected_users;
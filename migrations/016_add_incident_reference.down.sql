@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS incident_sequence;
+ALTER TABLE incidents DROP INDEX idx_reference;
+ALTER TABLE incidents DROP COLUMN reference;
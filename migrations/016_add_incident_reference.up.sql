@@ -0,0 +1,8 @@
+ALTER TABLE incidents ADD COLUMN reference VARCHAR(32) NULL AFTER id;
+ALTER TABLE incidents ADD UNIQUE INDEX idx_reference (reference);
+
+-- One row per year holding the last incident reference sequence number, advanced atomically on every create
+CREATE TABLE IF NOT EXISTS incident_sequence (
+    year SMALLINT PRIMARY KEY,
+    value INT NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
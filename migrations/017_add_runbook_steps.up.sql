@@ -0,0 +1,11 @@
+-- Remediation steps responders attach to an incident and check off
+CREATE TABLE IF NOT EXISTS runbook_steps (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    incident_id INT NOT NULL,
+    step VARCHAR(500) NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    done_by VARCHAR(100) NULL,
+    done_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_runbook_steps_incident (incident_id, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
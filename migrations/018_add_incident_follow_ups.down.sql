@@ -0,0 +1 @@
+DROP TABLE IF EXISTS incident_follow_ups;
@@ -0,0 +1,9 @@
+-- Recurring reminders nudging the assignee of a long-running incident
+CREATE TABLE IF NOT EXISTS incident_follow_ups (
+    incident_id INT PRIMARY KEY,
+    interval_seconds INT UNSIGNED NOT NULL,
+    next_at TIMESTAMP NOT NULL,
+    nudges INT UNSIGNED NOT NULL DEFAULT 0,
+    last_nudged_at TIMESTAMP NULL,
+    INDEX idx_follow_ups_next_at (next_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
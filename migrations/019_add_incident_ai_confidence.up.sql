@@ -0,0 +1,3 @@
+-- The AI's confidence in its classification, from 0 to 1; NULL when the severity and category
+-- did not come from an AI analysis
+ALTER TABLE incidents ADD COLUMN ai_confidence DOUBLE NULL AFTER ai_input_truncated;
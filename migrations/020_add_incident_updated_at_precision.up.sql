@@ -0,0 +1,2 @@
+-- Microsecond precision so list ETags change on every edit, even several within one second
+ALTER TABLE incidents MODIFY COLUMN updated_at TIMESTAMP(6) DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6);
@@ -0,0 +1 @@
+ALTER TABLE runbook_steps DROP FOREIGN KEY fk_runbook_steps_incident;
@@ -0,0 +1,3 @@
+-- Steps of incidents purged before the constraint existed would block it
+DELETE FROM runbook_steps WHERE incident_id NOT IN (SELECT id FROM incidents);
+ALTER TABLE runbook_steps ADD CONSTRAINT fk_runbook_steps_incident FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE;
@@ -0,0 +1 @@
+ALTER TABLE incident_follow_ups DROP FOREIGN KEY fk_incident_follow_ups_incident;
@@ -0,0 +1,3 @@
+-- Reminders of incidents purged before the constraint existed would block it
+DELETE FROM incident_follow_ups WHERE incident_id NOT IN (SELECT id FROM incidents);
+ALTER TABLE incident_follow_ups ADD CONSTRAINT fk_incident_follow_ups_incident FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE;
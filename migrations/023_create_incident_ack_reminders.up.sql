@@ -0,0 +1,7 @@
+-- Reminders sent about incidents nobody has been assigned to, throttled by last_reminded_at
+CREATE TABLE IF NOT EXISTS incident_ack_reminders (
+    incident_id INT PRIMARY KEY,
+    reminders INT UNSIGNED NOT NULL DEFAULT 0,
+    last_reminded_at TIMESTAMP NOT NULL,
+    CONSTRAINT fk_incident_ack_reminders_incident FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;